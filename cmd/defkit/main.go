@@ -20,6 +20,7 @@ limitations under the License.
 //
 //	defkit generate [--output-dir <dir>]
 //	defkit register
+//	defkit coverage [--format markdown|html] [--output <file>] [--threshold <pct>]
 package main
 
 import (
@@ -31,6 +32,8 @@ import (
 
 	"github.com/oam-dev/kubevela/pkg/definition/defkit"
 
+	"github.com/oam-dev/vela-go-definitions/internal/paramcov"
+
 	// Import all definition packages to trigger init() registration
 	_ "github.com/oam-dev/vela-go-definitions/components"
 	_ "github.com/oam-dev/vela-go-definitions/policies"
@@ -46,6 +49,7 @@ func main() {
 
 	root.AddCommand(generateCmd())
 	root.AddCommand(registerCmd())
+	root.AddCommand(coverageCmd())
 
 	if err := root.Execute(); err != nil {
 		os.Exit(1)
@@ -91,6 +95,88 @@ func registerCmd() *cobra.Command {
 	}
 }
 
+func coverageCmd() *cobra.Command {
+	var (
+		applicationsDir string
+		testDirs        []string
+		format          string
+		output          string
+		threshold       float64
+		minDefinition   float64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "coverage",
+		Short: "Report which definition parameters the unit tests and e2e Applications exercise",
+		// A failed threshold is not a usage error.
+		SilenceUsage: true,
+		Long: `Walk the parameter schema of every registered definition and report, per
+definition, which parameter paths are set by the unit tests (WithParam/WithParams
+on defkit.TestContext) and by the e2e Applications.
+
+Fails when total coverage is below --threshold or any definition is below
+--min-definition, so it can gate CI.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCoverage(applicationsDir, testDirs, format, output, threshold, minDefinition)
+		},
+	}
+
+	cmd.Flags().StringVar(&applicationsDir, "applications", "test/builtin-definition-example/applications", "directory of e2e Application manifests")
+	cmd.Flags().StringSliceVar(&testDirs, "tests", []string{"components", "traits", "policies", "workflowsteps"}, "directories scanned for unit tests")
+	cmd.Flags().StringVar(&format, "format", "markdown", "report format: markdown or html")
+	cmd.Flags().StringVar(&output, "output", "", "write the report to this file instead of stdout")
+	cmd.Flags().Float64Var(&threshold, "threshold", 0, "minimum total parameter coverage in percent")
+	cmd.Flags().Float64Var(&minDefinition, "min-definition", 0, "minimum parameter coverage of each definition in percent")
+
+	return cmd
+}
+
+func runCoverage(applicationsDir string, testDirs []string, format, output string, threshold, minDefinition float64) error {
+	defs := defkit.All()
+	if len(defs) == 0 {
+		return fmt.Errorf("no definitions registered")
+	}
+	keys := make([]paramcov.Key, 0, len(defs))
+	for _, def := range defs {
+		keys = append(keys, paramcov.Key{Type: def.DefType(), Name: def.DefName()})
+	}
+
+	e2e, err := paramcov.FromApplications(applicationsDir)
+	if err != nil {
+		return fmt.Errorf("failed to collect e2e properties: %w", err)
+	}
+	unit, err := paramcov.FromUnitTests(testDirs, keys)
+	if err != nil {
+		return fmt.Errorf("failed to collect unit test properties: %w", err)
+	}
+	report := paramcov.Build(defs, append(unit, e2e...))
+
+	out := os.Stdout
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", output, err)
+		}
+		defer f.Close()
+		out = f
+	}
+	switch format {
+	case "markdown", "md":
+		err = report.WriteMarkdown(out)
+	case "html":
+		err = report.WriteHTML(out)
+	default:
+		return fmt.Errorf("unknown report format %q", format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	paths, covered := report.Totals()
+	fmt.Fprintf(os.Stderr, "Parameter coverage: %.1f%% (%d of %d paths)\n", report.TotalPercent(), covered, paths)
+	return report.Check(threshold, minDefinition)
+}
+
 func runGenerate(outputDir string) error {
 	defs := defkit.All()
 	if len(defs) == 0 {
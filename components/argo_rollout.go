@@ -0,0 +1,294 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// ArgoRollout creates the argo-rollout component definition.
+// It runs the webservice container as an Argo Rollouts Rollout, with a canary
+// or blue-green strategy. The Services the strategy switches traffic between
+// are emitted alongside: stable and canary, or active and preview.
+func ArgoRollout() *defkit.ComponentDefinition {
+	labels := defkit.StringKeyMap("labels").Optional().Description("Specify the labels in the workload")
+	annotations := defkit.StringKeyMap("annotations").Optional().Description("Specify the annotations in the workload")
+	image := defkit.String("image").Description("Which image would you like to use for your service").Short("i")
+	imagePullPolicy := defkit.Enum("imagePullPolicy").
+		Optional().
+		Values("Always", "Never", "IfNotPresent").
+		Description("Specify image pull policy for your service")
+	imagePullSecrets := defkit.StringList("imagePullSecrets").
+		Optional().
+		Description("Specify image pull secrets for your service")
+	ports := defkit.List("ports").
+		Description("Ports of the container, all of them are exposed by the Services of the strategy").
+		WithFields(
+			defkit.Int("port").Description("Number of the port, on both the container and the Services"),
+			defkit.String("name").Optional().Description("Name of the port, defaults to `port-<port>`"),
+			defkit.Enum("protocol").Values("TCP", "UDP", "SCTP").Default("TCP").Description("Protocol for port. Must be UDP, TCP, or SCTP"),
+		)
+	cmd := defkit.StringList("cmd").Optional().Description("Commands to run in the container")
+	args := defkit.StringList("args").Optional().Description("Arguments to the entrypoint")
+	env := ContainerEnvParam()
+	cpu := defkit.String("cpu").Optional().Description("Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)")
+	memory := defkit.String("memory").Optional().Description("Specifies the attributes of the memory resource required for the container.")
+	volumeMounts := VolumeMountsParam(volumeMountSources...)
+	livenessProbe := defkit.Object("livenessProbe").
+		Optional().
+		Description("Instructions for assessing whether the container is alive.").
+		WithSchemaRef("HealthProbe")
+	readinessProbe := defkit.Object("readinessProbe").
+		Optional().
+		Description("Instructions for assessing whether the container is in a suitable state to serve traffic.").
+		WithSchemaRef("HealthProbe")
+
+	strategy := defkit.Object("strategy").
+		Description("Progressive delivery strategy of the rollout").
+		WithFields(
+			defkit.OneOf("type").
+				Description(`Specify the strategy type, options: "canary","blueGreen", default to canary`).
+				Default("canary").
+				Variants(
+					defkit.Variant("canary").WithFields(
+						defkit.Field("steps", defkit.ParamTypeArray).Optional().
+							Description("Steps of the canary, the new version gets all the traffic once they complete").
+							Nested(defkit.Struct("").WithFields(
+								defkit.Field("setWeight", defkit.ParamTypeInt).Optional().
+									Description("Percentage of the traffic sent to the canary"),
+								defkit.Field("pause", defkit.ParamTypeStruct).Optional().
+									Description("Pause the rollout, until promoted when duration is empty").
+									Nested(defkit.Struct("").WithFields(
+										defkit.Field("duration", defkit.ParamTypeString).Optional().
+											Description("Duration of the pause, like `30s` or `5m`"),
+									)),
+								defkit.Field("analysis", defkit.ParamTypeStruct).Optional().
+									Description("Run an analysis and wait for its result before the next step").
+									Nested(rolloutAnalysisStruct()),
+							)),
+						defkit.Field("analysis", defkit.ParamTypeStruct).Optional().
+							Description("Analysis running in the background during the whole canary").
+							Nested(rolloutAnalysisStruct().WithFields(
+								defkit.Field("startingStep", defkit.ParamTypeInt).Optional().
+									Description("Index of the step the background analysis starts at"),
+							)),
+						defkit.Field("trafficRouting", defkit.ParamTypeStruct).Optional().
+							Description("Traffic router splitting the traffic by weight, replica counts are used if empty").
+							Nested(defkit.Struct("").WithFields(
+								defkit.Field("nginx", defkit.ParamTypeStruct).Optional().
+									Description("NGINX ingress controller").
+									Nested(defkit.Struct("").WithFields(
+										defkit.Field("stableIngress", defkit.ParamTypeString).
+											Description("Name of the Ingress routing to the stable Service"),
+									)),
+								defkit.Field("istio", defkit.ParamTypeStruct).Optional().
+									Description("Istio service mesh").
+									Nested(defkit.Struct("").WithFields(
+										defkit.Field("virtualService", defkit.ParamTypeStruct).
+											Description("VirtualService whose routes are weighted").
+											Nested(defkit.Struct("").WithFields(
+												defkit.Field("name", defkit.ParamTypeString).
+													Description("Name of the VirtualService"),
+												defkit.Field("routes", defkit.ParamTypeArray).Optional().Of(defkit.ParamTypeString).
+													Description("Names of the HTTP routes to weight, required when the VirtualService has several"),
+											)),
+									)),
+								defkit.Field("smi", defkit.ParamTypeStruct).Optional().
+									Description("Service Mesh Interface").
+									Nested(defkit.Struct("").WithFields(
+										defkit.Field("trafficSplitName", defkit.ParamTypeString).Optional().
+											Description("Name of the TrafficSplit, defaults to the component name"),
+									)),
+							)),
+					),
+					defkit.Variant("blueGreen").WithFields(
+						defkit.Field("autoPromotionEnabled", defkit.ParamTypeBool).Default(true).
+							Description("Promote the preview version once it is ready, otherwise wait for a manual promotion"),
+						defkit.Field("autoPromotionSeconds", defkit.ParamTypeInt).Optional().
+							Description("Delay in seconds before the automatic promotion"),
+						defkit.Field("previewReplicaCount", defkit.ParamTypeInt).Optional().
+							Description("Number of replicas of the preview version before promotion"),
+						defkit.Field("scaleDownDelaySeconds", defkit.ParamTypeInt).Optional().
+							Description("Delay in seconds before the previous version is scaled down after promotion"),
+					),
+				),
+		)
+
+	return defkit.NewComponent("argo-rollout").
+		Description("Describes long-running, scalable, containerized services progressively delivered by an Argo Rollouts canary or blue-green strategy.").
+		Workload("argoproj.io/v1alpha1", "Rollout").
+		CustomStatus(rolloutStatus()).
+		HealthPolicy(rolloutHealth()).
+		Helper("HealthProbe", HealthProbeParam()).
+		Params(
+			labels, annotations,
+			image, imagePullPolicy, imagePullSecrets, ports,
+			cmd, args, env,
+			cpu, memory, volumeMounts,
+			livenessProbe, readinessProbe,
+			strategy,
+		).
+		Template(argoRolloutTemplate)
+}
+
+// rolloutAnalysisStruct returns the AnalysisTemplate references shared by
+// analysis steps and the background analysis.
+func rolloutAnalysisStruct() *defkit.StructParam {
+	return defkit.Struct("").WithFields(
+		defkit.Field("templates", defkit.ParamTypeArray).
+			Description("AnalysisTemplates to run").
+			Nested(defkit.Struct("").WithFields(
+				defkit.Field("templateName", defkit.ParamTypeString).Description("Name of the AnalysisTemplate"),
+				defkit.Field("clusterScope", defkit.ParamTypeBool).Optional().Description("Refer to a ClusterAnalysisTemplate instead"),
+			)),
+	)
+}
+
+// rolloutStatus reports the rollout phase, the current canary step, the
+// stable and current ReplicaSet hashes, and whether the rollout was aborted.
+func rolloutStatus() string {
+	return defkit.Status().
+		StringField("rollout.phase", "status.phase", "Progressing").
+		IntField("rollout.step", "status.currentStepIndex", 0).
+		StringField("rollout.stable", "status.stableRS", "").
+		StringField("rollout.current", "status.currentPodHash", "").
+		Build() + `
+_aborted: *"" | string
+if context.output.status.abort != _|_ if context.output.status.abort {
+	_aborted: ", aborted"
+}
+message: "\(rollout.phase), step: \(rollout.step), stable: \(rollout.stable), canary: \(rollout.current)\(_aborted)"`
+}
+
+// rolloutHealth treats a Paused rollout as healthy: it waits for a manual
+// promotion, which must not block the application workflow.
+func rolloutHealth() string {
+	return defkit.Health().
+		StringField("rollout.phase", "status.phase", "Progressing").
+		HealthyWhen(defkit.StatusOr(`rollout.phase == "Healthy"`, `rollout.phase == "Paused"`)).
+		Build()
+}
+
+// argoRolloutTemplate defines the template function for argo-rollout.
+func argoRolloutTemplate(tpl *defkit.Template) {
+	vela := defkit.VelaCtx()
+
+	// Parameter references for template
+	labels := defkit.StringKeyMap("labels")
+	annotations := defkit.StringKeyMap("annotations")
+	image := defkit.String("image")
+	imagePullPolicy := defkit.String("imagePullPolicy")
+	imagePullSecrets := defkit.StringList("imagePullSecrets")
+	ports := defkit.List("ports")
+	cmd := defkit.StringList("cmd")
+	args := defkit.StringList("args")
+	env := defkit.List("env")
+	cpu := defkit.String("cpu")
+	memory := defkit.String("memory")
+	volumeMounts := defkit.Object("volumeMounts")
+	livenessProbe := defkit.Object("livenessProbe")
+	readinessProbe := defkit.Object("readinessProbe")
+	strategy := defkit.Object("strategy")
+
+	isCanary := defkit.Eq(strategy.Field("type"), defkit.Lit("canary"))
+	isBlueGreen := defkit.Eq(strategy.Field("type"), defkit.Lit("blueGreen"))
+	canaryService := defkit.Interpolation(vela.Name(), defkit.Lit("-canary"))
+	previewService := defkit.Interpolation(vela.Name(), defkit.Lit("-preview"))
+
+	mountsArray := tpl.Helper("mountsArray").
+		FromFields(volumeMounts, volumeMountSources...).
+		Pick("name", "mountPath").
+		PickIf(defkit.ItemFieldIsSet("subPath"), "subPath").
+		Build()
+	volumesList := tpl.Helper("volumesList").
+		FromFields(volumeMounts, volumeMountSources...).
+		MapBySource(podVolumeMappings()).
+		Build()
+	deDupVolumesArray := tpl.Helper("deDupVolumesArray").
+		FromHelper(volumesList).
+		Dedupe("name").
+		Build()
+
+	rollout := defkit.NewResource("argoproj.io/v1alpha1", "Rollout").
+		Set("metadata.name", vela.Name()).
+		Set("spec.selector.matchLabels[app.oam.dev/component]", vela.Name()).
+		Set("spec.template.metadata.labels[app.oam.dev/name]", vela.AppName()).
+		Set("spec.template.metadata.labels[app.oam.dev/component]", vela.Name()).
+		SpreadIf(labels.IsSet(), "spec.template.metadata.labels", labels).
+		SetIf(annotations.IsSet(), "spec.template.metadata.annotations", annotations).
+		Set("spec.template.spec.containers[0].name", vela.Name()).
+		Set("spec.template.spec.containers[0].image", image).
+		Set("spec.template.spec.containers[0].ports", ContainerPortsTransform(ports)).
+		SetIf(imagePullPolicy.IsSet(), "spec.template.spec.containers[0].imagePullPolicy", imagePullPolicy).
+		SetIf(cmd.IsSet(), "spec.template.spec.containers[0].command", cmd).
+		SetIf(args.IsSet(), "spec.template.spec.containers[0].args", args).
+		SetIf(env.IsSet(), "spec.template.spec.containers[0].env", env).
+		If(cpu.IsSet()).
+		Set("spec.template.spec.containers[0].resources.limits.cpu", cpu).
+		Set("spec.template.spec.containers[0].resources.requests.cpu", cpu).
+		EndIf().
+		If(memory.IsSet()).
+		Set("spec.template.spec.containers[0].resources.limits.memory", memory).
+		Set("spec.template.spec.containers[0].resources.requests.memory", memory).
+		EndIf().
+		SetIf(volumeMounts.IsSet(), "spec.template.spec.containers[0].volumeMounts", mountsArray).
+		SetIf(livenessProbe.IsSet(), "spec.template.spec.containers[0].livenessProbe", livenessProbe).
+		SetIf(readinessProbe.IsSet(), "spec.template.spec.containers[0].readinessProbe", readinessProbe).
+		SetIf(volumeMounts.IsSet(), "spec.template.spec.volumes", deDupVolumesArray).
+		SetIf(imagePullSecrets.IsSet(), "spec.template.spec.imagePullSecrets", ImagePullSecretsTransform(imagePullSecrets)).
+		ConditionalStruct(isCanary, "spec.strategy.canary", func(b *defkit.OutputStructBuilder) {
+			b.Set("stableService", vela.Name())
+			b.Set("canaryService", canaryService)
+			b.SetIf(strategy.Field("steps").IsSet(), "steps", strategy.Field("steps"))
+			b.SetIf(strategy.Field("analysis").IsSet(), "analysis", strategy.Field("analysis"))
+			b.SetIf(strategy.Field("trafficRouting").IsSet(), "trafficRouting", strategy.Field("trafficRouting"))
+		}).
+		ConditionalStruct(isBlueGreen, "spec.strategy.blueGreen", func(b *defkit.OutputStructBuilder) {
+			b.Set("activeService", vela.Name())
+			b.Set("previewService", previewService)
+			b.Set("autoPromotionEnabled", strategy.Field("autoPromotionEnabled"))
+			b.SetIf(strategy.Field("autoPromotionSeconds").IsSet(), "autoPromotionSeconds", strategy.Field("autoPromotionSeconds"))
+			b.SetIf(strategy.Field("previewReplicaCount").IsSet(), "previewReplicaCount", strategy.Field("previewReplicaCount"))
+			b.SetIf(strategy.Field("scaleDownDelaySeconds").IsSet(), "scaleDownDelaySeconds", strategy.Field("scaleDownDelaySeconds"))
+		})
+
+	// The controller adds the rollouts-pod-template-hash selector that pins
+	// each Service to its ReplicaSet.
+	service := defkit.NewResource("v1", "Service").
+		Set("metadata.name", vela.Name()).
+		Set("spec.selector[app.oam.dev/component]", vela.Name()).
+		Set("spec.ports", ServicePortsTransform(ports))
+
+	canary := defkit.NewResource("v1", "Service").
+		Set("metadata.name", canaryService).
+		Set("spec.selector[app.oam.dev/component]", vela.Name()).
+		Set("spec.ports", ServicePortsTransform(ports))
+
+	preview := defkit.NewResource("v1", "Service").
+		Set("metadata.name", previewService).
+		Set("spec.selector[app.oam.dev/component]", vela.Name()).
+		Set("spec.ports", ServicePortsTransform(ports))
+
+	tpl.Output(rollout)
+	tpl.Outputs("rolloutService", service)
+	tpl.OutputsIf(isCanary, "canaryService", canary)
+	tpl.OutputsIf(isBlueGreen, "previewService", preview)
+}
+
+func init() {
+	defkit.Register(ArgoRollout())
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"cuelang.org/go/cue"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("ArgoRollout Component", func() {
+	Describe("ArgoRollout()", func() {
+		It("should create an argo-rollout component definition", func() {
+			comp := components.ArgoRollout()
+			Expect(comp.GetName()).To(Equal("argo-rollout"))
+			Expect(comp.GetDescription()).To(ContainSubstring("Argo Rollouts"))
+		})
+
+		It("should have Rollout workload", func() {
+			workload := components.ArgoRollout().GetWorkload()
+			Expect(workload.APIVersion()).To(Equal("argoproj.io/v1alpha1"))
+			Expect(workload.Kind()).To(Equal("Rollout"))
+		})
+
+		It("should have container and strategy parameters", func() {
+			comp := components.ArgoRollout()
+			for _, name := range []string{"image", "ports", "env", "cpu", "memory", "volumeMounts", "livenessProbe", "readinessProbe", "strategy"} {
+				Expect(comp).To(HaveParamNamed(name))
+			}
+		})
+	})
+
+	Describe("Status", func() {
+		const canaryPaused = `{status: {phase: "Paused", currentStepIndex: 1, stableRS: "5d8b7f", currentPodHash: "6c9f4d"}}`
+		const aborted = `{status: {phase: "Degraded", currentStepIndex: 0, stableRS: "5d8b7f", currentPodHash: "6c9f4d", abort: true}}`
+
+		DescribeTable("health",
+			func(output string, healthy bool) {
+				v := evalStatus(components.ArgoRollout().GetHealthPolicy(), output)
+				Expect(v.LookupPath(cue.ParsePath("isHealth")).Bool()).To(Equal(healthy))
+			},
+			Entry("Healthy", `{status: {phase: "Healthy"}}`, true),
+			Entry("Paused for a promotion", canaryPaused, true),
+			Entry("Progressing", `{status: {phase: "Progressing"}}`, false),
+			Entry("Degraded after an abort", aborted, false),
+			Entry("no status reported yet", `{spec: {}}`, false),
+		)
+
+		DescribeTable("message",
+			func(output, message string) {
+				v := evalStatus(components.ArgoRollout().GetCustomStatus(), output)
+				Expect(v.LookupPath(cue.ParsePath("message")).String()).To(Equal(message))
+			},
+			Entry("paused canary", canaryPaused, "Paused, step: 1, stable: 5d8b7f, canary: 6c9f4d"),
+			Entry("aborted canary", aborted, "Degraded, step: 0, stable: 5d8b7f, canary: 6c9f4d, aborted"),
+			Entry("no status reported yet", `{spec: {}}`, "Progressing, step: 0, stable: , canary: "),
+		)
+	})
+
+	Describe("CUE Generation", func() {
+		var doc *cueassert.Document
+
+		BeforeEach(func() {
+			doc = cueassert.MustParse(components.ArgoRollout().ToCue())
+		})
+
+		It("should generate the strategy as a discriminated union", func() {
+			Expect(doc.Lookup("parameter.strategy.type")).To(cueassert.HaveDefault("canary"))
+			Expect(doc.Lookup("parameter.strategy.steps.setWeight")).To(cueassert.BeOptionalField())
+			Expect(doc.Lookup("parameter.strategy.trafficRouting.nginx.stableIngress")).To(cueassert.BeRequiredField())
+			Expect(doc.Lookup("parameter.strategy.autoPromotionEnabled")).To(cueassert.HaveDefault(true))
+			Expect(doc.Lookup("parameter.strategy.steps").Conditional()).To(BeTrue())
+			Expect(doc.UntypedLists()).To(BeEmpty())
+		})
+
+		It("should share the env schema with webservice", func() {
+			webservice := cueassert.MustParse(components.Webservice().ToCue())
+			Expect(doc.Lookup("parameter.env").Value()).To(Equal(webservice.Lookup("parameter.env").Value()))
+		})
+
+		It("should only emit the strategy block of the selected type", func() {
+			Expect(doc.Lookup("output.spec.strategy").Struct().FieldNames()).To(ConsistOf("canary", "blueGreen"))
+			Expect(doc.Lookup("output.spec.strategy.canary").Struct().FieldNames()).To(ConsistOf("stableService", "canaryService", "steps", "analysis", "trafficRouting"))
+			Expect(doc.Lookup("output.spec.strategy.canary.canaryService")).To(cueassert.HaveValue(`"\(context.name)-canary"`))
+
+			Expect(doc.Lookup("output.spec.strategy.blueGreen.previewService")).To(cueassert.HaveValue(`"\(context.name)-preview"`))
+			Expect(doc.Lookup("output.spec.strategy.blueGreen.autoPromotionEnabled")).To(cueassert.HaveValue("parameter.strategy.autoPromotionEnabled"))
+			Expect(doc.Output().Source()).To(SatisfyAll(
+				ContainSubstring(`if parameter.strategy.type == "canary" {`),
+				ContainSubstring(`if parameter.strategy.type == "blueGreen" {`),
+			))
+		})
+
+		It("should emit the Service pair of the selected strategy", func() {
+			Expect(doc.Lookup("outputs.rolloutService").Conditional()).To(BeFalse())
+			Expect(doc.Lookup("outputs.canaryService").Conditional()).To(BeTrue())
+			Expect(doc.Lookup("outputs.previewService").Conditional()).To(BeTrue())
+			Expect(doc.Lookup("outputs.canaryService.metadata.name")).To(cueassert.HaveValue(`"\(context.name)-canary"`))
+			Expect(doc.Lookup("outputs.previewService.metadata.name")).To(cueassert.HaveValue(`"\(context.name)-preview"`))
+		})
+	})
+
+	Describe("Render with TestContext", func() {
+		var comp *defkit.ComponentDefinition
+
+		BeforeEach(func() {
+			comp = components.ArgoRollout()
+		})
+
+		It("should render the container and the stable Service", func() {
+			outputs := comp.RenderAll(
+				defkit.TestContext().
+					WithName("frontend").
+					WithParam("image", "oamdev/testapp:v1").
+					WithParam("ports", []map[string]any{{"port": 8080, "protocol": "TCP"}}).
+					WithParam("cpu", "100m").
+					WithParam("env", []map[string]any{{"name": "FOO", "value": "bar"}}),
+			)
+
+			rollout := outputs.Primary
+			Expect(rollout.Kind()).To(Equal("Rollout"))
+			Expect(rollout.Get("spec.selector.matchLabels")).To(HaveKeyWithValue("app.oam.dev/component", "frontend"))
+			Expect(rollout.Get("spec.template.spec.containers[0].image")).To(Equal("oamdev/testapp:v1"))
+			Expect(rollout.Get("spec.template.spec.containers[0].ports")).To(HaveLen(1))
+			Expect(rollout.Get("spec.template.spec.containers[0].env")).NotTo(BeNil())
+			Expect(rollout.Get("spec.template.spec.containers[0].resources.limits.cpu")).To(Equal("100m"))
+
+			Expect(outputs.Auxiliary).To(HaveKey("rolloutService"))
+			Expect(outputs.Auxiliary["rolloutService"].Get("metadata.name")).To(Equal("frontend"))
+			Expect(outputs.Auxiliary["rolloutService"].Get("spec.ports")).To(HaveLen(1))
+		})
+	})
+})
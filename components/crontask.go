@@ -26,6 +26,10 @@ func CronTask() *defkit.ComponentDefinition {
 	labels := defkit.StringKeyMap("labels").Optional().Description("Specify the labels in the workload")
 	annotations := defkit.StringKeyMap("annotations").Optional().Description("Specify the annotations in the workload")
 	schedule := defkit.String("schedule").Description("Specify the schedule in Cron format, see https://en.wikipedia.org/wiki/Cron")
+	timeZone := defkit.String("timeZone").
+		Optional().
+		Pattern(`^[A-Za-z][A-Za-z0-9_+-]*(/[A-Za-z0-9_+-]+)*$`).
+		Description("Specify the time zone of the schedule as an IANA name, like `Europe/Berlin`, defaults to the time zone of the kube-controller-manager. Requires Kubernetes 1.27 or later")
 	startingDeadlineSeconds := defkit.Int("startingDeadlineSeconds").Optional().Description("Specify deadline in seconds for starting the job if it misses scheduled")
 	suspend := defkit.Bool("suspend").Default(false).Description("suspend subsequent executions")
 	concurrencyPolicy := defkit.String("concurrencyPolicy").
@@ -121,24 +125,92 @@ func CronTask() *defkit.ComponentDefinition {
 		Optional().
 		WithSchemaRef("HealthProbe").
 		Description("Instructions for assessing whether the container is in a suitable state to serve traffic.")
+	sidecars := JobSidecarsParam()
 
 	return defkit.NewComponent("cron-task").
 		Description("Describes cron jobs that run code or a script to completion.").
 		AutodetectWorkload().
+		CustomStatus(cronTaskStatus()).
+		HealthPolicy(cronTaskHealth()).
 		Helper("HealthProbe", CronTaskHealthProbeParam()).
 		Params(
 			labels, annotations,
-			schedule, startingDeadlineSeconds, suspend,
+			schedule, timeZone, startingDeadlineSeconds, suspend,
 			concurrencyPolicy, successfulJobsHistoryLimit, failedJobsHistoryLimit,
 			count, image, imagePullPolicy, imagePullSecrets,
 			restart, cmd, env,
 			cpu, memory, volumeMounts, volumes, hostAliases,
 			ttlSecondsAfterFinished, activeDeadlineSeconds, backoffLimit,
-			livenessProbe, readinessProbe,
+			livenessProbe, readinessProbe, sidecars,
+		).
+		Validators(
+			NativeSidecarsRequired(),
+			defkit.Validate("timeZone requires Kubernetes 1.27 or later").
+				WithName("_validateTimeZone").
+				OnlyWhen(timeZone.IsSet()).
+				FailWhen(defkit.Lt(defkit.VelaCtx().ClusterVersion().Minor(), defkit.Lit(27))),
 		).
 		Template(cronTaskTemplate)
 }
 
+// cronTaskStatus reports the active jobs, the last schedule and success
+// times, and the schedule, like
+// "Active:0, last schedule: 2025-06-01T01:00:00Z, last success: 2025-06-01T01:00:42Z, schedule: 0 1 * * * (Europe/Berlin)".
+// The last success of a batch/v1beta1 CronJob that reports none is unknown, as
+// Kubernetes only reports it from 1.21.
+func cronTaskStatus() string {
+	return `_active: *0 | int
+if context.output.status.active != _|_ {
+	_active: len(context.output.status.active)
+}
+_lastSchedule: *"never" | string
+if context.output.status.lastScheduleTime != _|_ {
+	_lastSchedule: context.output.status.lastScheduleTime
+}
+_lastSuccess: *"never" | string
+if context.output.status.lastSuccessfulTime != _|_ {
+	_lastSuccess: context.output.status.lastSuccessfulTime
+}
+if context.output.status.lastSuccessfulTime == _|_ if context.output.apiVersion != _|_ if context.output.apiVersion == "batch/v1beta1" {
+	_lastSuccess: "unknown"
+}
+_zone: *"" | string
+if context.output.spec.timeZone != _|_ {
+	_zone: " (\(context.output.spec.timeZone))"
+}
+_schedule: *"schedule: \(context.output.spec.schedule)\(_zone)" | string
+if context.output.spec.suspend != _|_ if context.output.spec.suspend {
+	_schedule: "suspended"
+}
+message: "Active:\(_active), last schedule: \(_lastSchedule), last success: \(_lastSuccess), \(_schedule)"`
+}
+
+// cronTaskHealth flags the task when its most recent job failed: no job is
+// running and the last success is older than the last schedule. The times are
+// RFC 3339 in UTC, so they compare as strings.
+// Kubernetes only reports the last success from 1.21, and health policies
+// can't read the cluster version, so a batch/v1beta1 CronJob, rendered for
+// clusters older than 1.25, is healthy until it reports one.
+func cronTaskHealth() string {
+	return `_active: *0 | int
+if context.output.status.active != _|_ {
+	_active: len(context.output.status.active)
+}
+_lastSchedule: *"" | string
+if context.output.status.lastScheduleTime != _|_ {
+	_lastSchedule: context.output.status.lastScheduleTime
+}
+_lastSuccess: *"" | string
+if context.output.status.lastSuccessfulTime != _|_ {
+	_lastSuccess: context.output.status.lastSuccessfulTime
+}
+_unreported: *false | bool
+if context.output.apiVersion != _|_ if context.output.apiVersion == "batch/v1beta1" {
+	_unreported: _lastSuccess == ""
+}
+isHealth: _active > 0 || _lastSchedule == "" || _unreported || _lastSuccess >= _lastSchedule`
+}
+
 // CronTaskHealthProbeParam returns a HealthProbe Param without host and scheme fields
 // in httpGet, matching the cron-task reference CUE (which omits them unlike webservice/daemon/statefulset).
 func CronTaskHealthProbeParam() *defkit.MapParam {
@@ -225,6 +297,7 @@ func cronTaskTemplate(tpl *defkit.Template) {
 
 	// Parameter references for template
 	schedule := defkit.String("schedule")
+	timeZone := defkit.String("timeZone")
 	concurrencyPolicy := defkit.String("concurrencyPolicy")
 	suspend := defkit.Bool("suspend")
 	successfulJobsHistoryLimit := defkit.Int("successfulJobsHistoryLimit")
@@ -247,6 +320,7 @@ func cronTaskTemplate(tpl *defkit.Template) {
 	volumes := defkit.List("volumes")
 	imagePullSecrets := defkit.StringList("imagePullSecrets")
 	hostAliases := defkit.List("hostAliases")
+	sidecars := defkit.List("sidecars")
 
 	// Build struct-based array helpers matching original cron-task.cue pattern:
 	// mountsArray: {
@@ -320,6 +394,12 @@ func cronTaskTemplate(tpl *defkit.Template) {
 		}).
 		Build()
 
+	// mountsList uses list.Concat to combine all mount types; list addition
+	// with + is rejected since CUE v0.11
+	mountsList := tpl.ConcatHelper("mountsList", mountsArray).
+		Fields("pvc", "configMap", "secret", "emptyDir", "hostPath").
+		Build()
+
 	// volumesList uses list.Concat to combine all volume types
 	volumesList := tpl.ConcatHelper("volumesList", volumesArray).
 		Fields("pvc", "configMap", "secret", "emptyDir", "hostPath").
@@ -336,6 +416,7 @@ func cronTaskTemplate(tpl *defkit.Template) {
 		VersionIf(defkit.Ge(vela.ClusterVersion().Minor(), defkit.Lit(25)), "batch/v1").
 		// CronJob spec fields
 		Set("spec.schedule", schedule).
+		SetIf(timeZone.IsSet(), "spec.timeZone", timeZone).
 		Set("spec.concurrencyPolicy", concurrencyPolicy).
 		Set("spec.suspend", suspend).
 		Set("spec.successfulJobsHistoryLimit", successfulJobsHistoryLimit).
@@ -376,8 +457,7 @@ func cronTaskTemplate(tpl *defkit.Template) {
 		EndIf().
 		// New-style volumeMounts on container - uses mountsArray concatenation
 		If(volumeMounts.IsSet()).
-		Set("spec.jobTemplate.spec.template.spec.containers[0].volumeMounts",
-			defkit.ConcatExpr(mountsArray, "pvc", "configMap", "secret", "emptyDir", "hostPath")).
+		Set("spec.jobTemplate.spec.template.spec.containers[0].volumeMounts", mountsList).
 		EndIf().
 		// Deprecated volumes fallback - container volumeMounts
 		If(defkit.And(volumes.IsSet(), volumeMounts.NotSet())).
@@ -387,6 +467,8 @@ func cronTaskTemplate(tpl *defkit.Template) {
 				"name":      defkit.FieldRef("name"),
 			})).
 		EndIf().
+		// Sidecars as restartable init containers
+		SetIf(sidecars.LenGt(0), "spec.jobTemplate.spec.template.spec.initContainers", NativeSidecars()).
 		// imagePullSecrets
 		SetIf(imagePullSecrets.IsSet(), "spec.jobTemplate.spec.template.spec.imagePullSecrets",
 			ImagePullSecretsTransform(imagePullSecrets)).
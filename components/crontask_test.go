@@ -17,12 +17,17 @@ limitations under the License.
 package components_test
 
 import (
+	"strconv"
 	"strings"
 
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/oam-dev/vela-go-definitions/components"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 
 	"github.com/oam-dev/kubevela/pkg/definition/defkit"
 	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
@@ -55,6 +60,7 @@ var _ = Describe("CronTask Component", func() {
 			Expect(comp).To(HaveParamNamed("suspend"))
 			Expect(comp).To(HaveParamNamed("successfulJobsHistoryLimit"))
 			Expect(comp).To(HaveParamNamed("failedJobsHistoryLimit"))
+			Expect(comp).To(HaveParamNamed("timeZone"))
 		})
 
 		It("should execute template and produce CronJob output", func() {
@@ -245,4 +251,71 @@ var _ = Describe("CronTask Component", func() {
 			Expect(cue).NotTo(ContainSubstring(`scheme:`))
 		})
 	})
+
+	Describe("Time zone", func() {
+		It("should generate timeZone as an optional parameter", func() {
+			doc := cueassert.MustParse(components.CronTask().ToCue())
+			Expect(doc.Lookup("parameter.timeZone")).To(cueassert.BeOptionalField())
+		})
+
+		DescribeTable("should only accept IANA time zone names",
+			func(zone string, valid bool) {
+				v := cuecontext.New().CompileString(components.CronTask().ToCue() +
+					"\ncontext: {name: \"site\", appName: \"docs\", namespace: \"default\", clusterVersion: minor: 30}" +
+					"\ntemplate: parameter: {image: \"busybox\", schedule: \"0 1 * * *\", timeZone: " + strconv.Quote(zone) + "}")
+				tpl := v.LookupPath(cue.ParsePath("template"))
+				if !valid {
+					Expect(tpl.Validate()).NotTo(Succeed())
+					return
+				}
+				Expect(tpl.Validate(cue.Concrete(true))).To(Succeed())
+				Expect(tpl.LookupPath(cue.ParsePath("output.spec.timeZone")).String()).To(Equal(zone))
+			},
+			Entry("region and city", "Europe/Berlin", true),
+			Entry("nested location", "America/Argentina/Buenos_Aires", true),
+			Entry("UTC", "UTC", true),
+			Entry("Etc offset", "Etc/GMT+5", true),
+			Entry("raw UTC offset", "+02:00", false),
+			Entry("spaces", "Europe/ Berlin", false),
+		)
+
+		It("should reject timeZone before Kubernetes 1.27", func() {
+			v := cuecontext.New().CompileString(components.CronTask().ToCue() +
+				"\ncontext: {name: \"site\", appName: \"docs\", namespace: \"default\", clusterVersion: minor: 26}" +
+				"\ntemplate: parameter: {image: \"busybox\", schedule: \"0 1 * * *\", timeZone: \"Europe/Berlin\"}")
+			Expect(v.Validate()).To(MatchError(ContainSubstring("timeZone requires Kubernetes 1.27 or later")))
+		})
+	})
+
+	Describe("Status", func() {
+		DescribeTable("health",
+			func(output string, healthy bool) {
+				v := evalStatus(components.CronTask().GetHealthPolicy(), output)
+				Expect(v.LookupPath(cue.ParsePath("isHealth")).Bool()).To(Equal(healthy))
+			},
+			Entry("never scheduled", `{spec: schedule: "0 1 * * *"}`, true),
+			Entry("last job succeeded", `{status: {lastScheduleTime: "2025-06-01T01:00:00Z", lastSuccessfulTime: "2025-06-01T01:00:42Z"}}`, true),
+			Entry("last job running", `{status: {active: [{name: "backup-2"}], lastScheduleTime: "2025-06-02T01:00:00Z", lastSuccessfulTime: "2025-06-01T01:00:42Z"}}`, true),
+			Entry("last job failed", `{status: {lastScheduleTime: "2025-06-02T01:00:00Z", lastSuccessfulTime: "2025-06-01T01:00:42Z"}}`, false),
+			Entry("first job failed", `{status: {lastScheduleTime: "2025-06-01T01:00:00Z"}}`, false),
+			Entry("first job failed on batch/v1", `{apiVersion: "batch/v1", status: {lastScheduleTime: "2025-06-01T01:00:00Z"}}`, false),
+			Entry("no last success reported by batch/v1beta1", `{apiVersion: "batch/v1beta1", status: {lastScheduleTime: "2025-06-01T01:00:00Z"}}`, true),
+			Entry("last job failed on batch/v1beta1", `{apiVersion: "batch/v1beta1", status: {lastScheduleTime: "2025-06-02T01:00:00Z", lastSuccessfulTime: "2025-06-01T01:00:42Z"}}`, false),
+		)
+
+		DescribeTable("message",
+			func(output, message string) {
+				v := evalStatus(components.CronTask().GetCustomStatus(), output)
+				Expect(v.LookupPath(cue.ParsePath("message")).String()).To(Equal(message))
+			},
+			Entry("never scheduled", `{spec: schedule: "0 1 * * *"}`,
+				"Active:0, last schedule: never, last success: never, schedule: 0 1 * * *"),
+			Entry("running in a time zone", `{spec: {schedule: "0 1 * * *", timeZone: "Europe/Berlin"}, status: {active: [{name: "backup-2"}], lastScheduleTime: "2025-06-02T01:00:00Z", lastSuccessfulTime: "2025-06-01T01:00:42Z"}}`,
+				"Active:1, last schedule: 2025-06-02T01:00:00Z, last success: 2025-06-01T01:00:42Z, schedule: 0 1 * * * (Europe/Berlin)"),
+			Entry("no last success reported by batch/v1beta1", `{apiVersion: "batch/v1beta1", spec: schedule: "0 1 * * *", status: lastScheduleTime: "2025-06-01T01:00:00Z"}`,
+				"Active:0, last schedule: 2025-06-01T01:00:00Z, last success: unknown, schedule: 0 1 * * *"),
+			Entry("suspended", `{spec: {schedule: "0 1 * * *", suspend: true}, status: {lastScheduleTime: "2025-06-01T01:00:00Z", lastSuccessfulTime: "2025-06-01T01:00:42Z"}}`,
+				"Active:0, last schedule: 2025-06-01T01:00:00Z, last success: 2025-06-01T01:00:42Z, suspended"),
+		)
+	})
 })
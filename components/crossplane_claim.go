@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// CrossplaneClaim creates the crossplane-claim component definition.
+// It describes any claim of a Crossplane CompositeResourceDefinition, so
+// infrastructure is requested through the platform's own APIs rather than a
+// specific cloud.
+//
+// The claim is written as a raw output: its apiVersion and kind come from the
+// parameters, while NewResource always renders them as string literals.
+func CrossplaneClaim() *defkit.ComponentDefinition {
+	apiVersion := defkit.String("apiVersion").Description("API version of the claim, like `database.example.org/v1alpha1`")
+	kind := defkit.String("kind").Description("Kind of the claim, like `PostgreSQLInstance`")
+	spec := defkit.Object("spec").
+		Optional().
+		Description("Spec of the claim, as defined by the CompositeResourceDefinition")
+	labels := defkit.StringKeyMap("labels").Optional().Description("Specify the labels of the claim")
+	annotations := defkit.StringKeyMap("annotations").Optional().Description("Specify the annotations of the claim")
+	compositionSelector := defkit.Object("compositionSelector").
+		Optional().
+		Description("Select the Composition by its labels, like the provider or environment it targets").
+		WithFields(
+			defkit.StringKeyMap("matchLabels").Description("Labels the Composition must have"),
+		)
+	compositionRef := defkit.Object("compositionRef").
+		Optional().
+		Description("Use the Composition with this name, exclusive to compositionSelector").
+		WithFields(
+			defkit.String("name").Description("Name of the Composition"),
+		)
+	compositionUpdatePolicy := defkit.Enum("compositionUpdatePolicy").
+		Values("Automatic", "Manual").
+		Optional().
+		Description("Whether the claim moves to new revisions of its Composition automatically")
+	writeConnectionSecretToRef := defkit.Object("writeConnectionSecretToRef").
+		Optional().
+		Description("Secret Crossplane writes the connection details of the claim to").
+		WithFields(
+			defkit.String("name").Description("Name of the Secret, defaults to `<component>-conn`"),
+		)
+
+	return defkit.NewComponent("crossplane-claim").
+		Description("Describes infrastructure requested as a Crossplane claim, independent of the cloud that provides it.").
+		AutodetectWorkload().
+		CustomStatus(CrossplaneConditionsStatus()).
+		HealthPolicy(CrossplaneConditionsHealth()).
+		Params(
+			apiVersion, kind, spec,
+			labels, annotations,
+			compositionSelector, compositionRef, compositionUpdatePolicy,
+			writeConnectionSecretToRef,
+		).
+		Validators(
+			defkit.Validate("compositionRef and compositionSelector are mutually exclusive").
+				WithName("_validateComposition").
+				FailWhen(defkit.And(compositionRef.IsSet(), compositionSelector.IsSet())),
+		).
+		Template(crossplaneClaimTemplate)
+}
+
+// crossplaneConditionsPreamble extracts the Synced and Ready conditions Crossplane
+// sets on claims. The conditions are absent until Crossplane first reconciles the
+// claim, so the comprehension is guarded to keep status evaluation complete.
+const crossplaneConditionsPreamble = `_conditions: *[] | [...]
+if context.output.status != _|_ if context.output.status.conditions != _|_ {
+	_conditions: context.output.status.conditions
+}
+_synced: [ for c in _conditions if c.type == "Synced" && c.status == "True" { c } ]
+_ready: [ for c in _conditions if c.type == "Ready" && c.status == "True" { c } ]`
+
+// CrossplaneConditionsHealth returns a health policy for Crossplane claims and
+// composite resources. They are healthy once both Synced and Ready are True.
+func CrossplaneConditionsHealth() string {
+	return crossplaneConditionsPreamble + `
+isHealth: len(_synced) > 0 && len(_ready) > 0`
+}
+
+// CrossplaneConditionsStatus returns a custom status that surfaces the first
+// condition that is not True, like "Ready is False, Creating: waiting for the
+// database", so failures of the composed resources show up on the Application.
+func CrossplaneConditionsStatus() string {
+	return crossplaneConditionsPreamble + `
+_pending: [ for c in _conditions if c.status != "True" { c } ]
+if len(_pending) == 0 {
+	message: *"waiting for the Synced and Ready conditions" | string
+	if len(_synced) > 0 && len(_ready) > 0 {
+		message: "Synced and Ready"
+	}
+}
+if len(_pending) > 0 {
+	_reason: *"" | string
+	if _pending[0].reason != _|_ {
+		_reason: ", \(_pending[0].reason)"
+	}
+	_detail: *"" | string
+	if _pending[0].message != _|_ {
+		_detail: ": \(_pending[0].message)"
+	}
+	message: "\(_pending[0].type) is \(_pending[0].status)\(_reason)\(_detail)"
+}`
+}
+
+// crossplaneClaimHeader renders the claim. The composition and connection Secret
+// fields are merged into the given spec, so they can be set either way.
+const crossplaneClaimHeader = `output: {
+	apiVersion: parameter.apiVersion
+	kind:       parameter.kind
+	metadata: {
+		name: context.name
+		if parameter.labels != _|_ {
+			labels: parameter.labels
+		}
+		if parameter.annotations != _|_ {
+			annotations: parameter.annotations
+		}
+	}
+	spec: {
+		if parameter.spec != _|_ {
+			parameter.spec
+		}
+		if parameter.compositionSelector != _|_ {
+			compositionSelector: parameter.compositionSelector
+		}
+		if parameter.compositionRef != _|_ {
+			compositionRef: parameter.compositionRef
+		}
+		if parameter.compositionUpdatePolicy != _|_ {
+			compositionUpdatePolicy: parameter.compositionUpdatePolicy
+		}
+		if parameter.writeConnectionSecretToRef != _|_ {
+			writeConnectionSecretToRef: name: parameter.writeConnectionSecretToRef.name
+		}
+		if parameter.writeConnectionSecretToRef == _|_ {
+			writeConnectionSecretToRef: name: "\(context.name)-conn"
+		}
+	}
+}`
+
+// crossplaneClaimTemplate defines the template function for crossplane-claim.
+func crossplaneClaimTemplate(tpl *defkit.Template) {
+	tpl.SetRawHeaderBlock(crossplaneClaimHeader)
+}
+
+func init() {
+	defkit.Register(CrossplaneClaim())
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
+
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("CrossplaneClaim Component", func() {
+	Describe("CrossplaneClaim()", func() {
+		It("should create a crossplane-claim component definition", func() {
+			comp := components.CrossplaneClaim()
+			Expect(comp.GetName()).To(Equal("crossplane-claim"))
+			Expect(comp.GetDescription()).To(ContainSubstring("Crossplane claim"))
+		})
+
+		It("should autodetect the workload of the claim", func() {
+			Expect(components.CrossplaneClaim().GetWorkload().IsAutodetect()).To(BeTrue())
+		})
+
+		It("should have claim and composition parameters", func() {
+			comp := components.CrossplaneClaim()
+			for _, name := range []string{"apiVersion", "kind", "spec", "labels", "annotations", "compositionSelector", "compositionRef", "compositionUpdatePolicy", "writeConnectionSecretToRef"} {
+				Expect(comp).To(HaveParamNamed(name))
+			}
+		})
+	})
+
+	Describe("Status", func() {
+		const ready = `{status: {conditions: [{type: "Synced", status: "True", reason: "ReconcileSuccess"}, {type: "Ready", status: "True", reason: "Available"}]}}`
+		const creating = `{status: {conditions: [{type: "Synced", status: "True", reason: "ReconcileSuccess"}, {type: "Ready", status: "False", reason: "Creating", message: "waiting for the database"}]}}`
+		const syncFailed = `{status: {conditions: [{type: "Synced", status: "False", reason: "ReconcileError", message: "cannot find Composition"}]}}`
+
+		DescribeTable("CrossplaneConditionsHealth",
+			func(output string, healthy bool) {
+				v := evalStatus(components.CrossplaneConditionsHealth(), output)
+				Expect(v.LookupPath(cue.ParsePath("isHealth")).Bool()).To(Equal(healthy))
+			},
+			Entry("Synced and Ready", ready, true),
+			Entry("still creating", creating, false),
+			Entry("sync failed", syncFailed, false),
+			Entry("only Synced reported", `{status: {conditions: [{type: "Synced", status: "True"}]}}`, false),
+			Entry("no status reported yet", `{spec: {}}`, false),
+		)
+
+		DescribeTable("CrossplaneConditionsStatus",
+			func(output, message string) {
+				v := evalStatus(components.CrossplaneConditionsStatus(), output)
+				Expect(v.LookupPath(cue.ParsePath("message")).String()).To(Equal(message))
+			},
+			Entry("Synced and Ready", ready, "Synced and Ready"),
+			Entry("still creating", creating, "Ready is False, Creating: waiting for the database"),
+			Entry("sync failed", syncFailed, "Synced is False, ReconcileError: cannot find Composition"),
+			Entry("condition without reason", `{status: {conditions: [{type: "Ready", status: "Unknown"}]}}`, "Ready is Unknown"),
+			Entry("no status reported yet", `{spec: {}}`, "waiting for the Synced and Ready conditions"),
+		)
+	})
+
+	Describe("CUE Generation", func() {
+		var doc *cueassert.Document
+
+		BeforeEach(func() {
+			doc = cueassert.MustParse(components.CrossplaneClaim().ToCue())
+		})
+
+		It("should require the claim type and keep its spec open", func() {
+			Expect(doc.Lookup("parameter.apiVersion")).To(cueassert.BeRequiredField())
+			Expect(doc.Lookup("parameter.kind")).To(cueassert.BeRequiredField())
+			Expect(doc.Lookup("parameter.spec")).To(cueassert.BeOptionalField())
+			Expect(doc.Lookup("parameter.compositionRef")).To(cueassert.BeOptionalField())
+		})
+	})
+
+	Describe("Template", func() {
+		lookup := func(v cue.Value, path string) string {
+			s, err := v.LookupPath(cue.ParsePath(path)).String()
+			Expect(err).NotTo(HaveOccurred(), path)
+			return s
+		}
+
+		It("should render the claim with its spec and connection Secret", func() {
+			v := evalTemplate(components.CrossplaneClaim(), `{
+				apiVersion: "database.example.org/v1alpha1"
+				kind:       "PostgreSQLInstance"
+				spec: parameters: storageGB: 20
+				compositionSelector: matchLabels: provider: "aws"
+			}`)
+			Expect(lookup(v, "output.apiVersion")).To(Equal("database.example.org/v1alpha1"))
+			Expect(lookup(v, "output.kind")).To(Equal("PostgreSQLInstance"))
+			Expect(lookup(v, "output.metadata.name")).To(Equal("site"))
+			Expect(v.LookupPath(cue.ParsePath("output.spec.parameters.storageGB")).Int64()).To(BeEquivalentTo(20))
+			Expect(lookup(v, "output.spec.compositionSelector.matchLabels.provider")).To(Equal("aws"))
+			Expect(lookup(v, "output.spec.writeConnectionSecretToRef.name")).To(Equal("site-conn"))
+		})
+
+		It("should use the given Composition and connection Secret", func() {
+			v := evalTemplate(components.CrossplaneClaim(), `{
+				apiVersion: "storage.example.org/v1alpha1"
+				kind:       "Bucket"
+				compositionRef: name: "bucket-gcp"
+				compositionUpdatePolicy: "Manual"
+				writeConnectionSecretToRef: name: "bucket-creds"
+			}`)
+			Expect(lookup(v, "output.spec.compositionRef.name")).To(Equal("bucket-gcp"))
+			Expect(lookup(v, "output.spec.compositionUpdatePolicy")).To(Equal("Manual"))
+			Expect(lookup(v, "output.spec.writeConnectionSecretToRef.name")).To(Equal("bucket-creds"))
+			Expect(v.LookupPath(cue.ParsePath("output.spec.compositionSelector")).Exists()).To(BeFalse())
+		})
+
+		It("should reject both compositionRef and compositionSelector", func() {
+			v := cuecontext.New().CompileString(components.CrossplaneClaim().ToCue() + `
+context: {name: "site", appName: "docs", namespace: "default"}
+template: parameter: {apiVersion: "storage.example.org/v1alpha1", kind: "Bucket", compositionRef: name: "a", compositionSelector: matchLabels: b: "c"}`)
+			Expect(v.Validate()).To(MatchError(ContainSubstring("compositionRef and compositionSelector are mutually exclusive")))
+		})
+	})
+})
@@ -48,7 +48,9 @@ func Daemon() *defkit.ComponentDefinition {
 			defkit.String("name").Optional().Description("Name of the port"),
 			defkit.Enum("protocol").Values("TCP", "UDP", "SCTP").Default("TCP").Description("Protocol for port. Must be UDP, TCP, or SCTP"),
 			defkit.Bool("expose").Default(false).Description("Specify if the port should be exposed"),
-		)
+			defkit.Int("nodePort").Optional().Description("exposed node port. Only Valid when the Service of the port is NodePort"),
+		).
+		WithFields(ServicePortFields()...)
 
 	// Deprecated port parameter - fallback for older definitions
 	port := defkit.Int("port").
@@ -63,6 +65,9 @@ func Daemon() *defkit.ComponentDefinition {
 		Ignore().
 		Description("Specify what kind of Service you want. options: \"ClusterIP\", \"NodePort\", \"LoadBalancer\", \"ExternalName\"")
 
+	// Ingress or HTTPRoute to an exposed HTTP port
+	route := RouteParam()
+
 	addRevisionLabel := defkit.Bool("addRevisionLabel").
 		Default(false).
 		Ignore().
@@ -199,6 +204,12 @@ func Daemon() *defkit.ComponentDefinition {
 			defkit.StringList("hostnames"),
 		)
 
+	// Additional containers sharing the pod volumes with the main container
+	containers := ContainersParam()
+
+	// Sidecars starting before and stopping after the main container
+	sidecars := SidecarsParam()
+
 	return defkit.NewComponent("daemon").
 		Description("Describes daemonset services in Kubernetes.").
 		Workload("apps/v1", "DaemonSet").
@@ -207,12 +218,14 @@ func Daemon() *defkit.ComponentDefinition {
 		Params(
 			labels, annotations,
 			image, imagePullPolicy, imagePullSecrets,
-			port, ports, exposeType, addRevisionLabel,
+			port, ports, exposeType, route, addRevisionLabel,
 			cmd, env,
 			cpu, memory, volumeMounts, volumes,
 			livenessProbe, readinessProbe, hostAliases,
+			containers, sidecars,
 		).
 		Helper("HealthProbe", HealthProbeParam()).
+		Validators(ExposeValidators()...).
 		Template(daemonTemplate)
 }
 
@@ -222,7 +235,6 @@ func daemonTemplate(tpl *defkit.Template) {
 	image := defkit.String("image")
 	port := defkit.Int("port")
 	ports := defkit.List("ports")
-	exposeType := defkit.String("exposeType")
 	addRevisionLabel := defkit.Bool("addRevisionLabel")
 	cmd := defkit.StringList("cmd")
 	env := defkit.List("env")
@@ -237,6 +249,7 @@ func daemonTemplate(tpl *defkit.Template) {
 	annotations := defkit.Object("annotations")
 	imagePullPolicy := defkit.String("imagePullPolicy")
 	imagePullSecrets := defkit.StringList("imagePullSecrets")
+	sidecars := defkit.List("sidecars")
 
 	// Transform ports to container format using fluent collection API:
 	// {port, name, protocol, expose} -> {containerPort, name, protocol}
@@ -301,6 +314,33 @@ func daemonTemplate(tpl *defkit.Template) {
 	// Suppress unused variable warnings
 	_ = volumesList
 
+	// Primary container built from the top-level parameters
+	mainContainer := defkit.NewArrayElement().
+		Set("name", vela.Name()).
+		Set("image", image).
+		// Deprecated port fallback (before modern ports)
+		SetIf(defkit.And(port.IsSet(), ports.NotSet()), "ports", defkit.InlineArray(map[string]defkit.Value{
+			"containerPort": port,
+		})).
+		SetIf(ports.IsSet(), "ports", containerPorts).
+		SetIf(imagePullPolicy.IsSet(), "imagePullPolicy", imagePullPolicy).
+		SetIf(cmd.IsSet(), "command", cmd).
+		SetIf(env.IsSet(), "env", env).
+		SetIf(defkit.PathExists(`context["config"]`), "env", defkit.Reference("context.config")).
+		SetIf(cpu.IsSet(), "resources.limits.cpu", cpu).
+		SetIf(cpu.IsSet(), "resources.requests.cpu", cpu).
+		SetIf(memory.IsSet(), "resources.limits.memory", memory).
+		SetIf(memory.IsSet(), "resources.requests.memory", memory).
+		// Deprecated volumes fallback - container volumeMounts
+		SetIf(defkit.And(volumes.IsSet(), volumeMounts.NotSet()), "volumeMounts",
+			defkit.Each(volumes).Map(defkit.FieldMap{
+				"mountPath": defkit.FieldRef("mountPath"),
+				"name":      defkit.FieldRef("name"),
+			})).
+		SetIf(volumeMounts.IsSet(), "volumeMounts", mountsArray).
+		SetIf(livenessProbe.IsSet(), "livenessProbe", livenessProbe).
+		SetIf(readinessProbe.IsSet(), "readinessProbe", readinessProbe)
+
 	// Primary output: DaemonSet
 	daemonset := defkit.NewResource("apps/v1", "DaemonSet").
 		Set("spec.selector.matchLabels[app.oam.dev/component]", vela.Name()).
@@ -312,35 +352,8 @@ func daemonTemplate(tpl *defkit.Template) {
 		// SpreadIf spreads user labels inside the labels block
 		SpreadIf(labels.IsSet(), "spec.template.metadata.labels", labels).
 		SetIf(annotations.IsSet(), "spec.template.metadata.annotations", annotations).
-		// Container spec
-		Set("spec.template.spec.containers[0].name", vela.Name()).
-		Set("spec.template.spec.containers[0].image", image).
-		// Deprecated port fallback (before modern ports)
-		If(defkit.And(port.IsSet(), ports.NotSet())).
-		Set("spec.template.spec.containers[0].ports", defkit.InlineArray(map[string]defkit.Value{
-			"containerPort": port,
-		})).
-		EndIf().
-		SetIf(ports.IsSet(), "spec.template.spec.containers[0].ports", containerPorts).
-		SetIf(imagePullPolicy.IsSet(), "spec.template.spec.containers[0].imagePullPolicy", imagePullPolicy).
-		SetIf(cmd.IsSet(), "spec.template.spec.containers[0].command", cmd).
-		SetIf(env.IsSet(), "spec.template.spec.containers[0].env", env).
-		SetIf(defkit.PathExists(`context["config"]`), "spec.template.spec.containers[0].env", defkit.Reference("context.config")).
-		SetIf(cpu.IsSet(), "spec.template.spec.containers[0].resources.limits.cpu", cpu).
-		SetIf(cpu.IsSet(), "spec.template.spec.containers[0].resources.requests.cpu", cpu).
-		SetIf(memory.IsSet(), "spec.template.spec.containers[0].resources.limits.memory", memory).
-		SetIf(memory.IsSet(), "spec.template.spec.containers[0].resources.requests.memory", memory).
-		// Deprecated volumes fallback - container volumeMounts
-		If(defkit.And(volumes.IsSet(), volumeMounts.NotSet())).
-		Set("spec.template.spec.containers[0].volumeMounts",
-			defkit.Each(volumes).Map(defkit.FieldMap{
-				"mountPath": defkit.FieldRef("mountPath"),
-				"name":      defkit.FieldRef("name"),
-			})).
-		EndIf().
-		SetIf(volumeMounts.IsSet(), "spec.template.spec.containers[0].volumeMounts", mountsArray).
-		SetIf(livenessProbe.IsSet(), "spec.template.spec.containers[0].livenessProbe", livenessProbe).
-		SetIf(readinessProbe.IsSet(), "spec.template.spec.containers[0].readinessProbe", readinessProbe).
+		Set("spec.template.spec.containers", AdditionalContainers(defkit.NewArray().Item(mainContainer))).
+		SetIf(defkit.And(sidecars.LenGt(0), NativeSidecarsSupported()), "spec.template.spec.initContainers", NativeSidecars()).
 		// Pod spec
 		SetIf(hostAliases.IsSet(), "spec.template.spec.hostAliases", hostAliases).
 		Directive("spec.template.spec.hostAliases", "patchKey=ip").
@@ -381,29 +394,24 @@ func daemonTemplate(tpl *defkit.Template) {
 
 	tpl.Output(daemonset)
 
-	// exposePorts helper: Filter ports where expose=true and map to Service format
-	// Guard() adds the outer condition: if parameter.ports != _|_ for v in ...
-	// AfterOutput() places this helper after the output: block, matching the original CUE structure
-	exposePorts := tpl.Helper("exposePorts").
-		From(ports).
-		Guard(ports.IsSet()).
-		Filter(defkit.FieldEquals("expose", true)).
-		Map(defkit.FieldMap{
-			"port":       defkit.FieldRef("port"),
-			"targetPort": defkit.FieldRef("port"),
-			"name":       defkit.FieldRef("name").OrConditional(defkit.Format("port-%v", defkit.FieldRef("port"))),
-		}).
-		AfterOutput().
-		Build()
-
-	// Auxiliary output: Service (only if there are exposed ports)
-	service := defkit.NewResource("v1", "Service").
-		Set("metadata.name", vela.Name()).
-		Set("spec.selector[app.oam.dev/component]", vela.Name()).
-		Set("spec.ports", exposePorts).
-		Set("spec.type", exposeType)
+	// Services of the exposed ports, and the route to one of them
+	ExposeServices(tpl, "webserviceExpose", daemonServicePort)
+}
 
-	tpl.OutputsIf(exposePorts.NotEmpty(), "webserviceExpose", service)
+// daemonServicePort maps an exposed ports entry to its Service port: the port
+// named after itself by default. Unlike ServicePort it leaves the protocol
+// out, keeping the Service of existing daemons unchanged.
+func daemonServicePort(item *defkit.ItemBuilder) {
+	v := item.Var()
+
+	item.Set("port", v.Field("port"))
+	item.Set("targetPort", v.Field("port"))
+	item.IfSet("name", func() {
+		item.Set("name", v.Field("name"))
+	})
+	item.IfNotSet("name", func() {
+		item.Set("name", defkit.Plus(defkit.Lit("port-"), defkit.StrconvFormatInt(v.Field("port"), 10)))
+	})
 }
 
 func init() {
@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// helmChartLayerMediaType selects the chart tarball from an OCI artifact.
+const helmChartLayerMediaType = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+
+// HelmRelease creates the helm-release component definition.
+// It installs a Helm chart through the FluxCD helm-controller: a HelmRepository
+// or OCIRepository source is emitted alongside the HelmRelease that consumes it.
+func HelmRelease() *defkit.ComponentDefinition {
+	repoType := defkit.Enum("repoType").
+		Values("helm", "oci").
+		Default("helm").
+		Description("Type of the chart repository, `helm` for an HTTP(S) Helm repository or `oci` for an OCI registry")
+	url := defkit.String("url").
+		Description("URL of the chart repository, like `https://stefanprodan.github.io/podinfo` or `oci://ghcr.io/stefanprodan/charts`")
+	chart := defkit.String("chart").Description("Name of the chart in the repository")
+	version := defkit.String("version").
+		Optional().
+		Description("Semver version constraint of the chart, like `6.x` or `>=6.0.0 <7.0.0`. The latest version is used if empty")
+	values := defkit.Object("values").Optional().Description("Inline Helm values for the release")
+	valuesFrom := defkit.List("valuesFrom").Optional().
+		Description("References to ConfigMaps or Secrets holding Helm values, merged in order before the inline values").
+		WithFields(
+			defkit.Enum("kind").Values("ConfigMap", "Secret").Default("ConfigMap").Description("Kind of the values source"),
+			defkit.String("name").Description("Name of the ConfigMap or Secret in the application namespace"),
+			defkit.String("valuesKey").Optional().Description("Data key holding the values, defaults to `values.yaml`"),
+			defkit.String("targetPath").Optional().Description("YAML dot notation path the value is merged at, instead of merging the whole document"),
+			defkit.Bool("optional").Optional().Description("Do not fail the release when the source is missing"),
+		)
+	install := defkit.Object("install").Optional().Description("Helm install configuration").
+		WithFields(
+			defkit.Bool("createNamespace").Optional().Description("Create the target namespace if it does not exist"),
+			defkit.Object("remediation").Optional().Description("Actions taken when the install fails").
+				WithFields(
+					defkit.Int("retries").Optional().Description("Number of retries after a failed install, a negative value retries forever"),
+				),
+		)
+	upgrade := defkit.Object("upgrade").Optional().Description("Helm upgrade configuration").
+		WithFields(
+			defkit.Object("remediation").Optional().Description("Actions taken when the upgrade fails").
+				WithFields(
+					defkit.Int("retries").Optional().Description("Number of retries after a failed upgrade, a negative value retries forever"),
+					defkit.Bool("remediateLastFailure").Optional().Description("Remediate the last failure when no retries remain"),
+					defkit.Enum("strategy").Values("rollback", "uninstall").Optional().Description("Remediation strategy of a failed upgrade"),
+				),
+		)
+	targetNamespace := defkit.String("targetNamespace").
+		Optional().
+		Description("Namespace the chart is installed into, defaults to the application namespace")
+	interval := defkit.String("interval").
+		Default("5m").
+		Description("Interval at which the source and the release are reconciled")
+
+	return defkit.NewComponent("helm-release").
+		Description("Installs a Helm chart through a FluxCD HelmRelease.").
+		Workload("helm.toolkit.fluxcd.io/v2", "HelmRelease").
+		CustomStatus(ReadyConditionStatus("revision", "status.lastAttemptedRevision")).
+		HealthPolicy(ReadyConditionHealth()).
+		Params(
+			repoType, url, chart, version,
+			values, valuesFrom,
+			install, upgrade,
+			targetNamespace, interval,
+		).
+		Template(helmReleaseTemplate)
+}
+
+// helmReleaseTemplate defines the template function for helm-release.
+func helmReleaseTemplate(tpl *defkit.Template) {
+	vela := defkit.VelaCtx()
+
+	// Parameter references for template
+	repoType := defkit.String("repoType").Default("helm")
+	url := defkit.String("url")
+	chart := defkit.String("chart")
+	version := defkit.String("version")
+	values := defkit.Object("values")
+	valuesFrom := defkit.List("valuesFrom")
+	install := defkit.Object("install")
+	upgrade := defkit.Object("upgrade")
+	targetNamespace := defkit.String("targetNamespace")
+	interval := defkit.String("interval").Default("5m")
+
+	isHelm := defkit.Eq(repoType, defkit.Lit("helm"))
+	isOCI := defkit.Eq(repoType, defkit.Lit("oci"))
+
+	release := defkit.NewResource("helm.toolkit.fluxcd.io/v2", "HelmRelease").
+		Set("metadata.name", vela.Name()).
+		Set("spec.interval", interval).
+		If(isHelm).
+		Set("spec.chart.spec.chart", chart).
+		SetIf(version.IsSet(), "spec.chart.spec.version", version).
+		Set("spec.chart.spec.sourceRef.kind", defkit.Lit("HelmRepository")).
+		Set("spec.chart.spec.sourceRef.name", vela.Name()).
+		Set("spec.chart.spec.interval", interval).
+		EndIf().
+		If(isOCI).
+		Set("spec.chartRef.kind", defkit.Lit("OCIRepository")).
+		Set("spec.chartRef.name", vela.Name()).
+		EndIf().
+		SetIf(targetNamespace.IsSet(), "spec.targetNamespace", targetNamespace).
+		SetIf(install.IsSet(), "spec.install", install).
+		SetIf(upgrade.IsSet(), "spec.upgrade", upgrade).
+		SetIf(values.IsSet(), "spec.values", values).
+		SetIf(valuesFrom.IsSet(), "spec.valuesFrom", valuesFrom)
+
+	helmRepository := defkit.NewResource("source.toolkit.fluxcd.io/v1", "HelmRepository").
+		Set("metadata.name", vela.Name()).
+		Set("spec.url", url).
+		Set("spec.interval", interval)
+
+	ociRepository := defkit.NewResource("source.toolkit.fluxcd.io/v1beta2", "OCIRepository").
+		Set("metadata.name", vela.Name()).
+		Set("spec.url", defkit.Interpolation(url, defkit.Lit("/"), chart)).
+		Set("spec.interval", interval).
+		SetIf(version.IsSet(), "spec.ref.semver", version).
+		Set("spec.layerSelector.mediaType", defkit.Lit(helmChartLayerMediaType)).
+		Set("spec.layerSelector.operation", defkit.Lit("copy"))
+
+	tpl.Output(release)
+	tpl.OutputsIf(isHelm, "helmRepository", helmRepository)
+	tpl.OutputsIf(isOCI, "ociRepository", ociRepository)
+}
+
+func init() {
+	defkit.Register(HelmRelease())
+}
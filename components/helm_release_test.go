@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("HelmRelease Component", func() {
+	Describe("HelmRelease()", func() {
+		It("should create a helm-release component definition", func() {
+			comp := components.HelmRelease()
+			Expect(comp.GetName()).To(Equal("helm-release"))
+			Expect(comp.GetDescription()).To(ContainSubstring("HelmRelease"))
+		})
+
+		It("should have HelmRelease workload", func() {
+			comp := components.HelmRelease()
+			workload := comp.GetWorkload()
+			Expect(workload.APIVersion()).To(Equal("helm.toolkit.fluxcd.io/v2"))
+			Expect(workload.Kind()).To(Equal("HelmRelease"))
+		})
+
+		It("should have source and release parameters", func() {
+			comp := components.HelmRelease()
+			for _, name := range []string{"repoType", "url", "chart", "version", "values", "valuesFrom", "install", "upgrade", "targetNamespace", "interval"} {
+				Expect(comp).To(HaveParamNamed(name))
+			}
+		})
+
+		It("should derive status and health from the Ready condition", func() {
+			comp := components.HelmRelease()
+			Expect(comp.GetHealthPolicy()).To(Equal(components.ReadyConditionHealth()))
+			Expect(comp.GetCustomStatus()).To(ContainSubstring(`c.type == "Ready"`))
+			Expect(comp.GetCustomStatus()).To(ContainSubstring("context.output.status.lastAttemptedRevision"))
+		})
+	})
+
+	Describe("CUE Generation", func() {
+		var doc *cueassert.Document
+
+		BeforeEach(func() {
+			doc = cueassert.MustParse(components.HelmRelease().ToCue())
+		})
+
+		It("should generate the parameter schema", func() {
+			Expect(doc.Lookup("parameter.repoType")).To(cueassert.HaveDefault("helm"))
+			Expect(doc.Lookup("parameter.url")).To(cueassert.BeRequiredField())
+			Expect(doc.Lookup("parameter.chart")).To(cueassert.BeRequiredField())
+			Expect(doc.Lookup("parameter.version")).To(cueassert.BeOptionalField())
+			Expect(doc.Lookup("parameter.interval")).To(cueassert.HaveDefault("5m"))
+			Expect(doc.Lookup("parameter.valuesFrom.kind")).To(cueassert.HaveDefault("ConfigMap"))
+			Expect(doc.Lookup("parameter.upgrade.remediation.retries")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("int")))
+			Expect(doc.UntypedLists()).To(BeEmpty())
+		})
+
+		It("should emit both repository kinds as auxiliary outputs", func() {
+			Expect(doc.Outputs("helmRepository")).To(cueassert.HaveKind("HelmRepository"))
+			Expect(doc.Outputs("ociRepository")).To(cueassert.HaveKind("OCIRepository"))
+			Expect(doc.Lookup("outputs.ociRepository.spec.url")).To(cueassert.HaveValue(`"\(parameter.url)/\(parameter.chart)"`))
+		})
+	})
+
+	Describe("Render with TestContext", func() {
+		var comp *defkit.ComponentDefinition
+
+		BeforeEach(func() {
+			comp = components.HelmRelease()
+		})
+
+		It("should render a HelmRepository source for helm repositories", func() {
+			outputs := comp.RenderAll(
+				defkit.TestContext().
+					WithName("podinfo").
+					WithParam("url", "https://stefanprodan.github.io/podinfo").
+					WithParam("chart", "podinfo").
+					WithParam("version", "6.x").
+					WithParam("targetNamespace", "apps").
+					WithParam("values", map[string]any{"replicaCount": 2}).
+					WithParam("valuesFrom", []map[string]any{{"kind": "Secret", "name": "podinfo-values"}}).
+					WithParam("upgrade", map[string]any{"remediation": map[string]any{"retries": 3, "strategy": "rollback"}}),
+			)
+
+			release := outputs.Primary
+			Expect(release.Kind()).To(Equal("HelmRelease"))
+			Expect(release.Get("spec.interval")).To(Equal("5m"))
+			Expect(release.Get("spec.chart.spec.chart")).To(Equal("podinfo"))
+			Expect(release.Get("spec.chart.spec.version")).To(Equal("6.x"))
+			Expect(release.Get("spec.chart.spec.sourceRef.kind")).To(Equal("HelmRepository"))
+			Expect(release.Get("spec.chart.spec.sourceRef.name")).To(Equal("podinfo"))
+			Expect(release.Get("spec.targetNamespace")).To(Equal("apps"))
+			Expect(release.Get("spec.values.replicaCount")).To(Equal(2))
+			Expect(release.Get("spec.upgrade.remediation.retries")).To(Equal(3))
+			Expect(release.Get("spec.chartRef")).To(BeNil())
+
+			Expect(outputs.Auxiliary).To(HaveKey("helmRepository"))
+			Expect(outputs.Auxiliary).NotTo(HaveKey("ociRepository"))
+			Expect(outputs.Auxiliary["helmRepository"].Get("spec.url")).To(Equal("https://stefanprodan.github.io/podinfo"))
+		})
+
+		It("should render an OCIRepository source and chartRef for OCI registries", func() {
+			outputs := comp.RenderAll(
+				defkit.TestContext().
+					WithName("podinfo").
+					WithParam("repoType", "oci").
+					WithParam("url", "oci://ghcr.io/stefanprodan/charts").
+					WithParam("chart", "podinfo").
+					WithParam("version", ">=6.0.0 <7.0.0").
+					WithParam("interval", "10m"),
+			)
+
+			release := outputs.Primary
+			Expect(release.Get("spec.chartRef.kind")).To(Equal("OCIRepository"))
+			Expect(release.Get("spec.chartRef.name")).To(Equal("podinfo"))
+			Expect(release.Get("spec.chart")).To(BeNil())
+
+			Expect(outputs.Auxiliary).To(HaveKey("ociRepository"))
+			Expect(outputs.Auxiliary).NotTo(HaveKey("helmRepository"))
+			oci := outputs.Auxiliary["ociRepository"]
+			Expect(oci.Get("spec.ref.semver")).To(Equal(">=6.0.0 <7.0.0"))
+			Expect(oci.Get("spec.interval")).To(Equal("10m"))
+		})
+	})
+})
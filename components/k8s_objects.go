@@ -22,37 +22,121 @@ import (
 
 // K8sObjects creates the k8s-objects component definition.
 // K8s-objects allow users to specify raw K8s objects in properties.
+//
+// Only the parameters, status and health are fluent; the template stays a raw
+// header, as defkit has no builder for what it does. The output is the user's
+// object, while NewResource always renders a literal apiVersion and kind, and
+// the outputs are keyed by their objects, while Outputs takes a fixed name.
+// The ConfigMaps are read with kube.#Get, and KubeRead only renders the
+// kube.#Read of workflow steps.
 func K8sObjects() *defkit.ComponentDefinition {
+	objects := defkit.Array("objects").
+		Of(defkit.ParamTypeMap).
+		Description("Kubernetes objects to apply, the first one is the workload of the component")
+	objectsFrom := defkit.List("objectsFrom").
+		Optional().
+		Description("Load more objects from the YAML manifests in ConfigMaps of the application namespace").
+		WithFields(
+			defkit.String("name").Description("Name of the ConfigMap"),
+			defkit.StringList("keys").Optional().Description("Data keys holding the manifests, defaults to all keys. A key may hold several documents separated by `---`"),
+		)
+
 	return defkit.NewComponent("k8s-objects").
 		Description("K8s-objects allow users to specify raw K8s objects in properties").
 		AutodetectWorkload().
-		RawCUE(`"k8s-objects": {
-	type: "component"
-	annotations: {}
-	labels: {}
-	description: "K8s-objects allow users to specify raw K8s objects in properties"
-	attributes: workload: type: "autodetects.core.oam.dev"
+		WithImports("vela/kube", "encoding/yaml", "strings", "encoding/hex", "crypto/sha256").
+		CustomStatus(ObjectsStatus()).
+		HealthPolicy(ObjectsHealth()).
+		Params(objects, objectsFrom).
+		Template(k8sObjectsTemplate)
 }
-template: {
-	output: {
-		if len(parameter.objects) > 0 {
-			parameter.objects[0]
-		}
-		...
-	}
 
-	outputs: {
-		for i, v in parameter.objects {
-			if i > 0 {
-				"objects-\(i)": v
+// k8sObjectsHeader renders the objects. The first one is the output, the others
+// are outputs named <kind>.<namespace>.<name>, so reordering the objects keeps
+// their names. Output names become label values, so names over 63 characters
+// are shortened to the kind and a hash, and objects without a name fall back
+// to objects-<index>. Objects without a kind, objects given more than once,
+// even with another apiVersion, and keys missing from their ConfigMap are
+// reported through errs, which KubeVela lists as user errors of the component.
+// Only the first of the objects given more than once is rendered.
+const k8sObjectsHeader = `configMapsFrom: {
+	if parameter.objectsFrom != _|_ for i, ref in parameter.objectsFrom {
+		"\(i)": kube.#Get & {
+			$params: resource: {
+				apiVersion: "v1"
+				kind:       "ConfigMap"
+				metadata: {
+					name:      ref.name
+					namespace: context.namespace
+				}
 			}
 		}
 	}
-	parameter: {
-		objects: [...{}]
+}
+_objects: [
+	for i, o in parameter.objects { {source: "objects[\(i)]", object: o} },
+	if parameter.objectsFrom != _|_ for i, ref in parameter.objectsFrom if ref.keys == _|_ if configMapsFrom["\(i)"].$returns.data != _|_ for k, v in configMapsFrom["\(i)"].$returns.data for j, o in yaml.UnmarshalStream(v) if o != null { {source: "ConfigMap \(ref.name) key \(k) document \(j+1)", object: o} },
+	if parameter.objectsFrom != _|_ for i, ref in parameter.objectsFrom if ref.keys != _|_ for k in ref.keys if configMapsFrom["\(i)"].$returns.data[k] != _|_ for j, o in yaml.UnmarshalStream(configMapsFrom["\(i)"].$returns.data[k]) if o != null { {source: "ConfigMap \(ref.name) key \(k) document \(j+1)", object: o} },
+]
+_objectKey: {
+	object: {...}
+	index: int
+	key:   string
+
+	name: *"" | string
+	if object.metadata.name != _|_ {
+		name: object.metadata.name
+	}
+	namespace: *context.namespace | string
+	if object.metadata.namespace != _|_ {
+		namespace: object.metadata.namespace
+	}
+	kind: *"" | string
+	if object.kind != _|_ {
+		kind: object.kind
+	}
+	_kind: strings.ToLower(kind)
+	_id:   "\(_kind).\(namespace).\(name)"
+	if name == "" {
+		key: "objects-\(index)"
+	}
+	if name != "" && len(_id) <= 63 {
+		key: _id
+	}
+	if name != "" && len(_id) > 63 {
+		key: "\(_kind).\(strings.SliceRunes(hex.Encode(sha256.Sum256(_id)), 0, 16))"
 	}
 }
-`)
+_keys: [ for i, e in _objects { _objectKey & {object: e.object, index: i} } ]
+_firsts: [ for i, k in _keys {
+	[ for j, p in _keys if j < i && k.name != "" && k.kind != "" && p.key == k.key { j }, i ][0]
+} ]
+errs: [
+	if parameter.objectsFrom != _|_ for i, ref in parameter.objectsFrom if ref.keys != _|_ for k in ref.keys if configMapsFrom["\(i)"].$returns.data[k] == _|_ {
+		"ConfigMap \(ref.name) has no key \(k)"
+	},
+	for i, k in _keys if k.kind == "" {
+		"\(_objects[i].source) has no kind"
+	},
+	for i, k in _keys if _firsts[i] != i {
+		"\(_objects[i].source) and \(_objects[_firsts[i]].source) are both \(k.kind) \(k.namespace)/\(k.name)"
+	},
+]
+output: {
+	if len(_objects) > 0 {
+		_objects[0].object
+	}
+	...
+}
+outputs: {
+	for i, e in _objects if i > 0 if _firsts[i] == i {
+		(_keys[i].key): e.object
+	}
+}`
+
+// k8sObjectsTemplate defines the template function for k8s-objects.
+func k8sObjectsTemplate(tpl *defkit.Template) {
+	tpl.SetRawHeaderBlock(k8sObjectsHeader)
 }
 
 func init() {
@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"context"
+
+	"cuelang.org/go/cue"
+	"github.com/kubevela/pkg/cue/cuex"
+	"github.com/kubevela/pkg/cue/cuex/providers/kube"
+	"github.com/kubevela/pkg/util/singleton"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
+
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+// evalTemplateWithKube evaluates the template of def like evalTemplate, but
+// through the cuex compiler KubeVela renders components with, so vela/kube
+// reads are served from the given objects.
+func evalTemplateWithKube(def interface{ ToCue() string }, parameter string, objs ...client.Object) cue.Value {
+	singleton.KubeClient.Set(fake.NewClientBuilder().WithObjects(objs...).Build())
+	v, err := cuex.NewCompilerWithInternalPackages(kube.Package).CompileString(context.Background(),
+		def.ToCue()+"\ncontext: {name: \"site\", appName: \"docs\", namespace: \"default\"}\ntemplate: parameter: "+parameter)
+	Expect(err).NotTo(HaveOccurred())
+	tpl := v.LookupPath(cue.ParsePath("template"))
+	Expect(tpl.Validate(cue.Concrete(true))).To(Succeed())
+	return tpl
+}
+
+// outputNames returns the names of the auxiliary outputs of an evaluated template.
+func outputNames(tpl cue.Value) []string {
+	var names []string
+	it, err := tpl.LookupPath(cue.ParsePath("outputs")).Fields()
+	Expect(err).NotTo(HaveOccurred())
+	for it.Next() {
+		names = append(names, it.Selector().Unquoted())
+	}
+	return names
+}
+
+var _ = Describe("K8sObjects Component", func() {
+	Describe("K8sObjects()", func() {
+		It("should create a k8s-objects component definition", func() {
+			comp := components.K8sObjects()
+			Expect(comp.GetName()).To(Equal("k8s-objects"))
+			Expect(comp.GetWorkload().IsAutodetect()).To(BeTrue())
+			Expect(comp).To(HaveParamNamed("objects"))
+			Expect(comp).To(HaveParamNamed("objectsFrom"))
+		})
+
+		It("should check every object by the rules of its kind", func() {
+			comp := components.K8sObjects()
+			Expect(comp.GetHealthPolicy()).To(Equal(components.ObjectsHealth()))
+			Expect(comp.GetCustomStatus()).To(Equal(components.ObjectsStatus()))
+		})
+	})
+
+	Describe("CUE Generation", func() {
+		It("should keep objects open and objectsFrom optional", func() {
+			doc := cueassert.MustParse(components.K8sObjects().ToCue())
+			Expect(doc.Lookup("parameter.objectsFrom")).To(cueassert.BeOptionalField())
+			Expect(doc.Lookup("parameter.objectsFrom.keys")).To(cueassert.BeOptionalField())
+		})
+	})
+
+	Describe("Template", func() {
+		const (
+			deployment = `{apiVersion: "apps/v1", kind: "Deployment", metadata: name: "api", spec: {}}`
+			service    = `{apiVersion: "v1", kind: "Service", metadata: name: "api", spec: ports: [{port: 80}]}`
+			configMap  = `{apiVersion: "v1", kind: "ConfigMap", metadata: {name: "settings", namespace: "prod"}, data: level: "debug"}`
+		)
+
+		It("should name the outputs after their objects", func() {
+			v := evalTemplateWithKube(components.K8sObjects(), `{objects: [`+deployment+`, `+service+`, `+configMap+`]}`)
+			Expect(v.LookupPath(cue.ParsePath("output.kind")).String()).To(Equal("Deployment"))
+			Expect(outputNames(v)).To(Equal([]string{"service.default.api", "configmap.prod.settings"}))
+		})
+
+		It("should keep the output names when the objects are reordered", func() {
+			v := evalTemplateWithKube(components.K8sObjects(), `{objects: [`+deployment+`, `+configMap+`, `+service+`]}`)
+			Expect(outputNames(v)).To(ConsistOf("service.default.api", "configmap.prod.settings"))
+			Expect(v.LookupPath(cue.ParsePath(`outputs."configmap.prod.settings".data.level`)).String()).To(Equal("debug"))
+		})
+
+		It("should shorten long names and fall back to the index without a name", func() {
+			v := evalTemplateWithKube(components.K8sObjects(), `{objects: [`+deployment+`, {
+				apiVersion: "v1", kind: "ConfigMap", metadata: name: "a-configmap-with-a-name-long-enough-to-overflow-a-label-value"
+			}, {
+				apiVersion: "v1", kind: "ConfigMap", metadata: generateName: "scratch-"
+			}]}`)
+			names := outputNames(v)
+			Expect(names).To(HaveLen(2))
+			Expect(names[0]).To(MatchRegexp(`^configmap\.[0-9a-f]{16}$`))
+			Expect(names[1]).To(Equal("objects-2"))
+		})
+
+		It("should load the objects from every key of a ConfigMap", func() {
+			manifests := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "manifests", Namespace: "default"},
+				Data: map[string]string{
+					"app.yaml":  "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\nspec: {}\n---\napiVersion: v1\nkind: Service\nmetadata:\n  name: web\n",
+					"rbac.yaml": "---\napiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: web\n",
+				},
+			}
+			v := evalTemplateWithKube(components.K8sObjects(), `{objectsFrom: [{name: "manifests"}]}`, manifests)
+			Expect(v.LookupPath(cue.ParsePath("output.metadata.name")).String()).To(Equal("web"))
+			Expect(outputNames(v)).To(Equal([]string{"service.default.web", "serviceaccount.default.web"}))
+		})
+
+		It("should only load the given keys after the inline objects", func() {
+			manifests := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "manifests", Namespace: "default"},
+				Data: map[string]string{
+					"service.yaml": "apiVersion: v1\nkind: Service\nmetadata:\n  name: web\n",
+					"debug.yaml":   "apiVersion: v1\nkind: Pod\nmetadata:\n  name: debug\n",
+				},
+			}
+			v := evalTemplateWithKube(components.K8sObjects(), `{objects: [`+deployment+`], objectsFrom: [{name: "manifests", keys: ["service.yaml"]}]}`, manifests)
+			Expect(v.LookupPath(cue.ParsePath("output.metadata.name")).String()).To(Equal("api"))
+			Expect(outputNames(v)).To(Equal([]string{"service.default.web"}))
+			var errs []string
+			Expect(v.LookupPath(cue.ParsePath("errs")).Decode(&errs)).To(Succeed())
+			Expect(errs).To(BeEmpty())
+		})
+
+		It("should report the keys missing from a ConfigMap", func() {
+			manifests := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "manifests", Namespace: "default"},
+				Data:       map[string]string{"service.yaml": "apiVersion: v1\nkind: Service\nmetadata:\n  name: web\n"},
+			}
+			v := evalTemplateWithKube(components.K8sObjects(), `{objects: [`+deployment+`], objectsFrom: [{name: "manifests", keys: ["service.yaml", "ingress.yaml"]}]}`, manifests)
+			Expect(outputNames(v)).To(Equal([]string{"service.default.web"}))
+			var errs []string
+			Expect(v.LookupPath(cue.ParsePath("errs")).Decode(&errs)).To(Succeed())
+			Expect(errs).To(Equal([]string{"ConfigMap manifests has no key ingress.yaml"}))
+		})
+
+		It("should report objects given more than once and only render the first", func() {
+			manifests := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "manifests", Namespace: "default"},
+				Data:       map[string]string{"service.yaml": "apiVersion: v1\nkind: Service\nmetadata:\n  name: api\n"},
+			}
+			v := evalTemplateWithKube(components.K8sObjects(), `{objects: [`+deployment+`, `+service+`, {
+				apiVersion: "apps/v1beta1", kind: "Deployment", metadata: name: "api"
+			}], objectsFrom: [{name: "manifests"}]}`, manifests)
+			Expect(outputNames(v)).To(Equal([]string{"service.default.api"}))
+			Expect(v.LookupPath(cue.ParsePath(`outputs."service.default.api".spec.ports[0].port`)).Int64()).To(BeEquivalentTo(80))
+			var errs []string
+			Expect(v.LookupPath(cue.ParsePath("errs")).Decode(&errs)).To(Succeed())
+			Expect(errs).To(Equal([]string{
+				"objects[2] and objects[0] are both Deployment default/api",
+				"ConfigMap manifests key service.yaml document 1 and objects[1] are both Service default/api",
+			}))
+		})
+
+		It("should report objects without a kind", func() {
+			v := evalTemplateWithKube(components.K8sObjects(), `{objects: [`+deployment+`, {apiVersion: "v1", metadata: name: "settings"}]}`)
+			var errs []string
+			Expect(v.LookupPath(cue.ParsePath("errs")).Decode(&errs)).To(Succeed())
+			Expect(errs).To(Equal([]string{"objects[1] has no kind"}))
+		})
+	})
+})
@@ -0,0 +1,237 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// KedaScaledJob creates the keda-scaled-job component definition.
+// It runs the container of a task as a KEDA ScaledJob, which starts Jobs as
+// events arrive on the configured triggers instead of a fixed count.
+//
+// The triggers are a raw let: KEDA takes the scaler settings as a string map of
+// every trigger field but type, name and authenticationRef, and ForEachMap has
+// neither a key filter nor a way to run inside the list of triggers.
+func KedaScaledJob() *defkit.ComponentDefinition {
+	labels := defkit.StringKeyMap("labels").Optional().Description("Specify the labels in the workload")
+	annotations := defkit.StringKeyMap("annotations").Optional().Description("Specify the annotations in the workload")
+	image := defkit.String("image").Description("Which image would you like to use for your service").Short("i")
+	imagePullPolicy := defkit.String("imagePullPolicy").
+		Optional().
+		Values("Always", "Never", "IfNotPresent").
+		Description("Specify image pull policy for your service")
+	imagePullSecrets := defkit.StringList("imagePullSecrets").Optional().Description("Specify image pull secrets for your service")
+	restart := defkit.String("restart").Default("Never").
+		Description("Define the job restart policy, the value can only be Never or OnFailure. By default, it's Never.")
+	cmd := defkit.StringList("cmd").Optional().Description("Commands to run in the container")
+	env := ContainerEnvParam()
+	cpu := defkit.String("cpu").Optional().Description("Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)")
+	memory := defkit.String("memory").Optional().Description("Specifies the attributes of the memory resource required for the container.")
+	volumes := TaskVolumesParam()
+
+	// Job controls, applied to every Job the ScaledJob starts.
+	parallelism := defkit.Int("parallelism").Optional().
+		Description("Number of pods of each job running at the same time")
+	completions := defkit.Int("completions").Optional().
+		Description("Number of successful pods each job needs to complete")
+	backoffLimit := defkit.Int("backoffLimit").Optional().
+		Description("Number of retries of each job before it is marked as failed")
+	activeDeadlineSeconds := defkit.Int("activeDeadlineSeconds").Optional().
+		Description("Fail a job once it has been running for this many seconds")
+
+	triggers := defkit.List("triggers").
+		Description("Event sources the jobs are scaled on. The scaler settings are passed to KEDA as trigger metadata").
+		WithFields(
+			defkit.String("name").Optional().Description("Name of the trigger, shown in the KEDA metrics"),
+			defkit.Object("authenticationRef").Optional().
+				Description("TriggerAuthentication holding the credentials of the event source").
+				WithFields(
+					defkit.String("name").Description("Name of the TriggerAuthentication"),
+					defkit.Enum("kind").Values("TriggerAuthentication", "ClusterTriggerAuthentication").
+						Default("TriggerAuthentication").
+						Description("Use ClusterTriggerAuthentication for credentials shared across namespaces"),
+				),
+			defkit.OneOf("type").
+				Description("Specify the scaler, options: \"kafka\",\"rabbitmq\",\"redis\" (list length),\"prometheus\",\"cron\"").
+				Variants(
+					defkit.Variant("kafka").WithFields(
+						defkit.Field("bootstrapServers", defkit.ParamTypeString).Description("Comma separated list of Kafka brokers"),
+						defkit.Field("consumerGroup", defkit.ParamTypeString).Description("Consumer group whose lag is measured"),
+						defkit.Field("topic", defkit.ParamTypeString).Optional().Description("Topic to measure, all topics of the group when unset"),
+						defkit.Field("lagThreshold", defkit.ParamTypeInt).Default(10).Description("Lag per job"),
+						defkit.Field("offsetResetPolicy", defkit.ParamTypeString).Optional().Values("latest", "earliest").
+							Description("Offset of a consumer group without a committed offset"),
+					),
+					defkit.Variant("rabbitmq").WithFields(
+						defkit.Field("queueName", defkit.ParamTypeString).Description("Queue to measure"),
+						defkit.Field("host", defkit.ParamTypeString).Optional().
+							Description("Connection string of the broker, usually read from the authenticationRef instead"),
+						defkit.Field("mode", defkit.ParamTypeString).Default("QueueLength").Values("QueueLength", "MessageRate").
+							Description("Scale on the queue length or the publish rate"),
+						defkit.Field("value", defkit.ParamTypeString).Default("5").Description("Messages, or messages per second, per job"),
+						defkit.Field("protocol", defkit.ParamTypeString).Default("auto").Values("auto", "amqp", "http").
+							Description("Protocol used to read the queue"),
+					),
+					defkit.Variant("redis").WithFields(
+						defkit.Field("address", defkit.ParamTypeString).Description("Address of the Redis server, like `redis:6379`"),
+						defkit.Field("listName", defkit.ParamTypeString).Description("List to measure"),
+						defkit.Field("listLength", defkit.ParamTypeInt).Default(5).Description("List items per job"),
+						defkit.Field("databaseIndex", defkit.ParamTypeInt).Optional().Description("Index of the database holding the list"),
+						defkit.Field("enableTLS", defkit.ParamTypeBool).Optional().Description("Connect over TLS"),
+					),
+					defkit.Variant("prometheus").WithFields(
+						defkit.Field("serverAddress", defkit.ParamTypeString).Description("Address of the Prometheus server"),
+						defkit.Field("query", defkit.ParamTypeString).Description("PromQL query returning a single value"),
+						defkit.Field("threshold", defkit.ParamTypeString).Description("Query value per job, like `100` or `2.5`"),
+						defkit.Field("namespace", defkit.ParamTypeString).Optional().Description("Tenant of a multi-tenant Prometheus, like Thanos or Cortex"),
+					),
+					defkit.Variant("cron").WithFields(
+						defkit.Field("timezone", defkit.ParamTypeString).Default("Etc/UTC").Description("IANA time zone of start and end"),
+						defkit.Field("start", defkit.ParamTypeString).Description("Cron expression starting the window, like `0 8 * * *`"),
+						defkit.Field("end", defkit.ParamTypeString).Description("Cron expression ending the window, like `0 18 * * *`"),
+						defkit.Field("desiredReplicas", defkit.ParamTypeInt).Default(1).Description("Jobs running during the window"),
+					),
+				),
+		)
+
+	pollingInterval := defkit.Int("pollingInterval").Default(30).
+		Description("Seconds between checks of the triggers")
+	minReplicaCount := defkit.Int("minReplicaCount").Optional().
+		Description("Jobs kept running even when the triggers are idle")
+	maxReplicaCount := defkit.Int("maxReplicaCount").Default(100).
+		Description("Maximum number of jobs running at the same time")
+	successfulJobsHistoryLimit := defkit.Int("successfulJobsHistoryLimit").Default(100).
+		Description("Number of successful jobs to keep")
+	failedJobsHistoryLimit := defkit.Int("failedJobsHistoryLimit").Default(100).
+		Description("Number of failed jobs to keep")
+	scalingStrategy := defkit.Object("scalingStrategy").
+		Description("How the number of new jobs is computed from the pending events").
+		WithFields(
+			defkit.Enum("strategy").Values("default", "custom", "accurate", "eager").Default("default").
+				Description("Use accurate when consumed messages leave the queue, eager to fill up to maxReplicaCount, custom to tune the deduction"),
+			defkit.Int("customScalingQueueLengthDeduction").Optional().
+				Description("Events subtracted from the queue length before scaling. Requires the custom strategy"),
+			defkit.String("customScalingRunningJobPercentage").Optional().
+				Description("Fraction of the running jobs subtracted before scaling, like `0.5`. Requires the custom strategy"),
+			defkit.Enum("multipleScalersCalculation").Values("max", "min", "avg", "sum").Optional().
+				Description("How the jobs wanted by several triggers are combined, max by default"),
+		)
+
+	return defkit.NewComponent("keda-scaled-job").
+		Description("Describes jobs started by KEDA as events arrive, such as queue messages or metrics.").
+		Workload("keda.sh/v1alpha1", "ScaledJob").
+		CustomStatus(kedaScaledJobStatus).
+		HealthPolicy(ReadyConditionHealth()).
+		Params(
+			labels, annotations,
+			image, imagePullPolicy, imagePullSecrets, restart, cmd, env, cpu, memory, volumes,
+			parallelism, completions, backoffLimit, activeDeadlineSeconds,
+			triggers,
+			pollingInterval, minReplicaCount, maxReplicaCount,
+			successfulJobsHistoryLimit, failedJobsHistoryLimit,
+			scalingStrategy,
+		).
+		Validators(
+			defkit.Validate("customScalingQueueLengthDeduction and customScalingRunningJobPercentage require the custom strategy").
+				WithName("_validateScalingStrategy").
+				FailWhen(defkit.Or(
+					defkit.And(scalingStrategy.Field("customScalingQueueLengthDeduction").IsSet(), scalingStrategy.Field("strategy").Ne("custom")),
+					defkit.And(scalingStrategy.Field("customScalingRunningJobPercentage").IsSet(), scalingStrategy.Field("strategy").Ne("custom")),
+				)),
+		).
+		Template(kedaScaledJobTemplate)
+}
+
+// kedaScaledJobStatus reports the Ready condition until KEDA accepts the
+// ScaledJob, then whether the triggers are active and jobs are being started.
+// KEDA does not count the running jobs, so the last time a trigger was active is
+// shown instead.
+const kedaScaledJobStatus = readyConditionPreamble + `
+_readyMessage: *"waiting for the Ready condition" | string
+if len(_ready) > 0 if _ready[0].message != _|_ {
+	_readyMessage: _ready[0].message
+}
+_active: [ for c in _conditions if c.type == "Active" && c.status == "True" { c } ]
+_lastActive: *"" | string
+if context.output.status != _|_ if context.output.status.lastActiveTime != _|_ {
+	_lastActive: ", last active: \(context.output.status.lastActiveTime)"
+}
+message: *_readyMessage | string
+if len(_ready) > 0 if _ready[0].status == "True" {
+	if len(_active) > 0 {
+		message: "Ready, jobs active"
+	}
+	if len(_active) == 0 {
+		message: "Ready, no active jobs\(_lastActive)"
+	}
+}`
+
+// kedaScaledJobHeader turns each trigger into a KEDA trigger. Every scaler
+// field becomes a metadata entry, as KEDA reads all settings as strings.
+const kedaScaledJobHeader = `let _triggers = [ for t in parameter.triggers {
+	type: t.type
+	if t.name != _|_ {
+		name: t.name
+	}
+	metadata: {
+		for k, v in t if k != "type" && k != "name" && k != "authenticationRef" {
+			(k): "\(v)"
+		}
+	}
+	if t.authenticationRef != _|_ {
+		authenticationRef: t.authenticationRef
+	}
+}]`
+
+// kedaScaledJobTemplate defines the template function for keda-scaled-job.
+func kedaScaledJobTemplate(tpl *defkit.Template) {
+	vela := defkit.VelaCtx()
+
+	parallelism := defkit.Int("parallelism")
+	completions := defkit.Int("completions")
+	backoffLimit := defkit.Int("backoffLimit")
+	activeDeadlineSeconds := defkit.Int("activeDeadlineSeconds")
+	pollingInterval := defkit.Int("pollingInterval")
+	minReplicaCount := defkit.Int("minReplicaCount")
+	maxReplicaCount := defkit.Int("maxReplicaCount")
+	successfulJobsHistoryLimit := defkit.Int("successfulJobsHistoryLimit")
+	failedJobsHistoryLimit := defkit.Int("failedJobsHistoryLimit")
+	scalingStrategy := defkit.Object("scalingStrategy")
+
+	tpl.SetRawHeaderBlock(kedaScaledJobHeader)
+
+	scaledJob := defkit.NewResource("keda.sh/v1alpha1", "ScaledJob").
+		Set("metadata.name", vela.Name()).
+		SetIf(parallelism.IsSet(), "spec.jobTargetRef.parallelism", parallelism).
+		SetIf(completions.IsSet(), "spec.jobTargetRef.completions", completions).
+		SetIf(backoffLimit.IsSet(), "spec.jobTargetRef.backoffLimit", backoffLimit).
+		SetIf(activeDeadlineSeconds.IsSet(), "spec.jobTargetRef.activeDeadlineSeconds", activeDeadlineSeconds).
+		Set("spec.pollingInterval", pollingInterval).
+		SetIf(minReplicaCount.IsSet(), "spec.minReplicaCount", minReplicaCount).
+		Set("spec.maxReplicaCount", maxReplicaCount).
+		Set("spec.successfulJobsHistoryLimit", successfulJobsHistoryLimit).
+		Set("spec.failedJobsHistoryLimit", failedJobsHistoryLimit).
+		Set("spec.scalingStrategy", scalingStrategy).
+		Set("spec.triggers", defkit.LetVariable("_triggers"))
+
+	tpl.Output(taskPodTemplate(scaledJob, "spec.jobTargetRef.template"))
+}
+
+func init() {
+	defkit.Register(KedaScaledJob())
+}
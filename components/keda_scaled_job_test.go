@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
+
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("KedaScaledJob Component", func() {
+	Describe("KedaScaledJob()", func() {
+		It("should create a keda-scaled-job component definition", func() {
+			comp := components.KedaScaledJob()
+			Expect(comp.GetName()).To(Equal("keda-scaled-job"))
+			Expect(comp.GetDescription()).To(ContainSubstring("KEDA"))
+		})
+
+		It("should have ScaledJob workload", func() {
+			workload := components.KedaScaledJob().GetWorkload()
+			Expect(workload.APIVersion()).To(Equal("keda.sh/v1alpha1"))
+			Expect(workload.Kind()).To(Equal("ScaledJob"))
+		})
+
+		It("should share the container parameters of task", func() {
+			comp := components.KedaScaledJob()
+			for _, name := range []string{"image", "cmd", "env", "cpu", "memory", "volumes", "triggers", "pollingInterval", "maxReplicaCount", "scalingStrategy", "successfulJobsHistoryLimit", "failedJobsHistoryLimit"} {
+				Expect(comp).To(HaveParamNamed(name))
+			}
+		})
+	})
+
+	Describe("Status", func() {
+		const accepted = `{type: "Ready", status: "True", reason: "ScaledJobReady", message: "ScaledJob is defined correctly and is ready to scaling"}`
+
+		DescribeTable("health",
+			func(output string, healthy bool) {
+				v := evalStatus(components.KedaScaledJob().GetHealthPolicy(), output)
+				Expect(v.LookupPath(cue.ParsePath("isHealth")).Bool()).To(Equal(healthy))
+			},
+			Entry("ready", `{status: {conditions: [`+accepted+`]}}`, true),
+			Entry("invalid trigger", `{status: {conditions: [{type: "Ready", status: "False", reason: "ScaledJobCheckFailed"}]}}`, false),
+			Entry("no status reported yet", `{spec: {}}`, false),
+		)
+
+		DescribeTable("message",
+			func(output, message string) {
+				v := evalStatus(components.KedaScaledJob().GetCustomStatus(), output)
+				Expect(v.LookupPath(cue.ParsePath("message")).String()).To(Equal(message))
+			},
+			Entry("triggers active", `{status: {conditions: [`+accepted+`, {type: "Active", status: "True"}]}}`, "Ready, jobs active"),
+			Entry("triggers idle", `{status: {lastActiveTime: "2025-06-01T10:00:00Z", conditions: [`+accepted+`, {type: "Active", status: "False"}]}}`,
+				"Ready, no active jobs, last active: 2025-06-01T10:00:00Z"),
+			Entry("invalid trigger", `{status: {conditions: [{type: "Ready", status: "False", message: "error parsing kafka metadata"}]}}`, "error parsing kafka metadata"),
+			Entry("no status reported yet", `{spec: {}}`, "waiting for the Ready condition"),
+		)
+	})
+
+	Describe("CUE Generation", func() {
+		var doc *cueassert.Document
+
+		BeforeEach(func() {
+			doc = cueassert.MustParse(components.KedaScaledJob().ToCue())
+		})
+
+		It("should default to the KEDA scaling settings", func() {
+			Expect(doc.Lookup("parameter.triggers")).To(cueassert.BeRequiredField())
+			Expect(doc.Lookup("parameter.pollingInterval")).To(cueassert.HaveDefault(30))
+			Expect(doc.Lookup("parameter.maxReplicaCount")).To(cueassert.HaveDefault(100))
+			Expect(doc.Lookup("parameter.scalingStrategy.strategy")).To(cueassert.HaveDefault("default"))
+			Expect(doc.Lookup("parameter.minReplicaCount")).To(cueassert.BeOptionalField())
+		})
+	})
+
+	Describe("Template", func() {
+		lookup := func(v cue.Value, path string) string {
+			s, err := v.LookupPath(cue.ParsePath(path)).String()
+			Expect(err).NotTo(HaveOccurred(), path)
+			return s
+		}
+
+		It("should run the task container for each event", func() {
+			v := evalTemplate(components.KedaScaledJob(), `{
+				image: "busybox"
+				cmd: ["sh", "-c", "consume"]
+				volumes: [{name: "scratch", mountPath: "/scratch"}]
+				triggers: [{type: "cron", start: "0 8 * * *", end: "0 18 * * *"}]
+			}`)
+			Expect(lookup(v, "output.metadata.name")).To(Equal("site"))
+			Expect(lookup(v, "output.spec.jobTargetRef.template.spec.containers[0].image")).To(Equal("busybox"))
+			Expect(lookup(v, "output.spec.jobTargetRef.template.spec.containers[0].volumeMounts[0].mountPath")).To(Equal("/scratch"))
+			Expect(lookup(v, "output.spec.jobTargetRef.template.spec.restartPolicy")).To(Equal("Never"))
+			Expect(lookup(v, "output.spec.jobTargetRef.template.metadata.labels[\"app.oam.dev/component\"]")).To(Equal("site"))
+			Expect(lookup(v, "output.spec.scalingStrategy.strategy")).To(Equal("default"))
+			Expect(v.LookupPath(cue.ParsePath("output.spec.pollingInterval")).Int64()).To(BeEquivalentTo(30))
+		})
+
+		It("should pass the scaler settings as string metadata", func() {
+			v := evalTemplate(components.KedaScaledJob(), `{
+				image: "busybox"
+				triggers: [{
+					type: "kafka"
+					name: "orders"
+					bootstrapServers: "kafka:9092"
+					consumerGroup: "billing"
+					topic: "orders"
+					authenticationRef: name: "kafka-creds"
+				}, {
+					type: "redis"
+					address: "redis:6379"
+					listName: "jobs"
+					enableTLS: true
+				}]
+			}`)
+			Expect(lookup(v, "output.spec.triggers[0].type")).To(Equal("kafka"))
+			Expect(lookup(v, "output.spec.triggers[0].name")).To(Equal("orders"))
+			Expect(lookup(v, "output.spec.triggers[0].metadata.lagThreshold")).To(Equal("10"))
+			Expect(lookup(v, "output.spec.triggers[0].metadata.topic")).To(Equal("orders"))
+			Expect(v.LookupPath(cue.ParsePath("output.spec.triggers[0].metadata.name")).Exists()).To(BeFalse())
+			Expect(lookup(v, "output.spec.triggers[0].authenticationRef.kind")).To(Equal("TriggerAuthentication"))
+			Expect(lookup(v, "output.spec.triggers[1].metadata.listLength")).To(Equal("5"))
+			Expect(lookup(v, "output.spec.triggers[1].metadata.enableTLS")).To(Equal("true"))
+			Expect(v.LookupPath(cue.ParsePath("output.spec.triggers[1].authenticationRef")).Exists()).To(BeFalse())
+		})
+
+		It("should reject custom scaling settings without the custom strategy", func() {
+			v := cuecontext.New().CompileString(components.KedaScaledJob().ToCue() + `
+context: {name: "site", appName: "docs", namespace: "default"}
+template: parameter: {image: "busybox", triggers: [{type: "cron", start: "0 8 * * *", end: "0 18 * * *"}], scalingStrategy: {strategy: "accurate", customScalingQueueLengthDeduction: 1}}`)
+			Expect(v.Validate()).To(MatchError(ContainSubstring("require the custom strategy")))
+		})
+	})
+})
@@ -0,0 +1,195 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// knativeVolumeMountSources are the volume sources Knative Serving accepts
+// without enabling feature flags; pvc and hostPath are rejected by its webhook.
+var knativeVolumeMountSources = []string{"configMap", "secret", "emptyDir"}
+
+// KnativeService creates the knative-service component definition.
+// It describes a request-driven, autoscaled container served by Knative Serving,
+// reusing the container parameters of webservice.
+func KnativeService() *defkit.ComponentDefinition {
+	labels := defkit.StringKeyMap("labels").Optional().Description("Specify the labels of the revisions")
+	annotations := defkit.StringKeyMap("annotations").Optional().Description("Specify the annotations of the revisions")
+	image := defkit.String("image").Description("Which image would you like to use for your service").Short("i")
+	imagePullPolicy := defkit.Enum("imagePullPolicy").
+		Optional().
+		Values("Always", "Never", "IfNotPresent").
+		Description("Specify image pull policy for your service")
+	imagePullSecrets := defkit.StringList("imagePullSecrets").
+		Optional().
+		Description("Specify image pull secrets for your service")
+	port := defkit.Int("port").
+		Optional().
+		Description("Port the container listens on for requests, Knative defaults to 8080").
+		Short("p")
+	cmd := defkit.StringList("cmd").Optional().Description("Commands to run in the container")
+	args := defkit.StringList("args").Optional().Description("Arguments to the entrypoint")
+	env := ContainerEnvParam()
+	cpu := defkit.String("cpu").Optional().Description("Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)")
+	memory := defkit.String("memory").Optional().Description("Specifies the attributes of the memory resource required for the container.")
+	volumeMounts := VolumeMountsParam(knativeVolumeMountSources...)
+	livenessProbe := defkit.Object("livenessProbe").
+		Optional().
+		Description("Instructions for assessing whether the container is alive.").
+		WithSchemaRef("HealthProbe")
+	readinessProbe := defkit.Object("readinessProbe").
+		Optional().
+		Description("Instructions for assessing whether the container is in a suitable state to serve traffic.").
+		WithSchemaRef("HealthProbe")
+
+	minScale := defkit.Int("minScale").
+		Optional().
+		Description("Minimum number of replicas of each revision, takes precedence over scaleToZero")
+	maxScale := defkit.Int("maxScale").
+		Optional().
+		Description("Maximum number of replicas of each revision, 0 means unlimited")
+	concurrencyTarget := defkit.Int("concurrencyTarget").
+		Optional().
+		Description("Number of in-flight requests per replica the autoscaler aims for")
+	scaleToZero := defkit.Bool("scaleToZero").
+		Default(true).
+		Description("Scale revisions to zero replicas when they receive no traffic. If false, at least one replica is kept")
+	containerConcurrency := defkit.Int("containerConcurrency").
+		Optional().
+		Description("Hard limit of concurrent requests per replica, 0 means unlimited")
+	timeoutSeconds := defkit.Int("timeoutSeconds").
+		Optional().
+		Description("Maximum duration in seconds to respond to a request")
+	revisionSuffix := defkit.String("revisionSuffix").
+		Optional().
+		Description("Name the revision created by this version `<component>-<revisionSuffix>`, so traffic can refer to it")
+	traffic := defkit.List("traffic").
+		Optional().
+		Description("Split of the traffic between revisions. All traffic goes to the latest ready revision if empty").
+		WithFields(
+			defkit.String("revisionName").Optional().Description("Revision receiving this share of the traffic"),
+			defkit.Bool("latestRevision").Optional().Description("Send this share of the traffic to the latest ready revision instead of revisionName"),
+			defkit.Int("percent").Description("Share of the traffic in percent, the shares must add up to 100"),
+			defkit.String("tag").Optional().Description("Expose the target on its own URL `<tag>-<component>.<domain>`"),
+		)
+
+	return defkit.NewComponent("knative-service").
+		Description("Describes request-driven, autoscaled containerized services served by Knative Serving.").
+		Workload("serving.knative.dev/v1", "Service").
+		CustomStatus(ReadyConditionStatus("url", "status.url")).
+		HealthPolicy(ReadyConditionHealth()).
+		Helper("HealthProbe", HealthProbeParam()).
+		Params(
+			labels, annotations,
+			image, imagePullPolicy, imagePullSecrets, port,
+			cmd, args, env,
+			cpu, memory, volumeMounts,
+			livenessProbe, readinessProbe,
+			minScale, maxScale, concurrencyTarget, scaleToZero,
+			containerConcurrency, timeoutSeconds,
+			revisionSuffix, traffic,
+		).
+		Template(knativeServiceTemplate)
+}
+
+// knativeServiceTemplate defines the template function for knative-service.
+func knativeServiceTemplate(tpl *defkit.Template) {
+	vela := defkit.VelaCtx()
+
+	// Parameter references for template
+	labels := defkit.StringKeyMap("labels")
+	annotations := defkit.StringKeyMap("annotations")
+	image := defkit.String("image")
+	imagePullPolicy := defkit.String("imagePullPolicy")
+	imagePullSecrets := defkit.StringList("imagePullSecrets")
+	port := defkit.Int("port")
+	cmd := defkit.StringList("cmd")
+	args := defkit.StringList("args")
+	env := defkit.List("env")
+	cpu := defkit.String("cpu")
+	memory := defkit.String("memory")
+	volumeMounts := defkit.Object("volumeMounts")
+	livenessProbe := defkit.Object("livenessProbe")
+	readinessProbe := defkit.Object("readinessProbe")
+	minScale := defkit.Int("minScale")
+	maxScale := defkit.Int("maxScale")
+	concurrencyTarget := defkit.Int("concurrencyTarget")
+	scaleToZero := defkit.Bool("scaleToZero").Default(true)
+	containerConcurrency := defkit.Int("containerConcurrency")
+	timeoutSeconds := defkit.Int("timeoutSeconds")
+	revisionSuffix := defkit.String("revisionSuffix")
+	traffic := defkit.List("traffic")
+
+	mountsArray := tpl.Helper("mountsArray").
+		FromFields(volumeMounts, knativeVolumeMountSources...).
+		Pick("name", "mountPath").
+		PickIf(defkit.ItemFieldIsSet("subPath"), "subPath").
+		Build()
+	volumesList := tpl.Helper("volumesList").
+		FromFields(volumeMounts, knativeVolumeMountSources...).
+		MapBySource(podVolumeMappings()).
+		Build()
+	deDupVolumesArray := tpl.Helper("deDupVolumesArray").
+		FromHelper(volumesList).
+		Dedupe("name").
+		Build()
+
+	service := defkit.NewResource("serving.knative.dev/v1", "Service").
+		Set("metadata.name", vela.Name()).
+		SetIf(revisionSuffix.IsSet(), "spec.template.metadata.name",
+			defkit.Interpolation(vela.Name(), defkit.Lit("-"), revisionSuffix)).
+		SpreadIf(labels.IsSet(), "spec.template.metadata.labels", labels).
+		Set("spec.template.metadata.labels[app.oam.dev/name]", vela.AppName()).
+		Set("spec.template.metadata.labels[app.oam.dev/component]", vela.Name()).
+		SpreadIf(annotations.IsSet(), "spec.template.metadata.annotations", annotations).
+		SetIf(minScale.IsSet(), "spec.template.metadata.annotations[autoscaling.knative.dev/min-scale]", defkit.Interpolation(minScale)).
+		SetIf(defkit.And(minScale.NotSet(), scaleToZero.IsFalse()), "spec.template.metadata.annotations[autoscaling.knative.dev/min-scale]", defkit.Lit("1")).
+		SetIf(maxScale.IsSet(), "spec.template.metadata.annotations[autoscaling.knative.dev/max-scale]", defkit.Interpolation(maxScale)).
+		SetIf(concurrencyTarget.IsSet(), "spec.template.metadata.annotations[autoscaling.knative.dev/target]", defkit.Interpolation(concurrencyTarget)).
+		SetIf(containerConcurrency.IsSet(), "spec.template.spec.containerConcurrency", containerConcurrency).
+		SetIf(timeoutSeconds.IsSet(), "spec.template.spec.timeoutSeconds", timeoutSeconds).
+		Set("spec.template.spec.containers[0].name", vela.Name()).
+		Set("spec.template.spec.containers[0].image", image).
+		SetIf(port.IsSet(), "spec.template.spec.containers[0].ports", defkit.InlineArray(map[string]defkit.Value{
+			"containerPort": port,
+		})).
+		SetIf(imagePullPolicy.IsSet(), "spec.template.spec.containers[0].imagePullPolicy", imagePullPolicy).
+		SetIf(cmd.IsSet(), "spec.template.spec.containers[0].command", cmd).
+		SetIf(args.IsSet(), "spec.template.spec.containers[0].args", args).
+		SetIf(env.IsSet(), "spec.template.spec.containers[0].env", env).
+		If(cpu.IsSet()).
+		Set("spec.template.spec.containers[0].resources.limits.cpu", cpu).
+		Set("spec.template.spec.containers[0].resources.requests.cpu", cpu).
+		EndIf().
+		If(memory.IsSet()).
+		Set("spec.template.spec.containers[0].resources.limits.memory", memory).
+		Set("spec.template.spec.containers[0].resources.requests.memory", memory).
+		EndIf().
+		SetIf(volumeMounts.IsSet(), "spec.template.spec.containers[0].volumeMounts", mountsArray).
+		SetIf(livenessProbe.IsSet(), "spec.template.spec.containers[0].livenessProbe", livenessProbe).
+		SetIf(readinessProbe.IsSet(), "spec.template.spec.containers[0].readinessProbe", readinessProbe).
+		SetIf(volumeMounts.IsSet(), "spec.template.spec.volumes", deDupVolumesArray).
+		SetIf(imagePullSecrets.IsSet(), "spec.template.spec.imagePullSecrets", ImagePullSecretsTransform(imagePullSecrets)).
+		SetIf(traffic.IsSet(), "spec.traffic", traffic)
+
+	tpl.Output(service)
+}
+
+func init() {
+	defkit.Register(KnativeService())
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("KnativeService Component", func() {
+	Describe("KnativeService()", func() {
+		It("should create a knative-service component definition", func() {
+			comp := components.KnativeService()
+			Expect(comp.GetName()).To(Equal("knative-service"))
+			Expect(comp.GetDescription()).To(ContainSubstring("Knative"))
+		})
+
+		It("should have Knative Service workload", func() {
+			workload := components.KnativeService().GetWorkload()
+			Expect(workload.APIVersion()).To(Equal("serving.knative.dev/v1"))
+			Expect(workload.Kind()).To(Equal("Service"))
+		})
+
+		It("should have container and autoscaling parameters", func() {
+			comp := components.KnativeService()
+			for _, name := range []string{"image", "env", "cpu", "memory", "volumeMounts", "livenessProbe", "readinessProbe", "minScale", "maxScale", "concurrencyTarget", "scaleToZero", "containerConcurrency", "timeoutSeconds", "revisionSuffix", "traffic"} {
+				Expect(comp).To(HaveParamNamed(name))
+			}
+		})
+
+		It("should surface the route URL in the status", func() {
+			comp := components.KnativeService()
+			Expect(comp.GetHealthPolicy()).To(Equal(components.ReadyConditionHealth()))
+			Expect(comp.GetCustomStatus()).To(Equal(components.ReadyConditionStatus("url", "status.url")))
+		})
+	})
+
+	Describe("CUE Generation", func() {
+		var doc *cueassert.Document
+
+		BeforeEach(func() {
+			doc = cueassert.MustParse(components.KnativeService().ToCue())
+		})
+
+		It("should generate the parameter schema", func() {
+			Expect(doc.Lookup("parameter.image")).To(cueassert.BeRequiredField())
+			Expect(doc.Lookup("parameter.scaleToZero")).To(SatisfyAll(cueassert.HaveDefault(true), cueassert.HaveType("bool")))
+			Expect(doc.Lookup("parameter.minScale")).To(cueassert.BeOptionalField())
+			Expect(doc.Lookup("parameter.traffic.percent")).To(cueassert.BeRequiredField())
+			Expect(doc.Lookup("parameter.traffic.tag")).To(cueassert.BeOptionalField())
+			Expect(doc.Lookup("parameter.livenessProbe")).To(cueassert.HaveType("#HealthProbe"))
+			Expect(doc.UntypedLists()).To(BeEmpty())
+		})
+
+		It("should share the env schema with webservice", func() {
+			webservice := cueassert.MustParse(components.Webservice().ToCue())
+			Expect(doc.Lookup("parameter.env").Value()).To(Equal(webservice.Lookup("parameter.env").Value()))
+		})
+
+		It("should only accept volume sources Knative supports", func() {
+			Expect(doc.Lookup("parameter.volumeMounts").Struct().FieldNames()).To(ConsistOf("configMap", "secret", "emptyDir"))
+		})
+
+		It("should set the autoscaling annotations as strings", func() {
+			Expect(doc.Lookup("output.spec.template.metadata.annotations").Struct().Source()).To(SatisfyAll(
+				ContainSubstring(`"autoscaling.knative.dev/min-scale": "\(parameter.minScale)"`),
+				ContainSubstring(`"autoscaling.knative.dev/max-scale": "\(parameter.maxScale)"`),
+				ContainSubstring(`"autoscaling.knative.dev/target": "\(parameter.concurrencyTarget)"`),
+				ContainSubstring(`if parameter["minScale"] == _|_ && !parameter.scaleToZero`),
+			))
+		})
+
+		It("should name the revision after the suffix", func() {
+			Expect(doc.Lookup("output.spec.template.metadata").Struct().Source()).To(ContainSubstring(`name: "\(context.name)-\(parameter.revisionSuffix)"`))
+		})
+	})
+
+	Describe("Render with TestContext", func() {
+		var comp *defkit.ComponentDefinition
+
+		BeforeEach(func() {
+			comp = components.KnativeService()
+		})
+
+		It("should render the container of the revision template", func() {
+			rendered := comp.Render(
+				defkit.TestContext().
+					WithName("hello").
+					WithAppName("demo").
+					WithParam("image", "ghcr.io/knative/helloworld-go:latest").
+					WithParam("port", 8080).
+					WithParam("cpu", "100m").
+					WithParam("env", []map[string]any{{"name": "TARGET", "value": "vela"}}),
+			)
+
+			Expect(rendered.APIVersion()).To(Equal("serving.knative.dev/v1"))
+			Expect(rendered.Kind()).To(Equal("Service"))
+			Expect(rendered.Get("metadata.name")).To(Equal("hello"))
+			Expect(rendered.Get("spec.template.spec.containers[0].image")).To(Equal("ghcr.io/knative/helloworld-go:latest"))
+			Expect(rendered.Get("spec.template.spec.containers[0].ports")).NotTo(BeNil())
+			Expect(rendered.Get("spec.template.spec.containers[0].env")).NotTo(BeNil())
+			Expect(rendered.Get("spec.template.spec.containers[0].resources.requests.cpu")).To(Equal("100m"))
+			Expect(rendered.Get("spec.template.spec.containers[0].resources.limits.cpu")).To(Equal("100m"))
+			Expect(rendered.Get("spec.traffic")).To(BeNil())
+		})
+
+		It("should render request limits and traffic splits", func() {
+			rendered := comp.Render(
+				defkit.TestContext().
+					WithName("hello").
+					WithParam("image", "ghcr.io/knative/helloworld-go:latest").
+					WithParam("containerConcurrency", 10).
+					WithParam("timeoutSeconds", 30).
+					WithParam("traffic", []map[string]any{
+						{"revisionName": "hello-v1", "percent": 90, "tag": "stable"},
+						{"latestRevision": true, "percent": 10, "tag": "canary"},
+					}),
+			)
+
+			Expect(rendered.Get("spec.template.spec.containerConcurrency")).To(Equal(10))
+			Expect(rendered.Get("spec.template.spec.timeoutSeconds")).To(Equal(30))
+			Expect(rendered.Get("spec.traffic")).To(HaveLen(2))
+		})
+	})
+})
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// Kustomize creates the kustomize component definition.
+// It applies a Kustomize overlay through the FluxCD kustomize-controller: a
+// GitRepository or OCIRepository source is emitted alongside the Kustomization
+// that builds it.
+func Kustomize() *defkit.ComponentDefinition {
+	sourceType := defkit.Enum("sourceType").
+		Values("git", "oci").
+		Default("git").
+		Description("Type of the source holding the overlay, `git` for a Git repository or `oci` for an OCI artifact")
+	url := defkit.String("url").
+		Description("URL of the source, like `https://github.com/stefanprodan/podinfo` or `oci://ghcr.io/stefanprodan/manifests/podinfo`")
+	ref := defkit.Object("ref").Optional().
+		Description("Revision of the source to check out. The default branch or the `latest` tag is used if empty").
+		WithFields(
+			defkit.String("branch").Optional().Description("Git branch, ignored for OCI sources"),
+			defkit.String("tag").Optional().Description("Git or OCI tag"),
+			defkit.String("semver").Optional().Description("Semver range the tag is selected by"),
+			defkit.String("commit").Optional().Description("Git commit SHA, ignored for OCI sources"),
+			defkit.String("digest").Optional().Description("OCI artifact digest, ignored for Git sources"),
+		)
+	secretRef := defkit.String("secretRef").
+		Optional().
+		Description("Name of the Secret holding the credentials of the source")
+	path := defkit.String("path").
+		Default("./").
+		Description("Path to the directory containing the kustomization.yaml file, relative to the source root")
+	prune := defkit.Bool("prune").
+		Default(true).
+		Description("Delete the objects removed from the overlay")
+	targetNamespace := defkit.String("targetNamespace").
+		Optional().
+		Description("Namespace the objects are applied into, defaults to the application namespace")
+	patches := defkit.List("patches").Optional().
+		Description("Strategic merge or JSON6902 patches applied on top of the overlay").
+		WithFields(
+			defkit.String("patch").Description("Inline strategic merge patch, or JSON6902 patch as a YAML list of operations"),
+			defkit.Object("target").Optional().Description("Objects the patch applies to, required for JSON6902 patches").
+				WithFields(
+					defkit.String("group").Optional().Description("API group of the objects"),
+					defkit.String("version").Optional().Description("API version of the objects"),
+					defkit.String("kind").Optional().Description("Kind of the objects"),
+					defkit.String("name").Optional().Description("Name of the objects, a regular expression"),
+					defkit.String("namespace").Optional().Description("Namespace of the objects"),
+					defkit.String("labelSelector").Optional().Description("Label selector of the objects"),
+					defkit.String("annotationSelector").Optional().Description("Annotation selector of the objects"),
+				),
+		)
+	images := defkit.List("images").Optional().
+		Description("Overrides of the container images used in the overlay").
+		WithFields(
+			defkit.String("name").Description("Image name as written in the overlay, without the tag"),
+			defkit.String("newName").Optional().Description("Replacement image name"),
+			defkit.String("newTag").Optional().Description("Replacement image tag"),
+			defkit.String("digest").Optional().Description("Replacement image digest, takes precedence over newTag"),
+		)
+	postBuild := defkit.Object("postBuild").Optional().
+		Description("Variable substitutions of `${var}` placeholders in the built manifests").
+		WithFields(
+			defkit.StringKeyMap("substitute").Optional().Description("Inline variables"),
+			defkit.List("substituteFrom").Optional().Description("ConfigMaps or Secrets in the application namespace whose data are variables").
+				WithFields(
+					defkit.Enum("kind").Values("ConfigMap", "Secret").Default("ConfigMap").Description("Kind of the variables source"),
+					defkit.String("name").Description("Name of the ConfigMap or Secret"),
+					defkit.Bool("optional").Optional().Description("Do not fail the build when the source is missing"),
+				),
+		)
+	dependsOn := defkit.List("dependsOn").Optional().
+		Description("Kustomizations that must be ready before this one is applied").
+		WithFields(
+			defkit.String("name").Description("Name of the Kustomization"),
+			defkit.String("namespace").Optional().Description("Namespace of the Kustomization, defaults to the application namespace"),
+		)
+	interval := defkit.String("interval").
+		Default("5m").
+		Description("Interval at which the source and the overlay are reconciled")
+
+	return defkit.NewComponent("kustomize").
+		Description("Applies a Kustomize overlay through a FluxCD Kustomization.").
+		Workload("kustomize.toolkit.fluxcd.io/v1", "Kustomization").
+		CustomStatus(ReadyConditionStatus("revision", "status.lastAppliedRevision")).
+		HealthPolicy(ReadyConditionHealth()).
+		Params(
+			sourceType, url, ref, secretRef,
+			path, prune, targetNamespace,
+			patches, images, postBuild,
+			dependsOn, interval,
+		).
+		Template(kustomizeTemplate)
+}
+
+// kustomizeTemplate defines the template function for kustomize.
+func kustomizeTemplate(tpl *defkit.Template) {
+	vela := defkit.VelaCtx()
+
+	// Parameter references for template
+	sourceType := defkit.String("sourceType").Default("git")
+	url := defkit.String("url")
+	ref := defkit.Object("ref")
+	secretRef := defkit.String("secretRef")
+	path := defkit.String("path").Default("./")
+	prune := defkit.Bool("prune").Default(true)
+	targetNamespace := defkit.String("targetNamespace")
+	patches := defkit.List("patches")
+	images := defkit.List("images")
+	postBuild := defkit.Object("postBuild")
+	dependsOn := defkit.List("dependsOn")
+	interval := defkit.String("interval").Default("5m")
+
+	isGit := defkit.Eq(sourceType, defkit.Lit("git"))
+	isOCI := defkit.Eq(sourceType, defkit.Lit("oci"))
+
+	kustomization := defkit.NewResource("kustomize.toolkit.fluxcd.io/v1", "Kustomization").
+		Set("metadata.name", vela.Name()).
+		Set("spec.interval", interval).
+		Set("spec.path", path).
+		Set("spec.prune", prune).
+		SetIf(isGit, "spec.sourceRef.kind", defkit.Lit("GitRepository")).
+		SetIf(isOCI, "spec.sourceRef.kind", defkit.Lit("OCIRepository")).
+		Set("spec.sourceRef.name", vela.Name()).
+		SetIf(targetNamespace.IsSet(), "spec.targetNamespace", targetNamespace).
+		SetIf(targetNamespace.NotSet(), "spec.targetNamespace", vela.Namespace()).
+		SetIf(patches.IsSet(), "spec.patches", patches).
+		SetIf(images.IsSet(), "spec.images", images).
+		SetIf(postBuild.IsSet(), "spec.postBuild", postBuild).
+		SetIf(dependsOn.IsSet(), "spec.dependsOn", dependsOn)
+
+	gitRepository := defkit.NewResource("source.toolkit.fluxcd.io/v1", "GitRepository").
+		Set("metadata.name", vela.Name()).
+		Set("spec.url", url).
+		Set("spec.interval", interval).
+		If(ref.IsSet()).
+		SetIf(ref.Field("branch").IsSet(), "spec.ref.branch", ref.Field("branch")).
+		SetIf(ref.Field("tag").IsSet(), "spec.ref.tag", ref.Field("tag")).
+		SetIf(ref.Field("semver").IsSet(), "spec.ref.semver", ref.Field("semver")).
+		SetIf(ref.Field("commit").IsSet(), "spec.ref.commit", ref.Field("commit")).
+		EndIf().
+		SetIf(secretRef.IsSet(), "spec.secretRef.name", secretRef)
+
+	ociRepository := defkit.NewResource("source.toolkit.fluxcd.io/v1beta2", "OCIRepository").
+		Set("metadata.name", vela.Name()).
+		Set("spec.url", url).
+		Set("spec.interval", interval).
+		If(ref.IsSet()).
+		SetIf(ref.Field("tag").IsSet(), "spec.ref.tag", ref.Field("tag")).
+		SetIf(ref.Field("semver").IsSet(), "spec.ref.semver", ref.Field("semver")).
+		SetIf(ref.Field("digest").IsSet(), "spec.ref.digest", ref.Field("digest")).
+		EndIf().
+		SetIf(secretRef.IsSet(), "spec.secretRef.name", secretRef)
+
+	tpl.Output(kustomization)
+	tpl.OutputsIf(isGit, "gitRepository", gitRepository)
+	tpl.OutputsIf(isOCI, "ociRepository", ociRepository)
+}
+
+func init() {
+	defkit.Register(Kustomize())
+}
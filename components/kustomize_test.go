@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("Kustomize Component", func() {
+	Describe("Kustomize()", func() {
+		It("should create a kustomize component definition", func() {
+			comp := components.Kustomize()
+			Expect(comp.GetName()).To(Equal("kustomize"))
+			Expect(comp.GetDescription()).To(ContainSubstring("Kustomization"))
+		})
+
+		It("should have Kustomization workload", func() {
+			workload := components.Kustomize().GetWorkload()
+			Expect(workload.APIVersion()).To(Equal("kustomize.toolkit.fluxcd.io/v1"))
+			Expect(workload.Kind()).To(Equal("Kustomization"))
+		})
+
+		It("should have source and overlay parameters", func() {
+			comp := components.Kustomize()
+			for _, name := range []string{"sourceType", "url", "ref", "secretRef", "path", "prune", "targetNamespace", "patches", "images", "postBuild", "dependsOn", "interval"} {
+				Expect(comp).To(HaveParamNamed(name))
+			}
+		})
+
+		It("should surface the last applied revision in the status", func() {
+			comp := components.Kustomize()
+			Expect(comp.GetHealthPolicy()).To(Equal(components.ReadyConditionHealth()))
+			Expect(comp.GetCustomStatus()).To(Equal(components.ReadyConditionStatus("revision", "status.lastAppliedRevision")))
+		})
+	})
+
+	Describe("CUE Generation", func() {
+		var doc *cueassert.Document
+
+		BeforeEach(func() {
+			doc = cueassert.MustParse(components.Kustomize().ToCue())
+		})
+
+		It("should generate the parameter schema", func() {
+			Expect(doc.Lookup("parameter.sourceType")).To(cueassert.HaveDefault("git"))
+			Expect(doc.Lookup("parameter.url")).To(cueassert.BeRequiredField())
+			Expect(doc.Lookup("parameter.path")).To(cueassert.HaveDefault("./"))
+			Expect(doc.Lookup("parameter.prune")).To(SatisfyAll(cueassert.HaveDefault(true), cueassert.HaveType("bool")))
+			Expect(doc.Lookup("parameter.patches.patch")).To(cueassert.BeRequiredField())
+			Expect(doc.Lookup("parameter.patches.target.kind")).To(cueassert.BeOptionalField())
+			Expect(doc.Lookup("parameter.postBuild.substituteFrom.kind")).To(cueassert.HaveDefault("ConfigMap"))
+			Expect(doc.UntypedLists()).To(BeEmpty())
+		})
+
+		It("should default the target namespace to the application namespace", func() {
+			Expect(doc.Output().Source()).To(ContainSubstring("targetNamespace: context.namespace"))
+		})
+
+		It("should only map the ref fields each source kind supports", func() {
+			git := doc.Lookup("outputs.gitRepository.spec.ref").Struct()
+			Expect(git.FieldNames()).To(ConsistOf("branch", "tag", "semver", "commit"))
+			oci := doc.Lookup("outputs.ociRepository.spec.ref").Struct()
+			Expect(oci.FieldNames()).To(ConsistOf("tag", "semver", "digest"))
+		})
+	})
+
+	Describe("Render with TestContext", func() {
+		var comp *defkit.ComponentDefinition
+
+		BeforeEach(func() {
+			comp = components.Kustomize()
+		})
+
+		It("should render a GitRepository source for Git overlays", func() {
+			outputs := comp.RenderAll(
+				defkit.TestContext().
+					WithName("podinfo").
+					WithNamespace("apps").
+					WithParam("url", "https://github.com/stefanprodan/podinfo").
+					WithParam("path", "./kustomize").
+					WithParam("images", []map[string]any{{"name": "ghcr.io/stefanprodan/podinfo", "newTag": "6.7.0"}}).
+					WithParam("dependsOn", []map[string]any{{"name": "infra"}}).
+					WithParam("postBuild", map[string]any{
+						"substituteFrom": []map[string]any{{"kind": "ConfigMap", "name": "cluster-vars"}},
+					}),
+			)
+
+			kustomization := outputs.Primary
+			Expect(kustomization.Kind()).To(Equal("Kustomization"))
+			Expect(kustomization.Get("spec.path")).To(Equal("./kustomize"))
+			Expect(kustomization.Get("spec.prune")).To(BeTrue())
+			Expect(kustomization.Get("spec.sourceRef.kind")).To(Equal("GitRepository"))
+			Expect(kustomization.Get("spec.sourceRef.name")).To(Equal("podinfo"))
+			Expect(kustomization.Get("spec.targetNamespace")).To(Equal("apps"))
+			Expect(kustomization.Get("spec.images")).To(HaveLen(1))
+			Expect(kustomization.Get("spec.dependsOn")).To(HaveLen(1))
+			Expect(kustomization.Get("spec.postBuild.substituteFrom")).To(HaveLen(1))
+
+			Expect(outputs.Auxiliary).To(HaveKey("gitRepository"))
+			Expect(outputs.Auxiliary).NotTo(HaveKey("ociRepository"))
+			Expect(outputs.Auxiliary["gitRepository"].Get("spec.url")).To(Equal("https://github.com/stefanprodan/podinfo"))
+		})
+
+		It("should render an OCIRepository source and keep an explicit target namespace", func() {
+			outputs := comp.RenderAll(
+				defkit.TestContext().
+					WithName("podinfo").
+					WithParam("sourceType", "oci").
+					WithParam("url", "oci://ghcr.io/stefanprodan/manifests/podinfo").
+					WithParam("targetNamespace", "podinfo").
+					WithParam("prune", false).
+					WithParam("patches", []map[string]any{{
+						"patch":  "- op: replace\n  path: /spec/minReplicas\n  value: 1",
+						"target": map[string]any{"kind": "HorizontalPodAutoscaler", "name": "podinfo"},
+					}}),
+			)
+
+			kustomization := outputs.Primary
+			Expect(kustomization.Get("spec.sourceRef.kind")).To(Equal("OCIRepository"))
+			Expect(kustomization.Get("spec.targetNamespace")).To(Equal("podinfo"))
+			Expect(kustomization.Get("spec.prune")).To(BeFalse())
+			Expect(kustomization.Get("spec.patches")).To(HaveLen(1))
+
+			Expect(outputs.Auxiliary).To(HaveKey("ociRepository"))
+			Expect(outputs.Auxiliary).NotTo(HaveKey("gitRepository"))
+			Expect(outputs.Auxiliary["ociRepository"].Get("spec.url")).To(Equal("oci://ghcr.io/stefanprodan/manifests/podinfo"))
+		})
+	})
+})
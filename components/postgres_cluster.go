@@ -0,0 +1,223 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// PostgresCluster creates the postgres-cluster component definition.
+// It describes a PostgreSQL cluster managed by the CloudNativePG operator and
+// writes a connection Secret in the format generate-jdbc-connection reads.
+func PostgresCluster() *defkit.ComponentDefinition {
+	instances := defkit.Int("instances").Default(1).Description("Number of PostgreSQL instances, one primary and the rest replicas")
+	postgresVersion := defkit.Int("postgresVersion").Default(16).Description("Major version of PostgreSQL, selects the CloudNativePG operand image")
+	imageName := defkit.String("imageName").Optional().Description("Image of PostgreSQL, takes precedence over postgresVersion")
+	storage := defkit.Object("storage").
+		Description("Storage of each instance").
+		WithFields(
+			defkit.String("size").Default("1Gi").Description("Size of the volume of each instance"),
+			defkit.String("storageClass").Optional().Description("StorageClass of the volumes, the default StorageClass if empty"),
+		)
+	database := defkit.String("database").Default("app").Description("Name of the application database")
+	owner := defkit.String("owner").Default("app").Description("Name of the user owning the application database")
+	password := defkit.String("password").
+		Description("Password of the owner, stored in the `<component>-owner` Secret CloudNativePG bootstraps the owner with and written to DB_PASSWORD of the connection Secret")
+	bootstrap := defkit.Object("bootstrap").
+		Description("How the cluster is created").
+		WithFields(
+			defkit.OneOf("type").
+				Description(`Specify the bootstrap type, options: "initdb","recovery", default to initdb`).
+				Default("initdb").
+				Variants(
+					defkit.Variant("initdb").WithFields(
+						defkit.Field("postInitApplicationSQL", defkit.ParamTypeArray).Optional().Of(defkit.ParamTypeString).
+							Description("SQL statements run in the application database once it is created"),
+					),
+					defkit.Variant("recovery").WithFields(
+						defkit.Field("backupName", defkit.ParamTypeString).
+							Description("Name of the Backup to restore, in the namespace of the component"),
+						defkit.Field("targetTime", defkit.ParamTypeString).Optional().
+							Description("Restore up to this point in time, like `2025-01-02 15:04:05+00`, instead of the end of the WAL"),
+					),
+				),
+		)
+	backup := defkit.Object("backup").
+		Optional().
+		Description("Continuous backup of the WAL and base backups to an S3 compatible object storage").
+		WithFields(
+			defkit.String("destinationPath").Description("Path of the backups in the object storage, like `s3://bucket/path`"),
+			defkit.String("endpointURL").Optional().Description("Endpoint of the object storage, for storages other than AWS S3"),
+			defkit.Object("s3Credentials").
+				Description("Secret holding the credentials of the object storage").
+				WithFields(
+					defkit.String("secretName").Description("Name of the Secret"),
+					defkit.String("accessKeyIdKey").Default("ACCESS_KEY_ID").Description("Key of the access key id in the Secret"),
+					defkit.String("secretAccessKeyKey").Default("ACCESS_SECRET_KEY").Description("Key of the secret access key in the Secret"),
+				),
+			defkit.String("retentionPolicy").Default("30d").Description("How long backups are kept, like `30d` or `4w`"),
+			defkit.String("schedule").
+				Optional().
+				Description("Schedule of the base backups in cron format with seconds, like `0 0 0 * * *` for every midnight"),
+		)
+	writeConnectionSecretToRef := defkit.Object("writeConnectionSecretToRef").
+		Optional().
+		Description("Secret the connection details DB_HOST, DB_PORT, DB_NAME, DB_USER and DB_PASSWORD are written to").
+		WithFields(
+			defkit.String("name").Description("Name of the Secret, defaults to `<component>-conn`"),
+		)
+	cpu := defkit.String("cpu").Optional().Description("Number of CPU units of each instance, like `0.5` (0.5 CPU core), `1` (1 CPU core)")
+	memory := defkit.String("memory").Optional().Description("Specifies the attributes of the memory resource required for each instance.")
+
+	return defkit.NewComponent("postgres-cluster").
+		Description("Describes PostgreSQL clusters managed by the CloudNativePG operator.").
+		Workload("postgresql.cnpg.io/v1", "Cluster").
+		CustomStatus(postgresClusterStatus()).
+		HealthPolicy(defkit.Health().
+			StringField("cluster.phase", "status.phase", "").
+			IntField("cluster.readyInstances", "status.readyInstances", 0).
+			HealthyWhen(
+				defkit.StatusEq("cluster.phase", `"Cluster in healthy state"`),
+				defkit.StatusEq("cluster.readyInstances", "context.output.spec.instances"),
+			).
+			Build()).
+		Params(
+			instances, postgresVersion, imageName, storage,
+			database, owner, password, bootstrap,
+			backup, writeConnectionSecretToRef,
+			cpu, memory,
+		).
+		Template(postgresClusterTemplate)
+}
+
+// postgresClusterStatus reports the phase of the cluster with its ready
+// instances and current primary, like "Cluster in healthy state, ready: 3/3, primary: db-1".
+func postgresClusterStatus() string {
+	return defkit.Status().
+		StringField("cluster.phase", "status.phase", "Setting up primary").
+		IntField("cluster.readyInstances", "status.readyInstances", 0).
+		StringField("cluster.primary", "status.currentPrimary", "").
+		Message(`\(cluster.phase), ready: \(cluster.readyInstances)/\(context.output.spec.instances), primary: \(cluster.primary)`).
+		Build()
+}
+
+// postgresClusterTemplate defines the template function for postgres-cluster.
+func postgresClusterTemplate(tpl *defkit.Template) {
+	vela := defkit.VelaCtx()
+
+	// Parameter references for template
+	instances := defkit.Int("instances").Default(1)
+	postgresVersion := defkit.Int("postgresVersion").Default(16)
+	imageName := defkit.String("imageName")
+	storage := defkit.Object("storage")
+	database := defkit.String("database").Default("app")
+	owner := defkit.String("owner").Default("app")
+	password := defkit.String("password")
+	bootstrap := defkit.Object("bootstrap")
+	backup := defkit.Object("backup")
+	writeConnectionSecretToRef := defkit.Object("writeConnectionSecretToRef")
+	cpu := defkit.String("cpu")
+	memory := defkit.String("memory")
+
+	isInitdb := defkit.Eq(bootstrap.Field("type"), defkit.Lit("initdb"))
+	isRecovery := defkit.Eq(bootstrap.Field("type"), defkit.Lit("recovery"))
+	ownerSecretName := defkit.Interpolation(vela.Name(), defkit.Lit("-owner"))
+
+	cluster := defkit.NewResource("postgresql.cnpg.io/v1", "Cluster").
+		Set("metadata.name", vela.Name()).
+		Set("spec.instances", instances).
+		SetIf(imageName.IsSet(), "spec.imageName", imageName).
+		SetIf(imageName.NotSet(), "spec.imageName",
+			defkit.Interpolation(defkit.Lit("ghcr.io/cloudnative-pg/postgresql:"), postgresVersion)).
+		Set("spec.storage.size", storage.Field("size")).
+		SetIf(storage.Field("storageClass").IsSet(), "spec.storage.storageClass", storage.Field("storageClass")).
+		ConditionalStruct(isInitdb, "spec.bootstrap.initdb", func(b *defkit.OutputStructBuilder) {
+			b.Set("database", database)
+			b.Set("owner", owner)
+			b.SetIf(bootstrap.Field("postInitApplicationSQL").IsSet(), "postInitApplicationSQL", bootstrap.Field("postInitApplicationSQL"))
+		}).
+		ConditionalStruct(isInitdb, "spec.bootstrap.initdb.secret", func(b *defkit.OutputStructBuilder) {
+			b.Set("name", ownerSecretName)
+		}).
+		ConditionalStruct(isRecovery, "spec.bootstrap.recovery", func(b *defkit.OutputStructBuilder) {
+			b.Set("database", database)
+			b.Set("owner", owner)
+		}).
+		ConditionalStruct(isRecovery, "spec.bootstrap.recovery.backup", func(b *defkit.OutputStructBuilder) {
+			b.Set("name", bootstrap.Field("backupName"))
+		}).
+		ConditionalStruct(isRecovery, "spec.bootstrap.recovery.secret", func(b *defkit.OutputStructBuilder) {
+			b.Set("name", ownerSecretName)
+		}).
+		ConditionalStruct(defkit.And(isRecovery, bootstrap.Field("targetTime").IsSet()), "spec.bootstrap.recovery.recoveryTarget", func(b *defkit.OutputStructBuilder) {
+			b.Set("targetTime", bootstrap.Field("targetTime"))
+		}).
+		ConditionalStruct(backup.IsSet(), "spec.backup", func(b *defkit.OutputStructBuilder) {
+			b.Set("retentionPolicy", backup.Field("retentionPolicy"))
+		}).
+		ConditionalStruct(backup.IsSet(), "spec.backup.barmanObjectStore", func(b *defkit.OutputStructBuilder) {
+			b.Set("destinationPath", backup.Field("destinationPath"))
+			b.SetIf(backup.Field("endpointURL").IsSet(), "endpointURL", backup.Field("endpointURL"))
+		}).
+		ConditionalStruct(backup.IsSet(), "spec.backup.barmanObjectStore.s3Credentials.accessKeyId", func(b *defkit.OutputStructBuilder) {
+			b.Set("name", backup.Field("s3Credentials.secretName"))
+			b.Set("key", backup.Field("s3Credentials.accessKeyIdKey"))
+		}).
+		ConditionalStruct(backup.IsSet(), "spec.backup.barmanObjectStore.s3Credentials.secretAccessKey", func(b *defkit.OutputStructBuilder) {
+			b.Set("name", backup.Field("s3Credentials.secretName"))
+			b.Set("key", backup.Field("s3Credentials.secretAccessKeyKey"))
+		}).
+		If(cpu.IsSet()).
+		Set("spec.resources.limits.cpu", cpu).
+		Set("spec.resources.requests.cpu", cpu).
+		EndIf().
+		If(memory.IsSet()).
+		Set("spec.resources.limits.memory", memory).
+		Set("spec.resources.requests.memory", memory).
+		EndIf()
+
+	ownerSecret := defkit.NewResource("v1", "Secret").
+		Set("metadata.name", ownerSecretName).
+		Set("type", defkit.Lit("kubernetes.io/basic-auth")).
+		Set("stringData.username", owner).
+		Set("stringData.password", password)
+
+	// The -rw Service of CloudNativePG always points to the primary.
+	connectionSecret := defkit.NewResource("v1", "Secret").
+		SetIf(writeConnectionSecretToRef.IsSet(), "metadata.name", writeConnectionSecretToRef.Field("name")).
+		SetIf(writeConnectionSecretToRef.NotSet(), "metadata.name", defkit.Interpolation(vela.Name(), defkit.Lit("-conn"))).
+		Set("stringData.DB_HOST", defkit.Interpolation(vela.Name(), defkit.Lit("-rw."), vela.Namespace(), defkit.Lit(".svc"))).
+		Set("stringData.DB_PORT", defkit.Lit("5432")).
+		Set("stringData.DB_NAME", database).
+		Set("stringData.DB_USER", owner).
+		Set("stringData.DB_PASSWORD", password)
+
+	scheduledBackup := defkit.NewResource("postgresql.cnpg.io/v1", "ScheduledBackup").
+		Set("metadata.name", vela.Name()).
+		Set("spec.schedule", backup.Field("schedule")).
+		Set("spec.cluster.name", vela.Name()).
+		Set("spec.backupOwnerReference", defkit.Lit("self"))
+
+	tpl.Output(cluster)
+	tpl.Outputs("ownerSecret", ownerSecret)
+	tpl.Outputs("connectionSecret", connectionSecret)
+	tpl.OutputsIf(backup.Field("schedule").IsSet(), "scheduledBackup", scheduledBackup)
+}
+
+func init() {
+	defkit.Register(PostgresCluster())
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"cuelang.org/go/cue"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
+
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("PostgresCluster Component", func() {
+	Describe("PostgresCluster()", func() {
+		It("should create a postgres-cluster component definition", func() {
+			comp := components.PostgresCluster()
+			Expect(comp.GetName()).To(Equal("postgres-cluster"))
+			Expect(comp.GetDescription()).To(ContainSubstring("CloudNativePG"))
+		})
+
+		It("should have CloudNativePG Cluster workload", func() {
+			workload := components.PostgresCluster().GetWorkload()
+			Expect(workload.APIVersion()).To(Equal("postgresql.cnpg.io/v1"))
+			Expect(workload.Kind()).To(Equal("Cluster"))
+		})
+
+		It("should have cluster, bootstrap and backup parameters", func() {
+			comp := components.PostgresCluster()
+			for _, name := range []string{"instances", "postgresVersion", "storage", "database", "owner", "password", "bootstrap", "backup", "writeConnectionSecretToRef", "cpu", "memory"} {
+				Expect(comp).To(HaveParamNamed(name))
+			}
+		})
+	})
+
+	Describe("Status", func() {
+		const healthy = `{spec: {instances: 3}, status: {phase: "Cluster in healthy state", readyInstances: 3, currentPrimary: "db-1"}}`
+		const failingOver = `{spec: {instances: 3}, status: {phase: "Failing over", readyInstances: 2, currentPrimary: "db-1"}}`
+
+		DescribeTable("health",
+			func(output string, healthy bool) {
+				v := evalStatus(components.PostgresCluster().GetHealthPolicy(), output)
+				Expect(v.LookupPath(cue.ParsePath("isHealth")).Bool()).To(Equal(healthy))
+			},
+			Entry("all instances ready", healthy, true),
+			Entry("failing over", failingOver, false),
+			Entry("replica still joining", `{spec: {instances: 3}, status: {phase: "Cluster in healthy state", readyInstances: 2}}`, false),
+			Entry("no status reported yet", `{spec: {instances: 1}}`, false),
+		)
+
+		DescribeTable("message",
+			func(output, message string) {
+				v := evalStatus(components.PostgresCluster().GetCustomStatus(), output)
+				Expect(v.LookupPath(cue.ParsePath("message")).String()).To(Equal(message))
+			},
+			Entry("healthy cluster", healthy, "Cluster in healthy state, ready: 3/3, primary: db-1"),
+			Entry("failing over", failingOver, "Failing over, ready: 2/3, primary: db-1"),
+			Entry("no status reported yet", `{spec: {instances: 1}}`, "Setting up primary, ready: 0/1, primary: "),
+		)
+	})
+
+	Describe("CUE Generation", func() {
+		var doc *cueassert.Document
+
+		BeforeEach(func() {
+			doc = cueassert.MustParse(components.PostgresCluster().ToCue())
+		})
+
+		It("should generate the bootstrap as a discriminated union", func() {
+			Expect(doc.Lookup("parameter.bootstrap.type")).To(cueassert.HaveDefault("initdb"))
+			Expect(doc.Lookup("parameter.bootstrap.backupName").Conditional()).To(BeTrue())
+			Expect(doc.Lookup("parameter.storage.size")).To(cueassert.HaveDefault("1Gi"))
+			Expect(doc.Lookup("parameter.backup.s3Credentials.accessKeyIdKey")).To(cueassert.HaveDefault("ACCESS_KEY_ID"))
+			Expect(doc.UntypedLists()).To(BeEmpty())
+		})
+
+		It("should require the owner password the connection Secret carries", func() {
+			Expect(doc.Lookup("parameter.password")).To(SatisfyAll(Not(cueassert.BeOptionalField()), cueassert.HaveValue("string")))
+		})
+
+		It("should only create the Secrets and backups that are configured", func() {
+			Expect(doc.Lookup("outputs.connectionSecret").Conditional()).To(BeFalse())
+			Expect(doc.Lookup("outputs.ownerSecret").Conditional()).To(BeFalse())
+			Expect(doc.Lookup("outputs.scheduledBackup").Conditional()).To(BeTrue())
+		})
+	})
+
+	Describe("Template", func() {
+		lookup := func(v cue.Value, path string) string {
+			s, err := v.LookupPath(cue.ParsePath(path)).String()
+			Expect(err).NotTo(HaveOccurred(), path)
+			return s
+		}
+
+		It("should bootstrap a database owned by a user with the given password", func() {
+			v := evalTemplate(components.PostgresCluster(), `{password: "s3cret", writeConnectionSecretToRef: name: "db-conn"}`)
+			Expect(lookup(v, "output.spec.imageName")).To(Equal("ghcr.io/cloudnative-pg/postgresql:16"))
+			Expect(lookup(v, "output.spec.bootstrap.initdb.owner")).To(Equal("app"))
+			Expect(lookup(v, "output.spec.bootstrap.initdb.secret.name")).To(Equal("site-owner"))
+			Expect(v.LookupPath(cue.ParsePath("output.spec.backup")).Exists()).To(BeFalse())
+			Expect(lookup(v, "outputs.ownerSecret.stringData.password")).To(Equal("s3cret"))
+		})
+
+		It("should write the connection Secret read by generate-jdbc-connection", func() {
+			v := evalTemplate(components.PostgresCluster(), `{password: "s3cret", database: "links"}`)
+			Expect(lookup(v, "outputs.connectionSecret.metadata.name")).To(Equal("site-conn"))
+			for key, value := range map[string]string{
+				"DB_PORT":     "5432",
+				"DB_NAME":     "links",
+				"DB_USER":     "app",
+				"DB_PASSWORD": "s3cret",
+			} {
+				Expect(lookup(v, "outputs.connectionSecret.stringData."+key)).To(Equal(value))
+			}
+			Expect(lookup(v, "outputs.connectionSecret.stringData.DB_HOST")).To(Equal("site-rw.default.svc"))
+		})
+
+		It("should recover from a backup to a point in time", func() {
+			v := evalTemplate(components.PostgresCluster(), `{password: "s3cret", bootstrap: {type: "recovery", backupName: "nightly", targetTime: "2025-01-02 15:04:05+00"}}`)
+			Expect(v.LookupPath(cue.ParsePath("output.spec.bootstrap.initdb")).Exists()).To(BeFalse())
+			Expect(lookup(v, "output.spec.bootstrap.recovery.backup.name")).To(Equal("nightly"))
+			Expect(lookup(v, "output.spec.bootstrap.recovery.recoveryTarget.targetTime")).To(Equal("2025-01-02 15:04:05+00"))
+			Expect(lookup(v, "output.spec.bootstrap.recovery.secret.name")).To(Equal("site-owner"))
+			Expect(lookup(v, "outputs.connectionSecret.stringData.DB_PASSWORD")).To(Equal("s3cret"))
+		})
+
+		It("should back up to object storage on a schedule", func() {
+			v := evalTemplate(components.PostgresCluster(), `{password: "s3cret", backup: {destinationPath: "s3://backups/db", s3Credentials: secretName: "s3", schedule: "0 0 0 * * *"}}`)
+			Expect(lookup(v, "output.spec.backup.retentionPolicy")).To(Equal("30d"))
+			Expect(lookup(v, "output.spec.backup.barmanObjectStore.destinationPath")).To(Equal("s3://backups/db"))
+			Expect(lookup(v, "output.spec.backup.barmanObjectStore.s3Credentials.secretAccessKey.key")).To(Equal("ACCESS_SECRET_KEY"))
+			Expect(lookup(v, "outputs.scheduledBackup.spec.cluster.name")).To(Equal("site"))
+		})
+	})
+})
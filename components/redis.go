@@ -0,0 +1,384 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// Redis creates the redis component definition.
+// It describes a Redis, or Valkey, cache as a StatefulSet whose first pod is the
+// primary and the others replicate from it, optionally supervised by Sentinel.
+//
+// redis.conf and the startup scripts are joined with strings.Join and hashed with
+// sha256.Sum256, which defkit doesn't wrap, so they are raw lets.
+func Redis() *defkit.ComponentDefinition {
+	image := defkit.String("image").
+		Default("redis:7.2-alpine").
+		Description("Image of Redis, Valkey images such as `valkey/valkey:8.0-alpine` also work")
+	replicas := defkit.Int("replicas").
+		Default(1).
+		Description("Number of pods, the first one is the primary and the others replicate from it")
+	persistence := defkit.Object("persistence").
+		Description("Persistence of the data of each pod").
+		WithFields(
+			defkit.Bool("enabled").Default(true).Description("Keep the data in a PersistentVolumeClaim per pod, otherwise in an emptyDir"),
+			defkit.String("size").Default("1Gi").Description("Size of the volume of each pod"),
+			defkit.String("storageClass").Optional().Description("StorageClass of the volumes, the default StorageClass if empty"),
+			defkit.Bool("appendOnly").Default(false).Description("Log every write to the append only file, in addition to the snapshots"),
+		)
+	auth := defkit.Object("auth").
+		Optional().
+		Description("Require a password from clients. The password is either given, and stored in the `<component>-auth` Secret, or read from an existing Secret").
+		WithFields(
+			defkit.String("password").Optional().Description("Password stored in the `<component>-auth` Secret"),
+			defkit.String("existingSecret").Optional().Description("Existing Secret holding the password, takes precedence over password"),
+			defkit.String("existingSecretKey").Default("password").Description("Key of the password in existingSecret"),
+		)
+	sentinel := defkit.Object("sentinel").
+		Description("Sentinel promoting a replica when the primary fails, requires at least 3 replicas").
+		WithFields(
+			defkit.Bool("enabled").Default(false).Description("Run a Sentinel next to each Redis"),
+			defkit.String("masterName").Default("mymaster").Description("Name of the monitored primary, used by the clients to ask Sentinel for its address"),
+			defkit.Int("quorum").Default(2).Description("Number of Sentinels that need to agree the primary is down"),
+		)
+	maxmemory := defkit.String("maxmemory").
+		Optional().
+		Description("Memory limit of the data set, like `256mb`, keys are evicted with maxmemoryPolicy once it is reached")
+	maxmemoryPolicy := defkit.Enum("maxmemoryPolicy").
+		Values("noeviction", "allkeys-lru", "allkeys-lfu", "allkeys-random", "volatile-lru", "volatile-lfu", "volatile-random", "volatile-ttl").
+		Default("allkeys-lru").
+		Description("Which keys are evicted once maxmemory is reached")
+	extraConfig := defkit.String("extraConfig").
+		Optional().
+		Description("Extra directives appended to redis.conf")
+	metrics := defkit.Object("metrics").
+		Description("Prometheus metrics exported by a redis_exporter sidecar on port 9121").
+		WithFields(
+			defkit.Bool("enabled").Default(true).Description("Run the redis_exporter sidecar"),
+			defkit.String("image").Default("oliver006/redis_exporter:v1.66.0").Description("Image of redis_exporter"),
+		)
+	writeConnectionSecretToRef := defkit.Object("writeConnectionSecretToRef").
+		Optional().
+		Description("Secret the connection details host, port and password are written to").
+		WithFields(
+			defkit.String("name").Description("Name of the Secret, defaults to `<component>-conn`"),
+		)
+	cpu := defkit.String("cpu").Optional().Description("Number of CPU units of each Redis, like `0.5` (0.5 CPU core), `1` (1 CPU core)")
+	memory := defkit.String("memory").Optional().Description("Specifies the attributes of the memory resource required for each Redis.")
+
+	authWithoutPassword := defkit.Validate("auth requires either password or existingSecret").
+		WithName("_validateAuth").
+		FailWhen(defkit.And(
+			auth.IsSet(),
+			defkit.Not(auth.Field("password").IsSet()),
+			defkit.Not(auth.Field("existingSecret").IsSet()),
+		))
+
+	sentinelEnabled := defkit.Eq(sentinel.Field("enabled"), defkit.Lit(true))
+	sentinelWithoutReplicas := defkit.Validate("sentinel requires at least 3 replicas").
+		WithName("_validateSentinelReplicas").
+		FailWhen(defkit.And(sentinelEnabled, defkit.Lt(replicas, defkit.Lit(3))))
+	sentinelQuorum := defkit.Validate("sentinel.quorum must not exceed replicas").
+		WithName("_validateSentinelQuorum").
+		FailWhen(defkit.And(sentinelEnabled, defkit.Gt(sentinel.Field("quorum"), replicas)))
+
+	return defkit.NewComponent("redis").
+		Description("Describes Redis, or Valkey, caches with persistence, replicas, Sentinel and metrics.").
+		Workload("apps/v1", "StatefulSet").
+		WithImports("strings", "encoding/hex", "crypto/sha256").
+		CustomStatus(redisStatus()).
+		HealthPolicy(
+			defkit.Health().
+				IntField("ready.updatedReplicas", "status.updatedReplicas", 0).
+				IntField("ready.readyReplicas", "status.readyReplicas", 0).
+				IntField("ready.replicas", "status.replicas", 0).
+				IntField("ready.observedGeneration", "status.observedGeneration", 0).
+				HealthyWhen(
+					defkit.StatusEq("context.output.spec.replicas", "ready.readyReplicas"),
+					defkit.StatusEq("context.output.spec.replicas", "ready.updatedReplicas"),
+					defkit.StatusEq("context.output.spec.replicas", "ready.replicas"),
+					defkit.StatusOr(defkit.StatusEq("ready.observedGeneration", "context.output.metadata.generation"), "ready.observedGeneration > context.output.metadata.generation"),
+				).
+				WithDefault().
+				WithDisableAnnotation("app.oam.dev/disable-health-check").
+				Build(),
+		).
+		Params(
+			image, replicas, persistence, auth, sentinel,
+			maxmemory, maxmemoryPolicy, extraConfig,
+			metrics, writeConnectionSecretToRef,
+			cpu, memory,
+		).
+		Validators(authWithoutPassword, sentinelWithoutReplicas, sentinelQuorum).
+		Template(redisTemplate)
+}
+
+// redisStatus reports the ready pods and the Service clients connect to, like
+// "Ready:3/3, service: cache:6379". Replicas are only ready while
+// connected to the primary, so all pods being ready means the primary is reachable.
+func redisStatus() string {
+	return defkit.Status().
+		IntField("ready.readyReplicas", "status.readyReplicas", 0).
+		Message(`Ready:\(ready.readyReplicas)/\(context.output.spec.replicas), service: \(context.output.metadata.name):6379`).
+		Build()
+}
+
+// redisHeader computes redis.conf, the startup scripts and the checksum rolling
+// the pods when they change.
+// Pods look up the current primary from Sentinel when it runs, and fall back
+// to the first pod, so a restarted former primary joins as a replica.
+const redisHeader = `let _redisConf = strings.Join([
+	"port 6379",
+	"bind * -::*",
+	"protected-mode no",
+	"dir /data",
+	if parameter.persistence.appendOnly {"appendonly yes"},
+	if !parameter.persistence.appendOnly {"appendonly no"},
+	if !parameter.persistence.enabled {"save \"\""},
+	if parameter.maxmemory != _|_ {"maxmemory \(parameter.maxmemory)"},
+	"maxmemory-policy \(parameter.maxmemoryPolicy)",
+	if parameter.extraConfig != _|_ {parameter.extraConfig},
+	"",
+], "\n")
+let _findPrimary = strings.Join([
+	"PRIMARY=\(context.name)-0.\(context.name)-headless.\(context.namespace).svc",
+	if parameter.sentinel.enabled {
+		"FOUND=$(redis-cli -h \(context.name) -p 26379 --raw sentinel get-master-addr-by-name \(parameter.sentinel.masterName) 2>/dev/null | head -n 1 || true)\nif [ -n \"$FOUND\" ]; then PRIMARY=$FOUND; fi"
+	},
+	"SELF=$(hostname).\(context.name)-headless.\(context.namespace).svc",
+], "\n")
+let _redisStart = strings.Join([
+	"set -e",
+	"cp /etc/redis/redis.conf /tmp/redis.conf",
+	_findPrimary,
+	"echo \"replica-announce-ip $SELF\" >> /tmp/redis.conf",
+	"if [ -n \"$REDIS_PASSWORD\" ]; then printf 'requirepass \"%s\"\\nmasterauth \"%s\"\\n' \"$REDIS_PASSWORD\" \"$REDIS_PASSWORD\" >> /tmp/redis.conf; fi",
+	"if [ \"$PRIMARY\" != \"$SELF\" ]; then echo \"replicaof $PRIMARY 6379\" >> /tmp/redis.conf; fi",
+	"exec redis-server /tmp/redis.conf",
+], "\n")
+let _sentinelStart = strings.Join([
+	"set -e",
+	_findPrimary,
+	"{",
+	"echo 'port 26379'",
+	"echo 'sentinel resolve-hostnames yes'",
+	"echo 'sentinel announce-hostnames yes'",
+	"echo \"sentinel announce-ip $SELF\"",
+	"echo \"sentinel monitor \(parameter.sentinel.masterName) $PRIMARY 6379 \(parameter.sentinel.quorum)\"",
+	"echo 'sentinel down-after-milliseconds \(parameter.sentinel.masterName) 5000'",
+	"echo 'sentinel failover-timeout \(parameter.sentinel.masterName) 60000'",
+	"if [ -n \"$REDIS_PASSWORD\" ]; then echo \"sentinel auth-pass \(parameter.sentinel.masterName) $REDIS_PASSWORD\"; fi",
+	"} > /tmp/sentinel.conf",
+	"exec redis-sentinel /tmp/sentinel.conf",
+], "\n")
+let _redisReady = strings.Join([
+	"if [ -n \"$REDIS_PASSWORD\" ]; then export REDISCLI_AUTH=$REDIS_PASSWORD; fi",
+	"test \"$(redis-cli ping)\" = PONG",
+	"if redis-cli info replication | grep -q '^role:slave'; then redis-cli info replication | grep -q '^master_link_status:up'; fi",
+], "\n")
+let _checksum = strings.SliceRunes(hex.Encode(sha256.Sum256(_redisConf + _redisStart + _sentinelStart)), 0, 16)`
+
+// redisTemplate defines the template function for redis.
+func redisTemplate(tpl *defkit.Template) {
+	vela := defkit.VelaCtx()
+
+	// Parameter references for template
+	image := defkit.String("image").Default("redis:7.2-alpine")
+	replicas := defkit.Int("replicas").Default(1)
+	persistence := defkit.Object("persistence")
+	auth := defkit.Object("auth")
+	sentinel := defkit.Object("sentinel")
+	metrics := defkit.Object("metrics")
+	writeConnectionSecretToRef := defkit.Object("writeConnectionSecretToRef")
+	cpu := defkit.String("cpu")
+	memory := defkit.String("memory")
+
+	tpl.SetRawHeaderBlock(redisHeader)
+
+	headlessName := defkit.Interpolation(vela.Name(), defkit.Lit("-headless"))
+	configName := defkit.Interpolation(vela.Name(), defkit.Lit("-config"))
+	authSecretName := defkit.Interpolation(vela.Name(), defkit.Lit("-auth"))
+	host := defkit.Interpolation(vela.Name(), defkit.Lit("."), vela.Namespace(), defkit.Lit(".svc"))
+	persistent := defkit.Eq(persistence.Field("enabled"), defkit.Lit(true))
+	sentinelEnabled := defkit.Eq(sentinel.Field("enabled"), defkit.Lit(true))
+	sentinelDisabled := defkit.Eq(sentinel.Field("enabled"), defkit.Lit(false))
+	metricsEnabled := defkit.Eq(metrics.Field("enabled"), defkit.Lit(true))
+	existingSecret := auth.Field("existingSecret").IsSet()
+	inlinePassword := defkit.And(auth.IsSet(), defkit.Not(existingSecret))
+
+	passwordEnv := func() *defkit.ArrayElement {
+		return defkit.NewArrayElement().
+			Set("name", defkit.Lit("REDIS_PASSWORD")).
+			Set("valueFrom", defkit.NewArrayElement().
+				Set("secretKeyRef", defkit.NewArrayElement().
+					SetIf(existingSecret, "name", auth.Field("existingSecret")).
+					SetIf(existingSecret, "key", auth.Field("existingSecretKey")).
+					SetIf(defkit.Not(existingSecret), "name", authSecretName).
+					SetIf(defkit.Not(existingSecret), "key", defkit.Lit("password")),
+				),
+			)
+	}
+	shell := func(script string) defkit.Value {
+		return defkit.Reference(`["sh", "-c", ` + script + `]`)
+	}
+	port := func(name string, port int) *defkit.ArrayElement {
+		return defkit.NewArrayElement().
+			Set("name", defkit.Lit(name)).
+			Set("containerPort", defkit.Lit(port))
+	}
+
+	redis := defkit.NewArrayElement().
+		Set("name", defkit.Lit("redis")).
+		Set("image", image).
+		Set("command", shell("_redisStart")).
+		Set("env", defkit.NewArray().ItemIf(auth.IsSet(), passwordEnv())).
+		Set("ports", defkit.NewArray().Item(port("redis", 6379))).
+		Set("readinessProbe", defkit.NewArrayElement().
+			Set("exec", defkit.NewArrayElement().Set("command", shell("_redisReady"))).
+			Set("periodSeconds", defkit.Lit(5)),
+		).
+		Set("livenessProbe", defkit.NewArrayElement().
+			Set("tcpSocket", defkit.NewArrayElement().Set("port", defkit.Lit("redis"))).
+			Set("initialDelaySeconds", defkit.Lit(15)),
+		).
+		SetIf(cpu.IsSet(), "resources.limits.cpu", cpu).
+		SetIf(cpu.IsSet(), "resources.requests.cpu", cpu).
+		SetIf(memory.IsSet(), "resources.limits.memory", memory).
+		SetIf(memory.IsSet(), "resources.requests.memory", memory).
+		Set("volumeMounts", defkit.NewArray().
+			Item(defkit.NewArrayElement().Set("name", defkit.Lit("data")).Set("mountPath", defkit.Lit("/data"))).
+			Item(defkit.NewArrayElement().Set("name", defkit.Lit("config")).Set("mountPath", defkit.Lit("/etc/redis"))),
+		)
+
+	sentinelContainer := defkit.NewArrayElement().
+		Set("name", defkit.Lit("sentinel")).
+		Set("image", image).
+		Set("command", shell("_sentinelStart")).
+		Set("env", defkit.NewArray().ItemIf(auth.IsSet(), passwordEnv())).
+		Set("ports", defkit.NewArray().Item(port("sentinel", 26379))).
+		Set("readinessProbe", defkit.NewArrayElement().
+			Set("exec", defkit.NewArrayElement().Set("command", shell(`"test \"$(redis-cli -p 26379 ping)\" = PONG"`))).
+			Set("periodSeconds", defkit.Lit(5)),
+		)
+
+	metricsContainer := defkit.NewArrayElement().
+		Set("name", defkit.Lit("metrics")).
+		Set("image", metrics.Field("image")).
+		Set("env", defkit.NewArray().
+			Item(defkit.NewArrayElement().Set("name", defkit.Lit("REDIS_ADDR")).Set("value", defkit.Lit("redis://localhost:6379"))).
+			ItemIf(auth.IsSet(), passwordEnv()),
+		).
+		Set("ports", defkit.NewArray().Item(port("metrics", 9121)))
+
+	volumeClaim := defkit.NewArrayElement().
+		Set("metadata", defkit.NewArrayElement().Set("name", defkit.Lit("data"))).
+		Set("spec", defkit.NewArrayElement().
+			Set("accessModes", defkit.Reference(`["ReadWriteOnce"]`)).
+			SetIf(persistence.Field("storageClass").IsSet(), "storageClassName", persistence.Field("storageClass")).
+			Set("resources", defkit.NewArrayElement().
+				Set("requests", defkit.NewArrayElement().Set("storage", persistence.Field("size"))),
+			),
+		)
+
+	statefulSet := defkit.NewResource("apps/v1", "StatefulSet").
+		Set("metadata.name", vela.Name()).
+		Set("spec.serviceName", headlessName).
+		Set("spec.replicas", replicas).
+		Set("spec.selector.matchLabels[app.oam.dev/component]", vela.Name()).
+		Set("spec.template.metadata.labels[app.oam.dev/name]", vela.AppName()).
+		Set("spec.template.metadata.labels[app.oam.dev/component]", vela.Name()).
+		Set("spec.template.metadata.annotations[redis.oam.dev/checksum]", defkit.LetVariable("_checksum")).
+		If(metricsEnabled).
+		Set("spec.template.metadata.annotations[prometheus.io/scrape]", defkit.Lit("true")).
+		Set("spec.template.metadata.annotations[prometheus.io/port]", defkit.Lit("9121")).
+		EndIf().
+		Set("spec.template.spec.containers", defkit.NewArray().
+			Item(redis).
+			ItemIf(sentinelEnabled, sentinelContainer).
+			ItemIf(metricsEnabled, metricsContainer),
+		).
+		Set("spec.template.spec.volumes", defkit.NewArray().
+			Item(defkit.NewArrayElement().
+				Set("name", defkit.Lit("config")).
+				Set("configMap", defkit.NewArrayElement().Set("name", configName)),
+			).
+			ItemIf(defkit.Not(persistent), defkit.NewArrayElement().
+				Set("name", defkit.Lit("data")).
+				Set("emptyDir", defkit.NewArrayElement()),
+			),
+		).
+		SetIf(persistent, "spec.volumeClaimTemplates", defkit.NewArray().Item(volumeClaim))
+
+	config := defkit.NewResource("v1", "ConfigMap").
+		Set("metadata.name", configName).
+		Set("data[redis.conf]", defkit.LetVariable("_redisConf"))
+
+	authSecret := defkit.NewResource("v1", "Secret").
+		Set("metadata.name", authSecretName).
+		Set("stringData.password", auth.Field("password"))
+
+	servicePort := func(name string, port int) *defkit.ArrayElement {
+		return defkit.NewArrayElement().
+			Set("name", defkit.Lit(name)).
+			Set("port", defkit.Lit(port)).
+			Set("targetPort", defkit.Lit(name))
+	}
+	ports := defkit.NewArray().
+		Item(servicePort("redis", 6379)).
+		ItemIf(sentinelEnabled, servicePort("sentinel", 26379))
+
+	// Pods are resolvable before they are ready, so replicas and Sentinels can
+	// reach the primary while it starts.
+	headless := defkit.NewResource("v1", "Service").
+		Set("metadata.name", headlessName).
+		Set("spec.clusterIP", defkit.Lit("None")).
+		Set("spec.publishNotReadyAddresses", defkit.Lit(true)).
+		Set("spec.selector[app.oam.dev/component]", vela.Name()).
+		Set("spec.ports", ports)
+
+	// Without Sentinel the primary is always the first pod; with Sentinel clients
+	// ask any Sentinel behind the Service for the current primary.
+	client := defkit.NewResource("v1", "Service").
+		Set("metadata.name", vela.Name()).
+		Set("spec.selector[app.oam.dev/component]", vela.Name()).
+		SetIf(sentinelDisabled, "spec.selector[statefulset.kubernetes.io/pod-name]", defkit.Interpolation(vela.Name(), defkit.Lit("-0"))).
+		Set("spec.ports", ports)
+
+	connectionSecret := defkit.NewResource("v1", "Secret").
+		SetIf(writeConnectionSecretToRef.IsSet(), "metadata.name", writeConnectionSecretToRef.Field("name")).
+		SetIf(writeConnectionSecretToRef.NotSet(), "metadata.name", defkit.Interpolation(vela.Name(), defkit.Lit("-conn"))).
+		Set("stringData.host", host).
+		Set("stringData.port", defkit.Lit("6379")).
+		SetIf(inlinePassword, "stringData.password", auth.Field("password")).
+		If(sentinelEnabled).
+		Set("stringData.sentinelHost", host).
+		Set("stringData.sentinelPort", defkit.Lit("26379")).
+		Set("stringData.sentinelMaster", sentinel.Field("masterName")).
+		EndIf()
+
+	tpl.Output(statefulSet)
+	tpl.Outputs("config", config)
+	tpl.OutputsIf(inlinePassword, "authSecret", authSecret)
+	tpl.Outputs("headlessService", headless)
+	tpl.Outputs("service", client)
+	tpl.Outputs("connectionSecret", connectionSecret)
+}
+
+func init() {
+	defkit.Register(Redis())
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
+
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("Redis Component", func() {
+	Describe("Redis()", func() {
+		It("should create a redis component definition", func() {
+			comp := components.Redis()
+			Expect(comp.GetName()).To(Equal("redis"))
+			Expect(comp.GetDescription()).To(ContainSubstring("Valkey"))
+		})
+
+		It("should have StatefulSet workload", func() {
+			workload := components.Redis().GetWorkload()
+			Expect(workload.APIVersion()).To(Equal("apps/v1"))
+			Expect(workload.Kind()).To(Equal("StatefulSet"))
+		})
+
+		It("should have persistence, auth, sentinel and metrics parameters", func() {
+			comp := components.Redis()
+			for _, name := range []string{"image", "replicas", "persistence", "auth", "sentinel", "maxmemory", "maxmemoryPolicy", "extraConfig", "metrics", "writeConnectionSecretToRef", "cpu", "memory"} {
+				Expect(comp).To(HaveParamNamed(name))
+			}
+		})
+	})
+
+	Describe("Status", func() {
+		const ready = `{metadata: {name: "cache", generation: 2}, spec: {replicas: 3}, status: {observedGeneration: 2, replicas: 3, readyReplicas: 3, updatedReplicas: 3}}`
+		const replicaDisconnected = `{metadata: {name: "cache", generation: 2}, spec: {replicas: 3}, status: {observedGeneration: 2, replicas: 3, readyReplicas: 2, updatedReplicas: 3}}`
+
+		DescribeTable("health",
+			func(output string, healthy bool) {
+				v := evalStatus(components.Redis().GetHealthPolicy(), output)
+				Expect(v.LookupPath(cue.ParsePath("isHealth")).Bool()).To(Equal(healthy))
+			},
+			Entry("all pods ready", ready, true),
+			Entry("a replica lost the primary", replicaDisconnected, false),
+			Entry("no status reported yet", `{metadata: {name: "cache", generation: 1}, spec: {replicas: 1}}`, false),
+		)
+
+		It("should report the ready pods and the Service address", func() {
+			v := evalStatus(components.Redis().GetCustomStatus(), replicaDisconnected)
+			Expect(v.LookupPath(cue.ParsePath("message")).String()).To(Equal("Ready:2/3, service: cache:6379"))
+		})
+	})
+
+	Describe("CUE Generation", func() {
+		var doc *cueassert.Document
+
+		BeforeEach(func() {
+			doc = cueassert.MustParse(components.Redis().ToCue())
+		})
+
+		It("should default to a single persistent pod", func() {
+			Expect(doc.Lookup("parameter.replicas")).To(cueassert.HaveDefault(1))
+			Expect(doc.Lookup("parameter.persistence.enabled")).To(cueassert.HaveDefault(true))
+			Expect(doc.Lookup("parameter.auth")).To(cueassert.BeOptionalField())
+			Expect(doc.Lookup("parameter.sentinel.enabled")).To(cueassert.HaveDefault(false))
+			Expect(doc.Lookup("parameter.metrics.enabled")).To(cueassert.HaveDefault(true))
+			Expect(doc.Lookup("parameter.maxmemoryPolicy")).To(cueassert.HaveDefault("allkeys-lru"))
+		})
+
+		It("should only create the auth Secret for inline passwords", func() {
+			Expect(doc.Lookup("outputs.authSecret").Conditional()).To(BeTrue())
+			Expect(doc.Lookup("outputs.connectionSecret").Conditional()).To(BeFalse())
+			Expect(doc.Lookup("outputs.headlessService").Conditional()).To(BeFalse())
+		})
+	})
+
+	Describe("Template", func() {
+		lookup := func(v cue.Value, path string) string {
+			s, err := v.LookupPath(cue.ParsePath(path)).String()
+			Expect(err).NotTo(HaveOccurred(), path)
+			return s
+		}
+		containerNames := func(v cue.Value) []string {
+			var names []string
+			iter, err := v.LookupPath(cue.ParsePath("output.spec.template.spec.containers")).List()
+			Expect(err).NotTo(HaveOccurred())
+			for iter.Next() {
+				names = append(names, lookup(iter.Value(), "name"))
+			}
+			return names
+		}
+
+		It("should run a persistent primary with the exporter sidecar", func() {
+			v := evalTemplate(components.Redis(), `{auth: password: "s3cret"}`)
+			Expect(containerNames(v)).To(Equal([]string{"redis", "metrics"}))
+			Expect(lookup(v, "output.spec.serviceName")).To(Equal("site-headless"))
+			Expect(lookup(v, "output.spec.volumeClaimTemplates[0].spec.resources.requests.storage")).To(Equal("1Gi"))
+			Expect(lookup(v, "outputs.config.data[\"redis.conf\"]")).To(ContainSubstring("maxmemory-policy allkeys-lru"))
+			Expect(lookup(v, "outputs.authSecret.stringData.password")).To(Equal("s3cret"))
+			Expect(lookup(v, "outputs.service.spec.selector[\"statefulset.kubernetes.io/pod-name\"]")).To(Equal("site-0"))
+		})
+
+		It("should export the connection details", func() {
+			v := evalTemplate(components.Redis(), `{auth: password: "s3cret", writeConnectionSecretToRef: name: "cache-conn"}`)
+			Expect(lookup(v, "outputs.connectionSecret.metadata.name")).To(Equal("cache-conn"))
+			Expect(lookup(v, "outputs.connectionSecret.stringData.host")).To(Equal("site.default.svc"))
+			Expect(lookup(v, "outputs.connectionSecret.stringData.port")).To(Equal("6379"))
+			Expect(lookup(v, "outputs.connectionSecret.stringData.password")).To(Equal("s3cret"))
+		})
+
+		It("should read the password from an existing Secret", func() {
+			v := evalTemplate(components.Redis(), `{auth: {existingSecret: "redis-pass", existingSecretKey: "pw"}, metrics: enabled: false}`)
+			Expect(v.LookupPath(cue.ParsePath("outputs.authSecret")).Exists()).To(BeFalse())
+			Expect(v.LookupPath(cue.ParsePath("outputs.connectionSecret.stringData.password")).Exists()).To(BeFalse())
+			Expect(containerNames(v)).To(Equal([]string{"redis"}))
+			Expect(lookup(v, "output.spec.template.spec.containers[0].env[0].valueFrom.secretKeyRef.name")).To(Equal("redis-pass"))
+			Expect(lookup(v, "output.spec.template.spec.containers[0].env[0].valueFrom.secretKeyRef.key")).To(Equal("pw"))
+		})
+
+		It("should run Sentinel next to each replica", func() {
+			v := evalTemplate(components.Redis(), `{replicas: 3, sentinel: enabled: true, persistence: enabled: false}`)
+			Expect(containerNames(v)).To(Equal([]string{"redis", "sentinel", "metrics"}))
+			Expect(v.LookupPath(cue.ParsePath("output.spec.volumeClaimTemplates")).Exists()).To(BeFalse())
+			Expect(lookup(v, "output.spec.template.spec.volumes[1].name")).To(Equal("data"))
+			Expect(v.LookupPath(cue.ParsePath("outputs.service.spec.selector[\"statefulset.kubernetes.io/pod-name\"]")).Exists()).To(BeFalse())
+			Expect(lookup(v, "outputs.service.spec.ports[1].name")).To(Equal("sentinel"))
+			Expect(lookup(v, "outputs.connectionSecret.stringData.sentinelMaster")).To(Equal("mymaster"))
+			Expect(v.LookupPath(cue.ParsePath("outputs.authSecret")).Exists()).To(BeFalse())
+			Expect(lookup(v, "output.spec.template.spec.containers[1].command[2]")).To(ContainSubstring("sentinel monitor mymaster $PRIMARY 6379 2"))
+		})
+
+		It("should limit the resources of the redis container only", func() {
+			v := evalTemplate(components.Redis(), `{cpu: "500m", memory: "256Mi"}`)
+			Expect(lookup(v, "output.spec.template.spec.containers[0].resources.limits.cpu")).To(Equal("500m"))
+			Expect(lookup(v, "output.spec.template.spec.containers[0].resources.requests.memory")).To(Equal("256Mi"))
+			Expect(v.LookupPath(cue.ParsePath("output.spec.template.spec.containers[1].resources")).Exists()).To(BeFalse())
+		})
+
+		DescribeTable("should reject invalid parameters",
+			func(parameter, message string) {
+				v := cuecontext.New().CompileString(components.Redis().ToCue() + "\ncontext: {name: \"site\", appName: \"docs\", namespace: \"default\"}\ntemplate: parameter: " + parameter)
+				Expect(v.Validate()).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("auth without a password or existing Secret", `auth: {}`, "auth requires either password or existingSecret"),
+			Entry("Sentinel with a single replica", `{sentinel: enabled: true}`, "sentinel requires at least 3 replicas"),
+			Entry("a quorum above the replicas", `{replicas: 3, sentinel: {enabled: true, quorum: 4}}`, "sentinel.quorum must not exceed replicas"),
+		)
+	})
+})
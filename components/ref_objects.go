@@ -17,11 +17,16 @@ limitations under the License.
 package components
 
 import (
+	"strings"
+
 	"github.com/oam-dev/kubevela/pkg/definition/defkit"
 )
 
 // RefObjects creates the ref-objects component definition.
 // Ref-objects allow users to specify ref objects to use. Notice that this component type have special handle logic.
+//
+// Its health and status come from ObjectsHealth and ObjectsStatus, so every
+// referenced object is checked by the rules of its kind.
 func RefObjects() *defkit.ComponentDefinition {
 	return defkit.NewComponent("ref-objects").
 		Description("Ref-objects allow users to specify ref objects to use. Notice that this component type have special handle logic.").
@@ -37,46 +42,10 @@ func RefObjects() *defkit.ComponentDefinition {
 		workload: type: "autodetects.core.oam.dev"
 		status: {
 			customStatus: #"""
-				if context.output.apiVersion == "apps/v1" && context.output.kind == "Deployment" {
-					ready: {
-						readyReplicas: *0 | int
-					} & {
-						if context.output.status.readyReplicas != _|_ {
-							readyReplicas: context.output.status.readyReplicas
-						}
-					}
-					message: "Ready:\(ready.readyReplicas)/\(context.output.spec.replicas)"
-				}
-				if context.output.apiVersion != "apps/v1" || context.output.kind != "Deployment" {
-					message: ""
-				}
+` + indentCUE(ObjectsStatus(), "\t\t\t\t") + `
 				"""#
 			healthPolicy: #"""
-				if context.output.apiVersion == "apps/v1" && context.output.kind == "Deployment" {
-					ready: {
-						updatedReplicas:    *0 | int
-						readyReplicas:      *0 | int
-						replicas:           *0 | int
-						observedGeneration: *0 | int
-					} & {
-						if context.output.status.updatedReplicas != _|_ {
-							updatedReplicas: context.output.status.updatedReplicas
-						}
-						if context.output.status.readyReplicas != _|_ {
-							readyReplicas: context.output.status.readyReplicas
-						}
-						if context.output.status.replicas != _|_ {
-							replicas: context.output.status.replicas
-						}
-						if context.output.status.observedGeneration != _|_ {
-							observedGeneration: context.output.status.observedGeneration
-						}
-					}
-					isHealth: (context.output.spec.replicas == ready.readyReplicas) && (context.output.spec.replicas == ready.updatedReplicas) && (context.output.spec.replicas == ready.replicas) && (ready.observedGeneration == context.output.metadata.generation || ready.observedGeneration > context.output.metadata.generation)
-				}
-				if context.output.apiVersion != "apps/v1" || context.output.kind != "Deployment" {
-					isHealth: true
-				}
+` + indentCUE(ObjectsHealth(), "\t\t\t\t") + `
 				"""#
 		}
 	}
@@ -122,6 +91,18 @@ template: {
 `)
 }
 
+// indentCUE indents the non-empty lines of a status block, so it can be
+// embedded in a multi-line string of a raw definition.
+func indentCUE(block, indent string) string {
+	lines := strings.Split(block, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 func init() {
 	defkit.Register(RefObjects())
 }
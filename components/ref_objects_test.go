@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+)
+
+var _ = Describe("RefObjects Component", func() {
+	var attributes cue.Value
+
+	BeforeEach(func() {
+		v := cuecontext.New().CompileString(components.RefObjects().ToCue())
+		Expect(v.Err()).NotTo(HaveOccurred())
+		attributes = v.LookupPath(cue.ParsePath(`"ref-objects".attributes`))
+	})
+
+	It("should create a ref-objects component definition", func() {
+		comp := components.RefObjects()
+		Expect(comp.GetName()).To(Equal("ref-objects"))
+		s, err := attributes.LookupPath(cue.ParsePath("workload.type")).String()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s).To(Equal("autodetects.core.oam.dev"))
+	})
+
+	It("should check every referenced object by the rules of its kind", func() {
+		health, err := attributes.LookupPath(cue.ParsePath("status.healthPolicy")).String()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(health).To(Equal(components.ObjectsHealth()))
+		status, err := attributes.LookupPath(cue.ParsePath("status.customStatus")).String()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Equal(components.ObjectsStatus()))
+	})
+})
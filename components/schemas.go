@@ -52,3 +52,318 @@ func HealthProbeParam() *defkit.MapParam {
 			defkit.Int("failureThreshold").Default(3).Description("Number of consecutive failures required to determine the container is not alive (liveness probe) or not ready (readiness probe)."),
 		)
 }
+
+// ContainerEnvParam returns the env parameter shared by container-based
+// components: a list of variables with a literal value or a valueFrom
+// reference to a Secret or ConfigMap key.
+func ContainerEnvParam() *defkit.ArrayParam {
+	return defkit.List("env").
+		Optional().
+		Description("Define arguments by using environment variables").
+		WithFields(
+			defkit.String("name").Description("Environment variable name"),
+			defkit.String("value").Optional().Description("The value of the environment variable"),
+			defkit.Object("valueFrom").Optional().Description("Specifies a source the value of this var should come from").
+				WithFields(
+					defkit.Object("secretKeyRef").Optional().Description("Selects a key of a secret in the pod's namespace").
+						WithFields(
+							defkit.String("name").Description("The name of the secret in the pod's namespace to select from"),
+							defkit.String("key").Description("The key of the secret to select from. Must be a valid secret key"),
+						),
+					defkit.Object("configMapKeyRef").Optional().Description("Selects a key of a config map in the pod's namespace").
+						WithFields(
+							defkit.String("name").Description("The name of the config map in the pod's namespace to select from"),
+							defkit.String("key").Description("The key of the config map to select from. Must be a valid secret key"),
+						),
+				),
+		)
+}
+
+// TaskVolumesParam returns the volumes parameter of task-style components: a
+// list of volumes mounted into the single container, each from a pvc,
+// configMap, secret or emptyDir source.
+func TaskVolumesParam() *defkit.ArrayParam {
+	return defkit.List("volumes").Optional().Description("Declare volumes and volumeMounts").
+		WithFields(
+			defkit.String("name"),
+			defkit.String("mountPath"),
+			defkit.OneOf("type").
+				Description("Specify volume type, options: \"pvc\",\"configMap\",\"secret\",\"emptyDir\", default to emptyDir").
+				Default("emptyDir").
+				Variants(
+					defkit.Variant("pvc").WithFields(
+						defkit.Field("claimName", defkit.ParamTypeString),
+					),
+					defkit.Variant("configMap").WithFields(
+						defkit.Field("defaultMode", defkit.ParamTypeInt).Default(420),
+						defkit.Field("cmName", defkit.ParamTypeString),
+						defkit.Field("items", defkit.ParamTypeArray).Optional().Nested(
+							defkit.Struct("").WithFields(
+								defkit.Field("key", defkit.ParamTypeString),
+								defkit.Field("path", defkit.ParamTypeString),
+								defkit.Field("mode", defkit.ParamTypeInt).Default(511),
+							),
+						),
+					),
+					defkit.Variant("secret").WithFields(
+						defkit.Field("defaultMode", defkit.ParamTypeInt).Default(420),
+						defkit.Field("secretName", defkit.ParamTypeString),
+						defkit.Field("items", defkit.ParamTypeArray).Optional().Nested(
+							defkit.Struct("").WithFields(
+								defkit.Field("key", defkit.ParamTypeString),
+								defkit.Field("path", defkit.ParamTypeString),
+								defkit.Field("mode", defkit.ParamTypeInt).Default(511),
+							),
+						),
+					),
+					defkit.Variant("emptyDir").WithFields(
+						defkit.Field("medium", defkit.ParamTypeString).Default("").Values("", "Memory"),
+					),
+				),
+		)
+}
+
+// ContainersParam returns the containers parameter of long-running
+// components: containers run in the pod next to the primary container
+// described by the top-level parameters. Their volumeMounts refer by name to
+// the pod volumes declared in the volumeMounts parameter.
+func ContainersParam() *defkit.ArrayParam {
+	return defkit.List("containers").
+		Optional().
+		Description("Additional containers to run in the pod next to the main container").
+		WithFields(containerFields()...)
+}
+
+// SidecarsParam returns the sidecars parameter of long-running components:
+// containers run as restartable init containers, which start before the main
+// container and stop after it. They take the fields of ContainersParam.
+func SidecarsParam() *defkit.ArrayParam {
+	return defkit.List("sidecars").
+		Optional().
+		Description("Sidecar containers that start before and stop after the main container. They run as restartable init containers on Kubernetes 1.29 or later, and next to the main container otherwise").
+		WithFields(containerFields()...)
+}
+
+// JobSidecarsParam returns the sidecars parameter of run-to-completion
+// components. A Job completes only once its containers exit, so the sidecars
+// run as restartable init containers only, which needs Kubernetes 1.29 or
+// later (see NativeSidecarsRequired).
+func JobSidecarsParam() *defkit.ArrayParam {
+	return SidecarsParam().
+		Description("Sidecar containers that start before and stop after the main container, so the job completes once the main container exits. Requires Kubernetes 1.29 or later")
+}
+
+// containerFields returns the fields of an entry of ContainersParam.
+func containerFields() []defkit.Param {
+	return []defkit.Param{
+		defkit.String("name").Description("Name of the container, unique within the pod"),
+		defkit.String("image").Description("Image of the container"),
+		defkit.Enum("imagePullPolicy").Optional().
+			Values("Always", "Never", "IfNotPresent").
+			Description("Specify image pull policy for the container"),
+		defkit.StringList("cmd").Optional().Description("Commands to run in the container"),
+		defkit.StringList("args").Optional().Description("Arguments to the entrypoint"),
+		defkit.List("ports").Optional().Description("Ports the container listens on").
+			WithFields(
+				defkit.Int("containerPort").Description("Number of port to expose on the pod's IP address"),
+				defkit.String("name").Optional().Description("Name of the port"),
+				defkit.Enum("protocol").Values("TCP", "UDP", "SCTP").Default("TCP").Description("Protocol for port. Must be UDP, TCP, or SCTP"),
+			),
+		ContainerEnvParam(),
+		defkit.String("cpu").Optional().Description("Number of CPU units for the container, like `0.5` (0.5 CPU core), `1` (1 CPU core)"),
+		defkit.String("memory").Optional().Description("Specifies the attributes of the memory resource required for the container."),
+		defkit.Object("startupProbe").Optional().
+			WithSchemaRef("HealthProbe").
+			Description("Instructions for assessing whether the container has started. Other probes wait until it succeeds."),
+		defkit.Object("livenessProbe").Optional().
+			WithSchemaRef("HealthProbe").
+			Description("Instructions for assessing whether the container is alive."),
+		defkit.Object("readinessProbe").Optional().
+			WithSchemaRef("HealthProbe").
+			Description("Instructions for assessing whether the container is in a suitable state to serve traffic."),
+		defkit.List("volumeMounts").Optional().Description("Mount pod volumes declared in volumeMounts into the container").
+			WithFields(
+				defkit.String("name").Description("Name of the pod volume"),
+				defkit.String("mountPath").Description("Path to mount the volume at"),
+				defkit.String("subPath").Optional().Description("Mount only this path of the volume"),
+				defkit.Bool("readOnly").Optional().Description("Mount the volume read-only"),
+			),
+	}
+}
+
+// ServicePortFields returns the fields of a ports entry choosing the Service
+// the port joins when exposed, and the settings of that Service. Ports of the
+// same Service must set its settings alike, see ExposeValidators. Used with
+// ExposeServices.
+func ServicePortFields() []defkit.Param {
+	return []defkit.Param{
+		defkit.Enum("serviceType").Optional().
+			Values("ClusterIP", "NodePort", "LoadBalancer").
+			Description("Type of the Service exposing the port, defaults to exposeType. Ports of another type than exposeType are exposed by a Service named after the component and the type, like `web-nodeport`"),
+		defkit.String("appProtocol").Optional().
+			Description("Application protocol of the port, like `http`, `https`, `kubernetes.io/h2c` or `grpc`. Ports with another protocol than HTTP cannot be routed"),
+		defkit.Enum("externalTrafficPolicy").Optional().
+			Values("Cluster", "Local").
+			Description("Route external traffic to node-local endpoints only to preserve the client source IP. Only valid for NodePort and LoadBalancer Services. Ports of the same Service must set it alike"),
+		defkit.Enum("sessionAffinity").Optional().
+			Values("None", "ClientIP").
+			Description("Send the requests of a client to the same pod. Ports of the same Service must set it alike"),
+		defkit.Enum("ipFamilyPolicy").Optional().
+			Values("SingleStack", "PreferDualStack", "RequireDualStack").
+			Description("Assign the Service IPv4 and IPv6 cluster IPs on dual-stack clusters. Ports of the same Service must set it alike"),
+	}
+}
+
+// IntOrPercentParam returns an optional parameter taking a number or a
+// percentage like "25%", as the IntOrString fields of rolling updates do. Its
+// #IntOrPercent type is declared by IntOrPercent.
+func IntOrPercentParam(name string) *defkit.MapParam {
+	return defkit.Object(name).Optional().WithSchemaRef("IntOrPercent")
+}
+
+// RouteParam returns the route parameter routing external HTTP traffic to an
+// exposed port through an Ingress or a Gateway API HTTPRoute. Used with
+// ExposeServices.
+func RouteParam() *defkit.MapParam {
+	return defkit.Object("route").Optional().
+		Description("Route external HTTP traffic to an exposed TCP port with an HTTP appProtocol").
+		WithFields(
+			defkit.OneOf("type").
+				Description(`Specify the route type, options: "Ingress","HTTPRoute", default to Ingress`).
+				Default("Ingress").
+				Variants(
+					defkit.Variant("Ingress").WithFields(
+						defkit.Field("className", defkit.ParamTypeString).Optional().
+							Description("Class of the Ingress, the cluster default if empty"),
+						defkit.Field("tlsSecretName", defkit.ParamTypeString).Optional().
+							Description("Secret holding the TLS certificate of the hosts"),
+					),
+					defkit.Variant("HTTPRoute").WithFields(
+						defkit.Field("gateway", defkit.ParamTypeStruct).
+							Description("Gateway the HTTPRoute attaches to, it terminates TLS").
+							Nested(defkit.Struct("").WithFields(
+								defkit.Field("name", defkit.ParamTypeString).Description("Name of the Gateway"),
+								defkit.Field("namespace", defkit.ParamTypeString).Optional().
+									Description("Namespace of the Gateway, defaults to the namespace of the component"),
+								defkit.Field("sectionName", defkit.ParamTypeString).Optional().
+									Description("Listener of the Gateway to attach to"),
+							)),
+					),
+				),
+			defkit.Int("port").Optional().
+				Description("Exposed HTTP port to route to, defaults to the first one"),
+			defkit.StringList("hosts").Optional().Description("Host names to route, all of them if empty"),
+			defkit.String("path").Default("/").Description("Path to route"),
+			defkit.Enum("pathType").Values("Prefix", "Exact").Default("Prefix").
+				Description("Match the path as a prefix or exactly"),
+			defkit.StringKeyMap("annotations").Optional().Description("Annotations of the Ingress or HTTPRoute"),
+		)
+}
+
+// DeploymentSettingsParams returns the rollout and scheduling parameters of
+// components rendering a Deployment, applied by DeploymentSettings. The
+// template declares #IntOrPercent with IntOrPercent.
+func DeploymentSettingsParams() []defkit.Param {
+	return []defkit.Param{
+		defkit.Object("strategy").Optional().
+			Description("Specify how pods are replaced when the pod template changes").
+			WithFields(
+				defkit.Enum("type").Values("RollingUpdate", "Recreate").Default("RollingUpdate").
+					Description("Replace pods gradually, or delete all of them before creating new ones"),
+				defkit.Object("rollingUpdate").Optional().Description("Only valid when type is RollingUpdate").WithFields(
+					IntOrPercentParam("maxSurge").Description("Maximum number or percentage of pods created over the desired replicas, defaults to 25%"),
+					IntOrPercentParam("maxUnavailable").Description("Maximum number or percentage of pods unavailable during the update, defaults to 25%"),
+				),
+			),
+		defkit.Int("minReadySeconds").Optional().Min(0).
+			Description("Number of seconds a new pod must be ready without crashing to count as available"),
+		defkit.Int("progressDeadlineSeconds").Optional().Min(1).
+			Description("Number of seconds the rollout may make no progress before it is reported as failed"),
+		defkit.Int("revisionHistoryLimit").Optional().Min(0).
+			Description("Number of old ReplicaSets kept to allow a rollback"),
+		defkit.Int("terminationGracePeriodSeconds").Optional().Min(0).
+			Description("Number of seconds the pod is given to shut down gracefully before it is killed"),
+		defkit.String("priorityClassName").Optional().
+			Description("Name of the PriorityClass of the pods"),
+		defkit.StringKeyMap("nodeSelector").Optional().
+			Description("Only schedule the pods on nodes with all of these labels"),
+		defkit.List("tolerations").Optional().
+			Description("Allow the pods to be scheduled on nodes with matching taints").
+			WithFields(
+				defkit.String("key").Optional().Description("Taint key to tolerate, all keys when empty and operator is Exists"),
+				defkit.Enum("operator").Values("Equal", "Exists").Default("Equal").Description("Whether the taint value must equal value, or any value is tolerated"),
+				defkit.String("value").Optional().Description("Taint value to tolerate when operator is Equal"),
+				defkit.Enum("effect").Optional().Values("NoSchedule", "PreferNoSchedule", "NoExecute").Description("Taint effect to tolerate, all effects when empty"),
+				defkit.Int("tolerationSeconds").Optional().Description("Number of seconds the pod stays bound to a node tainted NoExecute"),
+			),
+		defkit.String("serviceAccountName").Optional().
+			Description("Name of the ServiceAccount the pods run as"),
+	}
+}
+
+// VolumeMountsParam returns the volumeMounts parameter with one list per
+// volume source, restricted to the given sources (see volumeMountSources).
+// Runtimes that forbid some sources, such as Knative with pvc and hostPath,
+// pass the subset they support.
+func VolumeMountsParam(sources ...string) *defkit.MapParam {
+	fields := make([]defkit.Param, 0, len(sources))
+	for _, source := range sources {
+		fields = append(fields, volumeMountSourceParam(source))
+	}
+	return defkit.Object("volumeMounts").Optional().WithFields(fields...)
+}
+
+// volumeMountSourceParam returns the list parameter of a single volume source.
+func volumeMountSourceParam(source string) defkit.Param {
+	switch source {
+	case "pvc":
+		return defkit.List("pvc").Optional().Description("Mount PVC type volume").WithFields(
+			defkit.String("name"),
+			defkit.String("mountPath"),
+			defkit.String("subPath").Optional(),
+			defkit.String("claimName").Description("The name of the PVC"),
+		)
+	case "configMap":
+		return defkit.List("configMap").Optional().Description("Mount ConfigMap type volume").WithFields(
+			defkit.String("name"),
+			defkit.String("mountPath"),
+			defkit.String("subPath").Optional(),
+			defkit.Int("defaultMode").Default(420),
+			defkit.String("cmName"),
+			defkit.List("items").Optional().WithFields(
+				defkit.String("key"),
+				defkit.String("path"),
+				defkit.Int("mode").Default(511),
+			),
+		)
+	case "secret":
+		return defkit.List("secret").Optional().Description("Mount Secret type volume").WithFields(
+			defkit.String("name"),
+			defkit.String("mountPath"),
+			defkit.String("subPath").Optional(),
+			defkit.Int("defaultMode").Default(420),
+			defkit.String("secretName"),
+			defkit.List("items").Optional().WithFields(
+				defkit.String("key"),
+				defkit.String("path"),
+				defkit.Int("mode").Default(511),
+			),
+		)
+	case "emptyDir":
+		return defkit.List("emptyDir").Optional().Description("Mount EmptyDir type volume").WithFields(
+			defkit.String("name"),
+			defkit.String("mountPath"),
+			defkit.String("subPath").Optional(),
+			defkit.Enum("medium").Values("", "Memory").Default(""),
+		)
+	case "hostPath":
+		return defkit.List("hostPath").Optional().Description("Mount HostPath type volume").WithFields(
+			defkit.String("name"),
+			defkit.String("mountPath"),
+			defkit.String("subPath").Optional(),
+			defkit.String("path"),
+		)
+	default:
+		panic("components: unknown volume mount source " + source)
+	}
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package components
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/oam-dev/kubevela/pkg/definition/defkit"
 )
 
@@ -132,6 +135,83 @@ func podVolumeMappings() map[string]defkit.FieldMap {
 	}
 }
 
+// --- Container Helpers ---
+
+// AdditionalContainers appends the containers parameter (see ContainersParam)
+// to the pod containers, after the primary container built from the
+// top-level parameters. Their settings map onto the Kubernetes container as is,
+// except cmd, and cpu and memory, which set both the requests and limits. On
+// clusters without native sidecars the sidecars parameter is appended too (see
+// NativeSidecars).
+//
+// Usage:
+//
+//	mainContainer := defkit.NewArrayElement().Set("name", vela.Name()).Set("image", image)
+//	deployment.Set("spec.template.spec.containers", AdditionalContainers(defkit.NewArray().Item(mainContainer)))
+func AdditionalContainers(containers *defkit.ArrayBuilder) *defkit.ArrayBuilder {
+	extra := defkit.List("containers")
+	sidecars := defkit.List("sidecars")
+	return containers.
+		ForEachGuarded(extra.IsSet(), extra, paramContainer()).
+		ForEachGuarded(defkit.And(sidecars.LenGt(0), defkit.Not(NativeSidecarsSupported())), sidecars, paramContainer())
+}
+
+// NativeSidecars renders the sidecars parameter (see SidecarsParam) as
+// restartable init containers, mapped like AdditionalContainers. Kubernetes
+// supports them from 1.29, so they are set under NativeSidecarsSupported only.
+//
+// Usage:
+//
+//	sidecars := defkit.List("sidecars")
+//	deployment.SetIf(defkit.And(sidecars.LenGt(0), NativeSidecarsSupported()), "spec.template.spec.initContainers", NativeSidecars())
+func NativeSidecars() *defkit.ArrayBuilder {
+	sidecars := defkit.List("sidecars")
+	return defkit.NewArray().ForEach(sidecars, paramContainer().Set("restartPolicy", defkit.Lit("Always")))
+}
+
+// NativeSidecarsSupported checks that the cluster runs sidecars as
+// restartable init containers, which needs Kubernetes 1.29 or later.
+func NativeSidecarsSupported() defkit.Condition {
+	return defkit.Ge(defkit.VelaCtx().ClusterVersion().Minor(), defkit.Lit(29))
+}
+
+// NativeSidecarsRequired rejects the sidecars parameter (see JobSidecarsParam)
+// on clusters without native sidecars. Jobs complete only once all their
+// containers exit, so they cannot run sidecars next to the main container.
+func NativeSidecarsRequired() *defkit.Validator {
+	sidecars := defkit.List("sidecars")
+	return defkit.Validate("sidecars require Kubernetes 1.29 or later").
+		WithName("_validateSidecars").
+		OnlyWhen(sidecars.LenGt(0)).
+		FailWhen(defkit.Not(NativeSidecarsSupported()))
+}
+
+// paramContainer maps an entry m of the containers or sidecars parameter onto
+// a Kubernetes container.
+func paramContainer() *defkit.ArrayElement {
+	container := defkit.NewArrayElement().
+		Set("name", defkit.Reference("m.name")).
+		Set("image", defkit.Reference("m.image"))
+	for _, field := range []struct{ from, to string }{
+		{"imagePullPolicy", "imagePullPolicy"},
+		{"cmd", "command"},
+		{"args", "args"},
+		{"ports", "ports"},
+		{"env", "env"},
+		{"cpu", "resources.limits.cpu"},
+		{"cpu", "resources.requests.cpu"},
+		{"memory", "resources.limits.memory"},
+		{"memory", "resources.requests.memory"},
+		{"startupProbe", "startupProbe"},
+		{"livenessProbe", "livenessProbe"},
+		{"readinessProbe", "readinessProbe"},
+		{"volumeMounts", "volumeMounts"},
+	} {
+		container.SetIf(defkit.PathExists("m."+field.from), field.to, defkit.Reference("m."+field.from))
+	}
+	return container
+}
+
 // --- Image Pull Secrets Helper ---
 
 // ImagePullSecretsTransform transforms a string array of secret names
@@ -180,6 +260,357 @@ func ServicePortsTransform(ports defkit.Value) *defkit.CollectionOp {
 	})
 }
 
+// --- Expose Helpers ---
+
+// serviceTypes are the values of the serviceType field of ServicePortFields.
+var serviceTypes = []string{"ClusterIP", "NodePort", "LoadBalancer"}
+
+// serviceSettings are the fields of ServicePortFields setting the Service of
+// a port rather than the port.
+var serviceSettings = []string{"sessionAffinity", "ipFamilyPolicy", "externalTrafficPolicy"}
+
+// httpAppProtocols are the appProtocol values of the ports a route can target.
+var httpAppProtocols = []string{"http", "https", "kubernetes.io/h2c", "kubernetes.io/ws", "kubernetes.io/wss"}
+
+// ExposeServices exposes the ports parameter entries with expose set through
+// Services, and routes external HTTP traffic to one of them when the route
+// parameter is set (see ServicePortFields and RouteParam). Ports join the
+// Service of their serviceType: the one of exposeType is named after the
+// component and emitted as output, the others are suffixed with their type.
+// servicePort maps a ports entry to its Service port, see ServicePort. The
+// component must declare the ExposeValidators.
+//
+// Usage:
+//
+//	ExposeServices(tpl, "webserviceExpose", ServicePort)
+func ExposeServices(tpl *defkit.Template, output string, servicePort func(item *defkit.ItemBuilder)) {
+	vela := defkit.VelaCtx()
+	ports := defkit.List("ports")
+	exposeType := defkit.String("exposeType")
+	route := defkit.Object("route")
+
+	// exposedPorts holds the Service port of each exposed port, along with the
+	// type and name of its Service, its Service settings and the type of the
+	// route targeting it, if any.
+	exposedPortsArray := defkit.NewArray().ForEachWithGuardedFiltered(
+		ports.IsSet(),
+		defkit.FieldEquals("expose", true),
+		ports,
+		func(item *defkit.ItemBuilder) {
+			v := item.Var()
+
+			servicePort(item)
+			item.IfSet("appProtocol", func() {
+				item.Set("appProtocol", v.Field("appProtocol"))
+			})
+			for _, setting := range []string{"sessionAffinity", "ipFamilyPolicy"} {
+				item.IfSet(setting, func() {
+					item.Set(setting, v.Field(setting))
+				})
+			}
+
+			// nodePort only applies to NodePort Services, externalTrafficPolicy
+			// to the ones reachable from outside the cluster
+			serviceType := func(t defkit.Value) {
+				item.Set("serviceType", t)
+				item.IfSet("nodePort", func() {
+					item.If(defkit.Eq(t, defkit.Lit("NodePort")), func() {
+						item.Set("nodePort", v.Field("nodePort"))
+					})
+				})
+				item.IfSet("externalTrafficPolicy", func() {
+					item.If(defkit.Or(defkit.Eq(t, defkit.Lit("NodePort")), defkit.Eq(t, defkit.Lit("LoadBalancer"))), func() {
+						item.Set("externalTrafficPolicy", v.Field("externalTrafficPolicy"))
+					})
+				})
+			}
+			item.IfNotSet("serviceType", func() {
+				serviceType(exposeType)
+				item.Set("service", vela.Name())
+			})
+			item.IfSet("serviceType", func() {
+				serviceType(v.Field("serviceType"))
+				item.If(defkit.Eq(v.Field("serviceType"), exposeType), func() {
+					item.Set("service", vela.Name())
+				})
+				item.If(defkit.Ne(v.Field("serviceType"), exposeType), func() {
+					item.Set("service", defkit.Plus(vela.Name(), defkit.Lit("-"), defkit.StringsToLower(v.Field("serviceType"))))
+				})
+			})
+
+			// routeType: the type of the route when it targets the port, that is
+			// route.port if set, else any TCP port with an HTTP appProtocol
+			item.SetDefault("routeType", defkit.Lit(""), "string")
+			routeTo := func() {
+				item.If(defkit.Not(route.Field("port").IsSet()), func() {
+					item.Set("routeType", route.Field("type"))
+				})
+				item.If(route.Field("port").IsSet(), func() {
+					item.If(defkit.Eq(v.Field("port"), route.Field("port")), func() {
+						item.Set("routeType", route.Field("type"))
+					})
+				})
+			}
+			httpAppProtocol := make([]defkit.Condition, 0, len(httpAppProtocols))
+			for _, p := range httpAppProtocols {
+				httpAppProtocol = append(httpAppProtocol, defkit.Eq(v.Field("appProtocol"), defkit.Lit(p)))
+			}
+			item.If(route.IsSet(), func() {
+				item.If(defkit.Eq(v.Field("protocol"), defkit.Lit("TCP")), func() {
+					item.IfNotSet("appProtocol", routeTo)
+					item.IfSet("appProtocol", func() {
+						item.If(defkit.Or(httpAppProtocol...), routeTo)
+					})
+				})
+			})
+		},
+	)
+	exposedPorts := tpl.Helper("exposedPorts").
+		FromArray(exposedPortsArray).
+		AfterOutput().
+		Build()
+
+	// The Service of exposeType, then the ones of the other types. Filtering on
+	// exposeType compares the iteration variable to a parameter, which no
+	// predicate does, so it is written as a condition on a reference.
+	exposePorts := tpl.Helper("exposePorts").
+		FromHelper(exposedPorts).
+		FilterCond(defkit.Eq(defkit.Reference("v.serviceType"), exposeType)).
+		AfterOutput().
+		Build()
+	tpl.OutputsIf(exposePorts.NotEmpty(), output, exposeService(exposePorts, vela.Name(), exposeType))
+	for _, t := range serviceTypes {
+		typePorts := tpl.Helper("expose" + t + "Ports").
+			FromHelper(exposedPorts).
+			Filter(defkit.FieldEquals("serviceType", t)).
+			AfterOutput().
+			Build()
+		name := defkit.Plus(vela.Name(), defkit.Lit("-"+strings.ToLower(t)))
+		tpl.OutputsIf(defkit.And(defkit.Ne(exposeType, defkit.Lit(t)), typePorts.NotEmpty()), output+t, exposeService(typePorts, name, defkit.Lit(t)))
+	}
+
+	ingressPorts := tpl.Helper("ingressPorts").
+		FromHelper(exposedPorts).
+		Filter(defkit.FieldEquals("routeType", "Ingress")).
+		AfterOutput().
+		Build()
+	tpl.OutputsIf(ingressPorts.NotEmpty(), "ingress", routeIngress(ingressPorts))
+
+	httpRoutePorts := tpl.Helper("httpRoutePorts").
+		FromHelper(exposedPorts).
+		Filter(defkit.FieldEquals("routeType", "HTTPRoute")).
+		AfterOutput().
+		Build()
+	tpl.OutputsIf(httpRoutePorts.NotEmpty(), "httpRoute", routeHTTPRoute(httpRoutePorts))
+}
+
+// ServicePort maps an exposed ports entry to its Service port: the port
+// forwarded to containerPort, named after the target port and the protocol
+// unless TCP by default. Used with ExposeServices.
+func ServicePort(item *defkit.ItemBuilder) {
+	v := item.Var()
+
+	item.Set("port", v.Field("port"))
+	item.IfSet("containerPort", func() {
+		item.Set("targetPort", v.Field("containerPort"))
+	})
+	item.IfNotSet("containerPort", func() {
+		item.Set("targetPort", v.Field("port"))
+	})
+	item.IfSet("name", func() {
+		item.Set("name", v.Field("name"))
+	})
+	portName := func(targetPort string) func() {
+		return func() {
+			nameRef := item.Let("_name",
+				defkit.Plus(defkit.Lit("port-"), defkit.StrconvFormatInt(v.Field(targetPort), 10)))
+			item.SetDefault("name", nameRef, "string")
+			item.If(defkit.Ne(v.Field("protocol"), defkit.Lit("TCP")), func() {
+				item.Set("name", defkit.Plus(nameRef, defkit.Lit("-"), defkit.StringsToLower(v.Field("protocol"))))
+			})
+		}
+	}
+	item.IfNotSet("name", func() {
+		item.IfSet("containerPort", portName("containerPort"))
+		item.IfNotSet("containerPort", portName("port"))
+	})
+	item.Set("protocol", v.Field("protocol"))
+}
+
+// exposeService returns the Service of type serviceType named name exposing
+// ports, a helper filtering the exposedPorts of ExposeServices.
+func exposeService(ports *defkit.HelperVar, name, serviceType defkit.Value) *defkit.Resource {
+	service := defkit.NewResource("v1", "Service").
+		Set("metadata.name", name).
+		Set("spec.selector[app.oam.dev/component]", defkit.VelaCtx().Name()).
+		Set("spec.ports", defkit.Each(ports).Map(defkit.FieldMap{
+			"port":        defkit.FieldRef("port"),
+			"targetPort":  defkit.FieldRef("targetPort"),
+			"name":        defkit.FieldRef("name"),
+			"protocol":    defkit.OptionalFieldRef("protocol"),
+			"appProtocol": defkit.OptionalFieldRef("appProtocol"),
+			"nodePort":    defkit.OptionalFieldRef("nodePort"),
+		})).
+		Set("spec.type", serviceType)
+	// ExposeValidators makes the ports of a Service set its settings alike,
+	// so they are read from the first one. defkit has no list index value,
+	// hence the paths.
+	for _, setting := range serviceSettings {
+		first := ports.Name() + "[0]." + setting
+		service.SetIf(defkit.PathExists(first), "spec."+setting, defkit.Reference(first))
+	}
+	return service
+}
+
+// routeBackend returns the Service name and port of the first of ports, a
+// helper filtering the exposedPorts of ExposeServices. defkit has no list
+// index value, hence the paths.
+func routeBackend(ports *defkit.HelperVar) (service, port defkit.Value) {
+	return defkit.Reference(ports.Name() + "[0].service"), defkit.Reference(ports.Name() + "[0].port")
+}
+
+// routeIngress returns the Ingress of the route parameter, sending the
+// traffic to the first of ports.
+func routeIngress(ports *defkit.HelperVar) *defkit.Resource {
+	route := defkit.Object("route")
+	hosts := route.Field("hosts")
+	service, port := routeBackend(ports)
+
+	http := defkit.NewArrayElement().
+		Set("paths", defkit.NewArray().Item(defkit.NewArrayElement().
+			Set("path", route.Field("path")).
+			Set("pathType", route.Field("pathType")).
+			Set("backend", defkit.NewArrayElement().
+				Set("service", defkit.NewArrayElement().
+					Set("name", service).
+					Set("port", defkit.NewArrayElement().Set("number", port))))))
+
+	return defkit.NewResource("networking.k8s.io/v1", "Ingress").
+		Set("metadata.name", defkit.VelaCtx().Name()).
+		SetIf(route.Field("annotations").IsSet(), "metadata.annotations", route.Field("annotations")).
+		SetIf(route.Field("className").IsSet(), "spec.ingressClassName", route.Field("className")).
+		SetIf(route.Field("tlsSecretName").IsSet(), "spec.tls", defkit.NewArray().Item(defkit.NewArrayElement().
+			SetIf(hosts.IsSet(), "hosts", hosts).
+			Set("secretName", route.Field("tlsSecretName")))).
+		SetIf(defkit.Not(hosts.IsSet()), "spec.rules", defkit.NewArray().Item(defkit.NewArrayElement().
+			Set("http", http))).
+		SetIf(hosts.IsSet(), "spec.rules", defkit.NewArray().ForEach(hosts, defkit.NewArrayElement().
+			Set("host", defkit.Reference("m")).
+			Set("http", http)))
+}
+
+// routeHTTPRoute returns the Gateway API HTTPRoute of the route parameter,
+// sending the traffic to the first of ports.
+func routeHTTPRoute(ports *defkit.HelperVar) *defkit.Resource {
+	route := defkit.Object("route")
+	pathType := route.Field("pathType")
+	service, port := routeBackend(ports)
+
+	return defkit.NewResource("gateway.networking.k8s.io/v1", "HTTPRoute").
+		Set("metadata.name", defkit.VelaCtx().Name()).
+		SetIf(route.Field("annotations").IsSet(), "metadata.annotations", route.Field("annotations")).
+		Set("spec.parentRefs", defkit.NewArray().Item(defkit.NewArrayElement().
+			Set("name", route.Field("gateway.name")).
+			SetIf(route.Field("gateway.namespace").IsSet(), "namespace", route.Field("gateway.namespace")).
+			SetIf(route.Field("gateway.sectionName").IsSet(), "sectionName", route.Field("gateway.sectionName")))).
+		SetIf(route.Field("hosts").IsSet(), "spec.hostnames", route.Field("hosts")).
+		Set("spec.rules", defkit.NewArray().Item(defkit.NewArrayElement().
+			Set("matches", defkit.NewArray().Item(defkit.NewArrayElement().
+				Set("path", defkit.NewArrayElement().
+					SetIf(pathType.Eq("Prefix"), "type", defkit.Lit("PathPrefix")).
+					SetIf(pathType.Eq("Exact"), "type", defkit.Lit("Exact")).
+					Set("value", route.Field("path"))))).
+			Set("backendRefs", defkit.NewArray().Item(defkit.NewArrayElement().
+				Set("name", service).
+				Set("port", port)))))
+}
+
+// ExposeValidators reject the parameters ExposeServices cannot honour: ports
+// of the same Service setting its settings differently, and a route without
+// an exposed port to target.
+func ExposeValidators() []*defkit.Validator {
+	route := defkit.Object("route")
+	routeMissed := defkit.And(
+		defkit.LenEq(defkit.Reference("ingressPorts"), 0),
+		defkit.LenEq(defkit.Reference("httpRoutePorts"), 0),
+	)
+	validators := []*defkit.Validator{
+		defkit.Validate("route needs an exposed TCP port with an HTTP appProtocol").
+			WithName("_validateRoute").
+			OnlyWhen(defkit.And(route.IsSet(), defkit.Not(route.Field("port").IsSet()))).
+			FailWhen(routeMissed),
+		defkit.Validate("route.port must be an exposed TCP port with an HTTP appProtocol").
+			WithName("_validateRoutePort").
+			OnlyWhen(route.Field("port").IsSet()).
+			FailWhen(routeMissed),
+	}
+	// Comparing every pair of exposed ports takes a nested comprehension,
+	// which defkit conditions cannot express.
+	for _, setting := range serviceSettings {
+		validators = append(validators, defkit.Validate("ports of the same Service must set the same "+setting).
+			WithName("_validate"+strings.ToUpper(setting[:1])+setting[1:]).
+			FailWhen(defkit.CUEExpr(fmt.Sprintf(
+				`len([for a in exposedPorts for b in exposedPorts if a.serviceType == b.serviceType if (*a.%[1]s | "") != (*b.%[1]s | "") {a}]) > 0`,
+				setting))))
+	}
+	return validators
+}
+
+// --- Int Or Percent ---
+
+// IntOrPercent declares the #IntOrPercent type of IntOrPercentParam. It is
+// raw CUE as defkit renders helper types from structs, lists and ints only,
+// never a disjunction of an int and a string.
+func IntOrPercent(tpl *defkit.Template) {
+	addRawHeader(tpl, `#IntOrPercent: int & >=0 | =~"^[0-9]+%$"`)
+}
+
+// addRawHeader appends a block to the raw header of the template, which holds
+// a single one, so that several helpers can contribute to it.
+func addRawHeader(tpl *defkit.Template, block string) {
+	if header := tpl.GetRawHeaderBlock(); header != "" {
+		block = header + "\n" + block
+	}
+	tpl.SetRawHeaderBlock(block)
+}
+
+// --- Deployment Settings ---
+
+// deploymentSettings maps the parameters of DeploymentSettingsParams to their
+// Deployment path and the CUE type a trait patch may replace them with.
+var deploymentSettings = []struct{ param, path, schema string }{
+	{"strategy", "spec.strategy", "{...}"},
+	{"minReadySeconds", "spec.minReadySeconds", "int"},
+	{"progressDeadlineSeconds", "spec.progressDeadlineSeconds", "int"},
+	{"revisionHistoryLimit", "spec.revisionHistoryLimit", "int"},
+	{"terminationGracePeriodSeconds", "spec.template.spec.terminationGracePeriodSeconds", "int"},
+	{"priorityClassName", "spec.template.spec.priorityClassName", "string"},
+	{"nodeSelector", "spec.template.spec.nodeSelector", "{...}"},
+	{"tolerations", "spec.template.spec.tolerations", "[...]"},
+	{"serviceAccountName", "spec.template.spec.serviceAccountName", "string"},
+}
+
+// DeploymentSettings sets the parameters of DeploymentSettingsParams on a
+// Deployment when they are given. Traits patching the same fields, like
+// k8s-update-strategy, affinity, service-account or json-merge-patch, take
+// precedence (see TraitOverridable).
+func DeploymentSettings(deployment *defkit.Resource) *defkit.Resource {
+	for _, s := range deploymentSettings {
+		deployment.SetIf(defkit.Object(s.param).IsSet(), s.path, TraitOverridable(s.param, s.schema))
+	}
+	return deployment
+}
+
+// TraitOverridable renders a parameter as the CUE default of its field, like
+// `*parameter.nodeSelector | {...}`. Traits patch the rendered workload by
+// unification, so a trait setting the field replaces the default instead of
+// conflicting with it. defkit marks defaults on parameters only and has no
+// disjunction of values, so the expression is written through Reference,
+// which renders its path verbatim.
+func TraitOverridable(param, schema string) defkit.Value {
+	return defkit.Reference("*parameter." + param + " | " + schema)
+}
+
 // --- Common Parameter Definitions ---
 
 // CommonVolumeParams returns the standard volumeMounts parameter definition.
@@ -209,3 +640,328 @@ func CommonResourceParams() (cpu, memory defkit.Param) {
 		Description("Specifies the attributes of the memory resource required for the container.")
 	return
 }
+
+// --- Ready Condition Status ---
+
+// readyConditionPreamble extracts the Ready condition of the output. The
+// conditions are absent until the owning controller first reconciles the
+// object, so the comprehension is guarded to keep status evaluation complete.
+// The condition is stale while the object, or the condition itself, reports an
+// observedGeneration older than the generation of the object.
+const readyConditionPreamble = `_conditions: *[] | [...]
+if context.output.status != _|_ if context.output.status.conditions != _|_ {
+	_conditions: context.output.status.conditions
+}
+_ready: [ for c in _conditions if c.type == "Ready" { c } ]
+_generation: *0 | int
+if context.output.metadata != _|_ if context.output.metadata.generation != _|_ {
+	_generation: context.output.metadata.generation
+}
+_observedGenerations: [
+	if context.output.status != _|_ if context.output.status.observedGeneration != _|_ {
+		context.output.status.observedGeneration
+	},
+	if len(_ready) > 0 if _ready[0].observedGeneration != _|_ {
+		_ready[0].observedGeneration
+	},
+]
+_stale: len([ for g in _observedGenerations if g < _generation { g } ]) > 0`
+
+// ReadyConditionHealth returns a health policy for resources reconciled by
+// controllers that report a kstatus-style Ready condition (FluxCD, Knative,
+// Crossplane, ...). The resource is healthy once Ready is True for its current
+// generation.
+func ReadyConditionHealth() string {
+	return readyConditionPreamble + `
+_readyStatus: *"Unknown" | string
+if len(_ready) > 0 {
+	_readyStatus: _ready[0].status
+}
+isHealth: _readyStatus == "True" && !_stale`
+}
+
+// ReadyConditionStatus returns a custom status that surfaces the Ready
+// condition message, or its status when the controller sets no message
+// (Knative clears it once the resource is ready). When detailPath is set, the value at that path of the
+// output (e.g. "status.lastAppliedRevision") is appended as "<label>: <value>".
+//
+// Usage:
+//
+//	CustomStatus(ReadyConditionStatus("revision", "status.lastAppliedRevision"))
+func ReadyConditionStatus(label, detailPath string) string {
+	status := readyConditionPreamble + `
+_readyMessage: *"waiting for the Ready condition" | string
+if _stale {
+	_readyMessage: "waiting for generation \(_generation) to be observed"
+}
+if !_stale if len(_ready) > 0 if _ready[0].message != _|_ {
+	_readyMessage: _ready[0].message
+}
+if !_stale if len(_ready) > 0 if _ready[0].message == _|_ {
+	_readyMessage: "Ready: \(_ready[0].status)"
+}`
+	if detailPath == "" {
+		return status + `
+message: _readyMessage`
+	}
+	return status + `
+_detail: *"" | string
+if context.output.` + detailPath + ` != _|_ {
+	_detail: "\(context.output.` + detailPath + `)"
+}
+message: *_readyMessage | string
+if _detail != "" {
+	message: "\(_readyMessage), ` + label + `: \(_detail)"
+}`
+}
+
+// --- Object Health ---
+
+// objectHealthRule is how #ObjectHealth judges the objects of some kinds. The
+// rule is evaluated with the object as context.output, like the health policy
+// of a component, after the fields it reads are given their defaults.
+type objectHealthRule struct {
+	gvks     []string
+	defaults string
+	healthy  defkit.HealthExpression
+	message  string
+}
+
+// objectHealthRules returns the rules of the kinds #ObjectHealth knows:
+// workloads have all replicas ready and updated for their current generation,
+// Jobs have succeeded, CronJobs are always healthy, LoadBalancer Services have
+// an ingress and PersistentVolumeClaims are Bound.
+// A Pending claim may only be waiting for the first consumer of a
+// WaitForFirstConsumer StorageClass, but health policies can't read the
+// StorageClass to tell it from a stuck claim, so it is unhealthy and its
+// message says what it may be waiting for.
+func objectHealthRules() []objectHealthRule {
+	h := defkit.Health()
+	return []objectHealthRule{
+		{
+			gvks:     []string{"apps/v1/Deployment", "apps/v1/StatefulSet"},
+			defaults: `{metadata: {generation: *0 | int, ...}, spec: {replicas: *1 | int, ...}, status: {observedGeneration: *0 | int, readyReplicas: *0 | int, updatedReplicas: *0 | int, replicas: *0 | int, ...}, ...}`,
+			healthy: h.And(
+				h.Field("status.readyReplicas").Eq(h.FieldRef("spec.replicas")),
+				h.Field("status.updatedReplicas").Eq(h.FieldRef("spec.replicas")),
+				h.Field("status.replicas").Eq(h.FieldRef("spec.replicas")),
+				h.Field("status.observedGeneration").Gte(h.FieldRef("metadata.generation")),
+			),
+			message: `message: "Ready:\(context.output.status.readyReplicas)/\(context.output.spec.replicas)"`,
+		},
+		{
+			gvks:     []string{"apps/v1/DaemonSet"},
+			defaults: `{metadata: {generation: *0 | int, ...}, status: {observedGeneration: *0 | int, desiredNumberScheduled: *0 | int, numberReady: *0 | int, updatedNumberScheduled: *0 | int, ...}, ...}`,
+			healthy: h.And(
+				h.Field("status.numberReady").Eq(h.FieldRef("status.desiredNumberScheduled")),
+				h.Field("status.updatedNumberScheduled").Eq(h.FieldRef("status.desiredNumberScheduled")),
+				h.Field("status.observedGeneration").Gte(h.FieldRef("metadata.generation")),
+			),
+			message: `message: "Ready:\(context.output.status.numberReady)/\(context.output.status.desiredNumberScheduled)"`,
+		},
+		// The Failed condition of a Job is only ever added as True. Its
+		// presence is checked, as Condition().IsTrue() indexes the conditions
+		// even when there are none, and the message reads the _failedCond the
+		// condition extracts.
+		{
+			gvks:     []string{"batch/v1/Job"},
+			defaults: `{spec: {completions: *1 | int, ...}, status: {succeeded: *0 | int, conditions: *[] | [...], ...}, ...}`,
+			healthy: h.And(
+				h.Not(h.Condition("Failed").Exists()),
+				h.Field("status.succeeded").Gte(h.FieldRef("spec.completions")),
+			),
+			message: `if len(_failedCond) == 0 {
+	message: "Succeeded:\(context.output.status.succeeded)/\(context.output.spec.completions)"
+}
+if len(_failedCond) > 0 if _failedCond[0].message != _|_ {
+	message: "Failed: \(_failedCond[0].message)"
+}
+if len(_failedCond) > 0 if _failedCond[0].message == _|_ {
+	message: "Failed"
+}`,
+		},
+		{
+			gvks:     []string{"batch/v1/CronJob"},
+			defaults: `{spec: {suspend: *false | bool, ...}, status: {active: *[] | [...], lastScheduleTime: *"" | string, ...}, ...}`,
+			healthy:  h.Always(),
+			message: `if context.output.spec.suspend {
+	message: "Suspended"
+}
+if !context.output.spec.suspend && context.output.status.lastScheduleTime == "" {
+	message: "Active:\(len(context.output.status.active)), not scheduled yet"
+}
+if !context.output.spec.suspend && context.output.status.lastScheduleTime != "" {
+	message: "Active:\(len(context.output.status.active)), last schedule: \(context.output.status.lastScheduleTime)"
+}`,
+		},
+		{
+			gvks:     []string{"v1/Service"},
+			defaults: `{spec: {type: *"ClusterIP" | string, ...}, ...}`,
+			healthy: h.Or(
+				h.Field("spec.type").Ne("LoadBalancer"),
+				h.Exists("status.loadBalancer.ingress[0]"),
+			),
+			message: `_ingress: *[] | [...]
+if context.output.status.loadBalancer.ingress != _|_ {
+	_ingress: context.output.status.loadBalancer.ingress
+}
+if context.output.spec.type != "LoadBalancer" {
+	message: context.output.spec.type
+}
+if context.output.spec.type == "LoadBalancer" && len(_ingress) == 0 {
+	message: "waiting for LoadBalancer ingress"
+}
+if context.output.spec.type == "LoadBalancer" && len(_ingress) > 0 {
+	if _ingress[0].ip != _|_ {
+		message: "LoadBalancer: \(_ingress[0].ip)"
+	}
+	if _ingress[0].ip == _|_ && _ingress[0].hostname != _|_ {
+		message: "LoadBalancer: \(_ingress[0].hostname)"
+	}
+	if _ingress[0].ip == _|_ && _ingress[0].hostname == _|_ {
+		message: "LoadBalancer"
+	}
+}`,
+		},
+		{
+			gvks:     []string{"v1/PersistentVolumeClaim"},
+			defaults: `{status: {phase: *"Pending" | string, ...}, ...}`,
+			healthy:  h.Phase("Bound"),
+			message: `_selectedNode: context.output.metadata.annotations["volume.kubernetes.io/selected-node"] != _|_
+if context.output.status.phase == "Pending" && !_selectedNode {
+	message: "Pending: waiting for first consumer"
+}
+if context.output.status.phase != "Pending" || _selectedNode {
+	message: context.output.status.phase
+}`,
+		},
+	}
+}
+
+// objectHealthDefinition derives the health and a short message of a single
+// Kubernetes object with the rule of its kind. Objects of other kinds are
+// judged by their Ready or Available condition, unless it is stale like in
+// readyConditionPreamble, and are healthy when they report neither.
+func objectHealthDefinition() string {
+	var b strings.Builder
+	b.WriteString(`#ObjectHealth: {
+	object: {...}
+	id:      "\(_kind)/\(_name)"
+	healthy: bool
+	message: string
+
+	_apiVersion: *"" | string
+	if object.apiVersion != _|_ {
+		_apiVersion: object.apiVersion
+	}
+	_kind: *"" | string
+	if object.kind != _|_ {
+		_kind: object.kind
+	}
+	_name: *"" | string
+	if object.metadata != _|_ if object.metadata.name != _|_ {
+		_name: object.metadata.name
+	}
+	_gvk: "\(_apiVersion)/\(_kind)"
+	_generation: *0 | int
+	if object.metadata != _|_ if object.metadata.generation != _|_ {
+		_generation: object.metadata.generation
+	}
+	_status: {...}
+	if object.status != _|_ {
+		_status: object.status
+	}
+	_conditions: *[] | [...]
+	if _status.conditions != _|_ {
+		_conditions: _status.conditions
+	}
+`)
+	var gvks []string
+	for _, rule := range objectHealthRules() {
+		matches := make([]string, len(rule.gvks))
+		for i, gvk := range rule.gvks {
+			matches[i] = fmt.Sprintf("_gvk == %q", gvk)
+			gvks = append(gvks, fmt.Sprintf("%q", gvk))
+		}
+		fmt.Fprintf(&b, "\n\tif %s {\n\t\t_rule: {\n\t\t\tcontext: output: object & %s\n%s\n%s\n\t\t}\n\t\thealthy: _rule.isHealth\n\t\tmessage: _rule.message\n\t}\n",
+			strings.Join(matches, " || "), rule.defaults,
+			indentCUE(defkit.HealthPolicy(rule.healthy), "\t\t\t"), indentCUE(rule.message, "\t\t\t"))
+	}
+	fmt.Fprintf(&b, `
+	_rules: [%s]
+	if len([ for r in _rules if r == _gvk { r } ]) == 0 {
+		_ready: [ for t in ["Ready", "Available"] for c in _conditions if c.type == t { c } ]
+		if len(_ready) == 0 {
+			healthy: true
+			message: ""
+		}
+		if len(_ready) > 0 {
+			_observedGenerations: [
+				if _status.observedGeneration != _|_ {
+					_status.observedGeneration
+				},
+				if _ready[0].observedGeneration != _|_ {
+					_ready[0].observedGeneration
+				},
+			]
+			_stale: len([ for g in _observedGenerations if g < _generation { g } ]) > 0
+			healthy: _ready[0].status == "True" && !_stale
+			if _stale {
+				message: "waiting for generation \(_generation) to be observed"
+			}
+			if !_stale if _ready[0].message != _|_ {
+				message: _ready[0].message
+			}
+			if !_stale if _ready[0].message == _|_ {
+				message: "\(_ready[0].type): \(_ready[0].status)"
+			}
+		}
+	}
+}`, strings.Join(gvks, ", "))
+	return b.String()
+}
+
+// objectsPreamble applies #ObjectHealth to the output and every auxiliary
+// output. The outputs are only in the context when the component has any.
+func objectsPreamble() string {
+	return objectHealthDefinition() + `
+_outputs: {...}
+if context.outputs != _|_ {
+	_outputs: context.outputs
+}
+_health: [ for o in [context.output, for k, v in _outputs { v }] { #ObjectHealth & {object: o} } ]
+_unhealthy: [ for h in _health if !h.healthy { h } ]`
+}
+
+// ObjectsHealth returns a health policy for components that output arbitrary
+// Kubernetes objects, like ref-objects. The component is healthy once every
+// object it outputs is healthy by the rules of its kind: workloads have all
+// replicas ready and updated, Jobs have succeeded, LoadBalancer Services have
+// an ingress, PersistentVolumeClaims are Bound, and other kinds have a True
+// Ready or Available condition for their current generation when they report
+// one.
+func ObjectsHealth() string {
+	return objectsPreamble() + `
+isHealth: len(_unhealthy) == 0`
+}
+
+// ObjectsStatus returns the custom status matching ObjectsHealth. A single
+// object reports its own message, like "Ready:1/3". Otherwise the message
+// names the first unhealthy object, like "Service/web: waiting for
+// LoadBalancer ingress (2 unhealthy)", or counts the objects when all are
+// healthy.
+func ObjectsStatus() string {
+	return objectsPreamble() + `
+if len(_health) == 1 {
+	message: _health[0].message
+}
+if len(_health) > 1 && len(_unhealthy) == 0 {
+	message: "\(len(_health)) objects healthy"
+}
+if len(_health) > 1 && len(_unhealthy) == 1 {
+	message: "\(_unhealthy[0].id): \(_unhealthy[0].message)"
+}
+if len(_health) > 1 && len(_unhealthy) > 1 {
+	message: "\(_unhealthy[0].id): \(_unhealthy[0].message) (\(len(_unhealthy)) unhealthy)"
+}`
+}
@@ -0,0 +1,593 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/kubevela/workflow/pkg/cue/model/sets"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// evalStatus evaluates a customStatus or healthPolicy block against an
+// output status, the way the KubeVela controller does.
+func evalStatus(policy, output string) cue.Value {
+	v := cuecontext.New().CompileString("context: output: " + output + "\n" + policy)
+	Expect(v.Err()).NotTo(HaveOccurred())
+	return v
+}
+
+// evalTemplate evaluates the template of def with the given parameter, written
+// in CUE, for a component "site" of the application "docs" in the namespace
+// "default". Unlike Render, it evaluates raw CUE blocks and builtin calls.
+func evalTemplate(def defkit.Definition, parameter string) cue.Value {
+	v := cuecontext.New().CompileString(def.ToCue() + "\ncontext: {name: \"site\", appName: \"docs\", namespace: \"default\"}\ntemplate: parameter: " + parameter)
+	Expect(v.Err()).NotTo(HaveOccurred())
+	tpl := v.LookupPath(cue.ParsePath("template"))
+	Expect(tpl.Validate(cue.Concrete(true))).To(Succeed())
+	return tpl
+}
+
+var _ = Describe("Ready condition status", func() {
+	const ready = `{status: {lastAppliedRevision: "main@sha1:abc", conditions: [{type: "Reconciling", status: "False"}, {type: "Ready", status: "True", message: "Applied revision: main@sha1:abc"}]}}`
+	const notReady = `{status: {conditions: [{type: "Ready", status: "False", message: "install retries exhausted"}]}}`
+
+	DescribeTable("ReadyConditionHealth",
+		func(output string, healthy bool) {
+			v := evalStatus(components.ReadyConditionHealth(), output)
+			Expect(v.LookupPath(cue.ParsePath("isHealth")).Bool()).To(Equal(healthy))
+		},
+		Entry("Ready is True", ready, true),
+		Entry("Ready is False", notReady, false),
+		Entry("no status reported yet", `{spec: {}}`, false),
+		Entry("no conditions reported yet", `{status: {observedGeneration: -1}}`, false),
+		Entry("Ready of an older generation", `{metadata: generation: 3, status: {observedGeneration: 2, conditions: [{type: "Ready", status: "True"}]}}`, false),
+		Entry("Ready condition of an older generation", `{metadata: generation: 3, status: conditions: [{type: "Ready", status: "True", observedGeneration: 2}]}`, false),
+		Entry("Ready of the current generation", `{metadata: generation: 3, status: {observedGeneration: 3, conditions: [{type: "Ready", status: "True", observedGeneration: 3}]}}`, true),
+	)
+
+	DescribeTable("ReadyConditionStatus",
+		func(output, message string) {
+			v := evalStatus(components.ReadyConditionStatus("revision", "status.lastAppliedRevision"), output)
+			Expect(v.LookupPath(cue.ParsePath("message")).String()).To(Equal(message))
+		},
+		Entry("with detail", ready, "Applied revision: main@sha1:abc, revision: main@sha1:abc"),
+		Entry("without detail", notReady, "install retries exhausted"),
+		Entry("no status reported yet", `{spec: {}}`, "waiting for the Ready condition"),
+		Entry("Ready without a message", `{status: {conditions: [{type: "Ready", status: "True"}]}}`, "Ready: True"),
+		Entry("Ready of an older generation", `{metadata: generation: 3, status: {observedGeneration: 2, conditions: [{type: "Ready", status: "True", message: "done"}]}}`, "waiting for generation 3 to be observed"),
+	)
+
+	It("should only surface the Ready message without a detail path", func() {
+		v := evalStatus(components.ReadyConditionStatus("", ""), ready)
+		Expect(v.LookupPath(cue.ParsePath("message")).String()).To(Equal("Applied revision: main@sha1:abc"))
+	})
+})
+
+var _ = Describe("Object health", func() {
+	const (
+		deployment       = `{apiVersion: "apps/v1", kind: "Deployment", metadata: {name: "api", generation: 2}, spec: replicas: 3, status: {observedGeneration: 2, replicas: 3, updatedReplicas: 3, readyReplicas: 3}}`
+		rollingOut       = `{apiVersion: "apps/v1", kind: "Deployment", metadata: {name: "api", generation: 3}, spec: replicas: 3, status: {observedGeneration: 2, replicas: 3, updatedReplicas: 3, readyReplicas: 3}}`
+		statefulSet      = `{apiVersion: "apps/v1", kind: "StatefulSet", metadata: {name: "db", generation: 1}, spec: replicas: 3, status: {observedGeneration: 1, replicas: 3, updatedReplicas: 3, readyReplicas: 1}}`
+		daemonSet        = `{apiVersion: "apps/v1", kind: "DaemonSet", metadata: {name: "agent", generation: 1}, status: {observedGeneration: 1, desiredNumberScheduled: 4, updatedNumberScheduled: 4, numberReady: 4}}`
+		jobRunning       = `{apiVersion: "batch/v1", kind: "Job", metadata: name: "migrate", spec: completions: 2, status: succeeded: 1}`
+		jobComplete      = `{apiVersion: "batch/v1", kind: "Job", metadata: name: "migrate", status: {succeeded: 1, conditions: [{type: "Complete", status: "True"}]}}`
+		jobFailed        = `{apiVersion: "batch/v1", kind: "Job", metadata: name: "migrate", status: {failed: 7, conditions: [{type: "Failed", status: "True", reason: "BackoffLimitExceeded", message: "Job has reached the specified backoff limit"}]}}`
+		cronJob          = `{apiVersion: "batch/v1", kind: "CronJob", metadata: name: "backup", spec: schedule: "0 1 * * *", status: {active: [{name: "backup-1"}], lastScheduleTime: "2025-06-01T01:00:00Z"}}`
+		cronJobSuspended = `{apiVersion: "batch/v1", kind: "CronJob", metadata: name: "backup", spec: {schedule: "0 1 * * *", suspend: true}}`
+		clusterIP        = `{apiVersion: "v1", kind: "Service", metadata: name: "web", spec: {type: "ClusterIP", clusterIP: "10.0.0.1"}}`
+		lbPending        = `{apiVersion: "v1", kind: "Service", metadata: name: "web", spec: type: "LoadBalancer", status: loadBalancer: {}}`
+		lbReady          = `{apiVersion: "v1", kind: "Service", metadata: name: "web", spec: type: "LoadBalancer", status: loadBalancer: ingress: [{hostname: "web.elb.example.com"}]}`
+		pvcPending       = `{apiVersion: "v1", kind: "PersistentVolumeClaim", metadata: {name: "data", annotations: "volume.kubernetes.io/selected-node": "node-1"}, status: phase: "Pending"}`
+		pvcUnconsumed    = `{apiVersion: "v1", kind: "PersistentVolumeClaim", metadata: name: "data", status: phase: "Pending"}`
+		pvcBound         = `{apiVersion: "v1", kind: "PersistentVolumeClaim", metadata: name: "data", status: phase: "Bound"}`
+		certificate      = `{apiVersion: "cert-manager.io/v1", kind: "Certificate", metadata: name: "tls", status: conditions: [{type: "Ready", status: "False", message: "Issuing certificate as Secret does not exist"}]}`
+		certificateStale = `{apiVersion: "cert-manager.io/v1", kind: "Certificate", metadata: {name: "tls", generation: 3}, status: conditions: [{type: "Ready", status: "True", observedGeneration: 2}]}`
+		issuerStale      = `{apiVersion: "cert-manager.io/v1", kind: "Issuer", metadata: {name: "ca", generation: 3}, status: {observedGeneration: 2, conditions: [{type: "Ready", status: "True"}]}}`
+		issuerCurrent    = `{apiVersion: "cert-manager.io/v1", kind: "Issuer", metadata: {name: "ca", generation: 3}, status: {observedGeneration: 3, conditions: [{type: "Ready", status: "True"}]}}`
+		apiService       = `{apiVersion: "apiregistration.k8s.io/v1", kind: "APIService", metadata: name: "v1beta1.metrics.k8s.io", status: conditions: [{type: "Available", status: "True", reason: "Passed"}]}`
+		configMap        = `{apiVersion: "v1", kind: "ConfigMap", metadata: name: "settings", data: level: "debug"}`
+	)
+
+	// evalObjects evaluates a status block for the given output and auxiliary
+	// outputs, named like the outputs of ref-objects.
+	evalObjects := func(policy, output string, outputs ...string) cue.Value {
+		ctx := "context: output: " + output + "\n"
+		for i, o := range outputs {
+			ctx += fmt.Sprintf("context: outputs: \"objects-%d\": %s\n", i+1, o)
+		}
+		v := cuecontext.New().CompileString(ctx + policy)
+		Expect(v.Err()).NotTo(HaveOccurred())
+		return v
+	}
+
+	DescribeTable("ObjectsHealth of a single object",
+		func(output string, healthy bool) {
+			v := evalObjects(components.ObjectsHealth(), output)
+			Expect(v.LookupPath(cue.ParsePath("isHealth")).Bool()).To(Equal(healthy))
+		},
+		Entry("Deployment with all replicas ready", deployment, true),
+		Entry("Deployment with a newer generation", rollingOut, false),
+		Entry("StatefulSet with replicas not ready", statefulSet, false),
+		Entry("DaemonSet scheduled and ready", daemonSet, true),
+		Entry("Job still running", jobRunning, false),
+		Entry("Job complete", jobComplete, true),
+		Entry("Job failed", jobFailed, false),
+		Entry("CronJob", cronJob, true),
+		Entry("ClusterIP Service", clusterIP, true),
+		Entry("LoadBalancer Service without ingress", lbPending, false),
+		Entry("LoadBalancer Service with ingress", lbReady, true),
+		Entry("PersistentVolumeClaim Pending on a node", pvcPending, false),
+		Entry("PersistentVolumeClaim waiting for its first consumer", pvcUnconsumed, false),
+		Entry("PersistentVolumeClaim Bound", pvcBound, true),
+		Entry("Ready condition False", certificate, false),
+		Entry("Available condition True", apiService, true),
+		Entry("Ready condition of an older generation", certificateStale, false),
+		Entry("Ready condition with an older observedGeneration", issuerStale, false),
+		Entry("Ready condition with the current observedGeneration", issuerCurrent, true),
+		Entry("object without status", configMap, true),
+	)
+
+	DescribeTable("ObjectsStatus of a single object",
+		func(output, message string) {
+			v := evalObjects(components.ObjectsStatus(), output)
+			Expect(v.LookupPath(cue.ParsePath("message")).String()).To(Equal(message))
+		},
+		Entry("Deployment", deployment, "Ready:3/3"),
+		Entry("StatefulSet", statefulSet, "Ready:1/3"),
+		Entry("DaemonSet", daemonSet, "Ready:4/4"),
+		Entry("Job still running", jobRunning, "Succeeded:1/2"),
+		Entry("Job failed", jobFailed, "Failed: Job has reached the specified backoff limit"),
+		Entry("CronJob", cronJob, "Active:1, last schedule: 2025-06-01T01:00:00Z"),
+		Entry("suspended CronJob", cronJobSuspended, "Suspended"),
+		Entry("ClusterIP Service", clusterIP, "ClusterIP"),
+		Entry("LoadBalancer Service without ingress", lbPending, "waiting for LoadBalancer ingress"),
+		Entry("LoadBalancer Service with ingress", lbReady, "LoadBalancer: web.elb.example.com"),
+		Entry("PersistentVolumeClaim", pvcBound, "Bound"),
+		Entry("PersistentVolumeClaim Pending on a node", pvcPending, "Pending"),
+		Entry("PersistentVolumeClaim waiting for its first consumer", pvcUnconsumed, "Pending: waiting for first consumer"),
+		Entry("Ready condition", certificate, "Issuing certificate as Secret does not exist"),
+		Entry("Available condition without a message", apiService, "Available: True"),
+		Entry("stale Ready condition", issuerStale, "waiting for generation 3 to be observed"),
+		Entry("object without status", configMap, ""),
+	)
+
+	It("should only be healthy when every output is healthy", func() {
+		Expect(evalObjects(components.ObjectsHealth(), deployment, pvcBound, lbReady).LookupPath(cue.ParsePath("isHealth")).Bool()).To(BeTrue())
+		Expect(evalObjects(components.ObjectsHealth(), deployment, pvcBound, lbPending).LookupPath(cue.ParsePath("isHealth")).Bool()).To(BeFalse())
+	})
+
+	It("should name the first unhealthy object", func() {
+		status := func(output string, outputs ...string) string {
+			s, err := evalObjects(components.ObjectsStatus(), output, outputs...).LookupPath(cue.ParsePath("message")).String()
+			Expect(err).NotTo(HaveOccurred())
+			return s
+		}
+		Expect(status(deployment, pvcBound, lbReady)).To(Equal("3 objects healthy"))
+		Expect(status(deployment, pvcBound, lbPending)).To(Equal("Service/web: waiting for LoadBalancer ingress"))
+		Expect(status(statefulSet, pvcPending, configMap)).To(Equal("StatefulSet/db: Ready:1/3 (2 unhealthy)"))
+	})
+})
+
+// evalContainers evaluates the template of def like evalTemplate and decodes
+// the containers of the pod template of its output. Render cannot evaluate the
+// containers list, as it is built with a comprehension over the containers
+// parameter.
+func evalContainers(def defkit.Definition, parameter string) []corev1.Container {
+	raw, err := evalTemplate(def, parameter).LookupPath(cue.ParsePath("output.spec.template.spec.containers")).MarshalJSON()
+	Expect(err).NotTo(HaveOccurred())
+	var containers []corev1.Container
+	Expect(json.Unmarshal(raw, &containers)).To(Succeed())
+	return containers
+}
+
+var _ = Describe("Deployment settings", func() {
+	const settings = `{
+		image: "nginx:1.27"
+		strategy: {type: "RollingUpdate", rollingUpdate: {maxSurge: 1, maxUnavailable: "10%"}}
+		minReadySeconds: 5
+		progressDeadlineSeconds: 120
+		revisionHistoryLimit: 3
+		terminationGracePeriodSeconds: 45
+		priorityClassName: "high"
+		nodeSelector: disktype: "ssd"
+		tolerations: [{key: "dedicated", value: "web", effect: "NoSchedule"}]
+		serviceAccountName: "web"
+	}`
+
+	// decodeDeployment decodes an evaluated Deployment.
+	decodeDeployment := func(v cue.Value) appsv1.Deployment {
+		raw, err := v.MarshalJSON()
+		Expect(err).NotTo(HaveOccurred())
+		var deployment appsv1.Deployment
+		Expect(json.Unmarshal(raw, &deployment)).To(Succeed())
+		return deployment
+	}
+
+	DescribeTable("should set the rollout and scheduling settings of the Deployment",
+		func(def defkit.Definition) {
+			d := decodeDeployment(evalTemplate(def, settings).LookupPath(cue.ParsePath("output")))
+			Expect(d.Spec.Strategy.Type).To(Equal(appsv1.RollingUpdateDeploymentStrategyType))
+			Expect(*d.Spec.Strategy.RollingUpdate.MaxSurge).To(Equal(intstr.FromInt32(1)))
+			Expect(*d.Spec.Strategy.RollingUpdate.MaxUnavailable).To(Equal(intstr.FromString("10%")))
+			Expect(d.Spec.MinReadySeconds).To(Equal(int32(5)))
+			Expect(*d.Spec.ProgressDeadlineSeconds).To(Equal(int32(120)))
+			Expect(*d.Spec.RevisionHistoryLimit).To(Equal(int32(3)))
+			pod := d.Spec.Template.Spec
+			Expect(*pod.TerminationGracePeriodSeconds).To(Equal(int64(45)))
+			Expect(pod.PriorityClassName).To(Equal("high"))
+			Expect(pod.NodeSelector).To(Equal(map[string]string{"disktype": "ssd"}))
+			Expect(pod.Tolerations).To(Equal([]corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "web", Effect: corev1.TaintEffectNoSchedule}}))
+			Expect(pod.ServiceAccountName).To(Equal("web"))
+		},
+		Entry("webservice", components.Webservice()),
+		Entry("worker", components.Worker()),
+	)
+
+	DescribeTable("should leave the settings to Kubernetes when not given",
+		func(def defkit.Definition) {
+			d := decodeDeployment(evalTemplate(def, `{image: "nginx:1.27"}`).LookupPath(cue.ParsePath("output")))
+			Expect(d.Spec.Strategy.Type).To(BeEmpty())
+			Expect(d.Spec.RevisionHistoryLimit).To(BeNil())
+			Expect(d.Spec.Template.Spec.TerminationGracePeriodSeconds).To(BeNil())
+			Expect(d.Spec.Template.Spec.ServiceAccountName).To(BeEmpty())
+		},
+		Entry("webservice", components.Webservice()),
+		Entry("worker", components.Worker()),
+	)
+
+	DescribeTable("should only take numbers and percentages for the rolling update",
+		func(def defkit.Definition) {
+			v := cuecontext.New().CompileString(def.ToCue() + "\ncontext: {name: \"site\", appName: \"docs\", namespace: \"default\"}" +
+				"\ntemplate: parameter: {image: \"nginx:1.27\", strategy: rollingUpdate: maxSurge: \"1\"}")
+			Expect(v.Validate()).To(MatchError(ContainSubstring("maxSurge")))
+		},
+		Entry("webservice", components.Webservice()),
+		Entry("worker", components.Worker()),
+	)
+
+	// The patches below are the ones of the k8s-update-strategy, affinity,
+	// service-account and json-merge-patch traits, applied the way the
+	// KubeVela controller applies trait patches to the workload.
+	DescribeTable("should let trait patches take precedence",
+		func(patch string, check func(appsv1.Deployment)) {
+			base := evalTemplate(components.Webservice(), settings).LookupPath(cue.ParsePath("output"))
+			patcher := cuecontext.New().CompileString(patch)
+			Expect(patcher.Err()).NotTo(HaveOccurred())
+			patched, err := sets.StrategyUnify(base, patcher, sets.CreateUnifyOptionsForPatcher(patcher)...)
+			Expect(err).NotTo(HaveOccurred())
+			check(decodeDeployment(patched))
+		},
+		Entry("k8s-update-strategy", "spec: {\n// +patchStrategy=retainKeys\nstrategy: type: \"Recreate\"\n}", func(d appsv1.Deployment) {
+			Expect(d.Spec.Strategy).To(Equal(appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}))
+		}),
+		Entry("affinity", `spec: template: spec: tolerations: [{key: "gpu", operator: "Exists"}]`, func(d appsv1.Deployment) {
+			Expect(d.Spec.Template.Spec.Tolerations).To(Equal([]corev1.Toleration{{Key: "gpu", Operator: corev1.TolerationOpExists}}))
+		}),
+		Entry("service-account", `spec: template: spec: serviceAccountName: "deployer"`, func(d appsv1.Deployment) {
+			Expect(d.Spec.Template.Spec.ServiceAccountName).To(Equal("deployer"))
+		}),
+		Entry("json-merge-patch", "// +patchStrategy=jsonMergePatch\nspec: {revisionHistoryLimit: 10, template: spec: terminationGracePeriodSeconds: 5}", func(d appsv1.Deployment) {
+			Expect(*d.Spec.RevisionHistoryLimit).To(Equal(int32(10)))
+			Expect(*d.Spec.Template.Spec.TerminationGracePeriodSeconds).To(Equal(int64(5)))
+		}),
+		Entry("a node label", `spec: template: spec: nodeSelector: zone: "eu-1a"`, func(d appsv1.Deployment) {
+			Expect(d.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"disktype": "ssd", "zone": "eu-1a"}))
+		}),
+	)
+})
+
+var _ = Describe("Sidecars", func() {
+	const parameter = `{
+		image: "nginx:1.27"
+		sidecars: [{
+			name: "proxy"
+			image: "envoyproxy/envoy:v1.31"
+			cpu: "100m"
+			memory: "64Mi"
+			startupProbe: tcpSocket: port: 9901
+			livenessProbe: httpGet: {path: "/ready", port: 9901}
+		}]
+	}`
+
+	// compile compiles def with the sidecars parameter, unified with the
+	// required parameters of def, on a cluster of the given Kubernetes minor
+	// version.
+	compile := func(def defkit.Definition, required string, minor int) cue.Value {
+		return cuecontext.New().CompileString(def.ToCue() +
+			fmt.Sprintf("\ncontext: {name: \"site\", appName: \"docs\", namespace: \"default\", clusterVersion: minor: %d}", minor) +
+			"\ntemplate: parameter: " + parameter + " & " + required)
+	}
+
+	// evalPodTemplate evaluates the pod template of def at path on a cluster
+	// of the given Kubernetes minor version.
+	evalPodTemplate := func(def defkit.Definition, required, path string, minor int) corev1.PodTemplateSpec {
+		tpl := compile(def, required, minor).LookupPath(cue.ParsePath("template"))
+		Expect(tpl.Validate(cue.Concrete(true))).To(Succeed())
+		raw, err := tpl.LookupPath(cue.ParsePath(path)).MarshalJSON()
+		Expect(err).NotTo(HaveOccurred())
+		var pod corev1.PodTemplateSpec
+		Expect(json.Unmarshal(raw, &pod)).To(Succeed())
+		return pod
+	}
+
+	DescribeTable("should run sidecars as restartable init containers from Kubernetes 1.29",
+		func(def defkit.Definition) {
+			pod := evalPodTemplate(def, "{}", "output.spec.template", 29)
+			Expect(pod.Spec.Containers).To(HaveLen(1))
+			Expect(pod.Spec.InitContainers).To(HaveLen(1))
+			sidecar := pod.Spec.InitContainers[0]
+			Expect(sidecar.Name).To(Equal("proxy"))
+			Expect(*sidecar.RestartPolicy).To(Equal(corev1.ContainerRestartPolicyAlways))
+			Expect(sidecar.Resources.Requests.Cpu().String()).To(Equal("100m"))
+			Expect(sidecar.Resources.Limits.Memory().String()).To(Equal("64Mi"))
+			Expect(sidecar.StartupProbe.TCPSocket.Port.IntValue()).To(Equal(9901))
+			Expect(sidecar.LivenessProbe.HTTPGet.Path).To(Equal("/ready"))
+		},
+		Entry("webservice", components.Webservice()),
+		Entry("worker", components.Worker()),
+		Entry("statefulset", components.StatefulSet()),
+		Entry("daemon", components.Daemon()),
+	)
+
+	DescribeTable("should run sidecars next to the main container before Kubernetes 1.29",
+		func(def defkit.Definition) {
+			pod := evalPodTemplate(def, "{}", "output.spec.template", 28)
+			Expect(pod.Spec.InitContainers).To(BeEmpty())
+			Expect(pod.Spec.Containers).To(HaveLen(2))
+			sidecar := pod.Spec.Containers[1]
+			Expect(sidecar.Name).To(Equal("proxy"))
+			Expect(sidecar.RestartPolicy).To(BeNil())
+			Expect(sidecar.StartupProbe.TCPSocket.Port.IntValue()).To(Equal(9901))
+		},
+		Entry("webservice", components.Webservice()),
+		Entry("worker", components.Worker()),
+		Entry("statefulset", components.StatefulSet()),
+		Entry("daemon", components.Daemon()),
+	)
+
+	DescribeTable("should run the sidecars of Jobs as restartable init containers",
+		func(def defkit.Definition, required, path string) {
+			pod := evalPodTemplate(def, required, path, 29)
+			Expect(pod.Spec.Containers).To(HaveLen(1))
+			Expect(pod.Spec.InitContainers).To(HaveLen(1))
+			sidecar := pod.Spec.InitContainers[0]
+			Expect(sidecar.Name).To(Equal("proxy"))
+			Expect(*sidecar.RestartPolicy).To(Equal(corev1.ContainerRestartPolicyAlways))
+			Expect(sidecar.StartupProbe.TCPSocket.Port.IntValue()).To(Equal(9901))
+		},
+		Entry("task", components.Task(), "{}", "output.spec.template"),
+		Entry("cron-task", components.CronTask(), `{schedule: "0 1 * * *"}`, "output.spec.jobTemplate.spec.template"),
+	)
+
+	DescribeTable("should reject the sidecars of Jobs before Kubernetes 1.29",
+		func(def defkit.Definition, required string) {
+			Expect(compile(def, required, 28).Validate()).To(MatchError(ContainSubstring("sidecars require Kubernetes 1.29 or later")))
+		},
+		Entry("task", components.Task(), "{}"),
+		Entry("cron-task", components.CronTask(), `{schedule: "0 1 * * *"}`),
+	)
+
+	It("should not require the cluster version without sidecars", func() {
+		pod := evalTemplate(components.Webservice(), `{image: "nginx:1.27"}`).LookupPath(cue.ParsePath("output.spec.template.spec"))
+		Expect(pod.LookupPath(cue.ParsePath("initContainers")).Exists()).To(BeFalse())
+	})
+})
+
+var _ = Describe("Expose", func() {
+	// evalOutput decodes the auxiliary output name of the template evaluated
+	// with parameter into obj.
+	evalOutput := func(tpl cue.Value, name string, obj any) {
+		raw, err := tpl.LookupPath(cue.MakePath(cue.Str("outputs"), cue.Str(name))).MarshalJSON()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(json.Unmarshal(raw, obj)).To(Succeed())
+	}
+
+	// validate evaluates the template of def with parameter, written in CUE.
+	validate := func(def defkit.Definition, parameter string) error {
+		return cuecontext.New().CompileString(def.ToCue() +
+			"\ncontext: {name: \"site\", appName: \"docs\", namespace: \"default\"}\ntemplate: parameter: " + parameter).Validate()
+	}
+
+	DescribeTable("Services",
+		func(def defkit.Definition, output string) {
+			tpl := evalTemplate(def, `{image: "nginx:1.27", exposeType: "NodePort", ports: [
+				{expose: true, port: 80, nodePort: 30080, sessionAffinity: "ClientIP", externalTrafficPolicy: "Local"},
+				{expose: true, port: 53, protocol: "UDP", sessionAffinity: "ClientIP", externalTrafficPolicy: "Local"},
+				{expose: true, port: 443, serviceType: "ClusterIP", nodePort: 30443, appProtocol: "https", externalTrafficPolicy: "Local", ipFamilyPolicy: "PreferDualStack"},
+				{expose: true, port: 8443, containerPort: 443, serviceType: "LoadBalancer", nodePort: 30843},
+				{port: 9090},
+			]}`)
+			Expect(outputNames(tpl)).To(ConsistOf(output, output+"ClusterIP", output+"LoadBalancer"))
+
+			var svc corev1.Service
+			evalOutput(tpl, output, &svc)
+			Expect(svc.Name).To(Equal("site"))
+			Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeNodePort))
+			Expect(svc.Spec.Selector).To(Equal(map[string]string{"app.oam.dev/component": "site"}))
+			Expect(svc.Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityClientIP))
+			Expect(svc.Spec.ExternalTrafficPolicy).To(Equal(corev1.ServiceExternalTrafficPolicyLocal))
+			Expect(svc.Spec.IPFamilyPolicy).To(BeNil())
+			Expect(svc.Spec.Ports).To(HaveLen(2))
+			Expect(svc.Spec.Ports[0].Name).To(Equal("port-80"))
+			Expect(svc.Spec.Ports[0].NodePort).To(Equal(int32(30080)))
+			Expect(svc.Spec.Ports[0].Protocol).To(Equal(corev1.ProtocolTCP))
+			Expect(svc.Spec.Ports[0].TargetPort.IntValue()).To(Equal(80))
+			Expect(svc.Spec.Ports[1].Name).To(Equal("port-53-udp"))
+			Expect(svc.Spec.Ports[1].Protocol).To(Equal(corev1.ProtocolUDP))
+
+			var internal corev1.Service
+			evalOutput(tpl, output+"ClusterIP", &internal)
+			Expect(internal.Name).To(Equal("site-clusterip"))
+			Expect(internal.Spec.Type).To(Equal(corev1.ServiceTypeClusterIP))
+			Expect(internal.Spec.ExternalTrafficPolicy).To(BeEmpty())
+			Expect(*internal.Spec.IPFamilyPolicy).To(Equal(corev1.IPFamilyPolicyPreferDualStack))
+			Expect(internal.Spec.Ports).To(HaveLen(1))
+			Expect(internal.Spec.Ports[0].NodePort).To(BeZero())
+			Expect(*internal.Spec.Ports[0].AppProtocol).To(Equal("https"))
+
+			var public corev1.Service
+			evalOutput(tpl, output+"LoadBalancer", &public)
+			Expect(public.Name).To(Equal("site-loadbalancer"))
+			Expect(public.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer))
+			Expect(public.Spec.Ports).To(HaveLen(1))
+			Expect(public.Spec.Ports[0].Name).To(Equal("port-443"))
+			Expect(public.Spec.Ports[0].Port).To(Equal(int32(8443)))
+			Expect(public.Spec.Ports[0].TargetPort.IntValue()).To(Equal(443))
+			Expect(public.Spec.Ports[0].NodePort).To(BeZero())
+		},
+		Entry("webservice", components.Webservice(), "webserviceExpose"),
+		Entry("statefulset", components.StatefulSet(), "statefulsetsExpose"),
+	)
+
+	It("should keep the ports of the daemon Service", func() {
+		tpl := evalTemplate(components.Daemon(), `{image: "nginx:1.27", exposeType: "NodePort", ports: [
+			{expose: true, port: 53, protocol: "UDP", nodePort: 30053},
+			{expose: true, port: 8080, name: "http", serviceType: "ClusterIP", nodePort: 30080},
+		]}`)
+		Expect(outputNames(tpl)).To(ConsistOf("webserviceExpose", "webserviceExposeClusterIP"))
+
+		var svc corev1.Service
+		evalOutput(tpl, "webserviceExpose", &svc)
+		Expect(svc.Name).To(Equal("site"))
+		Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeNodePort))
+		Expect(svc.Spec.Ports).To(Equal([]corev1.ServicePort{{Name: "port-53", Port: 53, TargetPort: intstr.FromInt32(53), NodePort: 30053}}))
+
+		var internal corev1.Service
+		evalOutput(tpl, "webserviceExposeClusterIP", &internal)
+		Expect(internal.Name).To(Equal("site-clusterip"))
+		Expect(internal.Spec.Ports).To(Equal([]corev1.ServicePort{{Name: "http", Port: 8080, TargetPort: intstr.FromInt32(8080)}}))
+	})
+
+	It("should emit a daemon Service of exposeType ExternalName", func() {
+		tpl := evalTemplate(components.Daemon(), `{image: "nginx:1.27", exposeType: "ExternalName", ports: [{expose: true, port: 80}]}`)
+		Expect(outputNames(tpl)).To(ConsistOf("webserviceExpose"))
+		Expect(tpl.LookupPath(cue.ParsePath("outputs.webserviceExpose.spec.type")).String()).To(Equal("ExternalName"))
+	})
+
+	It("should not emit a Service without exposed ports", func() {
+		tpl := evalTemplate(components.Webservice(), `{image: "nginx:1.27", ports: [{port: 80}]}`)
+		Expect(outputNames(tpl)).To(BeEmpty())
+	})
+
+	DescribeTable("should reject ports of the same Service setting it differently",
+		func(ports, setting string) {
+			for _, def := range []defkit.Definition{components.Webservice(), components.StatefulSet(), components.Daemon()} {
+				Expect(validate(def, `{image: "nginx:1.27", exposeType: "LoadBalancer", ports: `+ports+`}`)).
+					To(MatchError(ContainSubstring("ports of the same Service must set the same " + setting)))
+			}
+		},
+		Entry("sessionAffinity", `[{expose: true, port: 80, sessionAffinity: "ClientIP"}, {expose: true, port: 81}]`, "sessionAffinity"),
+		Entry("ipFamilyPolicy", `[{expose: true, port: 80, ipFamilyPolicy: "SingleStack"}, {expose: true, port: 81, ipFamilyPolicy: "RequireDualStack"}]`, "ipFamilyPolicy"),
+		Entry("externalTrafficPolicy", `[{expose: true, port: 80, externalTrafficPolicy: "Local"}, {expose: true, port: 81, serviceType: "NodePort"}, {expose: true, port: 82, externalTrafficPolicy: "Cluster"}]`, "externalTrafficPolicy"),
+	)
+
+	It("should route an Ingress to the first HTTP port", func() {
+		tpl := evalTemplate(components.Webservice(), `{image: "nginx:1.27", ports: [
+			{expose: true, port: 5432, appProtocol: "postgresql"},
+			{expose: true, port: 8080, serviceType: "ClusterIP"},
+			{expose: true, port: 9090},
+		], exposeType: "LoadBalancer", route: {className: "nginx", tlsSecretName: "site-tls", hosts: ["a.example.com", "b.example.com"], path: "/api"}}`)
+		Expect(outputNames(tpl)).To(ConsistOf("webserviceExpose", "webserviceExposeClusterIP", "ingress"))
+
+		var ing networkingv1.Ingress
+		evalOutput(tpl, "ingress", &ing)
+		Expect(ing.Name).To(Equal("site"))
+		Expect(*ing.Spec.IngressClassName).To(Equal("nginx"))
+		Expect(ing.Spec.TLS).To(Equal([]networkingv1.IngressTLS{{Hosts: []string{"a.example.com", "b.example.com"}, SecretName: "site-tls"}}))
+		Expect(ing.Spec.Rules).To(HaveLen(2))
+		Expect(ing.Spec.Rules[1].Host).To(Equal("b.example.com"))
+		path := ing.Spec.Rules[1].HTTP.Paths[0]
+		Expect(path.Path).To(Equal("/api"))
+		Expect(*path.PathType).To(Equal(networkingv1.PathTypePrefix))
+		Expect(path.Backend.Service.Name).To(Equal("site-clusterip"))
+		Expect(path.Backend.Service.Port.Number).To(Equal(int32(8080)))
+	})
+
+	It("should route an Ingress without hosts to every host", func() {
+		tpl := evalTemplate(components.Daemon(), `{image: "nginx:1.27", ports: [{expose: true, port: 8080, appProtocol: "kubernetes.io/h2c"}], route: {}}`)
+
+		var ing networkingv1.Ingress
+		evalOutput(tpl, "ingress", &ing)
+		Expect(ing.Spec.TLS).To(BeEmpty())
+		Expect(ing.Spec.Rules).To(HaveLen(1))
+		Expect(ing.Spec.Rules[0].Host).To(BeEmpty())
+		Expect(ing.Spec.Rules[0].HTTP.Paths[0].Path).To(Equal("/"))
+		Expect(ing.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name).To(Equal("site"))
+	})
+
+	It("should route an HTTPRoute to the selected port", func() {
+		tpl := evalTemplate(components.StatefulSet(), `{image: "nginx:1.27", ports: [{expose: true, port: 8080}, {expose: true, port: 9090}],
+			route: {type: "HTTPRoute", port: 9090, gateway: {name: "public", namespace: "infra"}, hosts: ["site.example.com"], pathType: "Exact"}}`)
+		Expect(outputNames(tpl)).To(ConsistOf("statefulsetsExpose", "httpRoute"))
+		route := tpl.LookupPath(cue.ParsePath("outputs.httpRoute"))
+		Expect(route.LookupPath(cue.ParsePath("apiVersion")).String()).To(Equal("gateway.networking.k8s.io/v1"))
+
+		var spec struct {
+			ParentRefs []map[string]string `json:"parentRefs"`
+			Hostnames  []string            `json:"hostnames"`
+			Rules      []struct {
+				Matches []struct {
+					Path struct{ Type, Value string } `json:"path"`
+				} `json:"matches"`
+				BackendRefs []struct {
+					Name string `json:"name"`
+					Port int    `json:"port"`
+				} `json:"backendRefs"`
+			} `json:"rules"`
+		}
+		raw, err := route.LookupPath(cue.ParsePath("spec")).MarshalJSON()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(json.Unmarshal(raw, &spec)).To(Succeed())
+		Expect(spec.ParentRefs).To(Equal([]map[string]string{{"name": "public", "namespace": "infra"}}))
+		Expect(spec.Hostnames).To(Equal([]string{"site.example.com"}))
+		Expect(spec.Rules[0].Matches[0].Path.Type).To(Equal("Exact"))
+		Expect(spec.Rules[0].Matches[0].Path.Value).To(Equal("/"))
+		Expect(spec.Rules[0].BackendRefs[0].Name).To(Equal("site"))
+		Expect(spec.Rules[0].BackendRefs[0].Port).To(Equal(9090))
+	})
+
+	DescribeTable("should reject a route it cannot honour",
+		func(def defkit.Definition, parameter, message string) {
+			Expect(validate(def, parameter)).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("without an exposed HTTP port", components.Daemon(),
+			`{image: "nginx:1.27", ports: [{expose: true, port: 53, protocol: "UDP"}, {expose: true, port: 5432, appProtocol: "postgresql"}], route: {}}`,
+			"route needs an exposed TCP port with an HTTP appProtocol"),
+		Entry("without exposed ports", components.Webservice(),
+			`{image: "nginx:1.27", ports: [{port: 8080}], route: {}}`,
+			"route needs an exposed TCP port with an HTTP appProtocol"),
+		Entry("with a port that is not exposed", components.Webservice(),
+			`{image: "nginx:1.27", ports: [{expose: true, port: 8080}, {port: 9090}], route: port: 9090}`,
+			"route.port must be an exposed TCP port with an HTTP appProtocol"),
+		Entry("with a port that is not HTTP", components.StatefulSet(),
+			`{image: "nginx:1.27", ports: [{expose: true, port: 8080}, {expose: true, port: 5432, appProtocol: "postgresql"}], route: port: 5432}`,
+			"route.port must be an exposed TCP port with an HTTP appProtocol"),
+	)
+})
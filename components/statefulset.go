@@ -57,8 +57,9 @@ func StatefulSet() *defkit.ComponentDefinition {
 			defkit.String("name").Optional().Description("Name of the port"),
 			defkit.Enum("protocol").Values("TCP", "UDP", "SCTP").Default("TCP").Description("Protocol for port. Must be UDP, TCP, or SCTP"),
 			defkit.Bool("expose").Default(false).Description("Specify if the port should be exposed"),
-			defkit.Int("nodePort").Optional().Description("exposed node port. Only Valid when exposeType is NodePort"),
-		)
+			defkit.Int("nodePort").Optional().Description("exposed node port. Only Valid when the Service of the port is NodePort"),
+		).
+		WithFields(ServicePortFields()...)
 
 	exposeType := defkit.Enum("exposeType").
 		Values("ClusterIP", "NodePort", "LoadBalancer").
@@ -66,6 +67,9 @@ func StatefulSet() *defkit.ComponentDefinition {
 		Ignore().
 		Description(`Specify what kind of Service you want. options: "ClusterIP", "NodePort", "LoadBalancer"`)
 
+	// Ingress or HTTPRoute to an exposed HTTP port
+	route := RouteParam()
+
 	addRevisionLabel := defkit.Bool("addRevisionLabel").
 		Default(false).
 		Ignore().
@@ -206,10 +210,64 @@ func StatefulSet() *defkit.ComponentDefinition {
 			defkit.StringList("hostnames"),
 		)
 
+	// Additional containers sharing the pod volumes with the main container
+	containers := ContainersParam()
+
+	// Sidecars starting before and stopping after the main container
+	sidecars := SidecarsParam()
+
+	// Per-pod PVCs created by the StatefulSet controller and mounted into the main container
+	volumeClaimTemplates := defkit.List("volumeClaimTemplates").
+		Optional().
+		Description("Declare a PVC for every pod of the StatefulSet, mounted into the main container. Additional containers can mount it by name").
+		WithFields(
+			defkit.String("name").Description("Name of the claim and of the pod volume"),
+			defkit.String("mountPath").Description("Path to mount the volume at, or the device path when volumeMode is Block"),
+			defkit.String("storageClass").Optional().Description("Storage class of the claim, defaults to the cluster default"),
+			defkit.String("size").Default("8Gi").Pattern("^([1-9][0-9]{0,63})(E|P|T|G|M|K|Ei|Pi|Ti|Gi|Mi|Ki)$").Description("Requested storage of each claim"),
+			defkit.StringList("accessModes").OfEnum("ReadWriteOnce", "ReadOnlyMany", "ReadWriteMany", "ReadWriteOncePod").Default([]any{"ReadWriteOnce"}).Description("Access modes of the claim"),
+			defkit.Enum("volumeMode").Values("Filesystem", "Block").Default("Filesystem").Description("Mount the claim as a filesystem or attach it as a raw block device"),
+		)
+
+	persistentVolumeClaimRetentionPolicy := defkit.Object("persistentVolumeClaimRetentionPolicy").
+		Optional().
+		Description("Specify whether the PVCs of volumeClaimTemplates are deleted with the StatefulSet or when it is scaled down").
+		WithFields(
+			defkit.Enum("whenDeleted").Values("Retain", "Delete").Default("Retain").Description("What happens to the PVCs when the StatefulSet is deleted"),
+			defkit.Enum("whenScaled").Values("Retain", "Delete").Default("Retain").Description("What happens to the PVCs of removed pods when the StatefulSet is scaled down"),
+		)
+
+	podManagementPolicy := defkit.Enum("podManagementPolicy").
+		Optional().
+		Values("OrderedReady", "Parallel").
+		Description("Create and delete pods one by one in order, or all at once. Can't be changed after creation")
+
+	ordinals := defkit.Object("ordinals").
+		Optional().
+		Description("Specify the ordinals of the pod names").
+		WithFields(
+			defkit.Int("start").Min(0).Description("Ordinal of the first pod"),
+		)
+
+	updateStrategy := defkit.Object("updateStrategy").
+		Optional().
+		Description("Specify how pods are replaced when the pod template changes").
+		WithFields(
+			defkit.Enum("type").Values("RollingUpdate", "OnDelete").Default("RollingUpdate").Description("Replace pods automatically, or only when they are deleted"),
+			defkit.Object("rollingUpdate").Optional().Description("Only valid when type is RollingUpdate").WithFields(
+				defkit.Int("partition").Optional().Min(0).Description("Only pods with an ordinal greater than or equal to partition are updated"),
+				IntOrPercentParam("maxUnavailable").Description("Maximum number or percentage of pods that can be unavailable during the update"),
+			),
+		)
+
+	governingService := defkit.Bool("governingService").
+		Default(false).
+		Description("Create a headless Service named <component>-headless and set it as the serviceName of the StatefulSet, giving every pod a stable DNS name. The serviceName of a StatefulSet can't be changed, so enable it when the component is created")
+
 	return defkit.NewComponent("statefulset").
 		Description("Describes long-running, scalable, containerized services used to manage stateful application, like database.").
 		Workload("apps/v1", "StatefulSet").
-		WithImports("strings").
+		WithImports("strings", "list").
 		CustomStatus(
 			defkit.Status().
 				IntField("ready.readyReplicas", "status.readyReplicas", 0).
@@ -235,12 +293,16 @@ func StatefulSet() *defkit.ComponentDefinition {
 		Params(
 			labels, annotations,
 			image, imagePullPolicy, imagePullSecrets,
-			port, ports, exposeType, addRevisionLabel,
+			port, ports, exposeType, route, addRevisionLabel,
 			cmd, args, env,
 			cpu, memory, volumeMounts, volumes,
 			livenessProbe, readinessProbe, hostAliases,
+			containers, sidecars,
+			volumeClaimTemplates, persistentVolumeClaimRetentionPolicy,
+			podManagementPolicy, ordinals, updateStrategy, governingService,
 		).
 		Helper("HealthProbe", HealthProbeParam()).
+		Validators(ExposeValidators()...).
 		Template(statefulsetTemplate)
 }
 
@@ -250,7 +312,6 @@ func statefulsetTemplate(tpl *defkit.Template) {
 	image := defkit.String("image")
 	port := defkit.Int("port")
 	ports := defkit.List("ports")
-	exposeType := defkit.String("exposeType")
 	addRevisionLabel := defkit.Bool("addRevisionLabel")
 	cmd := defkit.StringList("cmd")
 	args := defkit.StringList("args")
@@ -266,6 +327,14 @@ func statefulsetTemplate(tpl *defkit.Template) {
 	annotations := defkit.Object("annotations")
 	imagePullPolicy := defkit.String("imagePullPolicy")
 	imagePullSecrets := defkit.StringList("imagePullSecrets")
+	sidecars := defkit.List("sidecars")
+	volumeClaimTemplates := defkit.List("volumeClaimTemplates")
+	persistentVolumeClaimRetentionPolicy := defkit.Object("persistentVolumeClaimRetentionPolicy")
+	podManagementPolicy := defkit.String("podManagementPolicy")
+	ordinals := defkit.Object("ordinals")
+	updateStrategy := defkit.Object("updateStrategy")
+	governingService := defkit.Bool("governingService")
+	headlessName := defkit.Plus(vela.Name(), defkit.Lit("-headless"))
 
 	// Transform ports to container format using ForEachWith for complex
 	// _name let binding with containerPort preference and protocol suffix.
@@ -362,8 +431,82 @@ func statefulsetTemplate(tpl *defkit.Template) {
 	// Suppress unused variable warnings
 	_ = volumesList
 
+	// claimMounts and claimDevices: volumeClaimTemplates of the main container,
+	// Block claims are attached as devices instead of mounted
+	claimMounts := tpl.Helper("claimMounts").
+		From(volumeClaimTemplates).
+		Guard(volumeClaimTemplates.IsSet()).
+		Filter(defkit.FieldEquals("volumeMode", "Filesystem")).
+		Map(defkit.FieldMap{
+			"name":      defkit.FieldRef("name"),
+			"mountPath": defkit.FieldRef("mountPath"),
+		}).
+		Build()
+	claimDevices := tpl.Helper("claimDevices").
+		From(volumeClaimTemplates).
+		Guard(volumeClaimTemplates.IsSet()).
+		Filter(defkit.FieldEquals("volumeMode", "Block")).
+		Map(defkit.FieldMap{
+			"name":       defkit.FieldRef("name"),
+			"devicePath": defkit.FieldRef("mountPath"),
+		}).
+		Build()
+
+	// claimTemplates: volumeClaimTemplates in the PVC spec format
+	claimTemplates := tpl.Helper("claimTemplates").
+		From(volumeClaimTemplates).
+		Guard(volumeClaimTemplates.IsSet()).
+		Map(defkit.FieldMap{
+			"metadata": defkit.Nested(defkit.FieldMap{"name": defkit.FieldRef("name")}),
+			"spec": defkit.Nested(defkit.FieldMap{
+				"accessModes":      defkit.FieldRef("accessModes"),
+				"volumeMode":       defkit.FieldRef("volumeMode"),
+				"storageClassName": defkit.Optional("storageClass"),
+				"resources": defkit.Nested(defkit.FieldMap{
+					"requests": defkit.Nested(defkit.FieldMap{"storage": defkit.FieldRef("size")}),
+				}),
+			}),
+		}).
+		Build()
+
+	// Primary container built from the top-level parameters
+	mainContainer := defkit.NewArrayElement().
+		Set("name", vela.Name()).
+		Set("image", image).
+		// Deprecated port fallback (before modern ports)
+		SetIf(defkit.And(port.IsSet(), ports.NotSet()), "ports", defkit.InlineArray(map[string]defkit.Value{
+			"containerPort": port,
+		})).
+		SetIf(ports.IsSet(), "ports", containerPorts).
+		SetIf(imagePullPolicy.IsSet(), "imagePullPolicy", imagePullPolicy).
+		SetIf(cmd.IsSet(), "command", cmd).
+		SetIf(args.IsSet(), "args", args).
+		SetIf(env.IsSet(), "env", env).
+		SetIf(defkit.PathExists(`context["config"]`), "env", defkit.Reference("context.config")).
+		SetIf(cpu.IsSet(), "resources.limits.cpu", cpu).
+		SetIf(cpu.IsSet(), "resources.requests.cpu", cpu).
+		SetIf(memory.IsSet(), "resources.limits.memory", memory).
+		SetIf(memory.IsSet(), "resources.requests.memory", memory).
+		// Deprecated volumes fallback - container volumeMounts
+		SetIf(defkit.And(volumes.IsSet(), volumeMounts.NotSet()), "volumeMounts", defkit.ArrayConcat(
+			defkit.Each(volumes).Map(defkit.FieldMap{
+				"mountPath": defkit.FieldRef("mountPath"),
+				"name":      defkit.FieldRef("name"),
+			}), claimMounts)).
+		SetIf(volumeMounts.IsSet(), "volumeMounts", defkit.ArrayConcat(mountsArray, claimMounts)).
+		SetIf(defkit.And(volumes.NotSet(), volumeMounts.NotSet(), claimMounts.NotEmpty()), "volumeMounts", claimMounts).
+		SetIf(claimDevices.NotEmpty(), "volumeDevices", claimDevices).
+		SetIf(livenessProbe.IsSet(), "livenessProbe", livenessProbe).
+		SetIf(readinessProbe.IsSet(), "readinessProbe", readinessProbe)
+
 	// Primary output: StatefulSet
 	statefulset := defkit.NewResource("apps/v1", "StatefulSet").
+		SetIf(governingService.IsTrue(), "spec.serviceName", headlessName).
+		SetIf(podManagementPolicy.IsSet(), "spec.podManagementPolicy", podManagementPolicy).
+		SetIf(ordinals.IsSet(), "spec.ordinals", ordinals).
+		SetIf(updateStrategy.IsSet(), "spec.updateStrategy", updateStrategy).
+		SetIf(persistentVolumeClaimRetentionPolicy.IsSet(), "spec.persistentVolumeClaimRetentionPolicy", persistentVolumeClaimRetentionPolicy).
+		SetIf(volumeClaimTemplates.IsSet(), "spec.volumeClaimTemplates", claimTemplates).
 		Set("spec.selector.matchLabels[app.oam.dev/component]", vela.Name()).
 		// Labels block always includes OAM labels; user labels are spread inside when set
 		Set("spec.template.metadata.labels[app.oam.dev/name]", vela.AppName()).
@@ -373,36 +516,8 @@ func statefulsetTemplate(tpl *defkit.Template) {
 		// SpreadIf spreads user labels inside the labels block
 		SpreadIf(labels.IsSet(), "spec.template.metadata.labels", labels).
 		SetIf(annotations.IsSet(), "spec.template.metadata.annotations", annotations).
-		// Container spec
-		Set("spec.template.spec.containers[0].name", vela.Name()).
-		Set("spec.template.spec.containers[0].image", image).
-		// Deprecated port fallback (before modern ports)
-		If(defkit.And(port.IsSet(), ports.NotSet())).
-		Set("spec.template.spec.containers[0].ports", defkit.InlineArray(map[string]defkit.Value{
-			"containerPort": port,
-		})).
-		EndIf().
-		SetIf(ports.IsSet(), "spec.template.spec.containers[0].ports", containerPorts).
-		SetIf(imagePullPolicy.IsSet(), "spec.template.spec.containers[0].imagePullPolicy", imagePullPolicy).
-		SetIf(cmd.IsSet(), "spec.template.spec.containers[0].command", cmd).
-		SetIf(args.IsSet(), "spec.template.spec.containers[0].args", args).
-		SetIf(env.IsSet(), "spec.template.spec.containers[0].env", env).
-		SetIf(defkit.PathExists(`context["config"]`), "spec.template.spec.containers[0].env", defkit.Reference("context.config")).
-		SetIf(cpu.IsSet(), "spec.template.spec.containers[0].resources.limits.cpu", cpu).
-		SetIf(cpu.IsSet(), "spec.template.spec.containers[0].resources.requests.cpu", cpu).
-		SetIf(memory.IsSet(), "spec.template.spec.containers[0].resources.limits.memory", memory).
-		SetIf(memory.IsSet(), "spec.template.spec.containers[0].resources.requests.memory", memory).
-		// Deprecated volumes fallback - container volumeMounts
-		If(defkit.And(volumes.IsSet(), volumeMounts.NotSet())).
-		Set("spec.template.spec.containers[0].volumeMounts",
-			defkit.Each(volumes).Map(defkit.FieldMap{
-				"mountPath": defkit.FieldRef("mountPath"),
-				"name":      defkit.FieldRef("name"),
-			})).
-		EndIf().
-		SetIf(volumeMounts.IsSet(), "spec.template.spec.containers[0].volumeMounts", mountsArray).
-		SetIf(livenessProbe.IsSet(), "spec.template.spec.containers[0].livenessProbe", livenessProbe).
-		SetIf(readinessProbe.IsSet(), "spec.template.spec.containers[0].readinessProbe", readinessProbe).
+		Set("spec.template.spec.containers", AdditionalContainers(defkit.NewArray().Item(mainContainer))).
+		SetIf(defkit.And(sidecars.LenGt(0), NativeSidecarsSupported()), "spec.template.spec.initContainers", NativeSidecars()).
 		// Pod spec
 		SetIf(hostAliases.IsSet(), "spec.template.spec.hostAliases", hostAliases).
 		Directive("spec.template.spec.hostAliases", "patchKey=ip").
@@ -442,79 +557,18 @@ func statefulsetTemplate(tpl *defkit.Template) {
 		SetIf(volumeMounts.IsSet(), "spec.template.spec.volumes", deDupVolumesArray)
 
 	tpl.Output(statefulset)
+	IntOrPercent(tpl)
 
-	// exposePorts helper: Complex iteration with guard, filter, conditionals,
-	// _name let binding with containerPort preference, and protocol suffix.
-	// Uses FromArray with ForEachWithGuardedFiltered for full expressiveness.
-	exposePortsArray := defkit.NewArray().ForEachWithGuardedFiltered(
-		ports.IsSet(),
-		defkit.FieldEquals("expose", true),
-		ports,
-		func(item *defkit.ItemBuilder) {
-			v := item.Var()
-
-			item.Set("port", v.Field("port"))
-
-			// targetPort: prefer containerPort, fall back to port
-			item.IfSet("containerPort", func() {
-				item.Set("targetPort", v.Field("containerPort"))
-			})
-			item.IfNotSet("containerPort", func() {
-				item.Set("targetPort", v.Field("port"))
-			})
-
-			// name: use v.name if set
-			item.IfSet("name", func() {
-				item.Set("name", v.Field("name"))
-			})
-
-			// Complex name fallback: _name with containerPort preference + protocol suffix
-			item.IfNotSet("name", func() {
-				item.IfSet("containerPort", func() {
-					nameRef := item.Let("_name",
-						defkit.Plus(defkit.Lit("port-"), defkit.StrconvFormatInt(v.Field("containerPort"), 10)))
-					item.SetDefault("name", nameRef, "string")
-					item.If(defkit.Ne(v.Field("protocol"), defkit.Lit("TCP")), func() {
-						item.Set("name", defkit.Plus(nameRef, defkit.Lit("-"), defkit.StringsToLower(v.Field("protocol"))))
-					})
-				})
-				item.IfNotSet("containerPort", func() {
-					nameRef := item.Let("_name",
-						defkit.Plus(defkit.Lit("port-"), defkit.StrconvFormatInt(v.Field("port"), 10)))
-					item.SetDefault("name", nameRef, "string")
-					item.If(defkit.Ne(v.Field("protocol"), defkit.Lit("TCP")), func() {
-						item.Set("name", defkit.Plus(nameRef, defkit.Lit("-"), defkit.StringsToLower(v.Field("protocol"))))
-					})
-				})
-			})
-
-			// nodePort: compound conditional
-			item.IfSet("nodePort", func() {
-				item.If(defkit.Eq(exposeType, defkit.Lit("NodePort")), func() {
-					item.Set("nodePort", v.Field("nodePort"))
-				})
-			})
-
-			// protocol: optional
-			item.IfSet("protocol", func() {
-				item.Set("protocol", v.Field("protocol"))
-			})
-		},
-	)
-
-	exposePorts := tpl.Helper("exposePorts").
-		FromArray(exposePortsArray).
-		AfterOutput().
-		Build()
+	// Services of the exposed ports, and the route to one of them
+	ExposeServices(tpl, "statefulsetsExpose", ServicePort)
 
-	// Auxiliary output: Service (only if there are exposed ports)
-	service := defkit.NewResource("v1", "Service").
-		Set("metadata.name", vela.Name()).
+	// Auxiliary output: headless Service governing the pod DNS names
+	headless := defkit.NewResource("v1", "Service").
+		Set("metadata.name", headlessName).
 		Set("spec.selector[app.oam.dev/component]", vela.Name()).
-		Set("spec.ports", exposePorts).
-		Set("spec.type", exposeType)
+		Set("spec.clusterIP", defkit.Lit("None"))
 
-	tpl.OutputsIf(exposePorts.NotEmpty(), "statefulsetsExpose", service)
+	tpl.OutputsIf(governingService.IsTrue(), "statefulsetsHeadless", headless)
 }
 
 func init() {
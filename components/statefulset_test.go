@@ -17,12 +17,19 @@ limitations under the License.
 package components_test
 
 import (
+	"encoding/json"
 	"strings"
 
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/oam-dev/vela-go-definitions/components"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 
 	"github.com/oam-dev/kubevela/pkg/definition/defkit"
 	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
@@ -52,9 +59,6 @@ var _ = Describe("StatefulSet Component", func() {
 			comp := components.StatefulSet()
 			Expect(comp).NotTo(HaveParamNamed("replicas"))
 			Expect(comp).NotTo(HaveParamNamed("serviceName"))
-			Expect(comp).NotTo(HaveParamNamed("podManagementPolicy"))
-			Expect(comp).NotTo(HaveParamNamed("updateStrategy"))
-			Expect(comp).NotTo(HaveParamNamed("volumeClaimTemplates"))
 		})
 
 		It("should have correct parameters matching reference CUE", func() {
@@ -78,6 +82,12 @@ var _ = Describe("StatefulSet Component", func() {
 			Expect(comp).To(HaveParamNamed("livenessProbe"))
 			Expect(comp).To(HaveParamNamed("readinessProbe"))
 			Expect(comp).To(HaveParamNamed("hostAliases"))
+			Expect(comp).To(HaveParamNamed("volumeClaimTemplates"))
+			Expect(comp).To(HaveParamNamed("persistentVolumeClaimRetentionPolicy"))
+			Expect(comp).To(HaveParamNamed("podManagementPolicy"))
+			Expect(comp).To(HaveParamNamed("ordinals"))
+			Expect(comp).To(HaveParamNamed("updateStrategy"))
+			Expect(comp).To(HaveParamNamed("governingService"))
 		})
 
 		It("should execute template and produce StatefulSet output", func() {
@@ -110,10 +120,12 @@ var _ = Describe("StatefulSet Component", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			comp := components.StatefulSet()
 			cueOutput = comp.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -124,8 +136,8 @@ var _ = Describe("StatefulSet Component", func() {
 		})
 
 		It("should generate correct workload definition", func() {
-			Expect(cueOutput).To(ContainSubstring(`apiVersion: "apps/v1"`))
-			Expect(cueOutput).To(ContainSubstring(`kind:       "StatefulSet"`))
+			Expect(doc.Lookup("output.apiVersion")).To(cueassert.HaveValue(`"apps/v1"`))
+			Expect(doc.Lookup("output.kind")).To(cueassert.HaveValue(`"StatefulSet"`))
 			Expect(cueOutput).To(ContainSubstring(`type: "statefulsets.apps"`))
 		})
 
@@ -145,27 +157,27 @@ var _ = Describe("StatefulSet Component", func() {
 		})
 
 		It("should NOT generate removed parameters in CUE", func() {
-			// Extract the parameter section to check for removed params
-			paramIdx := strings.Index(cueOutput, "\tparameter: {")
-			Expect(paramIdx).To(BeNumerically(">", 0))
-			paramSection := cueOutput[paramIdx:]
-
 			// These should not appear as top-level parameters
-			Expect(paramSection).NotTo(ContainSubstring(`serviceName`))
-			Expect(paramSection).NotTo(ContainSubstring(`podManagementPolicy`))
-			Expect(paramSection).NotTo(ContainSubstring(`updateStrategy`))
-			Expect(paramSection).NotTo(ContainSubstring(`volumeClaimTemplates`))
+			params := doc.Parameter()
+			Expect(params.Field("serviceName")).NotTo(cueassert.Exist())
+		})
+
+		It("should generate the claim and rollout parameters as optional", func() {
+			Expect(doc.Lookup("parameter.volumeClaimTemplates")).To(cueassert.BeOptionalField())
+			Expect(doc.Lookup("parameter.podManagementPolicy")).To(cueassert.BeOptionalField())
+			Expect(doc.Lookup("parameter.updateStrategy")).To(cueassert.BeOptionalField())
+			Expect(doc.Lookup("parameter.updateStrategy.rollingUpdate.partition")).To(cueassert.BeOptionalField())
 		})
 
 		It("should generate deprecated port parameter with ignore and short directives", func() {
 			Expect(cueOutput).To(ContainSubstring("// +ignore"))
 			Expect(cueOutput).To(ContainSubstring("// +short=p"))
-			Expect(cueOutput).To(ContainSubstring("port?: int"))
+			Expect(doc.Lookup("parameter.port")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("int")))
 		})
 
 		It("should generate image parameter with short directive", func() {
 			Expect(cueOutput).To(ContainSubstring("// +short=i"))
-			Expect(cueOutput).To(ContainSubstring("image: string"))
+			Expect(doc.Lookup("parameter.image")).To(cueassert.HaveValue("string"))
 		})
 
 		It("should generate ports parameter with containerPort and nodePort", func() {
@@ -174,11 +186,11 @@ var _ = Describe("StatefulSet Component", func() {
 		})
 
 		It("should generate args parameter", func() {
-			Expect(cueOutput).To(ContainSubstring("args?: [...string]"))
+			Expect(doc.Lookup("parameter.args")).To(cueassert.BeOptionalListOf("string"))
 		})
 
 		It("should generate addRevisionLabel with ignore directive", func() {
-			Expect(cueOutput).To(ContainSubstring("addRevisionLabel: *false | bool"))
+			Expect(doc.Lookup("parameter.addRevisionLabel")).To(SatisfyAll(cueassert.HaveDefault(false), cueassert.HaveType("bool")))
 		})
 
 		It("should generate exposeType with only 3 options", func() {
@@ -202,14 +214,14 @@ var _ = Describe("StatefulSet Component", func() {
 		})
 
 		It("should generate livenessProbe and readinessProbe referencing HealthProbe", func() {
-			Expect(cueOutput).To(ContainSubstring("livenessProbe?: #HealthProbe"))
-			Expect(cueOutput).To(ContainSubstring("readinessProbe?: #HealthProbe"))
+			Expect(doc.Lookup("parameter.livenessProbe")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("#HealthProbe")))
+			Expect(doc.Lookup("parameter.readinessProbe")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("#HealthProbe")))
 		})
 
 		It("should generate StatefulSet output (not DaemonSet)", func() {
 			Expect(cueOutput).To(ContainSubstring(`output: {`))
 			// The first kind should be StatefulSet
-			Expect(cueOutput).To(ContainSubstring(`kind:       "StatefulSet"`))
+			Expect(doc.Lookup("output.kind")).To(cueassert.HaveValue(`"StatefulSet"`))
 		})
 
 		It("should generate statefulsetsExpose output", func() {
@@ -273,5 +285,84 @@ var _ = Describe("StatefulSet Component", func() {
 			Expect(cueOutput).To(ContainSubstring("v.protocol != _|_"))
 			Expect(cueOutput).To(ContainSubstring("protocol: v.protocol"))
 		})
+
+	})
+
+	Describe("Template", func() {
+		evalStatefulSet := func(parameter string) (appsv1.StatefulSet, cue.Value) {
+			tpl := evalTemplate(components.StatefulSet(), parameter)
+			raw, err := tpl.LookupPath(cue.ParsePath("output")).MarshalJSON()
+			Expect(err).NotTo(HaveOccurred())
+			var sts appsv1.StatefulSet
+			Expect(json.Unmarshal(raw, &sts)).To(Succeed())
+			return sts, tpl
+		}
+
+		It("should declare the claims and mount them into the main container", func() {
+			sts, _ := evalStatefulSet(`{image: "postgres:16", volumeClaimTemplates: [
+				{name: "data", mountPath: "/var/lib/postgresql", storageClass: "fast", size: "20Gi"},
+				{name: "wal", mountPath: "/dev/wal", volumeMode: "Block", accessModes: ["ReadWriteOncePod"]},
+			]}`)
+			claims := sts.Spec.VolumeClaimTemplates
+			Expect(claims).To(HaveLen(2))
+			Expect(claims[0].Name).To(Equal("data"))
+			Expect(*claims[0].Spec.StorageClassName).To(Equal("fast"))
+			Expect(claims[0].Spec.AccessModes).To(Equal([]corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}))
+			Expect(claims[0].Spec.Resources.Requests.Storage().String()).To(Equal("20Gi"))
+			Expect(claims[1].Spec.StorageClassName).To(BeNil())
+			Expect(*claims[1].Spec.VolumeMode).To(Equal(corev1.PersistentVolumeBlock))
+			Expect(claims[1].Spec.Resources.Requests.Storage().String()).To(Equal("8Gi"))
+
+			main := sts.Spec.Template.Spec.Containers[0]
+			Expect(main.VolumeMounts).To(Equal([]corev1.VolumeMount{{Name: "data", MountPath: "/var/lib/postgresql"}}))
+			Expect(main.VolumeDevices).To(Equal([]corev1.VolumeDevice{{Name: "wal", DevicePath: "/dev/wal"}}))
+			Expect(sts.Spec.Template.Spec.Volumes).To(BeEmpty())
+		})
+
+		It("should append the claim mounts to the other volume mounts", func() {
+			sts, _ := evalStatefulSet(`{image: "postgres:16", volumeMounts: emptyDir: [{name: "tmp", mountPath: "/tmp"}], volumeClaimTemplates: [{name: "data", mountPath: "/data"}]}`)
+			Expect(sts.Spec.Template.Spec.Containers[0].VolumeMounts).To(Equal([]corev1.VolumeMount{
+				{Name: "tmp", MountPath: "/tmp"},
+				{Name: "data", MountPath: "/data"},
+			}))
+			Expect(sts.Spec.Template.Spec.Volumes).To(HaveLen(1))
+		})
+
+		It("should pass the rollout and retention settings through", func() {
+			sts, _ := evalStatefulSet(`{image: "postgres:16", podManagementPolicy: "Parallel", ordinals: start: 1,
+				updateStrategy: rollingUpdate: {partition: 2, maxUnavailable: 1},
+				persistentVolumeClaimRetentionPolicy: whenScaled: "Delete"}`)
+			Expect(sts.Spec.PodManagementPolicy).To(Equal(appsv1.ParallelPodManagement))
+			Expect(sts.Spec.Ordinals.Start).To(Equal(int32(1)))
+			Expect(sts.Spec.UpdateStrategy.Type).To(Equal(appsv1.RollingUpdateStatefulSetStrategyType))
+			Expect(*sts.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(int32(2)))
+			Expect(sts.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable.IntValue()).To(Equal(1))
+			Expect(sts.Spec.PersistentVolumeClaimRetentionPolicy.WhenDeleted).To(Equal(appsv1.RetainPersistentVolumeClaimRetentionPolicyType))
+			Expect(sts.Spec.PersistentVolumeClaimRetentionPolicy.WhenScaled).To(Equal(appsv1.DeletePersistentVolumeClaimRetentionPolicyType))
+		})
+
+		It("should take maxUnavailable as a percentage too", func() {
+			sts, _ := evalStatefulSet(`{image: "postgres:16", updateStrategy: rollingUpdate: maxUnavailable: "10%"}`)
+			Expect(sts.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable.String()).To(Equal("10%"))
+
+			v := cuecontext.New().CompileString(components.StatefulSet().ToCue() + "\ncontext: {name: \"site\", appName: \"docs\", namespace: \"default\"}\ntemplate: parameter: {image: \"postgres:16\", updateStrategy: rollingUpdate: maxUnavailable: \"2\"}")
+			Expect(v.Validate()).To(MatchError(ContainSubstring("maxUnavailable")))
+		})
+
+		It("should generate the headless governing Service on request", func() {
+			sts, tpl := evalStatefulSet(`{image: "postgres:16", governingService: true}`)
+			Expect(sts.Spec.ServiceName).To(Equal("site-headless"))
+			headless := tpl.LookupPath(cue.ParsePath("outputs.statefulsetsHeadless"))
+			Expect(headless.LookupPath(cue.ParsePath("metadata.name")).String()).To(Equal("site-headless"))
+			Expect(headless.LookupPath(cue.ParsePath("spec.clusterIP")).String()).To(Equal("None"))
+		})
+
+		It("should leave serviceName unset by default so existing StatefulSets keep updating", func() {
+			sts, tpl := evalStatefulSet(`{image: "postgres:16"}`)
+			Expect(sts.Spec.ServiceName).To(BeEmpty())
+			Expect(sts.Spec.VolumeClaimTemplates).To(BeEmpty())
+			Expect(sts.Spec.Template.Spec.Containers[0].VolumeMounts).To(BeEmpty())
+			Expect(tpl.LookupPath(cue.ParsePath("outputs.statefulsetsHeadless")).Exists()).To(BeFalse())
+		})
 	})
 })
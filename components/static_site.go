@@ -0,0 +1,256 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// StaticSite creates the static-site component definition.
+// It serves inline files, or the files of an existing ConfigMap, with nginx.
+//
+// The nginx.conf, the ConfigMap keys of the files and the content checksum live
+// in a raw header: defkit wraps no builtin past strconv.FormatInt, a few strings
+// helpers and list.Concat, so strings.Join, strings.Replace, json.Marshal and
+// sha256.Sum256 have no builder. The resources are fluent and read the results
+// through LetVariable.
+func StaticSite() *defkit.ComponentDefinition {
+	labels := defkit.StringKeyMap("labels").Optional().Description("Specify the labels in the workload")
+	annotations := defkit.StringKeyMap("annotations").Optional().Description("Specify the annotations in the workload")
+	image := defkit.String("image").Default("nginx:1.27-alpine").Description("nginx image serving the site")
+	replicas := defkit.Int("replicas").Default(1).Description("Number of nginx replicas")
+	files := defkit.StringKeyMap("files").
+		Optional().
+		Description("Content of the site as a map of path to content, like `index.html` or `css/site.css`. Stored in a ConfigMap, so the total size is limited to 1MiB")
+	existingConfigMap := defkit.String("existingConfigMap").
+		Optional().
+		Description("Serve the keys of an existing ConfigMap as files instead of the inline files. Changes to its content do not restart the pods")
+	spa := defkit.Bool("spa").
+		Default(false).
+		Description("Serve index.html for paths that match no file, for single-page applications with client-side routing")
+	cacheControl := defkit.String("cacheControl").
+		Optional().
+		Description("Value of the Cache-Control header of the responses, like `public, max-age=3600`")
+	gzip := defkit.Bool("gzip").Default(true).Description("Compress text responses with gzip")
+	nginxConfig := defkit.String("nginxConfig").
+		Optional().
+		Description("Extra nginx directives added to the server block, like additional location blocks or headers")
+	basicAuth := defkit.Object("basicAuth").
+		Optional().
+		Description("Protect the site with basic authentication").
+		WithFields(
+			defkit.String("secretName").Description("Secret holding the users in htpasswd format"),
+			defkit.String("key").Default("auth").Description("Key of the htpasswd content in the Secret"),
+			defkit.String("realm").Default("Restricted").Description("Realm shown by the browser when asking for credentials"),
+		)
+	port := defkit.Int("port").Default(80).Description("Port of the Service")
+	exposeType := defkit.Enum("exposeType").
+		Values("ClusterIP", "NodePort", "LoadBalancer").
+		Default("ClusterIP").
+		Description("Specify what kind of Service you want. options: \"ClusterIP\", \"NodePort\", \"LoadBalancer\"")
+	cpu := defkit.String("cpu").Optional().Description("Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)")
+	memory := defkit.String("memory").Optional().Description("Specifies the attributes of the memory resource required for the container.")
+
+	return defkit.NewComponent("static-site").
+		Description("Serves static content, such as docs or status pages, with nginx from inline files or a ConfigMap.").
+		Workload("apps/v1", "Deployment").
+		WithImports("strings", "encoding/json", "encoding/hex", "crypto/sha256").
+		CustomStatus(staticSiteStatus()).
+		HealthPolicy(defkit.DeploymentHealth().Build()).
+		Params(
+			labels, annotations,
+			image, replicas,
+			files, existingConfigMap,
+			spa, cacheControl, gzip, nginxConfig, basicAuth,
+			port, exposeType,
+			cpu, memory,
+		).
+		Template(staticSiteTemplate)
+}
+
+// staticSiteStatus reports the ready replicas with the number of inline files and
+// the content checksum, like "Ready:2/2, files: 3, checksum: 9f86d081884c7d65".
+func staticSiteStatus() string {
+	return defkit.Status().
+		IntField("ready.readyReplicas", "status.readyReplicas", 0).
+		StringField("site.checksum", "spec.template.metadata.annotations[\"static-site.oam.dev/checksum\"]", "").
+		Build() + `
+_files: *"" | string
+if context.output.spec.template.metadata.annotations["static-site.oam.dev/files"] != _|_ {
+	_files: ", files: \(context.output.spec.template.metadata.annotations["static-site.oam.dev/files"])"
+}
+message: "Ready:\(ready.readyReplicas)/\(context.output.spec.replicas)\(_files), checksum: \(site.checksum)"`
+}
+
+// staticSiteHeader computes the nginx.conf, the ConfigMap data and volume items of
+// the inline files, the checksum rolling the pods when either changes, and the
+// volumes of the pod.
+// ConfigMap keys can't contain "/", so nested paths are stored with "__" instead,
+// and "_" is escaped as "_." so that no two paths share a key ("a/b" is "a__b",
+// "a__b" is "a_._.b").
+const staticSiteHeader = `let _nginxConf = strings.Join([
+	"server {",
+	"\tlisten 8080;",
+	"\troot /usr/share/nginx/html;",
+	"\tindex index.html;",
+	if parameter.gzip {
+		"\tgzip on;\n\tgzip_min_length 1024;\n\tgzip_types text/plain text/css text/xml application/javascript application/json application/xml image/svg+xml;"
+	},
+	if parameter.basicAuth != _|_ {
+		"\tauth_basic \"\(parameter.basicAuth.realm)\";\n\tauth_basic_user_file /etc/nginx/auth/htpasswd;"
+	},
+	"\tlocation / {",
+	if parameter.spa {
+		"\t\ttry_files $uri $uri/ /index.html;"
+	},
+	if !parameter.spa {
+		"\t\ttry_files $uri $uri/ =404;"
+	},
+	if parameter.cacheControl != _|_ {
+		"\t\tadd_header Cache-Control \"\(parameter.cacheControl)\" always;"
+	},
+	"\t}",
+	"\tlocation = /healthz {",
+	"\t\tauth_basic off;",
+	"\t\taccess_log off;",
+	"\t\treturn 200 \"ok\";",
+	"\t}",
+	if parameter.nginxConfig != _|_ {
+		parameter.nginxConfig
+	},
+	"}",
+	"",
+], "\n")
+let _siteFiles = {
+	if parameter.existingConfigMap == _|_ && parameter.files != _|_ {
+		parameter.files
+	}
+	if parameter.existingConfigMap != _|_ || parameter.files == _|_ {
+		{}
+	}
+}
+let _siteKeys = {
+	for k, _ in _siteFiles {
+		(k): strings.Replace(strings.Replace(k, "_", "_.", -1), "/", "__", -1)
+	}
+}
+let _siteData = {
+	for k, v in _siteFiles {
+		(_siteKeys[k]): v
+	}
+}
+let _siteItems = [for k, _ in _siteFiles {
+	key:  _siteKeys[k]
+	path: k
+}]
+let _fileCount = "\(len(_siteFiles))"
+let _checksum = strings.SliceRunes(hex.Encode(sha256.Sum256(_nginxConf + json.Marshal(_siteFiles))), 0, 16)
+let _volumeMounts = [
+	{name: "nginx-config", mountPath: "/etc/nginx/conf.d"},
+	if parameter.existingConfigMap != _|_ || parameter.files != _|_ {
+		{name: "content", mountPath: "/usr/share/nginx/html"}
+	},
+	if parameter.basicAuth != _|_ {
+		{name: "auth", mountPath: "/etc/nginx/auth"}
+	},
+]
+let _volumes = [
+	{name: "nginx-config", configMap: name: "\(context.name)-nginx"},
+	if parameter.existingConfigMap != _|_ {
+		{name: "content", configMap: name: parameter.existingConfigMap}
+	},
+	if parameter.existingConfigMap == _|_ && parameter.files != _|_ {
+		{name: "content", configMap: {name: "\(context.name)-content", items: _siteItems}}
+	},
+	if parameter.basicAuth != _|_ {
+		{name: "auth", secret: {secretName: parameter.basicAuth.secretName, items: [{key: parameter.basicAuth.key, path: "htpasswd"}]}}
+	},
+]`
+
+// staticSiteTemplate defines the template function for static-site.
+func staticSiteTemplate(tpl *defkit.Template) {
+	vela := defkit.VelaCtx()
+
+	// Parameter references for template
+	labels := defkit.StringKeyMap("labels")
+	annotations := defkit.StringKeyMap("annotations")
+	image := defkit.String("image").Default("nginx:1.27-alpine")
+	replicas := defkit.Int("replicas").Default(1)
+	files := defkit.StringKeyMap("files")
+	existingConfigMap := defkit.String("existingConfigMap")
+	port := defkit.Int("port").Default(80)
+	exposeType := defkit.String("exposeType").Default("ClusterIP")
+	cpu := defkit.String("cpu")
+	memory := defkit.String("memory")
+
+	tpl.SetRawHeaderBlock(staticSiteHeader)
+
+	inlineFiles := defkit.And(existingConfigMap.NotSet(), files.IsSet())
+	configName := defkit.Interpolation(vela.Name(), defkit.Lit("-nginx"))
+	contentName := defkit.Interpolation(vela.Name(), defkit.Lit("-content"))
+
+	deployment := defkit.NewResource("apps/v1", "Deployment").
+		Set("spec.replicas", replicas).
+		Set("spec.selector.matchLabels[app.oam.dev/component]", vela.Name()).
+		SpreadIf(labels.IsSet(), "spec.template.metadata.labels", labels).
+		Set("spec.template.metadata.labels[app.oam.dev/name]", vela.AppName()).
+		Set("spec.template.metadata.labels[app.oam.dev/component]", vela.Name()).
+		SpreadIf(annotations.IsSet(), "spec.template.metadata.annotations", annotations).
+		Set("spec.template.metadata.annotations[static-site.oam.dev/checksum]", defkit.LetVariable("_checksum")).
+		SetIf(inlineFiles, "spec.template.metadata.annotations[static-site.oam.dev/files]", defkit.LetVariable("_fileCount")).
+		Set("spec.template.spec.containers[0].name", vela.Name()).
+		Set("spec.template.spec.containers[0].image", image).
+		Set("spec.template.spec.containers[0].ports[0].containerPort", defkit.Lit(8080)).
+		Set("spec.template.spec.containers[0].ports[0].name", defkit.Lit("http")).
+		Set("spec.template.spec.containers[0].readinessProbe.httpGet.path", defkit.Lit("/healthz")).
+		Set("spec.template.spec.containers[0].readinessProbe.httpGet.port", defkit.Lit("http")).
+		If(cpu.IsSet()).
+		Set("spec.template.spec.containers[0].resources.limits.cpu", cpu).
+		Set("spec.template.spec.containers[0].resources.requests.cpu", cpu).
+		EndIf().
+		If(memory.IsSet()).
+		Set("spec.template.spec.containers[0].resources.limits.memory", memory).
+		Set("spec.template.spec.containers[0].resources.requests.memory", memory).
+		EndIf().
+		Set("spec.template.spec.containers[0].volumeMounts", defkit.LetVariable("_volumeMounts")).
+		Set("spec.template.spec.volumes", defkit.LetVariable("_volumes"))
+
+	config := defkit.NewResource("v1", "ConfigMap").
+		Set("metadata.name", configName).
+		Set("data[default.conf]", defkit.LetVariable("_nginxConf"))
+
+	content := defkit.NewResource("v1", "ConfigMap").
+		Set("metadata.name", contentName).
+		Set("data", defkit.LetVariable("_siteData"))
+
+	service := defkit.NewResource("v1", "Service").
+		Set("metadata.name", vela.Name()).
+		Set("spec.selector[app.oam.dev/component]", vela.Name()).
+		Set("spec.ports[0].name", defkit.Lit("http")).
+		Set("spec.ports[0].port", port).
+		Set("spec.ports[0].targetPort", defkit.Lit("http")).
+		Set("spec.type", exposeType)
+
+	tpl.Output(deployment)
+	tpl.Outputs("nginxConfig", config)
+	tpl.OutputsIf(inlineFiles, "content", content)
+	tpl.Outputs("service", service)
+}
+
+func init() {
+	defkit.Register(StaticSite())
+}
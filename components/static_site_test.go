@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"cuelang.org/go/cue"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
+
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("StaticSite Component", func() {
+	Describe("StaticSite()", func() {
+		It("should create a static-site component definition", func() {
+			comp := components.StaticSite()
+			Expect(comp.GetName()).To(Equal("static-site"))
+			Expect(comp.GetDescription()).To(ContainSubstring("nginx"))
+		})
+
+		It("should have Deployment workload", func() {
+			workload := components.StaticSite().GetWorkload()
+			Expect(workload.APIVersion()).To(Equal("apps/v1"))
+			Expect(workload.Kind()).To(Equal("Deployment"))
+		})
+
+		It("should have content and nginx parameters", func() {
+			comp := components.StaticSite()
+			for _, name := range []string{"files", "existingConfigMap", "spa", "cacheControl", "gzip", "nginxConfig", "basicAuth", "port", "exposeType"} {
+				Expect(comp).To(HaveParamNamed(name))
+			}
+		})
+	})
+
+	Describe("CUE Generation", func() {
+		var doc *cueassert.Document
+
+		BeforeEach(func() {
+			doc = cueassert.MustParse(components.StaticSite().ToCue())
+		})
+
+		It("should generate the parameter schema", func() {
+			Expect(doc.Lookup("parameter.image")).To(cueassert.HaveDefault("nginx:1.27-alpine"))
+			Expect(doc.Lookup("parameter.files")).To(cueassert.BeOptionalField())
+			Expect(doc.Lookup("parameter.gzip")).To(cueassert.HaveDefault(true))
+			Expect(doc.Lookup("parameter.spa")).To(cueassert.HaveDefault(false))
+			Expect(doc.Lookup("parameter.basicAuth.secretName")).To(cueassert.BeRequiredField())
+			Expect(doc.Lookup("parameter.basicAuth.key")).To(cueassert.HaveDefault("auth"))
+		})
+
+		It("should only create the content ConfigMap for inline files", func() {
+			Expect(doc.Lookup("outputs.content").Conditional()).To(BeTrue())
+			Expect(doc.Lookup("outputs.nginxConfig").Conditional()).To(BeFalse())
+			Expect(doc.Lookup("outputs.service").Conditional()).To(BeFalse())
+		})
+	})
+
+	Describe("Template", func() {
+		const files = `{files: {"index.html": "<h1>docs</h1>", "css/site.css": "h1 {}"}}`
+
+		lookup := func(v cue.Value, path string) cue.Value {
+			return v.LookupPath(cue.ParsePath(path))
+		}
+		nginxConf := func(v cue.Value) string {
+			conf, err := lookup(v, `outputs.nginxConfig.data."default.conf"`).String()
+			Expect(err).NotTo(HaveOccurred())
+			return conf
+		}
+		checksum := func(v cue.Value) string {
+			sum, err := lookup(v, `output.spec.template.metadata.annotations."static-site.oam.dev/checksum"`).String()
+			Expect(err).NotTo(HaveOccurred())
+			return sum
+		}
+
+		It("should store nested paths under ConfigMap-safe keys", func() {
+			v := evalTemplate(components.StaticSite(), files)
+			Expect(lookup(v, `outputs.content.data`)).To(SatisfyAll(
+				WithTransform(func(d cue.Value) bool { return lookup(d, `"css__site.css"`).Exists() }, BeTrue()),
+				WithTransform(func(d cue.Value) bool { return lookup(d, `"index.html"`).Exists() }, BeTrue()),
+			))
+			items, err := lookup(v, "output.spec.template.spec.volumes[1].configMap.items[1].path").String()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(items).To(Equal("css/site.css"))
+			count, _ := lookup(v, `output.spec.template.metadata.annotations."static-site.oam.dev/files"`).String()
+			Expect(count).To(Equal("2"))
+			Expect(checksum(v)).To(HaveLen(16))
+		})
+
+		It("should keep paths that only differ in \"/\" and \"_\" apart", func() {
+			v := evalTemplate(components.StaticSite(), `{files: {"a/b": "slash", "a__b": "underscores", "a_/b": "both"}}`)
+			data := lookup(v, `outputs.content.data`)
+			for key, content := range map[string]string{`"a__b"`: "slash", `"a_._.b"`: "underscores", `"a_.__b"`: "both"} {
+				Expect(lookup(data, key).String()).To(Equal(content), key)
+			}
+		})
+
+		It("should roll the pods when the content or the config changes", func() {
+			base := checksum(evalTemplate(components.StaticSite(), files))
+			Expect(checksum(evalTemplate(components.StaticSite(), `{files: {"index.html": "<h1>v2</h1>", "css/site.css": "h1 {}"}}`))).NotTo(Equal(base))
+			Expect(checksum(evalTemplate(components.StaticSite(), `{files: {"index.html": "<h1>docs</h1>", "css/site.css": "h1 {}"}, spa: true}`))).NotTo(Equal(base))
+			Expect(checksum(evalTemplate(components.StaticSite(), files))).To(Equal(base))
+		})
+
+		It("should render the nginx.conf from the parameters", func() {
+			v := evalTemplate(components.StaticSite(), `{
+				files: {"index.html": "app"}
+				spa: true
+				gzip: false
+				cacheControl: "public, max-age=60"
+				nginxConfig: "location /api { return 404; }"
+				basicAuth: {secretName: "docs-users", realm: "Docs"}
+			}`)
+			Expect(nginxConf(v)).To(SatisfyAll(
+				ContainSubstring("try_files $uri $uri/ /index.html;"),
+				ContainSubstring(`add_header Cache-Control "public, max-age=60" always;`),
+				ContainSubstring(`auth_basic "Docs";`),
+				ContainSubstring("location /api { return 404; }"),
+				Not(ContainSubstring("gzip on;")),
+			))
+			secret, _ := lookup(v, "output.spec.template.spec.volumes[2].secret.secretName").String()
+			Expect(secret).To(Equal("docs-users"))
+		})
+
+		It("should mount an existing ConfigMap without creating one", func() {
+			v := evalTemplate(components.StaticSite(), `{existingConfigMap: "status-page"}`)
+			Expect(lookup(v, "outputs.content").Exists()).To(BeFalse())
+			name, _ := lookup(v, "output.spec.template.spec.volumes[1].configMap.name").String()
+			Expect(name).To(Equal("status-page"))
+			Expect(lookup(v, `output.spec.template.metadata.annotations."static-site.oam.dev/files"`).Exists()).To(BeFalse())
+			Expect(nginxConf(v)).To(ContainSubstring("try_files $uri $uri/ =404;"))
+		})
+	})
+
+	Describe("Status", func() {
+		DescribeTable("message",
+			func(output, message string) {
+				v := evalStatus(components.StaticSite().GetCustomStatus(), output)
+				Expect(v.LookupPath(cue.ParsePath("message")).String()).To(Equal(message))
+			},
+			Entry("inline files",
+				`{spec: {replicas: 2, template: metadata: annotations: {"static-site.oam.dev/checksum": "9f86d081884c7d65", "static-site.oam.dev/files": "3"}}, status: {readyReplicas: 2}}`,
+				"Ready:2/2, files: 3, checksum: 9f86d081884c7d65"),
+			Entry("existing ConfigMap",
+				`{spec: {replicas: 1, template: metadata: annotations: {"static-site.oam.dev/checksum": "9f86d081884c7d65"}}}`,
+				"Ready:0/1, checksum: 9f86d081884c7d65"),
+		)
+	})
+})
@@ -35,64 +35,10 @@ func Task() *defkit.ComponentDefinition {
 	restart := defkit.String("restart").Default("Never").
 		Description("Define the job restart policy, the value can only be Never or OnFailure. By default, it's Never.")
 	cmd := defkit.StringList("cmd").Optional().Description("Commands to run in the container")
-	env := defkit.List("env").Optional().Description("Define arguments by using environment variables").
-		WithFields(
-			defkit.String("name").Description("Environment variable name"),
-			defkit.String("value").Optional().Description("The value of the environment variable"),
-			defkit.Object("valueFrom").Optional().Description("Specifies a source the value of this var should come from").
-				WithFields(
-					defkit.Object("secretKeyRef").Optional().Description("Selects a key of a secret in the pod's namespace").
-						WithFields(
-							defkit.String("name").Description("The name of the secret in the pod's namespace to select from"),
-							defkit.String("key").Description("The key of the secret to select from. Must be a valid secret key"),
-						),
-					defkit.Object("configMapKeyRef").Optional().Description("Selects a key of a config map in the pod's namespace").
-						WithFields(
-							defkit.String("name").Description("The name of the config map in the pod's namespace to select from"),
-							defkit.String("key").Description("The key of the config map to select from. Must be a valid secret key"),
-						),
-				),
-		)
+	env := ContainerEnvParam()
 	cpu := defkit.String("cpu").Optional().Description("Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)")
 	memory := defkit.String("memory").Optional().Description("Specifies the attributes of the memory resource required for the container.")
-	volumes := defkit.List("volumes").Optional().Description("Declare volumes and volumeMounts").
-		WithFields(
-			defkit.String("name"),
-			defkit.String("mountPath"),
-			defkit.OneOf("type").
-				Description("Specify volume type, options: \"pvc\",\"configMap\",\"secret\",\"emptyDir\", default to emptyDir").
-				Default("emptyDir").
-				Variants(
-					defkit.Variant("pvc").WithFields(
-						defkit.Field("claimName", defkit.ParamTypeString),
-					),
-					defkit.Variant("configMap").WithFields(
-						defkit.Field("defaultMode", defkit.ParamTypeInt).Default(420),
-						defkit.Field("cmName", defkit.ParamTypeString),
-						defkit.Field("items", defkit.ParamTypeArray).Optional().Nested(
-							defkit.Struct("").WithFields(
-								defkit.Field("key", defkit.ParamTypeString),
-								defkit.Field("path", defkit.ParamTypeString),
-								defkit.Field("mode", defkit.ParamTypeInt).Default(511),
-							),
-						),
-					),
-					defkit.Variant("secret").WithFields(
-						defkit.Field("defaultMode", defkit.ParamTypeInt).Default(420),
-						defkit.Field("secretName", defkit.ParamTypeString),
-						defkit.Field("items", defkit.ParamTypeArray).Optional().Nested(
-							defkit.Struct("").WithFields(
-								defkit.Field("key", defkit.ParamTypeString),
-								defkit.Field("path", defkit.ParamTypeString),
-								defkit.Field("mode", defkit.ParamTypeInt).Default(511),
-							),
-						),
-					),
-					defkit.Variant("emptyDir").WithFields(
-						defkit.Field("medium", defkit.ParamTypeString).Default("").Values("", "Memory"),
-					),
-				),
-		)
+	volumes := TaskVolumesParam()
 	livenessProbe := defkit.Object("livenessProbe").
 		Optional().
 		WithSchemaRef("HealthProbe").
@@ -101,36 +47,135 @@ func Task() *defkit.ComponentDefinition {
 		Optional().
 		WithSchemaRef("HealthProbe").
 		Description("Instructions for assessing whether the container is in a suitable state to serve traffic.")
+	sidecars := JobSidecarsParam()
+
+	// Job controls. parallelism and completions default to count.
+	parallelism := defkit.Int("parallelism").Optional().
+		Description("Number of pods running at the same time, overrides count")
+	completions := defkit.Int("completions").Optional().
+		Description("Number of successful pods the job needs to complete, overrides count. In Indexed mode, pods get the indexes 0 to completions-1")
+	completionMode := defkit.Enum("completionMode").Optional().
+		Values("NonIndexed", "Indexed").
+		Description("Use Indexed to give each pod a completion index, exposed as the JOB_COMPLETION_INDEX environment variable")
+	backoffLimitPerIndex := defkit.Int("backoffLimitPerIndex").Optional().
+		Description("Number of retries of each index before it is marked as failed. Only valid in Indexed mode")
+	maxFailedIndexes := defkit.Int("maxFailedIndexes").Optional().
+		Description("Number of failed indexes after which the whole job fails. Requires backoffLimitPerIndex")
+	podFailurePolicy := defkit.List("podFailurePolicy").Optional().
+		Description("Rules deciding how pod failures are handled, the first matching rule applies. Requires restart to be Never").
+		WithFields(
+			defkit.Enum("action").Values("FailJob", "FailIndex", "Ignore", "Count").
+				Description("Action taken when the rule matches: fail the job, fail the index, ignore the failure or count it against the backoff limit"),
+			defkit.Object("onExitCodes").Optional().Description("Match container exit codes").
+				WithFields(
+					defkit.String("containerName").Optional().Description("Only match the exit codes of this container"),
+					defkit.Enum("operator").Values("In", "NotIn").Description("Match the exit codes in or not in values"),
+					defkit.IntList("values").Description("Exit codes to match"),
+				),
+			defkit.List("onPodConditions").Optional().Description("Match pod conditions, such as DisruptionTarget").
+				WithFields(
+					defkit.String("type").Description("Type of the pod condition"),
+					defkit.Enum("status").Values("True", "False", "Unknown").Default("True").Description("Status of the pod condition"),
+				),
+		)
+	ttlSecondsAfterFinished := defkit.Int("ttlSecondsAfterFinished").Optional().
+		Description("Delete the job this many seconds after it finishes")
+	activeDeadlineSeconds := defkit.Int("activeDeadlineSeconds").Optional().
+		Description("Fail the job once it has been running for this many seconds")
+	suspend := defkit.Bool("suspend").Optional().
+		Description("Create the job suspended, no pods run until it is resumed")
+	podReplacementPolicy := defkit.Enum("podReplacementPolicy").Optional().
+		Values("TerminatingOrFailed", "Failed").
+		Description("When to create replacement pods: as soon as a pod is terminating, or only once it has fully failed")
 
 	return defkit.NewComponent("task").
 		Description("Describes jobs that run code or a script to completion.").
 		Workload("batch/v1", "Job").
-		CustomStatus(defkit.Status().
-			IntField("status.active", "status.active", 0).
-			IntField("status.failed", "status.failed", 0).
-			IntField("status.succeeded", "status.succeeded", 0).
-			Message("Active/Failed/Succeeded:\\(status.active)/\\(status.failed)/\\(status.succeeded)").
+		CustomStatus(taskStatus()).
+		HealthPolicy(defkit.Health().
+			IntField("succeeded", "status.succeeded", 0).
+			HealthyWhen(defkit.StatusEq("succeeded", "context.output.spec.completions")).
 			Build()).
-		HealthPolicy(defkit.JobHealth().Build()).
 		Helper("HealthProbe", CronTaskHealthProbeParam()).
 		Params(
 			labels, annotations,
 			count, image, imagePullPolicy, imagePullSecrets,
 			restart, cmd, env,
 			cpu, memory, volumes,
-			livenessProbe, readinessProbe,
+			livenessProbe, readinessProbe, sidecars,
+			parallelism, completions, completionMode,
+			backoffLimitPerIndex, maxFailedIndexes, podFailurePolicy,
+			ttlSecondsAfterFinished, activeDeadlineSeconds,
+			suspend, podReplacementPolicy,
 		).
+		Validators(NativeSidecarsRequired()).
 		Template(taskTemplate)
 }
 
+// taskStatus reports the pod counts of the job and, in Indexed mode, the
+// completed and failed indexes, like "Active/Failed/Succeeded:1/0/3, completed indexes: 0-2".
+func taskStatus() string {
+	return defkit.Status().
+		IntField("status.active", "status.active", 0).
+		IntField("status.failed", "status.failed", 0).
+		IntField("status.succeeded", "status.succeeded", 0).
+		Build() + `
+_indexes: *"" | string
+if context.output.status.completedIndexes != _|_ if context.output.status.completedIndexes != "" {
+	_indexes: ", completed indexes: \(context.output.status.completedIndexes)"
+}
+_failedIndexes: *"" | string
+if context.output.status.failedIndexes != _|_ if context.output.status.failedIndexes != "" {
+	_failedIndexes: ", failed indexes: \(context.output.status.failedIndexes)"
+}
+message: "Active/Failed/Succeeded:\(status.active)/\(status.failed)/\(status.succeeded)\(_indexes)\(_failedIndexes)"`
+}
+
 // taskTemplate defines the template function for task.
 func taskTemplate(tpl *defkit.Template) {
 	vela := defkit.VelaCtx()
 
 	// Parameter references for template
+	count := defkit.Int("count")
+	parallelism := defkit.Int("parallelism")
+	completions := defkit.Int("completions")
+	completionMode := defkit.String("completionMode")
+	backoffLimitPerIndex := defkit.Int("backoffLimitPerIndex")
+	maxFailedIndexes := defkit.Int("maxFailedIndexes")
+	podFailurePolicy := defkit.List("podFailurePolicy")
+	ttlSecondsAfterFinished := defkit.Int("ttlSecondsAfterFinished")
+	activeDeadlineSeconds := defkit.Int("activeDeadlineSeconds")
+	suspend := defkit.Bool("suspend")
+	podReplacementPolicy := defkit.String("podReplacementPolicy")
+	sidecars := defkit.List("sidecars")
+
+	job := defkit.NewResource("batch/v1", "Job").
+		Set("metadata.name", defkit.Interpolation(vela.AppName(), defkit.Lit("-"), vela.Name())).
+		SetIf(parallelism.IsSet(), "spec.parallelism", parallelism).
+		SetIf(parallelism.NotSet(), "spec.parallelism", count).
+		SetIf(completions.IsSet(), "spec.completions", completions).
+		SetIf(completions.NotSet(), "spec.completions", count).
+		SetIf(completionMode.IsSet(), "spec.completionMode", completionMode).
+		SetIf(backoffLimitPerIndex.IsSet(), "spec.backoffLimitPerIndex", backoffLimitPerIndex).
+		SetIf(maxFailedIndexes.IsSet(), "spec.maxFailedIndexes", maxFailedIndexes).
+		SetIf(podFailurePolicy.IsSet(), "spec.podFailurePolicy.rules", podFailurePolicy).
+		SetIf(ttlSecondsAfterFinished.IsSet(), "spec.ttlSecondsAfterFinished", ttlSecondsAfterFinished).
+		SetIf(activeDeadlineSeconds.IsSet(), "spec.activeDeadlineSeconds", activeDeadlineSeconds).
+		SetIf(suspend.IsSet(), "spec.suspend", suspend).
+		SetIf(podReplacementPolicy.IsSet(), "spec.podReplacementPolicy", podReplacementPolicy)
+
+	tpl.Output(taskPodTemplate(job, "spec.template").
+		SetIf(sidecars.LenGt(0), "spec.template.spec.initContainers", NativeSidecars()))
+}
+
+// taskPodTemplate sets the pod template of a task-style workload at prefix,
+// like "spec.template" for a Job. The pod runs a single container built from
+// the image, cmd, env, resource and volumes parameters.
+func taskPodTemplate(r *defkit.Resource, prefix string) *defkit.Resource {
+	vela := defkit.VelaCtx()
+
 	labels := defkit.StringKeyMap("labels")
 	annotations := defkit.StringKeyMap("annotations")
-	count := defkit.Int("count")
 	image := defkit.String("image")
 	imagePullPolicy := defkit.String("imagePullPolicy")
 	imagePullSecrets := defkit.StringList("imagePullSecrets")
@@ -141,34 +186,31 @@ func taskTemplate(tpl *defkit.Template) {
 	memory := defkit.String("memory")
 	volumes := defkit.List("volumes")
 
-	job := defkit.NewResource("batch/v1", "Job").
-		Set("metadata.name", defkit.Interpolation(vela.AppName(), defkit.Lit("-"), vela.Name())).
-		Set("spec.parallelism", count).
-		Set("spec.completions", count).
-		SpreadIf(labels.IsSet(), "spec.template.metadata.labels", labels).
-		Set("spec.template.metadata.labels[app.oam.dev/name]", vela.AppName()).
-		Set("spec.template.metadata.labels[app.oam.dev/component]", vela.Name()).
-		SetIf(annotations.IsSet(), "spec.template.metadata.annotations", annotations).
-		Set("spec.template.spec.restartPolicy", restart).
-		Set("spec.template.spec.containers[0].name", vela.Name()).
-		Set("spec.template.spec.containers[0].image", image).
-		SetIf(imagePullPolicy.IsSet(), "spec.template.spec.containers[0].imagePullPolicy", imagePullPolicy).
-		SetIf(cmd.IsSet(), "spec.template.spec.containers[0].command", cmd).
-		SetIf(env.IsSet(), "spec.template.spec.containers[0].env", env).
+	return r.
+		SpreadIf(labels.IsSet(), prefix+".metadata.labels", labels).
+		Set(prefix+".metadata.labels[app.oam.dev/name]", vela.AppName()).
+		Set(prefix+".metadata.labels[app.oam.dev/component]", vela.Name()).
+		SetIf(annotations.IsSet(), prefix+".metadata.annotations", annotations).
+		Set(prefix+".spec.restartPolicy", restart).
+		Set(prefix+".spec.containers[0].name", vela.Name()).
+		Set(prefix+".spec.containers[0].image", image).
+		SetIf(imagePullPolicy.IsSet(), prefix+".spec.containers[0].imagePullPolicy", imagePullPolicy).
+		SetIf(cmd.IsSet(), prefix+".spec.containers[0].command", cmd).
+		SetIf(env.IsSet(), prefix+".spec.containers[0].env", env).
 		If(cpu.IsSet()).
-		Set("spec.template.spec.containers[0].resources.limits.cpu", cpu).
-		Set("spec.template.spec.containers[0].resources.requests.cpu", cpu).
+		Set(prefix+".spec.containers[0].resources.limits.cpu", cpu).
+		Set(prefix+".spec.containers[0].resources.requests.cpu", cpu).
 		EndIf().
 		If(memory.IsSet()).
-		Set("spec.template.spec.containers[0].resources.limits.memory", memory).
-		Set("spec.template.spec.containers[0].resources.requests.memory", memory).
+		Set(prefix+".spec.containers[0].resources.limits.memory", memory).
+		Set(prefix+".spec.containers[0].resources.requests.memory", memory).
 		EndIf().
-		SetIf(volumes.IsSet(), "spec.template.spec.containers[0].volumeMounts",
+		SetIf(volumes.IsSet(), prefix+".spec.containers[0].volumeMounts",
 			defkit.Each(volumes).Map(defkit.FieldMap{
 				"mountPath": defkit.FieldRef("mountPath"),
 				"name":      defkit.FieldRef("name"),
 			})).
-		SetIf(volumes.IsSet(), "spec.template.spec.volumes",
+		SetIf(volumes.IsSet(), prefix+".spec.volumes",
 			defkit.Each(volumes).
 				Map(defkit.FieldMap{
 					"name": defkit.FieldRef("name"),
@@ -197,10 +239,8 @@ func taskTemplate(tpl *defkit.Template) {
 						"medium": defkit.FieldRef("medium"),
 					}),
 				})).
-		SetIf(imagePullSecrets.IsSet(), "spec.template.spec.imagePullSecrets",
+		SetIf(imagePullSecrets.IsSet(), prefix+".spec.imagePullSecrets",
 			ImagePullSecretsTransform(imagePullSecrets))
-
-	tpl.Output(job)
 }
 
 func init() {
@@ -17,12 +17,12 @@ limitations under the License.
 package components_test
 
 import (
-	"strings"
-
+	"cuelang.org/go/cue"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/oam-dev/vela-go-definitions/components"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 
 	"github.com/oam-dev/kubevela/pkg/definition/defkit"
 	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
@@ -102,10 +102,12 @@ var _ = Describe("Task Component", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			comp := components.Task()
 			cueOutput = comp.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -116,13 +118,13 @@ var _ = Describe("Task Component", func() {
 		})
 
 		It("should generate correct workload definition", func() {
-			Expect(cueOutput).To(ContainSubstring(`apiVersion: "batch/v1"`))
-			Expect(cueOutput).To(ContainSubstring(`kind:       "Job"`))
+			Expect(doc.Lookup("output.apiVersion")).To(cueassert.HaveValue(`"batch/v1"`))
+			Expect(doc.Lookup("output.kind")).To(cueassert.HaveValue(`"Job"`))
 			Expect(cueOutput).To(ContainSubstring(`type: "jobs.batch"`))
 		})
 
 		It("should generate metadata.name with interpolation", func() {
-			Expect(cueOutput).To(ContainSubstring(`name: "\(context.appName)-\(context.name)"`))
+			Expect(doc.Lookup("output.metadata.name")).To(cueassert.HaveValue(`"\(context.appName)-\(context.name)"`))
 		})
 
 		It("should generate labels with StringKeyMap type", func() {
@@ -135,12 +137,12 @@ var _ = Describe("Task Component", func() {
 
 		It("should generate count with default and short directive", func() {
 			Expect(cueOutput).To(ContainSubstring("// +short=c"))
-			Expect(cueOutput).To(ContainSubstring("count: *1 | int"))
+			Expect(doc.Lookup("parameter.count")).To(SatisfyAll(cueassert.HaveDefault(1), cueassert.HaveType("int")))
 		})
 
 		It("should generate image with short directive", func() {
 			Expect(cueOutput).To(ContainSubstring("// +short=i"))
-			Expect(cueOutput).To(ContainSubstring("image: string"))
+			Expect(doc.Lookup("parameter.image")).To(cueassert.HaveValue("string"))
 		})
 
 		It("should generate imagePullPolicy as enum", func() {
@@ -168,8 +170,8 @@ var _ = Describe("Task Component", func() {
 		})
 
 		It("should generate livenessProbe and readinessProbe referencing HealthProbe", func() {
-			Expect(cueOutput).To(ContainSubstring("livenessProbe?: #HealthProbe"))
-			Expect(cueOutput).To(ContainSubstring("readinessProbe?: #HealthProbe"))
+			Expect(doc.Lookup("parameter.livenessProbe")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("#HealthProbe")))
+			Expect(doc.Lookup("parameter.readinessProbe")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("#HealthProbe")))
 		})
 
 		It("should generate HealthProbe helper definition", func() {
@@ -177,17 +179,11 @@ var _ = Describe("Task Component", func() {
 		})
 
 		It("should NOT generate args parameter", func() {
-			paramIdx := strings.Index(cueOutput, "\tparameter: {")
-			Expect(paramIdx).To(BeNumerically(">", 0))
-			paramSection := cueOutput[paramIdx:]
-			Expect(paramSection).NotTo(ContainSubstring("args?:"))
+			Expect(doc.Parameter().Field("args")).NotTo(cueassert.Exist())
 		})
 
 		It("should NOT generate volumeMounts parameter", func() {
-			paramIdx := strings.Index(cueOutput, "\tparameter: {")
-			Expect(paramIdx).To(BeNumerically(">", 0))
-			paramSection := cueOutput[paramIdx:]
-			Expect(paramSection).NotTo(ContainSubstring("volumeMounts?:"))
+			Expect(doc.Parameter().Field("volumeMounts")).NotTo(cueassert.Exist())
 		})
 
 		It("should generate conditional resources block for cpu and memory", func() {
@@ -209,14 +205,11 @@ var _ = Describe("Task Component", func() {
 		})
 
 		It("should NOT generate probe passthrough in template", func() {
-			// The template should NOT have livenessProbe or readinessProbe SetIf
-			outputIdx := strings.Index(cueOutput, "output: {")
-			Expect(outputIdx).To(BeNumerically(">", 0))
-			paramIdx := strings.Index(cueOutput, "\tparameter: {")
-			Expect(paramIdx).To(BeNumerically(">", 0))
-			templateSection := cueOutput[outputIdx:paramIdx]
-			Expect(templateSection).NotTo(ContainSubstring("livenessProbe:"))
-			Expect(templateSection).NotTo(ContainSubstring("readinessProbe:"))
+			// The main container should NOT have livenessProbe or readinessProbe SetIf
+			output := doc.Output()
+			Expect(output.Exists()).To(BeTrue())
+			Expect(output.Source()).NotTo(ContainSubstring("livenessProbe: parameter.livenessProbe"))
+			Expect(output.Source()).NotTo(ContainSubstring("readinessProbe: parameter.readinessProbe"))
 		})
 
 		It("should NOT generate helper arrays", func() {
@@ -243,5 +236,50 @@ var _ = Describe("Task Component", func() {
 			Expect(cueOutput).To(ContainSubstring("for v in parameter.imagePullSecrets"))
 			Expect(cueOutput).To(ContainSubstring("name: v"))
 		})
+
+		It("should generate the Job controls as optional parameters", func() {
+			for _, name := range []string{"parallelism", "completions", "completionMode", "backoffLimitPerIndex", "maxFailedIndexes", "podFailurePolicy", "ttlSecondsAfterFinished", "activeDeadlineSeconds", "suspend", "podReplacementPolicy"} {
+				Expect(doc.Lookup("parameter." + name)).To(cueassert.BeOptionalField())
+			}
+			Expect(doc.Lookup("parameter.podFailurePolicy.action")).To(cueassert.BeRequiredField())
+			Expect(doc.Lookup("parameter.podFailurePolicy.onExitCodes.values")).To(cueassert.HaveType("[...int]"))
+			Expect(doc.Lookup("parameter.podFailurePolicy.onPodConditions.status")).To(cueassert.HaveDefault("True"))
+			Expect(doc.UntypedLists()).To(BeEmpty())
+		})
+
+		It("should fall back to count for parallelism and completions", func() {
+			Expect(doc.Output().Source()).To(SatisfyAll(
+				ContainSubstring(`if parameter["parallelism"] == _|_ {`),
+				ContainSubstring("parallelism: parameter.count"),
+				ContainSubstring(`if parameter["completions"] == _|_ {`),
+				ContainSubstring("completions: parameter.count"),
+				ContainSubstring("rules: parameter.podFailurePolicy"),
+			))
+		})
+	})
+
+	Describe("Status", func() {
+		DescribeTable("health",
+			func(output string, healthy bool) {
+				v := evalStatus(components.Task().GetHealthPolicy(), output)
+				Expect(v.LookupPath(cue.ParsePath("isHealth")).Bool()).To(Equal(healthy))
+			},
+			Entry("all completions succeeded", `{spec: {parallelism: 2, completions: 4}, status: {succeeded: 4}}`, true),
+			Entry("only a batch of parallel pods succeeded", `{spec: {parallelism: 2, completions: 4}, status: {succeeded: 2}}`, false),
+			Entry("no status reported yet", `{spec: {parallelism: 1, completions: 1}}`, false),
+		)
+
+		DescribeTable("message",
+			func(output, message string) {
+				v := evalStatus(components.Task().GetCustomStatus(), output)
+				Expect(v.LookupPath(cue.ParsePath("message")).String()).To(Equal(message))
+			},
+			Entry("non-indexed job", `{status: {active: 1, succeeded: 2}}`, "Active/Failed/Succeeded:1/0/2"),
+			Entry("indexed job", `{status: {active: 1, failed: 1, succeeded: 3, completedIndexes: "0-2"}}`,
+				"Active/Failed/Succeeded:1/1/3, completed indexes: 0-2"),
+			Entry("indexed job with failed indexes", `{status: {failed: 2, succeeded: 2, completedIndexes: "0,2", failedIndexes: "1,3"}}`,
+				"Active/Failed/Succeeded:0/2/2, completed indexes: 0,2, failed indexes: 1,3"),
+			Entry("no status reported yet", `{spec: {}}`, "Active/Failed/Succeeded:0/0/0"),
+		)
 	})
 })
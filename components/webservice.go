@@ -57,8 +57,9 @@ func Webservice() *defkit.ComponentDefinition {
 			defkit.String("name").Optional().Description("Name of the port"),
 			defkit.Enum("protocol").Values("TCP", "UDP", "SCTP").Default("TCP").Description("Protocol for port. Must be UDP, TCP, or SCTP"),
 			defkit.Bool("expose").Default(false).Description("Specify if the port should be exposed"),
-			defkit.Int("nodePort").Optional().Description("exposed node port. Only Valid when exposeType is NodePort"),
-		)
+			defkit.Int("nodePort").Optional().Description("exposed node port. Only Valid when the Service of the port is NodePort"),
+		).
+		WithFields(ServicePortFields()...)
 
 	exposeType := defkit.Enum("exposeType").
 		Values("ClusterIP", "NodePort", "LoadBalancer").
@@ -66,6 +67,9 @@ func Webservice() *defkit.ComponentDefinition {
 		Ignore().
 		Description(`Specify what kind of Service you want. options: "ClusterIP", "NodePort", "LoadBalancer"`)
 
+	// Ingress or HTTPRoute to an exposed HTTP port
+	route := RouteParam()
+
 	addRevisionLabel := defkit.Bool("addRevisionLabel").
 		Default(false).
 		Ignore().
@@ -75,26 +79,7 @@ func Webservice() *defkit.ComponentDefinition {
 	args := defkit.StringList("args").Optional().Description("Arguments to the entrypoint")
 
 	// Structured env array with detailed valueFrom schema
-	env := defkit.List("env").
-		Optional().
-		Description("Define arguments by using environment variables").
-		WithFields(
-			defkit.String("name").Description("Environment variable name"),
-			defkit.String("value").Optional().Description("The value of the environment variable"),
-			defkit.Object("valueFrom").Optional().Description("Specifies a source the value of this var should come from").
-				WithFields(
-					defkit.Object("secretKeyRef").Optional().Description("Selects a key of a secret in the pod's namespace").
-						WithFields(
-							defkit.String("name").Description("The name of the secret in the pod's namespace to select from"),
-							defkit.String("key").Description("The key of the secret to select from. Must be a valid secret key"),
-						),
-					defkit.Object("configMapKeyRef").Optional().Description("Selects a key of a config map in the pod's namespace").
-						WithFields(
-							defkit.String("name").Description("The name of the config map in the pod's namespace to select from"),
-							defkit.String("key").Description("The key of the config map to select from. Must be a valid secret key"),
-						),
-				),
-		)
+	env := ContainerEnvParam()
 
 	cpu := defkit.String("cpu").Optional().Description("Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)")
 	memory := defkit.String("memory").Optional().Description("Specifies the attributes of the memory resource required for the container.")
@@ -106,52 +91,7 @@ func Webservice() *defkit.ComponentDefinition {
 	)
 
 	// VolumeMounts with subPath support, no mountPropagation/readOnly on hostPath
-	volumeMounts := defkit.Object("volumeMounts").
-		Optional().
-		WithFields(
-			defkit.List("pvc").Optional().Description("Mount PVC type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.String("subPath").Optional(),
-				defkit.String("claimName").Description("The name of the PVC"),
-			),
-			defkit.List("configMap").Optional().Description("Mount ConfigMap type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.String("subPath").Optional(),
-				defkit.Int("defaultMode").Default(420),
-				defkit.String("cmName"),
-				defkit.List("items").Optional().WithFields(
-					defkit.String("key"),
-					defkit.String("path"),
-					defkit.Int("mode").Default(511),
-				),
-			),
-			defkit.List("secret").Optional().Description("Mount Secret type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.String("subPath").Optional(),
-				defkit.Int("defaultMode").Default(420),
-				defkit.String("secretName"),
-				defkit.List("items").Optional().WithFields(
-					defkit.String("key"),
-					defkit.String("path"),
-					defkit.Int("mode").Default(511),
-				),
-			),
-			defkit.List("emptyDir").Optional().Description("Mount EmptyDir type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.String("subPath").Optional(),
-				defkit.Enum("medium").Values("", "Memory").Default(""),
-			),
-			defkit.List("hostPath").Optional().Description("Mount HostPath type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.String("subPath").Optional(),
-				defkit.String("path"),
-			),
-		)
+	volumeMounts := VolumeMountsParam(volumeMountSources...)
 
 	// Deprecated volumes parameter - discriminated union with type-based conditional fields
 	volumes := defkit.List("volumes").Optional().Description("Deprecated field, use volumeMounts instead.").
@@ -212,6 +152,12 @@ func Webservice() *defkit.ComponentDefinition {
 			defkit.StringList("hostnames"),
 		)
 
+	// Additional containers sharing the pod volumes with the main container
+	containers := ContainersParam()
+
+	// Sidecars starting before and stopping after the main container
+	sidecars := SidecarsParam()
+
 	return defkit.NewComponent("webservice").
 		Description("Describes long-running, scalable, containerized services that have a stable network endpoint to receive external network traffic from customers.").
 		Workload("apps/v1", "Deployment").
@@ -222,12 +168,15 @@ func Webservice() *defkit.ComponentDefinition {
 			labels, annotations,
 			image, imagePullPolicy, imagePullSecrets,
 			port, // deprecated
-			ports, exposeType, addRevisionLabel,
+			ports, exposeType, route, addRevisionLabel,
 			cmd, args, env,
 			cpu, memory, limit, volumeMounts, volumes,
 			livenessProbe, readinessProbe, hostAliases,
+			containers, sidecars,
 		).
+		Params(DeploymentSettingsParams()...).
 		Helper("HealthProbe", HealthProbeParam()).
+		Validators(ExposeValidators()...).
 		Template(webserviceTemplate)
 }
 
@@ -237,7 +186,6 @@ func webserviceTemplate(tpl *defkit.Template) {
 	image := defkit.String("image")
 	port := defkit.Int("port")
 	ports := defkit.List("ports")
-	exposeType := defkit.String("exposeType")
 	addRevisionLabel := defkit.Bool("addRevisionLabel")
 	cmd := defkit.StringList("cmd")
 	args := defkit.StringList("args")
@@ -253,6 +201,7 @@ func webserviceTemplate(tpl *defkit.Template) {
 	annotations := defkit.Object("annotations")
 	imagePullPolicy := defkit.String("imagePullPolicy")
 	imagePullSecrets := defkit.StringList("imagePullSecrets")
+	sidecars := defkit.List("sidecars")
 
 	// Transform ports to container format using ForEachWith for complex
 	// _name let binding with containerPort preference and protocol suffix.
@@ -349,61 +298,61 @@ func webserviceTemplate(tpl *defkit.Template) {
 	// Suppress unused variable warnings (helpers are registered and referenced by name)
 	_ = volumesList
 
-	// Primary output: Deployment
-	deployment := defkit.NewResource("apps/v1", "Deployment").
-		Set("spec.selector.matchLabels[app.oam.dev/component]", vela.Name()).
-		// Labels block always includes OAM labels; user labels are spread inside when set
-		Set("spec.template.metadata.labels[app.oam.dev/name]", vela.AppName()).
-		Set("spec.template.metadata.labels[app.oam.dev/component]", vela.Name()).
-		// Use IsTrue() to generate `if parameter.addRevisionLabel` (truthy check)
-		SetIf(addRevisionLabel.IsTrue(), "spec.template.metadata.labels[app.oam.dev/revision]", vela.Revision()).
-		// SpreadIf spreads user labels inside the labels block
-		SpreadIf(labels.IsSet(), "spec.template.metadata.labels", labels).
-		SetIf(annotations.IsSet(), "spec.template.metadata.annotations", annotations).
-		// Container spec
-		Set("spec.template.spec.containers[0].name", vela.Name()).
-		Set("spec.template.spec.containers[0].image", image).
+	// Primary container built from the top-level parameters
+	mainContainer := defkit.NewArrayElement().
+		Set("name", vela.Name()).
+		Set("image", image).
 		// Deprecated port fallback (before modern ports)
-		If(defkit.And(port.IsSet(), ports.NotSet())).
-		Set("spec.template.spec.containers[0].ports", defkit.InlineArray(map[string]defkit.Value{
+		SetIf(defkit.And(port.IsSet(), ports.NotSet()), "ports", defkit.InlineArray(map[string]defkit.Value{
 			"containerPort": port,
 		})).
-		EndIf().
-		SetIf(ports.IsSet(), "spec.template.spec.containers[0].ports", containerPorts).
-		SetIf(imagePullPolicy.IsSet(), "spec.template.spec.containers[0].imagePullPolicy", imagePullPolicy).
-		SetIf(cmd.IsSet(), "spec.template.spec.containers[0].command", cmd).
-		SetIf(args.IsSet(), "spec.template.spec.containers[0].args", args).
-		SetIf(env.IsSet(), "spec.template.spec.containers[0].env", env).
-		SetIf(defkit.PathExists(`context["config"]`), "spec.template.spec.containers[0].env", defkit.Reference("context.config")).
+		SetIf(ports.IsSet(), "ports", containerPorts).
+		SetIf(imagePullPolicy.IsSet(), "imagePullPolicy", imagePullPolicy).
+		SetIf(cmd.IsSet(), "command", cmd).
+		SetIf(args.IsSet(), "args", args).
+		SetIf(env.IsSet(), "env", env).
+		SetIf(defkit.PathExists(`context["config"]`), "env", defkit.Reference("context.config")).
 		// CPU with limit branching: when limit.cpu is set, use it for limits; otherwise use cpu for both
 		SetIf(defkit.And(cpu.IsSet(), defkit.PathExists("parameter.limit.cpu")),
-			"spec.template.spec.containers[0].resources.requests.cpu", cpu).
+			"resources.requests.cpu", cpu).
 		SetIf(defkit.And(cpu.IsSet(), defkit.PathExists("parameter.limit.cpu")),
-			"spec.template.spec.containers[0].resources.limits.cpu", defkit.Reference("parameter.limit.cpu")).
+			"resources.limits.cpu", defkit.Reference("parameter.limit.cpu")).
 		SetIf(defkit.And(cpu.IsSet(), defkit.Not(defkit.PathExists("parameter.limit.cpu"))),
-			"spec.template.spec.containers[0].resources.limits.cpu", cpu).
+			"resources.limits.cpu", cpu).
 		SetIf(defkit.And(cpu.IsSet(), defkit.Not(defkit.PathExists("parameter.limit.cpu"))),
-			"spec.template.spec.containers[0].resources.requests.cpu", cpu).
+			"resources.requests.cpu", cpu).
 		// Memory with limit branching: when limit.memory is set, use it for limits; otherwise use memory for both
 		SetIf(defkit.And(memory.IsSet(), defkit.PathExists("parameter.limit.memory")),
-			"spec.template.spec.containers[0].resources.limits.memory", defkit.Reference("parameter.limit.memory")).
+			"resources.limits.memory", defkit.Reference("parameter.limit.memory")).
 		SetIf(defkit.And(memory.IsSet(), defkit.PathExists("parameter.limit.memory")),
-			"spec.template.spec.containers[0].resources.requests.memory", memory).
+			"resources.requests.memory", memory).
 		SetIf(defkit.And(memory.IsSet(), defkit.Not(defkit.PathExists("parameter.limit.memory"))),
-			"spec.template.spec.containers[0].resources.limits.memory", memory).
+			"resources.limits.memory", memory).
 		SetIf(defkit.And(memory.IsSet(), defkit.Not(defkit.PathExists("parameter.limit.memory"))),
-			"spec.template.spec.containers[0].resources.requests.memory", memory).
+			"resources.requests.memory", memory).
 		// Deprecated volumes fallback - container volumeMounts
-		If(defkit.And(volumes.IsSet(), volumeMounts.NotSet())).
-		Set("spec.template.spec.containers[0].volumeMounts",
+		SetIf(defkit.And(volumes.IsSet(), volumeMounts.NotSet()), "volumeMounts",
 			defkit.Each(volumes).Map(defkit.FieldMap{
 				"mountPath": defkit.FieldRef("mountPath"),
 				"name":      defkit.FieldRef("name"),
 			})).
-		EndIf().
-		SetIf(volumeMounts.IsSet(), "spec.template.spec.containers[0].volumeMounts", mountsArray).
-		SetIf(livenessProbe.IsSet(), "spec.template.spec.containers[0].livenessProbe", livenessProbe).
-		SetIf(readinessProbe.IsSet(), "spec.template.spec.containers[0].readinessProbe", readinessProbe).
+		SetIf(volumeMounts.IsSet(), "volumeMounts", mountsArray).
+		SetIf(livenessProbe.IsSet(), "livenessProbe", livenessProbe).
+		SetIf(readinessProbe.IsSet(), "readinessProbe", readinessProbe)
+
+	// Primary output: Deployment
+	deployment := defkit.NewResource("apps/v1", "Deployment").
+		Set("spec.selector.matchLabels[app.oam.dev/component]", vela.Name()).
+		// Labels block always includes OAM labels; user labels are spread inside when set
+		Set("spec.template.metadata.labels[app.oam.dev/name]", vela.AppName()).
+		Set("spec.template.metadata.labels[app.oam.dev/component]", vela.Name()).
+		// Use IsTrue() to generate `if parameter.addRevisionLabel` (truthy check)
+		SetIf(addRevisionLabel.IsTrue(), "spec.template.metadata.labels[app.oam.dev/revision]", vela.Revision()).
+		// SpreadIf spreads user labels inside the labels block
+		SpreadIf(labels.IsSet(), "spec.template.metadata.labels", labels).
+		SetIf(annotations.IsSet(), "spec.template.metadata.annotations", annotations).
+		Set("spec.template.spec.containers", AdditionalContainers(defkit.NewArray().Item(mainContainer))).
+		SetIf(defkit.And(sidecars.LenGt(0), NativeSidecarsSupported()), "spec.template.spec.initContainers", NativeSidecars()).
 		// Pod spec
 		SetIf(hostAliases.IsSet(), "spec.template.spec.hostAliases", hostAliases).
 		Directive("spec.template.spec.hostAliases", "patchKey=ip").
@@ -442,80 +391,11 @@ func webserviceTemplate(tpl *defkit.Template) {
 		EndIf().
 		SetIf(volumeMounts.IsSet(), "spec.template.spec.volumes", deDupVolumesArray)
 
-	tpl.Output(deployment)
-
-	// exposePorts helper: Complex iteration with guard, filter, conditionals,
-	// _name let binding with containerPort preference, and protocol suffix.
-	// Uses FromArray with ForEachWithGuardedFiltered for full expressiveness.
-	exposePortsArray := defkit.NewArray().ForEachWithGuardedFiltered(
-		ports.IsSet(),
-		defkit.FieldEquals("expose", true),
-		ports,
-		func(item *defkit.ItemBuilder) {
-			v := item.Var()
-
-			item.Set("port", v.Field("port"))
-
-			// targetPort: prefer containerPort, fall back to port
-			item.IfSet("containerPort", func() {
-				item.Set("targetPort", v.Field("containerPort"))
-			})
-			item.IfNotSet("containerPort", func() {
-				item.Set("targetPort", v.Field("port"))
-			})
-
-			// name: use v.name if set
-			item.IfSet("name", func() {
-				item.Set("name", v.Field("name"))
-			})
-
-			// Complex name fallback: _name with containerPort preference + protocol suffix
-			item.IfNotSet("name", func() {
-				item.IfSet("containerPort", func() {
-					nameRef := item.Let("_name",
-						defkit.Plus(defkit.Lit("port-"), defkit.StrconvFormatInt(v.Field("containerPort"), 10)))
-					item.SetDefault("name", nameRef, "string")
-					item.If(defkit.Ne(v.Field("protocol"), defkit.Lit("TCP")), func() {
-						item.Set("name", defkit.Plus(nameRef, defkit.Lit("-"), defkit.StringsToLower(v.Field("protocol"))))
-					})
-				})
-				item.IfNotSet("containerPort", func() {
-					nameRef := item.Let("_name",
-						defkit.Plus(defkit.Lit("port-"), defkit.StrconvFormatInt(v.Field("port"), 10)))
-					item.SetDefault("name", nameRef, "string")
-					item.If(defkit.Ne(v.Field("protocol"), defkit.Lit("TCP")), func() {
-						item.Set("name", defkit.Plus(nameRef, defkit.Lit("-"), defkit.StringsToLower(v.Field("protocol"))))
-					})
-				})
-			})
-
-			// nodePort: compound conditional
-			item.IfSet("nodePort", func() {
-				item.If(defkit.Eq(exposeType, defkit.Lit("NodePort")), func() {
-					item.Set("nodePort", v.Field("nodePort"))
-				})
-			})
-
-			// protocol: optional
-			item.IfSet("protocol", func() {
-				item.Set("protocol", v.Field("protocol"))
-			})
-		},
-	)
-
-	exposePorts := tpl.Helper("exposePorts").
-		FromArray(exposePortsArray).
-		AfterOutput().
-		Build()
-
-	// Auxiliary output: Service (only if there are exposed ports)
-	service := defkit.NewResource("v1", "Service").
-		Set("metadata.name", vela.Name()).
-		Set("spec.selector[app.oam.dev/component]", vela.Name()).
-		Set("spec.ports", exposePorts).
-		Set("spec.type", exposeType)
+	tpl.Output(DeploymentSettings(deployment))
+	IntOrPercent(tpl)
 
-	tpl.OutputsIf(exposePorts.NotEmpty(), "webserviceExpose", service)
+	// Services of the exposed ports, and the route to one of them
+	ExposeServices(tpl, "webserviceExpose", ServicePort)
 }
 
 func init() {
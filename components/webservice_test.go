@@ -21,8 +21,10 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/oam-dev/vela-go-definitions/components"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 
 	"github.com/oam-dev/kubevela/pkg/definition/defkit"
 	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
@@ -56,7 +58,7 @@ var _ = Describe("Webservice Component", func() {
 				"cmd", "args", "env",
 				"cpu", "memory", "limit", "volumeMounts", "volumes",
 				"livenessProbe", "readinessProbe", "hostAliases",
-				"labels", "annotations",
+				"labels", "annotations", "containers",
 			}
 			for _, param := range expectedParams {
 				Expect(comp).To(HaveParamNamed(param))
@@ -99,7 +101,7 @@ var _ = Describe("Webservice Component", func() {
 			Expect(rendered.APIVersion()).To(Equal("apps/v1"))
 			Expect(rendered.Kind()).To(Equal("Deployment"))
 			Expect(rendered.Get("metadata.name")).To(Equal("my-web"))
-			Expect(rendered.Get("spec.template.spec.containers[0].image")).To(Equal("nginx:latest"))
+			Expect(evalContainers(comp, `{image: "nginx:latest"}`)[0].Image).To(Equal("nginx:latest"))
 		})
 
 		It("should render webservice with ports", func() {
@@ -113,9 +115,8 @@ var _ = Describe("Webservice Component", func() {
 			)
 
 			Expect(rendered.Kind()).To(Equal("Deployment"))
-			// Ports are set through ForEach transform which Render can't fully evaluate,
-			// so we verify the ports field is populated (CUE generation tests verify structure)
-			Expect(rendered.Get("spec.template.spec.containers[0].ports")).NotTo(BeNil())
+			containers := evalContainers(comp, `{image: "nginx:latest", ports: [{port: 80, protocol: "TCP"}]}`)
+			Expect(containers[0].Ports).To(ConsistOf(corev1.ContainerPort{Name: "port-80", ContainerPort: 80, Protocol: corev1.ProtocolTCP}))
 		})
 
 		It("should render webservice with exposeType", func() {
@@ -146,9 +147,8 @@ var _ = Describe("Webservice Component", func() {
 			)
 
 			Expect(rendered.Kind()).To(Equal("Deployment"))
-			// Env is set through ForEach transform which Render can't fully evaluate,
-			// so we verify the env field is populated (CUE generation tests verify structure)
-			Expect(rendered.Get("spec.template.spec.containers[0].env")).NotTo(BeNil())
+			containers := evalContainers(comp, `{image: "nginx:latest", env: [{name: "LOG_LEVEL", value: "debug"}, {name: "DB_HOST", value: "localhost"}]}`)
+			Expect(containers[0].Env).To(HaveLen(2))
 		})
 
 		It("should render webservice with resource limits", func() {
@@ -161,8 +161,9 @@ var _ = Describe("Webservice Component", func() {
 			)
 
 			Expect(rendered.Kind()).To(Equal("Deployment"))
-			Expect(rendered.Get("spec.template.spec.containers[0].resources.requests.cpu")).To(Equal("100m"))
-			Expect(rendered.Get("spec.template.spec.containers[0].resources.requests.memory")).To(Equal("128Mi"))
+			resources := evalContainers(comp, `{image: "nginx:latest", cpu: "100m", memory: "128Mi"}`)[0].Resources
+			Expect(resources.Requests.Cpu().String()).To(Equal("100m"))
+			Expect(resources.Requests.Memory().String()).To(Equal("128Mi"))
 		})
 
 		It("should render webservice with command and args", func() {
@@ -175,8 +176,9 @@ var _ = Describe("Webservice Component", func() {
 			)
 
 			Expect(rendered.Kind()).To(Equal("Deployment"))
-			Expect(rendered.Get("spec.template.spec.containers[0].command")).To(Equal([]string{"nginx"}))
-			Expect(rendered.Get("spec.template.spec.containers[0].args")).To(Equal([]string{"-g", "daemon off;"}))
+			container := evalContainers(comp, `{image: "nginx:latest", cmd: ["nginx"], args: ["-g", "daemon off;"]}`)[0]
+			Expect(container.Command).To(Equal([]string{"nginx"}))
+			Expect(container.Args).To(Equal([]string{"-g", "daemon off;"}))
 		})
 
 		It("should render webservice with labels and annotations", func() {
@@ -204,7 +206,7 @@ var _ = Describe("Webservice Component", func() {
 			)
 
 			Expect(rendered.Kind()).To(Equal("Deployment"))
-			Expect(rendered.Get("spec.template.spec.containers[0].imagePullPolicy")).To(Equal("Always"))
+			Expect(evalContainers(comp, `{image: "nginx:latest", imagePullPolicy: "Always"}`)[0].ImagePullPolicy).To(Equal(corev1.PullAlways))
 		})
 
 		It("should render webservice with image pull secrets", func() {
@@ -274,14 +276,43 @@ var _ = Describe("Webservice Component", func() {
 			Expect(ok).To(BeTrue())
 			Expect(labelsMap["app.oam.dev/component"]).To(Equal("my-web"))
 		})
+
+		It("should append additional containers after the primary container", func() {
+			containers := evalContainers(comp, `{
+				image: "nginx:latest"
+				volumeMounts: emptyDir: [{name: "cache", mountPath: "/var/cache/nginx"}]
+				containers: [{
+					name:  "exporter"
+					image: "nginx/nginx-prometheus-exporter:1.1"
+					args:  ["--nginx.scrape-uri=http://localhost:80/stub_status"]
+					ports: [{containerPort: 9113, name: "metrics"}]
+					cpu:    "50m"
+					memory: "32Mi"
+					startupProbe: httpGet: {path: "/metrics", port: 9113}
+					volumeMounts: [{name: "cache", mountPath: "/cache", readOnly: true}]
+				}]
+			}`)
+			Expect(containers).To(HaveLen(2))
+			Expect(containers[0].Name).To(Equal("site"))
+			Expect(containers[0].Image).To(Equal("nginx:latest"))
+			Expect(containers[1].Name).To(Equal("exporter"))
+			Expect(containers[1].Args).To(Equal([]string{"--nginx.scrape-uri=http://localhost:80/stub_status"}))
+			Expect(containers[1].Ports).To(ConsistOf(corev1.ContainerPort{Name: "metrics", ContainerPort: 9113, Protocol: corev1.ProtocolTCP}))
+			Expect(containers[1].Resources.Limits.Cpu().String()).To(Equal("50m"))
+			Expect(containers[1].Resources.Requests.Memory().String()).To(Equal("32Mi"))
+			Expect(containers[1].StartupProbe.HTTPGet.Path).To(Equal("/metrics"))
+			Expect(containers[1].VolumeMounts).To(ConsistOf(corev1.VolumeMount{Name: "cache", MountPath: "/cache", ReadOnly: true}))
+		})
 	})
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			comp := components.Webservice()
 			cueOutput = comp.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -292,8 +323,8 @@ var _ = Describe("Webservice Component", func() {
 		})
 
 		It("should generate correct workload definition", func() {
-			Expect(cueOutput).To(ContainSubstring(`apiVersion: "apps/v1"`))
-			Expect(cueOutput).To(ContainSubstring(`kind:       "Deployment"`))
+			Expect(doc.Lookup("output.apiVersion")).To(cueassert.HaveValue(`"apps/v1"`))
+			Expect(doc.Lookup("output.kind")).To(cueassert.HaveValue(`"Deployment"`))
 			Expect(cueOutput).To(ContainSubstring(`type: "deployments.apps"`))
 		})
 
@@ -315,12 +346,12 @@ var _ = Describe("Webservice Component", func() {
 		It("should generate deprecated port parameter with ignore and short directives", func() {
 			Expect(cueOutput).To(ContainSubstring("// +ignore"))
 			Expect(cueOutput).To(ContainSubstring("// +short=p"))
-			Expect(cueOutput).To(ContainSubstring("port?: int"))
+			Expect(doc.Lookup("parameter.port")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("int")))
 		})
 
 		It("should generate image parameter with short directive", func() {
 			Expect(cueOutput).To(ContainSubstring("// +short=i"))
-			Expect(cueOutput).To(ContainSubstring("image: string"))
+			Expect(doc.Lookup("parameter.image")).To(cueassert.HaveValue("string"))
 		})
 
 		It("should generate ports parameter with containerPort and nodePort", func() {
@@ -329,11 +360,11 @@ var _ = Describe("Webservice Component", func() {
 		})
 
 		It("should generate args parameter", func() {
-			Expect(cueOutput).To(ContainSubstring("args?: [...string]"))
+			Expect(doc.Lookup("parameter.args")).To(cueassert.BeOptionalListOf("string"))
 		})
 
 		It("should generate addRevisionLabel with ignore directive", func() {
-			Expect(cueOutput).To(ContainSubstring("addRevisionLabel: *false | bool"))
+			Expect(doc.Lookup("parameter.addRevisionLabel")).To(SatisfyAll(cueassert.HaveDefault(false), cueassert.HaveType("bool")))
 		})
 
 		It("should generate exposeType with only 3 options", func() {
@@ -354,13 +385,13 @@ var _ = Describe("Webservice Component", func() {
 		})
 
 		It("should generate livenessProbe and readinessProbe referencing HealthProbe", func() {
-			Expect(cueOutput).To(ContainSubstring("livenessProbe?: #HealthProbe"))
-			Expect(cueOutput).To(ContainSubstring("readinessProbe?: #HealthProbe"))
+			Expect(doc.Lookup("parameter.livenessProbe")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("#HealthProbe")))
+			Expect(doc.Lookup("parameter.readinessProbe")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("#HealthProbe")))
 		})
 
 		It("should generate Deployment output", func() {
 			Expect(cueOutput).To(ContainSubstring(`output: {`))
-			Expect(cueOutput).To(ContainSubstring(`kind:       "Deployment"`))
+			Expect(doc.Lookup("output.kind")).To(cueassert.HaveValue(`"Deployment"`))
 		})
 
 		It("should generate webserviceExpose output", func() {
@@ -430,23 +461,10 @@ var _ = Describe("Webservice Component", func() {
 		})
 
 		It("should generate hostPath volumeMounts without mountPropagation or readOnly", func() {
-			// Extract the hostPath section from the parameter block
-			paramIdx := strings.Index(cueOutput, "\tparameter: {")
-			Expect(paramIdx).To(BeNumerically(">", 0))
-			paramSection := cueOutput[paramIdx:]
-
-			// hostPath should exist
-			Expect(paramSection).To(ContainSubstring("hostPath"))
-
-			// Find the hostPath section within volumeMounts parameters
-			hostPathIdx := strings.Index(paramSection, `hostPath`)
-			Expect(hostPathIdx).To(BeNumerically(">", 0))
-
-			// mountPropagation and readOnly should not appear in hostPath fields
-			// They may appear elsewhere, so we check the hostPath subsection
-			hostPathSection := paramSection[hostPathIdx : hostPathIdx+200]
-			Expect(hostPathSection).NotTo(ContainSubstring("mountPropagation"))
-			Expect(hostPathSection).NotTo(ContainSubstring("readOnly"))
+			hostPath := doc.Lookup("parameter.volumeMounts.hostPath").Struct()
+			Expect(hostPath.FieldNames()).To(ContainElements("name", "mountPath", "path"))
+			Expect(hostPath.Field("mountPropagation")).NotTo(cueassert.Exist())
+			Expect(hostPath.Field("readOnly")).NotTo(cueassert.Exist())
 		})
 	})
 })
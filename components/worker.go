@@ -143,6 +143,12 @@ func Worker() *defkit.ComponentDefinition {
 		Description("Instructions for assessing whether the container is in a suitable state to serve traffic.").
 		WithSchemaRef("HealthProbe")
 
+	// Additional containers sharing the pod volumes with the main container
+	containers := ContainersParam()
+
+	// Sidecars starting before and stopping after the main container
+	sidecars := SidecarsParam()
+
 	return defkit.NewComponent("worker").
 		Description("Describes long-running, scalable, containerized services that running at backend. They do NOT have network endpoint to receive external network traffic.").
 		Workload("apps/v1", "Deployment").
@@ -164,7 +170,9 @@ func Worker() *defkit.ComponentDefinition {
 			cmd, env,
 			cpu, memory, volumeMounts, volumes,
 			livenessProbe, readinessProbe,
+			containers, sidecars,
 		).
+		Params(DeploymentSettingsParams()...).
 		Helper("HealthProbe", workerHealthProbeParam()).
 		Template(workerTemplate)
 }
@@ -183,6 +191,7 @@ func workerTemplate(tpl *defkit.Template) {
 	readinessProbe := defkit.Object("readinessProbe")
 	imagePullPolicy := defkit.String("imagePullPolicy")
 	imagePullSecrets := defkit.StringList("imagePullSecrets")
+	sidecars := defkit.List("sidecars")
 
 	// Transform imagePullSecrets
 	pullSecrets := ImagePullSecretsTransform(imagePullSecrets)
@@ -235,30 +244,33 @@ func workerTemplate(tpl *defkit.Template) {
 	// Suppress unused variable warnings
 	_ = volumesList
 
+	// Primary container built from the top-level parameters
+	mainContainer := defkit.NewArrayElement().
+		Set("name", vela.Name()).
+		Set("image", image).
+		SetIf(imagePullPolicy.IsSet(), "imagePullPolicy", imagePullPolicy).
+		SetIf(cmd.IsSet(), "command", cmd).
+		SetIf(env.IsSet(), "env", env).
+		SetIf(cpu.IsSet(), "resources.limits.cpu", cpu).
+		SetIf(cpu.IsSet(), "resources.requests.cpu", cpu).
+		SetIf(memory.IsSet(), "resources.limits.memory", memory).
+		SetIf(memory.IsSet(), "resources.requests.memory", memory).
+		SetIf(defkit.And(volumes.IsSet(), volumeMounts.NotSet()), "volumeMounts",
+			defkit.Each(volumes).Map(defkit.FieldMap{
+				"mountPath": defkit.FieldRef("mountPath"),
+				"name":      defkit.FieldRef("name"),
+			})).
+		SetIf(volumeMounts.IsSet(), "volumeMounts", mountsArray).
+		SetIf(livenessProbe.IsSet(), "livenessProbe", livenessProbe).
+		SetIf(readinessProbe.IsSet(), "readinessProbe", readinessProbe)
+
 	// Primary output: Deployment
 	deployment := defkit.NewResource("apps/v1", "Deployment").
 		Set("spec.selector.matchLabels[app.oam.dev/component]", vela.Name()).
 		Set("spec.template.metadata.labels[app.oam.dev/name]", vela.AppName()).
 		Set("spec.template.metadata.labels[app.oam.dev/component]", vela.Name()).
-		Set("spec.template.spec.containers[0].name", vela.Name()).
-		Set("spec.template.spec.containers[0].image", image).
-		SetIf(imagePullPolicy.IsSet(), "spec.template.spec.containers[0].imagePullPolicy", imagePullPolicy).
-		SetIf(cmd.IsSet(), "spec.template.spec.containers[0].command", cmd).
-		SetIf(env.IsSet(), "spec.template.spec.containers[0].env", env).
-		SetIf(cpu.IsSet(), "spec.template.spec.containers[0].resources.limits.cpu", cpu).
-		SetIf(cpu.IsSet(), "spec.template.spec.containers[0].resources.requests.cpu", cpu).
-		SetIf(memory.IsSet(), "spec.template.spec.containers[0].resources.limits.memory", memory).
-		SetIf(memory.IsSet(), "spec.template.spec.containers[0].resources.requests.memory", memory).
-		If(defkit.And(volumes.IsSet(), volumeMounts.NotSet())).
-		Set("spec.template.spec.containers[0].volumeMounts",
-			defkit.Each(volumes).Map(defkit.FieldMap{
-				"mountPath": defkit.FieldRef("mountPath"),
-				"name":      defkit.FieldRef("name"),
-			})).
-		EndIf().
-		SetIf(volumeMounts.IsSet(), "spec.template.spec.containers[0].volumeMounts", mountsArray).
-		SetIf(livenessProbe.IsSet(), "spec.template.spec.containers[0].livenessProbe", livenessProbe).
-		SetIf(readinessProbe.IsSet(), "spec.template.spec.containers[0].readinessProbe", readinessProbe).
+		Set("spec.template.spec.containers", AdditionalContainers(defkit.NewArray().Item(mainContainer))).
+		SetIf(defkit.And(sidecars.LenGt(0), NativeSidecarsSupported()), "spec.template.spec.initContainers", NativeSidecars()).
 		// imagePullSecrets at pod spec level (before legacy volumes)
 		SetIf(imagePullSecrets.IsSet(), "spec.template.spec.imagePullSecrets", pullSecrets).
 		If(defkit.And(volumes.IsSet(), volumeMounts.NotSet())).
@@ -294,7 +306,8 @@ func workerTemplate(tpl *defkit.Template) {
 		EndIf().
 		SetIf(volumeMounts.IsSet(), "spec.template.spec.volumes", deDupVolumesArray)
 
-	tpl.Output(deployment)
+	tpl.Output(DeploymentSettings(deployment))
+	IntOrPercent(tpl)
 }
 
 // workerHealthProbeParam returns a HealthProbe param for worker (without host/scheme in httpGet).
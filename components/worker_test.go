@@ -21,10 +21,12 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/oam-dev/kubevela/pkg/definition/defkit"
 	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
 	"github.com/oam-dev/vela-go-definitions/components"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 )
 
 var _ = Describe("Worker Component", func() {
@@ -60,7 +62,7 @@ var _ = Describe("Worker Component", func() {
 				"image", "imagePullPolicy", "imagePullSecrets",
 				"cmd", "env",
 				"cpu", "memory", "volumeMounts", "volumes",
-				"livenessProbe", "readinessProbe",
+				"livenessProbe", "readinessProbe", "containers",
 			}
 			for _, param := range expectedParams {
 				Expect(comp).To(HaveParamNamed(param))
@@ -107,7 +109,7 @@ var _ = Describe("Worker Component", func() {
 			Expect(rendered.APIVersion()).To(Equal("apps/v1"))
 			Expect(rendered.Kind()).To(Equal("Deployment"))
 			Expect(rendered.Get("metadata.name")).To(Equal("my-worker"))
-			Expect(rendered.Get("spec.template.spec.containers[0].image")).To(Equal("busybox:latest"))
+			Expect(evalContainers(comp, `{image: "busybox:latest"}`)[0].Image).To(Equal("busybox:latest"))
 		})
 
 		It("should render worker with environment variables", func() {
@@ -121,9 +123,8 @@ var _ = Describe("Worker Component", func() {
 			)
 
 			Expect(rendered.Kind()).To(Equal("Deployment"))
-			// Env is set through ForEach transform which Render can't fully evaluate,
-			// so we verify the env field is populated (CUE generation tests verify structure)
-			Expect(rendered.Get("spec.template.spec.containers[0].env")).NotTo(BeNil())
+			containers := evalContainers(comp, `{image: "busybox:latest", env: [{name: "LOG_LEVEL", value: "debug"}]}`)
+			Expect(containers[0].Env).To(ConsistOf(corev1.EnvVar{Name: "LOG_LEVEL", Value: "debug"}))
 		})
 
 		It("should render worker with resource limits", func() {
@@ -136,8 +137,9 @@ var _ = Describe("Worker Component", func() {
 			)
 
 			Expect(rendered.Kind()).To(Equal("Deployment"))
-			Expect(rendered.Get("spec.template.spec.containers[0].resources.requests.cpu")).To(Equal("500m"))
-			Expect(rendered.Get("spec.template.spec.containers[0].resources.requests.memory")).To(Equal("256Mi"))
+			resources := evalContainers(comp, `{image: "busybox:latest", cpu: "500m", memory: "256Mi"}`)[0].Resources
+			Expect(resources.Requests.Cpu().String()).To(Equal("500m"))
+			Expect(resources.Requests.Memory().String()).To(Equal("256Mi"))
 		})
 
 		It("should render worker with command", func() {
@@ -149,7 +151,7 @@ var _ = Describe("Worker Component", func() {
 			)
 
 			Expect(rendered.Kind()).To(Equal("Deployment"))
-			Expect(rendered.Get("spec.template.spec.containers[0].command")).To(Equal([]string{"sleep", "3600"}))
+			Expect(evalContainers(comp, `{image: "busybox:latest", cmd: ["sleep", "3600"]}`)[0].Command).To(Equal([]string{"sleep", "3600"}))
 		})
 
 		It("should render worker with imagePullPolicy", func() {
@@ -161,7 +163,7 @@ var _ = Describe("Worker Component", func() {
 			)
 
 			Expect(rendered.Kind()).To(Equal("Deployment"))
-			Expect(rendered.Get("spec.template.spec.containers[0].imagePullPolicy")).To(Equal("Always"))
+			Expect(evalContainers(comp, `{image: "busybox:latest", imagePullPolicy: "Always"}`)[0].ImagePullPolicy).To(Equal(corev1.PullAlways))
 		})
 
 		It("should render worker with imagePullSecrets", func() {
@@ -203,14 +205,39 @@ var _ = Describe("Worker Component", func() {
 			Expect(labelsMap["app.oam.dev/name"]).To(Equal("my-application"))
 			Expect(labelsMap["app.oam.dev/component"]).To(Equal("my-worker"))
 		})
+
+		It("should keep the primary container alone without additional containers", func() {
+			Expect(evalContainers(comp, `{image: "busybox:latest"}`)).To(HaveLen(1))
+		})
+
+		It("should render additional containers with their env and probes", func() {
+			containers := evalContainers(comp, `{
+				image: "busybox:latest"
+				containers: [{
+					name:            "log-shipper"
+					image:           "fluent/fluent-bit:3.1"
+					imagePullPolicy: "IfNotPresent"
+					env: [{name: "OUTPUT", value: "stdout"}]
+					livenessProbe: tcpSocket: port: 2020
+				}]
+			}`)
+			Expect(containers).To(HaveLen(2))
+			Expect(containers[1].Name).To(Equal("log-shipper"))
+			Expect(containers[1].ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+			Expect(containers[1].Env).To(ConsistOf(corev1.EnvVar{Name: "OUTPUT", Value: "stdout"}))
+			Expect(containers[1].LivenessProbe.TCPSocket.Port.IntValue()).To(Equal(2020))
+			Expect(containers[1].Resources.Limits).To(BeEmpty())
+		})
 	})
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			comp := components.Worker()
 			cueOutput = comp.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -226,8 +253,8 @@ var _ = Describe("Worker Component", func() {
 		})
 
 		It("should generate correct workload definition", func() {
-			Expect(cueOutput).To(ContainSubstring(`apiVersion: "apps/v1"`))
-			Expect(cueOutput).To(ContainSubstring(`kind:       "Deployment"`))
+			Expect(doc.Lookup("output.apiVersion")).To(cueassert.HaveValue(`"apps/v1"`))
+			Expect(doc.Lookup("output.kind")).To(cueassert.HaveValue(`"Deployment"`))
 			Expect(cueOutput).To(ContainSubstring(`type: "deployments.apps"`))
 		})
 
@@ -260,7 +287,7 @@ var _ = Describe("Worker Component", func() {
 		// Issue #11: Short directive on image
 		It("should generate image parameter with short directive", func() {
 			Expect(cueOutput).To(ContainSubstring("// +short=i"))
-			Expect(cueOutput).To(ContainSubstring("image: string"))
+			Expect(doc.Lookup("parameter.image")).To(cueassert.HaveValue("string"))
 		})
 
 		// Issue #6: Full typed env
@@ -326,33 +353,29 @@ var _ = Describe("Worker Component", func() {
 		})
 
 		It("should generate livenessProbe and readinessProbe referencing HealthProbe", func() {
-			Expect(cueOutput).To(ContainSubstring("livenessProbe?: #HealthProbe"))
-			Expect(cueOutput).To(ContainSubstring("readinessProbe?: #HealthProbe"))
+			Expect(doc.Lookup("parameter.livenessProbe")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("#HealthProbe")))
+			Expect(doc.Lookup("parameter.readinessProbe")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("#HealthProbe")))
 		})
 
 		It("should generate HealthProbe with exec, httpGet, and tcpSocket", func() {
 			Expect(cueOutput).To(ContainSubstring("exec?: {"))
-			Expect(cueOutput).To(ContainSubstring("command: [...string]"))
+			Expect(doc.Lookup("#HealthProbe.exec.command")).To(cueassert.HaveValue("[...string]"))
 			Expect(cueOutput).To(ContainSubstring("httpGet?: {"))
 			Expect(cueOutput).To(ContainSubstring("tcpSocket?: {"))
 		})
 
 		It("should generate HealthProbe timing fields", func() {
-			Expect(cueOutput).To(ContainSubstring("initialDelaySeconds: *0 | int"))
-			Expect(cueOutput).To(ContainSubstring("periodSeconds: *10 | int"))
-			Expect(cueOutput).To(ContainSubstring("timeoutSeconds: *1 | int"))
-			Expect(cueOutput).To(ContainSubstring("successThreshold: *1 | int"))
-			Expect(cueOutput).To(ContainSubstring("failureThreshold: *3 | int"))
+			Expect(doc.Lookup("#HealthProbe.initialDelaySeconds")).To(SatisfyAll(cueassert.HaveDefault(0), cueassert.HaveType("int")))
+			Expect(doc.Lookup("#HealthProbe.periodSeconds")).To(SatisfyAll(cueassert.HaveDefault(10), cueassert.HaveType("int")))
+			Expect(doc.Lookup("#HealthProbe.timeoutSeconds")).To(SatisfyAll(cueassert.HaveDefault(1), cueassert.HaveType("int")))
+			Expect(doc.Lookup("#HealthProbe.successThreshold")).To(SatisfyAll(cueassert.HaveDefault(1), cueassert.HaveType("int")))
+			Expect(doc.Lookup("#HealthProbe.failureThreshold")).To(SatisfyAll(cueassert.HaveDefault(3), cueassert.HaveType("int")))
 		})
 
 		It("should NOT include host and scheme in HealthProbe httpGet", func() {
-			// Find the #HealthProbe section
-			probeIdx := strings.Index(cueOutput, "#HealthProbe:")
-			Expect(probeIdx).To(BeNumerically(">", 0))
-			probeSection := cueOutput[probeIdx:]
-
-			Expect(probeSection).NotTo(ContainSubstring("host?:"))
-			Expect(probeSection).NotTo(ContainSubstring("scheme?:"))
+			Expect(doc.Lookup("#HealthProbe.httpGet")).To(cueassert.Exist())
+			Expect(doc.Lookup("#HealthProbe.httpGet.host")).NotTo(cueassert.Exist())
+			Expect(doc.Lookup("#HealthProbe.httpGet.scheme")).NotTo(cueassert.Exist())
 		})
 
 		// Issue #9: mountsArray helper name
@@ -0,0 +1,547 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cueassert parses generated definition CUE into an AST and offers
+// typed lookups and Gomega matchers for tests, so assertions describe the
+// structure of a template rather than its formatting:
+//
+//	doc := cueassert.MustParse(policy.ToCue())
+//	Expect(doc.Parameter().Field("rules")).To(cueassert.BeOptionalListOf("#GarbageCollectPolicyRule"))
+//	Expect(doc.Helper("HealthProbe").Field("periodSeconds")).To(cueassert.HaveDefault(10))
+//	Expect(doc.Outputs("hpa")).To(cueassert.HaveKind("HorizontalPodAutoscaler"))
+//
+// Fields declared inside comprehensions (`if parameter.x != _|_ { ... }`) are
+// found as well and report Conditional.
+package cueassert
+
+import (
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/parser"
+	"cuelang.org/go/cue/token"
+)
+
+// Document is a parsed definition: the metadata header and its template.
+type Document struct {
+	file     *ast.File
+	name     string
+	header   *Struct
+	template *Struct
+}
+
+// Parse parses the CUE produced by a definition's ToCue.
+func Parse(src string) (*Document, error) {
+	file, err := parser.ParseFile("definition.cue", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	doc := &Document{file: file}
+	root := newStruct("", file.Decls, false)
+	for _, f := range root.Fields() {
+		if f.Name() == "template" {
+			doc.template = f.Struct()
+		} else if doc.header == nil {
+			doc.name = f.Name()
+			doc.header = f.Struct()
+		}
+	}
+	if doc.template == nil {
+		return nil, fmt.Errorf("no template block in CUE output")
+	}
+	doc.template.path = ""
+	if doc.header == nil {
+		doc.header = missingStruct("<header>")
+	}
+	doc.template.doc = doc
+	return doc, nil
+}
+
+// MustParse is Parse for test setup; it panics on invalid CUE, which Ginkgo
+// reports as a failure of the current node.
+func MustParse(src string) *Document {
+	doc, err := Parse(src)
+	if err != nil {
+		panic(fmt.Sprintf("cueassert: %v", err))
+	}
+	return doc
+}
+
+// Name returns the definition name of the header block.
+func (d *Document) Name() string { return d.name }
+
+// Header returns the metadata block (type, description, attributes, ...).
+func (d *Document) Header() *Struct { return d.header }
+
+// Template returns the template block.
+func (d *Document) Template() *Struct { return d.template }
+
+// Parameter returns the parameter block. A parameter declared as a
+// disjunction (`*#Params | close({...})`) merges the fields of all arms.
+func (d *Document) Parameter() *Struct { return d.template.Field("parameter").Struct() }
+
+// Helper returns the helper definition #name, with or without the leading '#'.
+func (d *Document) Helper(name string) *Struct {
+	if !strings.HasPrefix(name, "#") {
+		name = "#" + name
+	}
+	return d.template.Field(name).Struct()
+}
+
+// Helpers returns the names of the helper definitions in declaration order.
+func (d *Document) Helpers() []string {
+	var names []string
+	for _, n := range d.template.FieldNames() {
+		if strings.HasPrefix(n, "#") {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+// Output returns the primary output of a component.
+func (d *Document) Output() *Struct { return d.template.Field("output").Struct() }
+
+// Outputs returns the auxiliary output name.
+func (d *Document) Outputs(name string) *Struct {
+	return d.template.Field("outputs").Struct().Field(name).Struct()
+}
+
+// Patch returns the patch block of a trait.
+func (d *Document) Patch() *Struct { return d.template.Field("patch").Struct() }
+
+// Lookup returns the field at a dotted path below the template, such as
+// "outputs.hpa.spec.scaleTargetRef".
+func (d *Document) Lookup(path string) *Field { return d.template.Lookup(path) }
+
+// UntypedLists returns the paths of open lists without an element type
+// (`[...]`) anywhere in the template.
+func (d *Document) UntypedLists() []string {
+	var (
+		out   []string
+		stack []string
+	)
+	ast.Walk(d.templateNode(), func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.Field:
+			name, _, err := ast.LabelName(v.Label)
+			if err != nil {
+				name = nodeSource(v.Label)
+			}
+			stack = append(stack, name)
+		case *ast.ListLit:
+			for _, elt := range v.Elts {
+				if e, ok := elt.(*ast.Ellipsis); ok && e.Type == nil {
+					out = append(out, strings.Join(stack, "."))
+				}
+			}
+		}
+		return true
+	}, func(n ast.Node) {
+		if _, ok := n.(*ast.Field); ok {
+			stack = stack[:len(stack)-1]
+		}
+	})
+	return out
+}
+
+// templateNode returns the template struct literal.
+func (d *Document) templateNode() ast.Node {
+	return &ast.StructLit{Elts: d.template.decls[0]}
+}
+
+// FindFields returns every declaration of name anywhere in the template,
+// including list elements, comprehension bodies and embedded `X & {...}`
+// expressions that have no path of their own.
+func (d *Document) FindFields(name string) []*Field {
+	var (
+		out   []*Field
+		stack []string
+	)
+	ast.Walk(d.templateNode(), func(n ast.Node) bool {
+		f, ok := n.(*ast.Field)
+		if !ok {
+			return true
+		}
+		label, _, err := ast.LabelName(f.Label)
+		if err != nil {
+			label = nodeSource(f.Label)
+		}
+		stack = append(stack, label)
+		if label == name {
+			out = append(out, &Field{name: label, path: strings.Join(stack, "."), field: f, doc: d})
+		}
+		return true
+	}, func(n ast.Node) {
+		if _, ok := n.(*ast.Field); ok {
+			stack = stack[:len(stack)-1]
+		}
+	})
+	return out
+}
+
+// References reports the helper references (#Name) used anywhere in the template.
+func (d *Document) References() []string {
+	seen := map[string]bool{}
+	var out []string
+	ast.Walk(d.file, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && strings.HasPrefix(id.Name, "#") && !seen[id.Name] {
+			seen[id.Name] = true
+			out = append(out, id.Name)
+		}
+		return true
+	}, nil)
+	return out
+}
+
+// Struct is a struct literal of the template, or the merged arms of a
+// disjunction of struct literals.
+type Struct struct {
+	path  string
+	decls [][]ast.Decl
+	cond  bool
+	doc   *Document
+}
+
+func newStruct(path string, decls []ast.Decl, cond bool) *Struct {
+	return &Struct{path: path, decls: [][]ast.Decl{decls}, cond: cond}
+}
+
+func missingStruct(path string) *Struct { return &Struct{path: path} }
+
+// Path returns the dotted path of the struct below the template.
+func (s *Struct) Path() string { return s.path }
+
+// Exists reports whether the struct was found.
+func (s *Struct) Exists() bool { return len(s.decls) > 0 }
+
+// Field returns the named field; a missing field reports Exists() == false.
+// Fields declared more than once (e.g. in several comprehensions) return the
+// first declaration, with the struct values of all declarations merged.
+func (s *Struct) Field(name string) *Field {
+	var found *Field
+	for _, f := range s.allFields() {
+		if f.name != name {
+			continue
+		}
+		if found == nil {
+			found = f
+			continue
+		}
+		if sub := f.Struct(); sub.Exists() {
+			found.extra = append(found.extra, sub.decls...)
+		}
+	}
+	if found == nil {
+		return &Field{name: name, path: join(s.path, name)}
+	}
+	return found
+}
+
+// Lookup returns the field at a dotted path below s.
+func (s *Struct) Lookup(path string) *Field {
+	parts := strings.Split(path, ".")
+	cur := s
+	for i, p := range parts {
+		f := cur.Field(p)
+		if i == len(parts)-1 || !f.Exists() {
+			return f
+		}
+		cur = f.Struct()
+	}
+	return nil
+}
+
+// FieldNames returns the distinct field names in declaration order.
+func (s *Struct) FieldNames() []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, f := range s.allFields() {
+		if !seen[f.name] {
+			seen[f.name] = true
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+// Fields returns the distinct fields in declaration order.
+func (s *Struct) Fields() []*Field {
+	var out []*Field
+	for _, n := range s.FieldNames() {
+		out = append(out, s.Field(n))
+	}
+	return out
+}
+
+// Counts classifies the regular fields of s: optional fields (`name?:`),
+// defaulted fields (`name: *v | T`) and required fields (everything else).
+// Helper definitions and hidden fields are not counted.
+func (s *Struct) Counts() (required, optional, defaulted int) {
+	for _, f := range s.Fields() {
+		if strings.HasPrefix(f.name, "#") || strings.HasPrefix(f.name, "_") {
+			continue
+		}
+		switch {
+		case f.IsOptional():
+			optional++
+		case f.HasDefault():
+			defaulted++
+		default:
+			required++
+		}
+	}
+	return required, optional, defaulted
+}
+
+// Source returns the formatted CUE of the struct.
+func (s *Struct) Source() string {
+	var parts []string
+	for _, decls := range s.decls {
+		parts = append(parts, nodeSource(&ast.StructLit{Elts: decls}))
+	}
+	return strings.Join(parts, " | ")
+}
+
+// GomegaString renders the struct in Gomega failure messages.
+func (s *Struct) GomegaString() string {
+	if !s.Exists() {
+		return fmt.Sprintf("<missing struct %s>", s.path)
+	}
+	return fmt.Sprintf("%s: %s", s.path, s.Source())
+}
+
+// allFields flattens the declarations of s, descending into embedded struct
+// literals and comprehension bodies.
+func (s *Struct) allFields() []*Field {
+	var out []*Field
+	var visit func(decls []ast.Decl, cond bool)
+	visit = func(decls []ast.Decl, cond bool) {
+		for _, decl := range decls {
+			switch d := decl.(type) {
+			case *ast.Field:
+				name, _, err := ast.LabelName(d.Label)
+				if err != nil {
+					name = nodeSource(d.Label)
+				}
+				out = append(out, &Field{name: name, path: join(s.path, name), field: d, cond: cond || s.cond, doc: s.doc})
+			case *ast.EmbedDecl:
+				if lit, ok := d.Expr.(*ast.StructLit); ok {
+					visit(lit.Elts, cond)
+				}
+			case *ast.Comprehension:
+				if lit, ok := d.Value.(*ast.StructLit); ok {
+					visit(lit.Elts, true)
+				}
+			}
+		}
+	}
+	for _, decls := range s.decls {
+		visit(decls, false)
+	}
+	return out
+}
+
+// Field is a field declaration of the template.
+type Field struct {
+	name  string
+	path  string
+	field *ast.Field
+	cond  bool
+	doc   *Document
+	// extra holds struct values of further declarations of the same field.
+	extra [][]ast.Decl
+}
+
+// Name returns the field label.
+func (f *Field) Name() string { return f.name }
+
+// Path returns the dotted path of the field below the template.
+func (f *Field) Path() string { return f.path }
+
+// Exists reports whether the field is declared.
+func (f *Field) Exists() bool { return f.field != nil }
+
+// Conditional reports whether the field is declared inside a comprehension.
+func (f *Field) Conditional() bool { return f.cond }
+
+// IsOptional reports whether the field is declared with `?:`.
+func (f *Field) IsOptional() bool { return f.Exists() && f.field.Constraint == token.OPTION }
+
+// HasDefault reports whether the field value is a disjunction with a default.
+func (f *Field) HasDefault() bool {
+	_, ok := f.defaultArm()
+	return ok
+}
+
+// IsRequired reports whether the field is neither optional nor defaulted.
+func (f *Field) IsRequired() bool { return f.Exists() && !f.IsOptional() && !f.HasDefault() }
+
+// Default returns the CUE source of the default value, e.g. `10` or `"Always"`.
+func (f *Field) Default() string {
+	if d, ok := f.defaultArm(); ok {
+		return nodeSource(d)
+	}
+	return ""
+}
+
+// Value returns the CUE source of the field value.
+func (f *Field) Value() string {
+	if !f.Exists() {
+		return ""
+	}
+	return nodeSource(f.field.Value)
+}
+
+// Type returns the CUE source of the value without its default arm:
+// `*false | bool` has type `bool`, `*"a" | "b"` has type `"b"`.
+func (f *Field) Type() string {
+	if !f.Exists() {
+		return ""
+	}
+	arms := disjuncts(f.field.Value)
+	if len(arms) < 2 {
+		return nodeSource(f.field.Value)
+	}
+	var out []string
+	for _, a := range arms {
+		if u, ok := a.(*ast.UnaryExpr); ok && u.Op == token.MUL {
+			continue
+		}
+		out = append(out, nodeSource(a))
+	}
+	return strings.Join(out, " | ")
+}
+
+// ListElement returns the element type of an open list type `[...T]`.
+func (f *Field) ListElement() (string, bool) {
+	if !f.Exists() {
+		return "", false
+	}
+	for _, arm := range disjuncts(f.field.Value) {
+		if u, ok := arm.(*ast.UnaryExpr); ok && u.Op == token.MUL {
+			continue
+		}
+		l, ok := arm.(*ast.ListLit)
+		if !ok || len(l.Elts) == 0 {
+			continue
+		}
+		if el, ok := l.Elts[len(l.Elts)-1].(*ast.Ellipsis); ok {
+			if el.Type == nil {
+				return "", true
+			}
+			return nodeSource(el.Type), true
+		}
+	}
+	return "", false
+}
+
+// Struct returns the struct value of the field. Disjunctions of struct
+// literals, close({...}) and references to helper definitions are merged, and
+// a list such as [...{...}] yields its element struct.
+func (f *Field) Struct() *Struct {
+	s := missingStruct(f.path)
+	s.cond = f.cond
+	s.doc = f.doc
+	if !f.Exists() {
+		return s
+	}
+	for _, arm := range disjuncts(f.field.Value) {
+		s.decls = append(s.decls, f.structDecls(arm)...)
+	}
+	s.decls = append(s.decls, f.extra...)
+	return s
+}
+
+func (f *Field) structDecls(expr ast.Expr) [][]ast.Decl {
+	switch e := expr.(type) {
+	case *ast.UnaryExpr:
+		if e.Op == token.MUL {
+			return f.structDecls(e.X)
+		}
+	case *ast.StructLit:
+		return [][]ast.Decl{e.Elts}
+	case *ast.CallExpr:
+		if id, ok := e.Fun.(*ast.Ident); ok && id.Name == "close" && len(e.Args) == 1 {
+			return f.structDecls(e.Args[0])
+		}
+	case *ast.ParenExpr:
+		return f.structDecls(e.X)
+	case *ast.ListLit:
+		if len(e.Elts) == 1 {
+			if el, ok := e.Elts[0].(*ast.Ellipsis); ok && el.Type != nil {
+				return f.structDecls(el.Type)
+			}
+		}
+	case *ast.Ident:
+		if strings.HasPrefix(e.Name, "#") && f.doc != nil && f.doc.template != nil && "#"+strings.TrimPrefix(f.name, "#") != e.Name {
+			return f.doc.Helper(e.Name).decls
+		}
+	}
+	return nil
+}
+
+// GomegaString renders the field in Gomega failure messages.
+func (f *Field) GomegaString() string {
+	if !f.Exists() {
+		return fmt.Sprintf("<missing field %s>", f.path)
+	}
+	marker := ":"
+	if f.IsOptional() {
+		marker = "?:"
+	}
+	return fmt.Sprintf("%s%s %s", f.path, marker, f.Value())
+}
+
+func (f *Field) defaultArm() (ast.Expr, bool) {
+	if !f.Exists() {
+		return nil, false
+	}
+	for _, a := range disjuncts(f.field.Value) {
+		if u, ok := a.(*ast.UnaryExpr); ok && u.Op == token.MUL {
+			return u.X, true
+		}
+	}
+	return nil, false
+}
+
+// disjuncts flattens a | b | c into its arms.
+func disjuncts(expr ast.Expr) []ast.Expr {
+	if b, ok := expr.(*ast.BinaryExpr); ok && b.Op == token.OR {
+		return append(disjuncts(b.X), disjuncts(b.Y)...)
+	}
+	if p, ok := expr.(*ast.ParenExpr); ok {
+		return disjuncts(p.X)
+	}
+	return []ast.Expr{expr}
+}
+
+func nodeSource(n ast.Node) string {
+	bs, err := format.Node(n, format.Simplify())
+	if err != nil {
+		return fmt.Sprintf("<%v>", err)
+	}
+	return strings.TrimSpace(string(bs))
+}
+
+func join(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cueassert_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
+)
+
+// sample is deliberately formatted unlike the generator output (no alignment,
+// extra blank lines) to show lookups do not depend on formatting.
+const sample = `"toy": {
+	type: "trait"
+	description: "Toy"
+}
+template: {
+	#Probe: {
+		path?: string
+
+		periodSeconds:   *10 | int
+		scheme: *"HTTP" | "HTTPS"
+	}
+	outputs: hpa: {
+		if context.clusterVersion.minor >= 23 {
+			apiVersion: "autoscaling/v2"
+		}
+		kind: "HorizontalPodAutoscaler"
+	}
+	output: {
+		kind: "Deployment"
+		spec: template: spec: containers: [{name: context.name}]
+	}
+	parameter: *#Probe | close({
+		probes: [...#Probe]
+		extra?: [...]
+		labels?: [string]: string
+		ports?: [...{port: int}]
+		values?: {...}
+	})
+}
+`
+
+var _ = Describe("cueassert", func() {
+	var doc *cueassert.Document
+
+	BeforeEach(func() {
+		doc = cueassert.MustParse(sample)
+	})
+
+	It("should read the header", func() {
+		Expect(doc.Name()).To(Equal("toy"))
+		Expect(doc.Header().Field("type")).To(cueassert.HaveValue(`"trait"`))
+	})
+
+	It("should classify helper fields", func() {
+		probe := doc.Helper("Probe")
+		Expect(doc.Helpers()).To(Equal([]string{"#Probe"}))
+		Expect(probe.Field("path")).To(cueassert.BeOptionalField())
+		Expect(probe.Field("periodSeconds")).To(cueassert.HaveDefault(10))
+		Expect(probe.Field("periodSeconds")).To(cueassert.HaveType("int"))
+		Expect(probe.Field("scheme")).To(cueassert.HaveDefault("HTTP"))
+		Expect(probe.Field("scheme")).To(cueassert.HaveType(`"HTTPS"`))
+		Expect(probe.Field("missing")).NotTo(cueassert.Exist())
+
+		required, optional, defaulted := probe.Counts()
+		Expect([]int{required, optional, defaulted}).To(Equal([]int{0, 1, 2}))
+	})
+
+	It("should merge the arms of a parameter disjunction", func() {
+		params := doc.Parameter()
+		Expect(params.FieldNames()).To(Equal([]string{"path", "periodSeconds", "scheme", "probes", "extra", "labels", "ports", "values"}))
+		Expect(params.Field("probes")).To(cueassert.BeListOf("#Probe"))
+		Expect(params.Field("probes")).To(cueassert.BeRequiredField())
+		Expect(params.Field("extra")).To(cueassert.BeOptionalListOf(""))
+		Expect(params).To(cueassert.HaveFields("periodSeconds", "labels"))
+		Expect(doc.Lookup("parameter.ports.port")).To(cueassert.HaveType("int"))
+	})
+
+	It("should find outputs and conditional fields", func() {
+		Expect(doc.Outputs("hpa")).To(cueassert.HaveKind("HorizontalPodAutoscaler"))
+		Expect(doc.Outputs("hpa").Field("apiVersion").Conditional()).To(BeTrue())
+		Expect(doc.Output()).To(cueassert.HaveKind("Deployment"))
+		Expect(doc.Lookup("output.spec.template.spec.containers")).To(cueassert.HaveValue("[{name: context.name}]"))
+		Expect(doc.Outputs("missing")).NotTo(cueassert.Exist())
+	})
+
+	It("should report untyped lists and references", func() {
+		Expect(doc.UntypedLists()).To(Equal([]string{"parameter.extra"}))
+		Expect(doc.References()).To(Equal([]string{"#Probe"}))
+	})
+
+	It("should find fields without a path regardless of alignment", func() {
+		Expect(doc).To(cueassert.ContainField("name", "context.name"))
+		Expect(doc).To(cueassert.ContainField("periodSeconds", "*10    |   int"))
+		Expect(doc).NotTo(cueassert.ContainField("name", "context.appName"))
+		Expect(doc.FindFields("kind")).To(HaveLen(2))
+	})
+
+	It("should describe missing fields in failure messages", func() {
+		m := cueassert.BeOptionalField()
+		_, err := m.Match(doc.Parameter().Field("nope"))
+		Expect(err).To(MatchError("field parameter.nope does not exist"))
+	})
+})
@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cueassert
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/gcustom"
+	"github.com/onsi/gomega/types"
+)
+
+func existing(f *Field) error {
+	if !f.Exists() {
+		return fmt.Errorf("field %s does not exist", f.Path())
+	}
+	return nil
+}
+
+// Exist succeeds for declared fields and found structs.
+func Exist() types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(actual interface{}) (bool, error) {
+		switch v := actual.(type) {
+		case *Field:
+			return v.Exists(), nil
+		case *Struct:
+			return v.Exists(), nil
+		}
+		return false, fmt.Errorf("Exist expects a *cueassert.Field or *cueassert.Struct, got %T", actual)
+	}).WithMessage("exist")
+}
+
+// BeOptionalField succeeds for fields declared with `?:`.
+func BeOptionalField() types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(f *Field) (bool, error) {
+		if err := existing(f); err != nil {
+			return false, err
+		}
+		return f.IsOptional(), nil
+	}).WithMessage("be an optional field")
+}
+
+// BeRequiredField succeeds for fields that are neither optional nor defaulted.
+func BeRequiredField() types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(f *Field) (bool, error) {
+		if err := existing(f); err != nil {
+			return false, err
+		}
+		return f.IsRequired(), nil
+	}).WithMessage("be a required field")
+}
+
+// HaveDefault succeeds when the field defaults to value, compared as a CUE
+// literal: HaveDefault(10), HaveDefault("Always"), HaveDefault(false). Use
+// HaveDefaultSource for defaults that are CUE expressions.
+func HaveDefault(value interface{}) types.GomegaMatcher {
+	want, err := json.Marshal(value)
+	return gcustom.MakeMatcher(func(f *Field) (bool, error) {
+		if err != nil {
+			return false, err
+		}
+		if err := existing(f); err != nil {
+			return false, err
+		}
+		return f.HasDefault() && f.Default() == string(want), nil
+	}).WithTemplate("Expected:\n{{.FormattedActual}}\n{{.To}} have default {{.Data}}", string(want))
+}
+
+// HaveDefaultSource succeeds when the default arm is the given CUE source,
+// e.g. `context.name` or `[]`.
+func HaveDefaultSource(src string) types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(f *Field) (bool, error) {
+		if err := existing(f); err != nil {
+			return false, err
+		}
+		return f.HasDefault() && f.Default() == src, nil
+	}).WithTemplate("Expected:\n{{.FormattedActual}}\n{{.To}} have default {{.Data}}", src)
+}
+
+// HaveType succeeds when the field value without its default arm is the
+// given CUE source, e.g. `int`, `bool` or `"orphan" | "cascading"`.
+func HaveType(src string) types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(f *Field) (bool, error) {
+		if err := existing(f); err != nil {
+			return false, err
+		}
+		return f.Type() == src, nil
+	}).WithTemplate("Expected:\n{{.FormattedActual}}\n{{.To}} have type {{.Data}}", src)
+}
+
+// HaveValue succeeds when the full field value is the given CUE source.
+func HaveValue(src string) types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(f *Field) (bool, error) {
+		if err := existing(f); err != nil {
+			return false, err
+		}
+		return f.Value() == src, nil
+	}).WithTemplate("Expected:\n{{.FormattedActual}}\n{{.To}} have value {{.Data}}", src)
+}
+
+// BeListOf succeeds for open list types `[...elem]`.
+func BeListOf(elem string) types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(f *Field) (bool, error) {
+		if err := existing(f); err != nil {
+			return false, err
+		}
+		got, ok := f.ListElement()
+		return ok && got == elem, nil
+	}).WithTemplate("Expected:\n{{.FormattedActual}}\n{{.To}} be a list of {{.Data}}", elem)
+}
+
+// BeOptionalListOf succeeds for optional fields of type `[...elem]`.
+func BeOptionalListOf(elem string) types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(f *Field) (bool, error) {
+		if err := existing(f); err != nil {
+			return false, err
+		}
+		got, ok := f.ListElement()
+		return f.IsOptional() && ok && got == elem, nil
+	}).WithTemplate("Expected:\n{{.FormattedActual}}\n{{.To}} be an optional list of {{.Data}}", elem)
+}
+
+// HaveKind succeeds for output structs whose kind is the given string.
+func HaveKind(kind string) types.GomegaMatcher {
+	want := fmt.Sprintf("%q", kind)
+	return gcustom.MakeMatcher(func(s *Struct) (bool, error) {
+		if !s.Exists() {
+			return false, fmt.Errorf("struct %s does not exist", s.Path())
+		}
+		return s.Field("kind").Value() == want, nil
+	}).WithTemplate("Expected:\n{{.FormattedActual}}\n{{.To}} have kind {{.Data}}", kind)
+}
+
+// HaveFields succeeds when the struct declares all the named fields.
+func HaveFields(names ...string) types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(s *Struct) (bool, error) {
+		if !s.Exists() {
+			return false, fmt.Errorf("struct %s does not exist", s.Path())
+		}
+		for _, n := range names {
+			if !s.Field(n).Exists() {
+				return false, nil
+			}
+		}
+		return true, nil
+	}).WithTemplate("Expected:\n{{.FormattedActual}}\n{{.To}} have fields {{.Data}}", names)
+}
+
+// ContainField succeeds when the document declares name with the given value
+// source anywhere in the template. Use it for fields inside list elements or
+// unification expressions that Lookup cannot address; whitespace in value is
+// not significant.
+func ContainField(name, value string) types.GomegaMatcher {
+	want := strings.Join(strings.Fields(value), " ")
+	return gcustom.MakeMatcher(func(d *Document) (bool, error) {
+		for _, f := range d.FindFields(name) {
+			if strings.Join(strings.Fields(f.Value()), " ") == want {
+				return true, nil
+			}
+		}
+		return false, nil
+	}).WithTemplate("Expected the template {{.To}} contain field {{.Data}}", name+": "+value)
+}
@@ -0,0 +1,348 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package paramcov
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// Source names where a property set was found.
+type Source string
+
+const (
+	// SourceUnit is a WithParam/WithParams call in a Go unit test.
+	SourceUnit Source = "unit"
+	// SourceE2E is an Application under the e2e applications directory.
+	SourceE2E Source = "e2e"
+)
+
+// Key identifies a definition; names are only unique per definition type.
+type Key struct {
+	Type defkit.DefinitionType
+	Name string
+}
+
+func (k Key) String() string { return fmt.Sprintf("%s/%s", k.Type, k.Name) }
+
+// Usage is one property set passed to a definition.
+type Usage struct {
+	Key    Key
+	Source Source
+	// Origin is the file (and line, for unit tests) the properties come from.
+	Origin string
+	Props  map[string]interface{}
+}
+
+// application is the subset of an Application that carries properties.
+type application struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Components []struct {
+			Type       string                 `json:"type"`
+			Properties map[string]interface{} `json:"properties"`
+			Traits     []typedProperties      `json:"traits"`
+		} `json:"components"`
+		Policies []typedProperties `json:"policies"`
+		Workflow *struct {
+			Steps []workflowStep `json:"steps"`
+		} `json:"workflow"`
+	} `json:"spec"`
+}
+
+type typedProperties struct {
+	Type       string                 `json:"type"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type workflowStep struct {
+	typedProperties `json:",inline"`
+	SubSteps        []workflowStep `json:"subSteps"`
+}
+
+// FromApplications collects the properties of every component, trait, policy
+// and workflow step in the Application manifests below dir.
+func FromApplications(dir string) ([]Usage, error) {
+	var usages []Usage
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || (filepath.Ext(path) != ".yaml" && filepath.Ext(path) != ".yml") {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		dec := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+		for {
+			var app application
+			if err := dec.Decode(&app); err != nil {
+				if err == io.EOF {
+					break
+				}
+				return fmt.Errorf("parse %s: %w", path, err)
+			}
+			if app.Kind == "Application" {
+				usages = append(usages, applicationUsages(path, app)...)
+			}
+		}
+		return nil
+	})
+	return usages, err
+}
+
+func applicationUsages(origin string, app application) []Usage {
+	var usages []Usage
+	add := func(t defkit.DefinitionType, name string, props map[string]interface{}) {
+		if name == "" {
+			return
+		}
+		usages = append(usages, Usage{Key: Key{Type: t, Name: name}, Source: SourceE2E, Origin: origin, Props: props})
+	}
+	for _, comp := range app.Spec.Components {
+		add(defkit.DefinitionTypeComponent, comp.Type, comp.Properties)
+		for _, tr := range comp.Traits {
+			add(defkit.DefinitionTypeTrait, tr.Type, tr.Properties)
+		}
+	}
+	for _, pol := range app.Spec.Policies {
+		add(defkit.DefinitionTypePolicy, pol.Type, pol.Properties)
+	}
+	if app.Spec.Workflow != nil {
+		var walk func(steps []workflowStep)
+		walk = func(steps []workflowStep) {
+			for _, step := range steps {
+				add(defkit.DefinitionTypeWorkflowStep, step.Type, step.Properties)
+				walk(step.SubSteps)
+			}
+		}
+		walk(app.Spec.Workflow.Steps)
+	}
+	return usages
+}
+
+// definitionPackages maps the repo's definition packages to the type their
+// constructors return.
+var definitionPackages = map[string]defkit.DefinitionType{
+	"components":    defkit.DefinitionTypeComponent,
+	"traits":        defkit.DefinitionTypeTrait,
+	"policies":      defkit.DefinitionTypePolicy,
+	"workflowsteps": defkit.DefinitionTypeWorkflowStep,
+}
+
+// FromUnitTests statically collects the properties passed through
+// defkit.TestContext().WithParam/WithParams in the _test.go files below dirs.
+//
+// A property set is attributed to the closest preceding constructor call such
+// as components.Webservice() in the same file. Values that are not Go literals
+// still mark their key as set, without descending into it.
+func FromUnitTests(dirs []string, known []Key) ([]Usage, error) {
+	byCtor := map[Key]Key{}
+	for _, k := range known {
+		byCtor[Key{Type: k.Type, Name: normalize(k.Name)}] = k
+	}
+
+	var usages []Usage
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(path, "_test.go") {
+				return err
+			}
+			found, err := unitTestUsages(path, byCtor)
+			usages = append(usages, found...)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return usages, nil
+}
+
+func unitTestUsages(path string, byCtor map[Key]Key) ([]Usage, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		usages  []Usage
+		current *Key
+	)
+	// ast.Inspect visits call chains outermost first, so WithParam calls of one
+	// TestContext chain are gathered from the outermost call down.
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if k, ok := constructorKey(call, byCtor); ok {
+			current = &k
+			return true
+		}
+		if current == nil || !isContextChain(call) {
+			return true
+		}
+		props := chainParams(call)
+		if len(props) > 0 {
+			origin := fmt.Sprintf("%s:%d", path, fset.Position(call.Pos()).Line)
+			usages = append(usages, Usage{Key: *current, Source: SourceUnit, Origin: origin, Props: props})
+		}
+		return false
+	})
+	return usages, nil
+}
+
+// constructorKey recognises calls like traits.K8sUpdateStrategy().
+func constructorKey(call *ast.CallExpr, byCtor map[Key]Key) (Key, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) != 0 {
+		return Key{}, false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return Key{}, false
+	}
+	t, ok := definitionPackages[pkg.Name]
+	if !ok {
+		return Key{}, false
+	}
+	k, ok := byCtor[Key{Type: t, Name: normalize(sel.Sel.Name)}]
+	return k, ok
+}
+
+// isContextChain reports whether call is a method chain rooted at defkit.TestContext().
+func isContextChain(call *ast.CallExpr) bool {
+	for {
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+		if pkg, ok := sel.X.(*ast.Ident); ok {
+			return pkg.Name == "defkit" && sel.Sel.Name == "TestContext"
+		}
+		inner, ok := sel.X.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		call = inner
+	}
+}
+
+// chainParams merges the WithParam and WithParams arguments of a TestContext chain.
+func chainParams(call *ast.CallExpr) map[string]interface{} {
+	props := map[string]interface{}{}
+	for {
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return props
+		}
+		switch sel.Sel.Name {
+		case "WithParam":
+			if len(call.Args) == 2 {
+				if name, ok := literal(call.Args[0]).(string); ok {
+					if _, seen := props[name]; !seen {
+						props[name] = literal(call.Args[1])
+					}
+				}
+			}
+		case "WithParams":
+			if len(call.Args) == 1 {
+				if m, ok := literal(call.Args[0]).(map[string]interface{}); ok {
+					for k, v := range m {
+						if _, seen := props[k]; !seen {
+							props[k] = v
+						}
+					}
+				}
+			}
+		}
+		inner, ok := sel.X.(*ast.CallExpr)
+		if !ok {
+			return props
+		}
+		call = inner
+	}
+}
+
+// opaque stands in for values that are not Go literals.
+type opaque struct{}
+
+// literal converts a Go literal expression to the shape Unmarshal would give it.
+func literal(expr ast.Expr) interface{} {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind == token.STRING {
+			if s, err := strconv.Unquote(e.Value); err == nil {
+				return s
+			}
+		}
+		return e.Value
+	case *ast.Ident:
+		switch e.Name {
+		case "true":
+			return true
+		case "false":
+			return false
+		case "nil":
+			return nil
+		}
+	case *ast.CompositeLit:
+		if len(e.Elts) > 0 {
+			if _, keyed := e.Elts[0].(*ast.KeyValueExpr); keyed {
+				m := map[string]interface{}{}
+				for _, elt := range e.Elts {
+					kv, ok := elt.(*ast.KeyValueExpr)
+					if !ok {
+						continue
+					}
+					if k, ok := literal(kv.Key).(string); ok {
+						m[k] = literal(kv.Value)
+					}
+				}
+				return m
+			}
+		}
+		if _, isMap := e.Type.(*ast.MapType); isMap {
+			return map[string]interface{}{}
+		}
+		l := make([]interface{}, 0, len(e.Elts))
+		for _, elt := range e.Elts {
+			l = append(l, literal(elt))
+		}
+		return l
+	}
+	return opaque{}
+}
+
+// normalize makes constructor and definition names comparable:
+// K8sUpdateStrategy and k8s-update-strategy both become k8supdatestrategy.
+func normalize(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "-", ""))
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package paramcov_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+
+	"github.com/oam-dev/vela-go-definitions/internal/paramcov"
+)
+
+var toyKey = paramcov.Key{Type: defkit.DefinitionTypeTrait, Name: "toy"}
+
+func toyTrait() *defkit.TraitDefinition {
+	return defkit.NewTrait("toy").
+		Description("Toy trait for paramcov tests.").
+		AppliesTo("*").
+		RawCUE(`
+	patch: metadata: labels: parameter.labels
+	parameter: {
+		labels: [string]: string
+		mounts?: [...{
+			name:      string
+			mountPath: string
+		}]
+		source?: close({
+			secretRef: string
+		}) | close({
+			value: string
+		})
+	}`)
+}
+
+var _ = Describe("paramcov", func() {
+	It("should walk lists, pattern constraints and every disjunction arm", func() {
+		r := paramcov.Build([]defkit.Definition{toyTrait()}, nil)
+		Expect(r.Definitions).To(HaveLen(1))
+		d := r.Definitions[0]
+		Expect(d.Err).NotTo(HaveOccurred())
+		Expect(d.Paths).To(ConsistOf(
+			"labels", "labels.*",
+			"mounts", "mounts.name", "mounts.mountPath",
+			"source", "source.secretRef", "source.value",
+		))
+		Expect(d.TotalPercent()).To(BeZero())
+	})
+
+	It("should attribute set paths to their source", func() {
+		usages := []paramcov.Usage{
+			{Key: toyKey, Source: paramcov.SourceUnit, Props: map[string]interface{}{
+				"labels": map[string]interface{}{"team": "core"},
+			}},
+			{Key: toyKey, Source: paramcov.SourceE2E, Props: map[string]interface{}{
+				"mounts": []interface{}{map[string]interface{}{"name": "data"}},
+				"typo":   true,
+			}},
+		}
+		d := paramcov.Build([]defkit.Definition{toyTrait()}, usages).Definitions[0]
+		Expect(d.Unit).To(Equal(map[string]bool{"labels": true, "labels.*": true}))
+		Expect(d.E2E).To(Equal(map[string]bool{"mounts": true, "mounts.name": true}))
+		Expect(d.Unknown).To(Equal([]string{"typo"}))
+		Expect(d.Uncovered()).To(ConsistOf("mounts.mountPath", "source", "source.secretRef", "source.value"))
+		Expect(d.TotalPercent()).To(BeNumerically("~", 50, 0.01))
+	})
+
+	It("should fail the gate below the threshold", func() {
+		usages := []paramcov.Usage{{Key: toyKey, Source: paramcov.SourceE2E, Props: map[string]interface{}{
+			"source": map[string]interface{}{"value": "x"},
+		}}}
+		r := paramcov.Build([]defkit.Definition{toyTrait()}, usages)
+		Expect(r.Check(25, 0)).To(Succeed())
+		Expect(r.Check(50, 0)).To(MatchError(ContainSubstring("total parameter coverage 25.0% is below 50.0%")))
+		Expect(r.Check(0, 30)).To(MatchError(ContainSubstring("trait/toy parameter coverage 25.0% is below 30.0%")))
+
+		var md, html bytes.Buffer
+		Expect(r.WriteMarkdown(&md)).To(Succeed())
+		Expect(md.String()).To(ContainSubstring("| toy | trait | 8 | 0.0% | 25.0% | 25.0% |"))
+		Expect(md.String()).To(ContainSubstring("- `mounts.mountPath`"))
+		Expect(r.WriteHTML(&html)).To(Succeed())
+		Expect(html.String()).To(ContainSubstring(`<li class="e2e"><code>source.value</code></li>`))
+		Expect(html.String()).To(ContainSubstring(`<li class="none"><code>labels</code></li>`))
+	})
+
+	It("should collect properties from Applications", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "app.yaml"), []byte(`apiVersion: core.oam.dev/v1beta1
+kind: Application
+metadata:
+  name: example
+spec:
+  components:
+  - name: web
+    type: webservice
+    properties:
+      image: nginx
+    traits:
+    - type: toy
+      properties:
+        labels:
+          team: core
+  policies:
+  - name: gc
+    type: garbage-collect
+    properties:
+      keepLegacyResource: true
+  workflow:
+    steps:
+    - name: group
+      type: step-group
+      subSteps:
+      - name: apply
+        type: apply-component
+        properties:
+          component: web
+`), 0o644)).To(Succeed())
+
+		usages, err := paramcov.FromApplications(dir)
+		Expect(err).NotTo(HaveOccurred())
+		var keys []string
+		for _, u := range usages {
+			Expect(u.Source).To(Equal(paramcov.SourceE2E))
+			keys = append(keys, u.Key.String())
+		}
+		Expect(keys).To(ConsistOf(
+			"component/webservice", "trait/toy", "policy/garbage-collect",
+			"workflow-step/step-group", "workflow-step/apply-component",
+		))
+	})
+
+	It("should collect TestContext parameters from unit tests", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "toy_test.go"), []byte(`package traits_test
+
+var _ = Describe("Toy", func() {
+	It("renders", func() {
+		trait := traits.Toy()
+		trait.Render(defkit.TestContext().
+			WithName("web").
+			WithParam("labels", map[string]any{"team": "core"}).
+			WithParams(map[string]any{"mounts": []map[string]any{{"name": "data"}}}))
+	})
+})
+`), 0o644)).To(Succeed())
+
+		usages, err := paramcov.FromUnitTests([]string{dir}, []paramcov.Key{toyKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(usages).To(HaveLen(1))
+		Expect(usages[0].Key).To(Equal(toyKey))
+		Expect(usages[0].Source).To(Equal(paramcov.SourceUnit))
+		Expect(usages[0].Origin).To(HaveSuffix("toy_test.go:6"))
+		Expect(usages[0].Props).To(Equal(map[string]interface{}{
+			"labels": map[string]interface{}{"team": "core"},
+			"mounts": []interface{}{map[string]interface{}{"name": "data"}},
+		}))
+	})
+})
@@ -0,0 +1,298 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package paramcov
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+
+	"github.com/oam-dev/vela-go-definitions/internal/paramfuzz"
+)
+
+// Definition is the parameter coverage of one definition.
+type Definition struct {
+	Key Key
+	// Paths lists every parameter path of the schema.
+	Paths []string
+	// Unit and E2E hold the paths set by each source.
+	Unit map[string]bool
+	E2E  map[string]bool
+	// Unknown lists property paths used by fixtures that the schema does not declare.
+	Unknown []string
+	// Err is set when the definition's parameter schema could not be compiled.
+	Err error
+}
+
+// Covered reports whether any source sets path.
+func (d *Definition) Covered(path string) bool { return d.Unit[path] || d.E2E[path] }
+
+// Uncovered returns the paths no source sets.
+func (d *Definition) Uncovered() []string {
+	var out []string
+	for _, p := range d.Paths {
+		if !d.Covered(p) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// Percent returns the share of paths set by the given sets, or 100 for
+// definitions without parameters.
+func (d *Definition) Percent(sets ...map[string]bool) float64 {
+	if len(d.Paths) == 0 {
+		return 100
+	}
+	n := 0
+	for _, p := range d.Paths {
+		for _, s := range sets {
+			if s[p] {
+				n++
+				break
+			}
+		}
+	}
+	return 100 * float64(n) / float64(len(d.Paths))
+}
+
+// UnitPercent, E2EPercent and TotalPercent are per-source coverage percentages.
+func (d *Definition) UnitPercent() float64  { return d.Percent(d.Unit) }
+func (d *Definition) E2EPercent() float64   { return d.Percent(d.E2E) }
+func (d *Definition) TotalPercent() float64 { return d.Percent(d.Unit, d.E2E) }
+
+// Report is the parameter coverage of all registered definitions.
+type Report struct {
+	Definitions []*Definition
+}
+
+// Build computes coverage for defs from the collected usages.
+func Build(defs []defkit.Definition, usages []Usage) *Report {
+	byKey := map[Key][]Usage{}
+	for _, u := range usages {
+		byKey[u.Key] = append(byKey[u.Key], u)
+	}
+
+	r := &Report{}
+	for _, def := range defs {
+		d := &Definition{
+			Key:  Key{Type: def.DefType(), Name: def.DefName()},
+			Unit: map[string]bool{},
+			E2E:  map[string]bool{},
+		}
+		r.Definitions = append(r.Definitions, d)
+
+		s, err := paramfuzz.Compile(def)
+		if errors.Is(err, paramfuzz.ErrNoParameter) {
+			continue
+		}
+		if err != nil {
+			d.Err = err
+			continue
+		}
+		tree := buildTree(s.Parameter())
+		d.Paths = tree.paths("")
+		unknown := map[string]bool{}
+		for _, u := range byKey[d.Key] {
+			set := d.E2E
+			if u.Source == SourceUnit {
+				set = d.Unit
+			}
+			for _, p := range tree.markSet("", u.Props, set) {
+				unknown[p] = true
+			}
+		}
+		for p := range unknown {
+			d.Unknown = append(d.Unknown, p)
+		}
+		sort.Strings(d.Unknown)
+	}
+	sort.Slice(r.Definitions, func(i, j int) bool {
+		a, b := r.Definitions[i].Key, r.Definitions[j].Key
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Name < b.Name
+	})
+	return r
+}
+
+// Totals returns the number of parameter paths and of paths set by any source.
+func (r *Report) Totals() (paths, covered int) {
+	for _, d := range r.Definitions {
+		paths += len(d.Paths)
+		for _, p := range d.Paths {
+			if d.Covered(p) {
+				covered++
+			}
+		}
+	}
+	return paths, covered
+}
+
+// TotalPercent is the share of all parameter paths set by any source.
+func (r *Report) TotalPercent() float64 {
+	paths, covered := r.Totals()
+	if paths == 0 {
+		return 100
+	}
+	return 100 * float64(covered) / float64(paths)
+}
+
+// Check returns an error when total coverage is below minTotal or any
+// definition is below minDefinition. Definitions that fail to compile always fail.
+func (r *Report) Check(minTotal, minDefinition float64) error {
+	var problems []string
+	if total := r.TotalPercent(); total < minTotal {
+		problems = append(problems, fmt.Sprintf("total parameter coverage %.1f%% is below %.1f%%", total, minTotal))
+	}
+	for _, d := range r.Definitions {
+		switch {
+		case d.Err != nil:
+			problems = append(problems, fmt.Sprintf("%s: %v", d.Key, d.Err))
+		case d.TotalPercent() < minDefinition:
+			problems = append(problems, fmt.Sprintf("%s parameter coverage %.1f%% is below %.1f%%", d.Key, d.TotalPercent(), minDefinition))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// WriteMarkdown renders the report as a Markdown summary table followed by the
+// uncovered paths of each definition.
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	paths, covered := r.Totals()
+	var sb strings.Builder
+	sb.WriteString("# Parameter Coverage\n\n")
+	sb.WriteString(fmt.Sprintf("**Total:** %.1f%% (%d of %d parameter paths)\n\n", r.TotalPercent(), covered, paths))
+	sb.WriteString("| Definition | Type | Paths | Unit | E2E | Total |\n")
+	sb.WriteString("|------------|------|------:|-----:|----:|------:|\n")
+	for _, d := range r.Definitions {
+		if d.Err != nil {
+			sb.WriteString(fmt.Sprintf("| %s | %s | - | - | - | error |\n", d.Key.Name, d.Key.Type))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %d | %.1f%% | %.1f%% | %.1f%% |\n",
+			d.Key.Name, d.Key.Type, len(d.Paths), d.UnitPercent(), d.E2EPercent(), d.TotalPercent()))
+	}
+
+	for _, d := range r.Definitions {
+		uncovered := d.Uncovered()
+		if d.Err == nil && len(uncovered) == 0 && len(d.Unknown) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("\n## %s\n\n", d.Key))
+		if d.Err != nil {
+			sb.WriteString(fmt.Sprintf("Failed to compile parameter schema: `%v`\n", d.Err))
+			continue
+		}
+		if len(uncovered) > 0 {
+			sb.WriteString("Never exercised:\n\n")
+			for _, p := range uncovered {
+				sb.WriteString(fmt.Sprintf("- `%s`\n", p))
+			}
+		}
+		if len(d.Unknown) > 0 {
+			sb.WriteString("\nSet by fixtures but not declared in the schema:\n\n")
+			for _, p := range d.Unknown {
+				sb.WriteString(fmt.Sprintf("- `%s`\n", p))
+			}
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+var htmlReport = template.Must(template.New("report").Funcs(template.FuncMap{
+	"pct": func(f float64) string { return fmt.Sprintf("%.1f%%", f) },
+	"level": func(f float64) string {
+		switch {
+		case f >= 80:
+			return "high"
+		case f >= 50:
+			return "medium"
+		}
+		return "low"
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Parameter Coverage</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+td.num { text-align: right; }
+.high { background: #d4edda; } .medium { background: #fff3cd; } .low { background: #f8d7da; }
+.unit { color: #1f6feb; } .e2e { color: #8250df; } .both { color: #1a7f37; } .none { color: #cf222e; font-weight: bold; }
+details { margin: 0.5em 0; }
+</style>
+</head>
+<body>
+<h1>Parameter Coverage</h1>
+<p><strong>Total:</strong> {{pct .Report.TotalPercent}} ({{.Covered}} of {{.Paths}} parameter paths)</p>
+<table>
+<tr><th>Definition</th><th>Type</th><th>Paths</th><th>Unit</th><th>E2E</th><th>Total</th></tr>
+{{- range .Report.Definitions}}
+{{- if .Err}}
+<tr><td><a href="#{{.Key}}">{{.Key.Name}}</a></td><td>{{.Key.Type}}</td><td colspan="4" class="low">error</td></tr>
+{{- else}}
+<tr><td><a href="#{{.Key}}">{{.Key.Name}}</a></td><td>{{.Key.Type}}</td><td class="num">{{len .Paths}}</td><td class="num">{{pct .UnitPercent}}</td><td class="num">{{pct .E2EPercent}}</td><td class="num {{level .TotalPercent}}">{{pct .TotalPercent}}</td></tr>
+{{- end}}
+{{- end}}
+</table>
+{{- range .Report.Definitions}}
+{{- $d := .}}
+<details id="{{.Key}}">
+<summary>{{.Key}}{{if not .Err}} &mdash; {{pct .TotalPercent}}{{end}}</summary>
+{{- if .Err}}
+<pre>{{.Err}}</pre>
+{{- else}}
+<ul>
+{{- range .Paths}}
+<li class="{{if and (index $d.Unit .) (index $d.E2E .)}}both{{else if index $d.Unit .}}unit{{else if index $d.E2E .}}e2e{{else}}none{{end}}"><code>{{.}}</code></li>
+{{- end}}
+</ul>
+{{- if .Unknown}}
+<p>Set by fixtures but not declared in the schema:</p>
+<ul>{{range .Unknown}}<li><code>{{.}}</code></li>{{end}}</ul>
+{{- end}}
+{{- end}}
+</details>
+{{- end}}
+<p>Legend: <span class="both">unit + e2e</span>, <span class="unit">unit only</span>, <span class="e2e">e2e only</span>, <span class="none">never exercised</span></p>
+</body>
+</html>
+`))
+
+// WriteHTML renders the report as a standalone HTML page listing every path,
+// colored by the sources that set it.
+func (r *Report) WriteHTML(w io.Writer) error {
+	paths, covered := r.Totals()
+	return htmlReport.Execute(w, struct {
+		Report         *Report
+		Paths, Covered int
+	}{r, paths, covered})
+}
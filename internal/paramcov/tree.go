@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package paramcov measures which parameter paths of the registered
+// definitions are exercised by the unit tests and by the e2e Applications.
+//
+// A parameter path is a dotted field path through the compiled `parameter`
+// schema. List elements are transparent (`volumeMounts.hostPath.name`) and
+// pattern constraints such as `[string]: string` appear as `*`.
+package paramcov
+
+import (
+	"sort"
+
+	"cuelang.org/go/cue"
+)
+
+// maxDepth stops the walk of recursive schemas.
+const maxDepth = 10
+
+// patternKey is the path segment of a pattern constraint.
+const patternKey = "*"
+
+// node is a field of the parameter schema.
+type node struct {
+	children map[string]*node
+}
+
+func newNode() *node {
+	return &node{children: map[string]*node{}}
+}
+
+// buildTree walks a parameter schema, merging the fields of every disjunction
+// arm so each OneOf variant and ClosedUnion option contributes its paths.
+func buildTree(v cue.Value) *node {
+	n := newNode()
+	n.merge(v, 0)
+	return n
+}
+
+func (n *node) merge(v cue.Value, depth int) {
+	if depth > maxDepth {
+		return
+	}
+	if op, args := v.Expr(); op == cue.OrOp && len(args) > 1 {
+		for _, arm := range args {
+			n.merge(arm, depth+1)
+		}
+		return
+	}
+
+	k := v.IncompleteKind()
+	if k&cue.ListKind != 0 {
+		if elem := v.LookupPath(cue.MakePath(cue.AnyIndex)); elem.Exists() {
+			n.merge(elem, depth+1)
+		}
+	}
+	if k&cue.StructKind == 0 {
+		return
+	}
+	iter, err := v.Fields(cue.Optional(true))
+	if err != nil {
+		return
+	}
+	for iter.Next() {
+		n.child(iter.Selector().Unquoted()).merge(iter.Value(), depth+1)
+	}
+	if elem := v.LookupPath(cue.MakePath(cue.AnyString)); elem.Exists() {
+		n.child(patternKey).merge(elem, depth+1)
+	}
+}
+
+func (n *node) child(name string) *node {
+	c, ok := n.children[name]
+	if !ok {
+		c = newNode()
+		n.children[name] = c
+	}
+	return c
+}
+
+// paths returns every field path below n in sorted order.
+func (n *node) paths(prefix string) []string {
+	var out []string
+	for name, c := range n.children {
+		p := join(prefix, name)
+		out = append(out, p)
+		out = append(out, c.paths(p)...)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// markSet records in set the schema paths that props assigns. Keys the schema
+// does not know are returned so typos in fixtures show up in the report.
+func (n *node) markSet(prefix string, props interface{}, set map[string]bool) (unknown []string) {
+	switch val := props.(type) {
+	case []interface{}:
+		for _, elem := range val {
+			unknown = append(unknown, n.markSet(prefix, elem, set)...)
+		}
+	case map[string]interface{}:
+		for key, elem := range val {
+			c, ok := n.children[key]
+			name := key
+			if !ok {
+				c, ok = n.children[patternKey]
+				name = patternKey
+			}
+			if !ok {
+				unknown = append(unknown, join(prefix, key))
+				continue
+			}
+			p := join(prefix, name)
+			set[p] = true
+			unknown = append(unknown, c.markSet(p, elem, set)...)
+		}
+	}
+	return unknown
+}
+
+func join(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
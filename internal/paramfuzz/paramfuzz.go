@@ -0,0 +1,530 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package paramfuzz builds random but schema-valid property sets for defkit
+// definitions and evaluates the generated CUE templates with them.
+//
+// Properties are derived from the definition's compiled `parameter` schema, so
+// every optional field, enum value, OneOf variant and ClosedUnion option the Go
+// parameter tree produces is reachable. A property set is only evaluated once the
+// schema accepts it; any error raised afterwards (conflicts, incomplete values,
+// references to unset optional fields) is a template bug.
+package paramfuzz
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/cue/errors"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/parser"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// fuzzContext is the KubeVela `context` templates are evaluated against. The
+// output is a minimal Deployment so traits that read context.output can render.
+// The cluster minor version defaults to the newest of ClusterMinors and is
+// set per property set by Run.
+const fuzzContext = `
+context: {
+	name:           "fuzz-comp"
+	namespace:      "fuzz-ns"
+	appName:        "fuzz-app"
+	appRevision:    "fuzz-app-v1"
+	appRevisionNum: 1
+	revision:       "fuzz-comp-v1"
+	appLabels: {}
+	appAnnotations: {}
+	appComponents: {}
+	components: []
+	workflowName:  "fuzz-app"
+	publishVersion: "v1"
+	clusterVersion: {
+		major:      1
+		minor:      *30 | int
+		gitVersion: "v1.\(minor).0"
+		platform:   "linux/amd64"
+	}
+	output: {
+		apiVersion: "apps/v1"
+		kind:       "Deployment"
+		metadata: name: "fuzz-comp"
+		spec: {
+			selector: matchLabels: "app.oam.dev/component": "fuzz-comp"
+			template: {
+				metadata: labels: "app.oam.dev/component": "fuzz-comp"
+				spec: containers: [{name: "fuzz-comp", image: "nginx"}]
+			}
+		}
+	}
+	outputs: {}
+}
+`
+
+// ClusterMinors are the Kubernetes minor versions Run evaluates templates on,
+// so both sides of version guards, like the native sidecars of 1.29, are
+// covered.
+var ClusterMinors = []int{30, 28}
+
+// clusterMinor picks the cluster minor version of the property set of seed.
+func clusterMinor(seed int64) int {
+	return ClusterMinors[uint64(seed)%uint64(len(ClusterMinors))]
+}
+
+// renderedFields are the template fields that must be concrete after evaluation.
+var renderedFields = []string{"output", "outputs", "patch"}
+
+// ErrNoParameter is returned by Compile for definitions without a parameter
+// schema, such as step-group.
+var ErrNoParameter = stderrors.New("definition has no parameter schema")
+
+// Schema is a compiled definition ready to accept generated properties.
+type Schema struct {
+	name      string
+	root      cue.Value
+	template  cue.Value
+	parameter cue.Value
+	// renderable is false for definitions importing KubeVela runtime packages
+	// (vela/op, vela/kube, ...) and for those marked SchemaOnly; only their
+	// parameter schema is checked.
+	renderable bool
+}
+
+// Compile compiles the CUE generated for def.
+func Compile(def defkit.Definition) (*Schema, error) {
+	src := def.ToCue()
+	file, err := parser.ParseFile(def.DefName()+".cue", src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", def.DefName(), err)
+	}
+
+	renderable := true
+	for _, imp := range file.Imports {
+		if strings.HasPrefix(strings.Trim(imp.Path.Value, `"`), "vela/") {
+			renderable = false
+		}
+	}
+	if !renderable {
+		if src, err = parameterOnlySource(file); err != nil {
+			return nil, fmt.Errorf("extract parameter schema of %s: %w", def.DefName(), err)
+		}
+	}
+
+	v := cuecontext.New().CompileString(src + fuzzContext)
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("compile %s: %s", def.DefName(), errors.Details(err, nil))
+	}
+	tmpl := v.LookupPath(cue.ParsePath("template"))
+	param := tmpl.LookupPath(cue.ParsePath("parameter"))
+	if !param.Exists() {
+		return nil, fmt.Errorf("%s: %w", def.DefName(), ErrNoParameter)
+	}
+	return &Schema{name: def.DefName(), root: v, template: tmpl, parameter: param, renderable: renderable}, nil
+}
+
+// parameterOnlySource keeps only the parameter block and the definitions (#Foo)
+// of the template, dropping everything that needs KubeVela runtime packages.
+func parameterOnlySource(file *ast.File) (string, error) {
+	var tmpl *ast.StructLit
+	for _, decl := range file.Decls {
+		if f, ok := decl.(*ast.Field); ok && labelName(f.Label) == "template" {
+			tmpl, _ = f.Value.(*ast.StructLit)
+		}
+	}
+	if tmpl == nil {
+		return "", fmt.Errorf("no template block")
+	}
+	kept := &ast.StructLit{}
+	for _, elt := range tmpl.Elts {
+		f, ok := elt.(*ast.Field)
+		if !ok {
+			continue
+		}
+		if name := labelName(f.Label); name == "parameter" || strings.HasPrefix(name, "#") {
+			kept.Elts = append(kept.Elts, f)
+		}
+	}
+	bs, err := format.Node(&ast.File{Decls: []ast.Decl{&ast.Field{Label: ast.NewIdent("template"), Value: kept}}})
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+func labelName(l ast.Label) string {
+	name, _, _ := ast.LabelName(l)
+	return name
+}
+
+// Name returns the definition name.
+func (s *Schema) Name() string { return s.name }
+
+// Parameter returns the compiled parameter schema.
+func (s *Schema) Parameter() cue.Value { return s.parameter }
+
+// Renderable reports whether Evaluate renders the template or only checks the parameter schema.
+func (s *Schema) Renderable() bool { return s.renderable }
+
+// AtClusterMinor returns s evaluated on a Kubernetes 1.minor cluster.
+func (s *Schema) AtClusterMinor(minor int) *Schema {
+	root := s.root.FillPath(cue.ParsePath("context.clusterVersion.minor"), minor)
+	tmpl := root.LookupPath(cue.ParsePath("template"))
+	return &Schema{
+		name:       s.name,
+		root:       root,
+		template:   tmpl,
+		parameter:  tmpl.LookupPath(cue.ParsePath("parameter")),
+		renderable: s.renderable,
+	}
+}
+
+// SchemaOnly stops Evaluate from rendering the template, for definitions whose
+// outputs are produced by the controller rather than by the template.
+func (s *Schema) SchemaOnly() *Schema {
+	s.renderable = false
+	return s
+}
+
+// encode converts props to a CUE value the way KubeVela does, through JSON, so
+// nil becomes null rather than top.
+func (s *Schema) encode(props map[string]interface{}) (cue.Value, error) {
+	bs, err := json.Marshal(props)
+	if err != nil {
+		return cue.Value{}, err
+	}
+	v := s.parameter.Context().CompileBytes(bs)
+	return v, v.Err()
+}
+
+// Accepts reports whether the parameter schema accepts props as a complete property set.
+// The props are filled into the template, and hidden fields are checked too, so
+// defkit validators, which refer to the template's parameter, see them.
+func (s *Schema) Accepts(props map[string]interface{}) error {
+	v, err := s.encode(props)
+	if err != nil {
+		return err
+	}
+	param := s.template.FillPath(cue.ParsePath("parameter"), v).LookupPath(cue.ParsePath("parameter"))
+	if err := param.Validate(cue.Concrete(true), cue.Hidden(true)); err != nil {
+		return fmt.Errorf("%s", errors.Details(err, nil))
+	}
+	return nil
+}
+
+// Evaluate fills the template with props and checks that the rendered fields
+// (output, outputs, patch) are free of conflicts and fully concrete.
+func (s *Schema) Evaluate(props map[string]interface{}) error {
+	v, err := s.encode(props)
+	if err != nil {
+		return err
+	}
+	filled := s.template.FillPath(cue.ParsePath("parameter"), v)
+	if err := filled.Err(); err != nil {
+		return fmt.Errorf("%s", errors.Details(err, nil))
+	}
+	if !s.renderable {
+		return s.Accepts(props)
+	}
+	for _, field := range renderedFields {
+		v := filled.LookupPath(cue.ParsePath(field))
+		if !v.Exists() {
+			continue
+		}
+		if err := v.Validate(cue.Concrete(true)); err != nil {
+			return fmt.Errorf("%s: %s", field, errors.Details(err, nil))
+		}
+	}
+	return nil
+}
+
+// Generator builds random property sets from a parameter schema.
+type Generator struct {
+	rnd *rand.Rand
+	// OptionalRate is the probability of setting an optional field.
+	OptionalRate float64
+	// MaxListLen bounds generated list and map sizes.
+	MaxListLen int
+	// MaxDepth stops recursion into deeply nested or recursive schemas.
+	MaxDepth int
+	// Hints pins fields, by name, to values that must agree with the
+	// evaluation context rather than just the schema.
+	Hints map[string]interface{}
+}
+
+// defaultHints ties container-targeting trait fields to the container of the
+// context output; any other name is rejected at runtime by design.
+var defaultHints = map[string]interface{}{
+	"containerName": "fuzz-comp",
+}
+
+// NewGenerator returns a Generator seeded with seed, so failures can be replayed.
+func NewGenerator(seed int64) *Generator {
+	return &Generator{rnd: rand.New(rand.NewSource(seed)), OptionalRate: 0.5, MaxListLen: 2, MaxDepth: 8, Hints: defaultHints}
+}
+
+// Generate returns a random property set for s. The result is not guaranteed to
+// satisfy cross-field constraints; check it with Schema.Accepts.
+func (g *Generator) Generate(s *Schema) (map[string]interface{}, error) {
+	v, err := g.value(s.parameter, 0)
+	if err != nil {
+		return nil, err
+	}
+	props, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("parameter of %s is not a struct", s.name)
+	}
+	return props, nil
+}
+
+// value generates a random value satisfying v.
+func (g *Generator) value(v cue.Value, depth int) (interface{}, error) {
+	if depth > g.MaxDepth {
+		return nil, fmt.Errorf("schema nested deeper than %d at %s", g.MaxDepth, v.Path())
+	}
+
+	// Pick one arm of a disjunction: enum values, OneOf variants, ClosedUnion options.
+	if op, args := v.Expr(); op == cue.OrOp && len(args) > 0 {
+		arm := args[g.rnd.Intn(len(args))]
+		if arm.Err() == nil {
+			return g.value(arm, depth+1)
+		}
+	}
+
+	if v.IsConcrete() && v.IncompleteKind() != cue.StructKind && v.IncompleteKind() != cue.ListKind {
+		var out interface{}
+		if err := v.Decode(&out); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+	if d, ok := v.Default(); ok && d.IsConcrete() && g.rnd.Intn(3) == 0 {
+		var out interface{}
+		if err := d.Decode(&out); err == nil {
+			return out, nil
+		}
+	}
+
+	switch k := v.IncompleteKind(); {
+	case k&cue.StructKind != 0:
+		return g.structValue(v, depth)
+	case k&cue.ListKind != 0:
+		return g.listValue(v, depth)
+	case k&cue.StringKind != 0:
+		return g.scalar(v, stringCandidates)
+	case k&cue.IntKind != 0:
+		return g.scalar(v, intCandidates)
+	case k&cue.FloatKind != 0:
+		return g.scalar(v, floatCandidates)
+	case k&cue.BoolKind != 0:
+		return g.rnd.Intn(2) == 0, nil
+	case k&cue.NullKind != 0:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("cannot generate a value for %s (%v)", v.Path(), v.IncompleteKind())
+}
+
+// structValue fills regular and required fields and a random subset of optional
+// fields. Fields enabled by comprehensions (`if parameter.type == "pvc" {...}`)
+// only appear once their guards are concrete, so the struct is re-unified with
+// the values chosen so far until no new fields show up.
+func (g *Generator) structValue(v cue.Value, depth int) (interface{}, error) {
+	out := map[string]interface{}{}
+	decided := map[string]bool{}
+	for round := 0; round < 4; round++ {
+		cur := v
+		if len(out) > 0 {
+			cur = v.Unify(v.Context().Encode(out))
+			if cur.Err() != nil {
+				cur = v
+			}
+		}
+		iter, err := cur.Fields(cue.Optional(true))
+		if err != nil {
+			return nil, err
+		}
+		added := false
+		for iter.Next() {
+			sel := iter.Selector()
+			name := sel.Unquoted()
+			if decided[name] {
+				continue
+			}
+			decided[name] = true
+			added = true
+			if sel.ConstraintType() == cue.OptionalConstraint && g.rnd.Float64() >= g.OptionalRate {
+				continue
+			}
+			val, err := g.field(name, iter.Value(), depth+1)
+			if err != nil {
+				if sel.ConstraintType() == cue.OptionalConstraint {
+					continue
+				}
+				return nil, err
+			}
+			out[name] = val
+		}
+		if !added {
+			break
+		}
+	}
+
+	// Pattern constraints such as [string]: string (labels, annotations, env maps).
+	if elem := v.LookupPath(cue.MakePath(cue.AnyString)); elem.Exists() && len(decided) == 0 {
+		for i := g.rnd.Intn(g.MaxListLen + 1); i > 0; i-- {
+			val, err := g.value(elem, depth+1)
+			if err != nil {
+				break
+			}
+			out[fmt.Sprintf("fuzz-key-%d", i)] = val
+		}
+	}
+	return out, nil
+}
+
+// field generates the value of a named struct field, honouring Hints.
+func (g *Generator) field(name string, v cue.Value, depth int) (interface{}, error) {
+	if hint, ok := g.Hints[name]; ok && v.Unify(v.Context().Encode(hint)).Err() == nil {
+		return hint, nil
+	}
+	return g.value(v, depth)
+}
+
+// listValue generates up to MaxListLen elements from the list's element schema.
+func (g *Generator) listValue(v cue.Value, depth int) (interface{}, error) {
+	out := []interface{}{}
+	elem := v.LookupPath(cue.MakePath(cue.AnyIndex))
+	if !elem.Exists() {
+		// Closed list literal; an open list ([...T]) counts as concrete too,
+		// so this is only checked once there is no element schema.
+		if v.IsConcrete() {
+			var lit interface{}
+			if err := v.Decode(&lit); err == nil {
+				return lit, nil
+			}
+		}
+		return out, nil
+	}
+	for i := g.rnd.Intn(g.MaxListLen + 1); i > 0; i-- {
+		val, err := g.value(elem, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, val)
+	}
+	return out, nil
+}
+
+var (
+	stringCandidates = []interface{}{
+		"fuzz", "nginx:1.27", "80", "10Mi", "1Gi", "100m", "/tmp/fuzz", "http://example.com",
+		"*/5 * * * *", "app.oam.dev/fuzz", "Always", "TCP", "ReadWriteOnce", "a",
+	}
+	intCandidates   = []interface{}{1, 0, 3, 80, 8080, 65535, 30}
+	floatCandidates = []interface{}{0.5, 1.0}
+)
+
+// scalar returns the default or a candidate accepted by v, tried in random order.
+func (g *Generator) scalar(v cue.Value, candidates []interface{}) (interface{}, error) {
+	order := g.rnd.Perm(len(candidates))
+	for _, i := range order {
+		c := candidates[i]
+		if v.Unify(v.Context().Encode(c)).Validate(cue.Concrete(true)) == nil {
+			return c, nil
+		}
+	}
+	if d, ok := v.Default(); ok && d.IsConcrete() {
+		var out interface{}
+		if err := d.Decode(&out); err == nil {
+			return out, nil
+		}
+	}
+	return nil, fmt.Errorf("no candidate satisfies %s", v.Path())
+}
+
+// Result counts the property sets a Run generated.
+type Result struct {
+	// Accepted property sets passed the parameter schema and were evaluated.
+	Accepted int
+	// Rejected property sets violated a cross-field constraint of the schema
+	// and were discarded; they say nothing about the template.
+	Rejected int
+}
+
+// Failure is a property set the schema accepts but the template cannot evaluate.
+type Failure struct {
+	Definition   string
+	Seed         int64
+	ClusterMinor int
+	Props        map[string]interface{}
+	Err          error
+}
+
+func (f *Failure) Error() string {
+	return fmt.Sprintf("%s failed to evaluate with seed %d on Kubernetes 1.%d: %v\nproperties:\n%s",
+		f.Definition, f.Seed, f.ClusterMinor, f.Err, Describe(f.Props))
+}
+
+// Run generates n property sets with seeds seed..seed+n-1 and evaluates every
+// set the schema accepts, stopping at the first Failure. Each set is evaluated
+// on one of ClusterMinors, picked by its seed.
+func Run(s *Schema, seed int64, n int) (Result, error) {
+	var res Result
+	for i := int64(0); i < int64(n); i++ {
+		minor := clusterMinor(seed + i)
+		cluster := s.AtClusterMinor(minor)
+		props, err := NewGenerator(seed + i).Generate(cluster)
+		if err != nil || cluster.Accepts(props) != nil {
+			res.Rejected++
+			continue
+		}
+		res.Accepted++
+		if err := cluster.Evaluate(props); err != nil {
+			return res, &Failure{Definition: s.name, Seed: seed + i, ClusterMinor: minor, Props: props, Err: err}
+		}
+	}
+	return res, nil
+}
+
+// Describe renders props as indented JSON for failure messages.
+func Describe(props map[string]interface{}) string {
+	bs, err := json.MarshalIndent(props, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%v", props)
+	}
+	return string(bs)
+}
+
+// DefaultIterations is the number of property sets generated per definition
+// when PARAMFUZZ_ITERATIONS is unset.
+const DefaultIterations = 25
+
+// Iterations returns the per-definition iteration count, read from the
+// PARAMFUZZ_ITERATIONS environment variable.
+func Iterations() int {
+	if n, err := strconv.Atoi(os.Getenv("PARAMFUZZ_ITERATIONS")); err == nil && n > 0 {
+		return n
+	}
+	return DefaultIterations
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package paramfuzz_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+
+	"github.com/oam-dev/vela-go-definitions/internal/paramfuzz"
+)
+
+func toyTrait(body string) *defkit.TraitDefinition {
+	return defkit.NewTrait("toy").
+		Description("Toy trait for paramfuzz tests.").
+		AppliesTo("*").
+		RawCUE(body)
+}
+
+var _ = Describe("paramfuzz", func() {
+	It("should report a reference to an unset optional field", func() {
+		s, err := paramfuzz.Compile(toyTrait(`
+	patch: metadata: labels: team: parameter.team
+	parameter: {
+		team?: string
+	}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = paramfuzz.Run(s, 0, 25)
+		Expect(err).To(HaveOccurred())
+		var failure *paramfuzz.Failure
+		Expect(err).To(BeAssignableToTypeOf(failure))
+		Expect(err.Error()).To(ContainSubstring("toy failed to evaluate"))
+	})
+
+	It("should evaluate templates on older clusters too", func() {
+		s, err := paramfuzz.Compile(toyTrait(`
+	patch: metadata: labels: {
+		if context.clusterVersion.minor < 29 {
+			team: parameter.team
+		}
+	}
+	parameter: {
+		team?: string
+	}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(s.AtClusterMinor(30).Evaluate(map[string]interface{}{})).To(Succeed())
+		_, err = paramfuzz.Run(s, 0, 25)
+		Expect(err).To(MatchError(ContainSubstring("on Kubernetes 1.28")))
+	})
+
+	It("should pass a template that guards optional fields", func() {
+		s, err := paramfuzz.Compile(toyTrait(`
+	patch: metadata: labels: {
+		if parameter.team != _|_ {
+			team: parameter.team
+		}
+	}
+	parameter: {
+		team?: string
+	}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		res, err := paramfuzz.Run(s, 0, 25)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.Accepted).To(Equal(25))
+	})
+
+	It("should cover every arm of a disjunction", func() {
+		s, err := paramfuzz.Compile(toyTrait(`
+	patch: metadata: labels: kind: parameter.source.type
+	parameter: {
+		source: close({
+			type:  "secret"
+			name:  string
+		}) | close({
+			type:  "value"
+			value: string
+		})
+	}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		seen := map[string]bool{}
+		for seed := int64(0); seed < 25; seed++ {
+			props, err := paramfuzz.NewGenerator(seed).Generate(s)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s.Accepts(props)).To(Succeed())
+			seen[props["source"].(map[string]interface{})["type"].(string)] = true
+		}
+		Expect(seen).To(HaveKey("secret"))
+		Expect(seen).To(HaveKey("value"))
+	})
+
+	It("should reject property sets failing a validator", func() {
+		s, err := paramfuzz.Compile(toyTrait(`
+	patch: metadata: labels: {
+		if parameter.team != _|_ {
+			team: parameter.team
+		}
+	}
+	parameter: {
+		enabled: *false | bool
+		team?:   string
+		_validateTeam: {
+			"team is required when enabled": true
+			if parameter.enabled && parameter.team == _|_ {
+				"team is required when enabled": false
+			}
+		}
+	}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(s.Accepts(map[string]interface{}{"enabled": true})).To(MatchError(ContainSubstring("team is required when enabled")))
+		Expect(s.Accepts(map[string]interface{}{"enabled": true, "team": "core"})).To(Succeed())
+
+		res, err := paramfuzz.Run(s, 0, 25)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.Rejected).To(BeNumerically(">", 0))
+	})
+
+	It("should replay the same property set for a seed", func() {
+		s, err := paramfuzz.Compile(toyTrait(`
+	patch: metadata: labels: team: parameter.team
+	parameter: {
+		team:      *"core" | string
+		replicas?: int
+		tags?: [...string]
+	}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		first, err := paramfuzz.NewGenerator(7).Generate(s)
+		Expect(err).NotTo(HaveOccurred())
+		second, err := paramfuzz.NewGenerator(7).Generate(s)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second).To(Equal(first))
+	})
+
+	It("should only check the parameter schema of SchemaOnly definitions", func() {
+		s, err := paramfuzz.Compile(toyTrait(`
+	patch: metadata: labels: team: parameter.team
+	parameter: {
+		team?: string
+	}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.SchemaOnly().Renderable()).To(BeFalse())
+
+		_, err = paramfuzz.Run(s, 0, 25)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package paramfuzz
+
+import (
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// compile compiles def, keeping only the parameter schema of the definitions
+// named in schemaOnly.
+func compile(def defkit.Definition, schemaOnly []string) (*Schema, error) {
+	s, err := Compile(def)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range schemaOnly {
+		if name == def.DefName() {
+			return s.SchemaOnly(), nil
+		}
+	}
+	return s, nil
+}
+
+// DescribeParameters registers a Ginkgo spec per definition in defs that
+// evaluates Iterations generated property sets. Definitions named in schemaOnly
+// render their output from controller-resolved data, so only their parameter
+// schema is checked.
+func DescribeParameters[D defkit.Definition](defs []D, schemaOnly ...string) bool {
+	return ginkgo.Describe("Parameter fuzzing", func() {
+		for _, def := range defs {
+			def := def
+			ginkgo.It("should evaluate generated parameters for "+def.DefName(), func() {
+				s, err := compile(def, schemaOnly)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				res, err := Run(s, 0, Iterations())
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(res.Accepted).To(gomega.BeNumerically(">", 0), "no generated property set was accepted by the schema")
+			})
+		}
+	})
+}
+
+// FuzzParameters lets `go test -fuzz` explore generator seeds for defs beyond
+// the fixed range DescribeParameters covers. schemaOnly is as for
+// DescribeParameters.
+func FuzzParameters[D defkit.Definition](f *testing.F, defs []D, schemaOnly ...string) {
+	schemas := make([]*Schema, 0, len(defs))
+	for _, def := range defs {
+		s, err := compile(def, schemaOnly)
+		if err != nil {
+			f.Fatalf("compile %s: %v", def.DefName(), err)
+		}
+		schemas = append(schemas, s)
+	}
+	f.Add(uint8(0), int64(0))
+	f.Fuzz(func(t *testing.T, idx uint8, seed int64) {
+		if _, err := Run(schemas[int(idx)%len(schemas)], seed, 1); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/oam-dev/kubevela/pkg/definition/defkit"
 	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/policies"
 )
 
@@ -254,9 +255,11 @@ var _ = Describe("ApplyOnce Policy", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			cueOutput = policy.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -291,11 +294,11 @@ var _ = Describe("ApplyOnce Policy", func() {
 			})
 
 			It("should have affect as optional string", func() {
-				Expect(cueOutput).To(ContainSubstring("affect?: string"))
+				Expect(doc.Lookup("#ApplyOnceStrategy.affect")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
 			})
 
 			It("should have path as required typed array", func() {
-				Expect(cueOutput).To(ContainSubstring("path: [...string]"))
+				Expect(doc.Lookup("#ApplyOnceStrategy.path")).To(cueassert.HaveValue("[...string]"))
 			})
 
 			It("should NOT have path as optional", func() {
@@ -317,11 +320,11 @@ var _ = Describe("ApplyOnce Policy", func() {
 			})
 
 			It("should have selector as optional with ref", func() {
-				Expect(cueOutput).To(ContainSubstring("selector?: #ResourcePolicyRuleSelector"))
+				Expect(doc.Lookup("#ApplyOncePolicyRule.selector")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("#ResourcePolicyRuleSelector")))
 			})
 
 			It("should have strategy as required with ref", func() {
-				Expect(cueOutput).To(ContainSubstring("strategy: #ApplyOnceStrategy"))
+				Expect(doc.Lookup("#ApplyOncePolicyRule.strategy")).To(cueassert.HaveValue("#ApplyOnceStrategy"))
 			})
 
 			It("should NOT have strategy as optional", func() {
@@ -371,11 +374,11 @@ var _ = Describe("ApplyOnce Policy", func() {
 			})
 
 			It("should have enable with bool default false", func() {
-				Expect(cueOutput).To(ContainSubstring("enable: *false | bool"))
+				Expect(doc.Lookup("parameter.enable")).To(SatisfyAll(cueassert.HaveDefault(false), cueassert.HaveType("bool")))
 			})
 
 			It("should have rules as optional array of ApplyOncePolicyRule", func() {
-				Expect(cueOutput).To(ContainSubstring("rules?: [...#ApplyOncePolicyRule]"))
+				Expect(doc.Lookup("parameter.rules")).To(cueassert.BeOptionalListOf("#ApplyOncePolicyRule"))
 			})
 
 			It("should include usage comments for enable", func() {
@@ -410,19 +413,19 @@ var _ = Describe("ApplyOnce Policy", func() {
 
 		Describe("Required vs optional field correctness", func() {
 			It("should have exactly one required field in ApplyOnceStrategy (path)", func() {
-				required, optional, _ := cueBlockFieldCounts(cueOutput, "#ApplyOnceStrategy:")
+				required, optional, _ := doc.Helper("ApplyOnceStrategy").Counts()
 				Expect(required).To(Equal(1), "ApplyOnceStrategy should have 1 required field (path)")
 				Expect(optional).To(Equal(1), "ApplyOnceStrategy should have 1 optional field (affect)")
 			})
 
 			It("should have exactly one required field in ApplyOncePolicyRule (strategy)", func() {
-				required, optional, _ := cueBlockFieldCounts(cueOutput, "#ApplyOncePolicyRule:")
+				required, optional, _ := doc.Helper("ApplyOncePolicyRule").Counts()
 				Expect(required).To(Equal(1), "ApplyOncePolicyRule should have 1 required field (strategy)")
 				Expect(optional).To(Equal(1), "ApplyOncePolicyRule should have 1 optional field (selector)")
 			})
 
 			It("should have all 6 optional fields in ResourcePolicyRuleSelector", func() {
-				required, optional, _ := cueBlockFieldCounts(cueOutput, "#ResourcePolicyRuleSelector:")
+				required, optional, _ := doc.Helper("ResourcePolicyRuleSelector").Counts()
 				Expect(required).To(Equal(0), "ResourcePolicyRuleSelector should have 0 required fields")
 				Expect(optional).To(Equal(6), "ResourcePolicyRuleSelector should have 6 optional fields")
 			})
@@ -430,7 +433,7 @@ var _ = Describe("ApplyOnce Policy", func() {
 
 		Describe("No untyped arrays anywhere in generated CUE", func() {
 			It("should not contain any untyped array literals", func() {
-				assertNoUntypedArrays(cueOutput)
+				Expect(doc.UntypedLists()).To(BeEmpty())
 			})
 		})
 	})
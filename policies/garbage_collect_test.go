@@ -24,6 +24,7 @@ import (
 
 	"github.com/oam-dev/kubevela/pkg/definition/defkit"
 	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/policies"
 )
 
@@ -261,9 +262,11 @@ var _ = Describe("GarbageCollect Policy", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			cueOutput = policy.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -295,7 +298,7 @@ var _ = Describe("GarbageCollect Policy", func() {
 			})
 
 			It("should have selector as required with ref", func() {
-				Expect(cueOutput).To(ContainSubstring("selector: #ResourcePolicyRuleSelector"))
+				Expect(doc.Lookup("#GarbageCollectPolicyRule.selector")).To(cueassert.HaveValue("#ResourcePolicyRuleSelector"))
 			})
 
 			It("should NOT have selector as optional", func() {
@@ -303,7 +306,7 @@ var _ = Describe("GarbageCollect Policy", func() {
 			})
 
 			It("should have strategy as enum with default onAppUpdate", func() {
-				Expect(cueOutput).To(ContainSubstring(`strategy: *"onAppUpdate" | "onAppDelete" | "never"`))
+				Expect(doc.Lookup("#GarbageCollectPolicyRule.strategy")).To(SatisfyAll(cueassert.HaveDefault("onAppUpdate"), cueassert.HaveType(`"onAppDelete" | "never"`)))
 			})
 
 			It("should NOT have strategy as plain string type", func() {
@@ -312,7 +315,7 @@ var _ = Describe("GarbageCollect Policy", func() {
 			})
 
 			It("should have propagation as optional enum without default", func() {
-				Expect(cueOutput).To(ContainSubstring(`propagation?: "orphan" | "cascading"`))
+				Expect(doc.Lookup("#GarbageCollectPolicyRule.propagation")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType(`"orphan" | "cascading"`)))
 			})
 
 			It("should NOT have propagation as plain string type", func() {
@@ -353,19 +356,19 @@ var _ = Describe("GarbageCollect Policy", func() {
 			})
 
 			It("should have applicationRevisionLimit as optional int", func() {
-				Expect(cueOutput).To(ContainSubstring("applicationRevisionLimit?: int"))
+				Expect(doc.Lookup("parameter.applicationRevisionLimit")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("int")))
 			})
 
 			It("should have keepLegacyResource with bool default false", func() {
-				Expect(cueOutput).To(ContainSubstring("keepLegacyResource: *false | bool"))
+				Expect(doc.Lookup("parameter.keepLegacyResource")).To(SatisfyAll(cueassert.HaveDefault(false), cueassert.HaveType("bool")))
 			})
 
 			It("should have continueOnFailure with bool default false", func() {
-				Expect(cueOutput).To(ContainSubstring("continueOnFailure: *false | bool"))
+				Expect(doc.Lookup("parameter.continueOnFailure")).To(SatisfyAll(cueassert.HaveDefault(false), cueassert.HaveType("bool")))
 			})
 
 			It("should have rules as optional array of GarbageCollectPolicyRule", func() {
-				Expect(cueOutput).To(ContainSubstring("rules?: [...#GarbageCollectPolicyRule]"))
+				Expect(doc.Lookup("parameter.rules")).To(cueassert.BeOptionalListOf("#GarbageCollectPolicyRule"))
 			})
 
 			It("should include usage comments for all parameters", func() {
@@ -398,21 +401,21 @@ var _ = Describe("GarbageCollect Policy", func() {
 
 		Describe("Required vs optional field correctness", func() {
 			It("should have exactly 1 required field in GarbageCollectPolicyRule (selector)", func() {
-				required, optional, defaulted := cueBlockFieldCounts(cueOutput, "#GarbageCollectPolicyRule:")
+				required, optional, defaulted := doc.Helper("GarbageCollectPolicyRule").Counts()
 				Expect(required).To(Equal(1), "should have 1 required field (selector)")
 				Expect(optional).To(Equal(1), "should have 1 optional field (propagation)")
 				Expect(defaulted).To(Equal(1), "should have 1 field with default (strategy)")
 			})
 
 			It("should have all 6 optional fields in ResourcePolicyRuleSelector", func() {
-				_, optional, _ := cueBlockFieldCounts(cueOutput, "#ResourcePolicyRuleSelector:")
+				_, optional, _ := doc.Helper("ResourcePolicyRuleSelector").Counts()
 				Expect(optional).To(Equal(6))
 			})
 		})
 
 		Describe("No untyped arrays anywhere in generated CUE", func() {
 			It("should not contain any untyped array literals", func() {
-				assertNoUntypedArrays(cueOutput)
+				Expect(doc.UntypedLists()).To(BeEmpty())
 			})
 		})
 	})
@@ -24,6 +24,7 @@ import (
 
 	"github.com/oam-dev/kubevela/pkg/definition/defkit"
 	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/policies"
 )
 
@@ -286,9 +287,11 @@ var _ = Describe("Override Policy", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			cueOutput = policy.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -320,15 +323,12 @@ var _ = Describe("Override Policy", func() {
 			})
 
 			It("should have type as required string", func() {
-				Expect(cueOutput).To(ContainSubstring("type: string"))
+				Expect(doc.Lookup("#TraitPatch.type")).To(cueassert.HaveValue("string"))
 			})
 
 			It("should NOT have type as optional", func() {
 				// Extract the TraitPatch block to check type specifically within it
-				start := strings.Index(cueOutput, "#TraitPatch:")
-				end := findClosingBrace(cueOutput, start)
-				block := cueOutput[start:end]
-				Expect(block).NotTo(ContainSubstring("type?: string"))
+				Expect(doc.Lookup("#TraitPatch.type")).To(cueassert.BeRequiredField())
 			})
 
 			It("should have properties as optional map", func() {
@@ -336,7 +336,7 @@ var _ = Describe("Override Policy", func() {
 			})
 
 			It("should have disable with bool default false", func() {
-				Expect(cueOutput).To(ContainSubstring("disable: *false | bool"))
+				Expect(doc.Lookup("#TraitPatch.disable")).To(SatisfyAll(cueassert.HaveDefault(false), cueassert.HaveType("bool")))
 			})
 
 			It("should include usage comment for type", func() {
@@ -344,9 +344,7 @@ var _ = Describe("Override Policy", func() {
 			})
 
 			It("should include usage comment for properties", func() {
-				start := strings.Index(cueOutput, "#TraitPatch:")
-				end := findClosingBrace(cueOutput, start)
-				block := cueOutput[start:end]
+				block := doc.Helper("TraitPatch").Source()
 				Expect(block).To(ContainSubstring("// +usage=Specify the properties to override"))
 			})
 
@@ -361,31 +359,23 @@ var _ = Describe("Override Policy", func() {
 			})
 
 			It("should have name as optional string", func() {
-				Expect(cueOutput).To(ContainSubstring("name?: string"))
+				Expect(doc.Lookup("#PatchParams.name")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
 			})
 
 			It("should have type as optional string in PatchParams", func() {
-				start := strings.Index(cueOutput, "#PatchParams:")
-				end := findClosingBrace(cueOutput, start)
-				block := cueOutput[start:end]
-				Expect(block).To(ContainSubstring("type?: string"))
+				Expect(doc.Lookup("#PatchParams.type")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
 			})
 
 			It("should have properties as optional map", func() {
-				start := strings.Index(cueOutput, "#PatchParams:")
-				end := findClosingBrace(cueOutput, start)
-				block := cueOutput[start:end]
-				Expect(block).To(ContainSubstring("properties?: {...}"))
+				Expect(doc.Lookup("#PatchParams.properties")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveValue("{...}")))
 			})
 
 			It("should have traits as optional array referencing TraitPatch", func() {
-				Expect(cueOutput).To(ContainSubstring("traits?: [...#TraitPatch]"))
+				Expect(doc.Lookup("#PatchParams.traits")).To(cueassert.BeOptionalListOf("#TraitPatch"))
 			})
 
 			It("should include usage comments for all PatchParams fields", func() {
-				start := strings.Index(cueOutput, "#PatchParams:")
-				end := findClosingBrace(cueOutput, start)
-				block := cueOutput[start:end]
+				block := doc.Helper("PatchParams").Source()
 				Expect(block).To(ContainSubstring("// +usage=Specify the name of the patch component"))
 				Expect(block).To(ContainSubstring("// +usage=Specify the type of the patch component"))
 				Expect(block).To(ContainSubstring("// +usage=Specify the properties to override"))
@@ -399,7 +389,7 @@ var _ = Describe("Override Policy", func() {
 			})
 
 			It("should have components as required array of PatchParams", func() {
-				Expect(cueOutput).To(ContainSubstring("components: [...#PatchParams]"))
+				Expect(doc.Lookup("parameter.components")).To(cueassert.HaveValue("[...#PatchParams]"))
 			})
 
 			It("should NOT have components as optional", func() {
@@ -407,7 +397,7 @@ var _ = Describe("Override Policy", func() {
 			})
 
 			It("should have selector as optional string array", func() {
-				Expect(cueOutput).To(ContainSubstring("selector?: [...string]"))
+				Expect(doc.Lookup("parameter.selector")).To(cueassert.BeOptionalListOf("string"))
 			})
 
 			It("should include usage comment for components", func() {
@@ -453,20 +443,20 @@ var _ = Describe("Override Policy", func() {
 
 		Describe("Required vs optional field correctness", func() {
 			It("should have exactly 1 required field in TraitPatch (type)", func() {
-				required, optional, defaulted := cueBlockFieldCounts(cueOutput, "#TraitPatch:")
+				required, optional, defaulted := doc.Helper("TraitPatch").Counts()
 				Expect(required).To(Equal(1), "TraitPatch should have 1 required field (type)")
 				Expect(optional).To(Equal(1), "TraitPatch should have 1 optional field (properties)")
 				Expect(defaulted).To(Equal(1), "TraitPatch should have 1 field with default (disable)")
 			})
 
 			It("should have all 4 optional fields in PatchParams", func() {
-				required, optional, _ := cueBlockFieldCounts(cueOutput, "#PatchParams:")
+				required, optional, _ := doc.Helper("PatchParams").Counts()
 				Expect(required).To(Equal(0), "PatchParams should have 0 required fields")
 				Expect(optional).To(Equal(4), "PatchParams should have 4 optional fields")
 			})
 
 			It("should have 1 required and 1 optional in parameter block", func() {
-				required, optional, _ := cueBlockFieldCounts(cueOutput, "parameter: {", "parameter")
+				required, optional, _ := doc.Parameter().Counts()
 				Expect(required).To(Equal(1), "parameter block should have 1 required field (components)")
 				Expect(optional).To(Equal(1), "parameter block should have 1 optional field (selector)")
 			})
@@ -474,17 +464,11 @@ var _ = Describe("Override Policy", func() {
 
 		Describe("Cross-reference integrity", func() {
 			It("should reference #TraitPatch in PatchParams traits field", func() {
-				start := strings.Index(cueOutput, "#PatchParams:")
-				end := findClosingBrace(cueOutput, start)
-				block := cueOutput[start:end]
-				Expect(block).To(ContainSubstring("[...#TraitPatch]"))
+				Expect(doc.Lookup("#PatchParams.traits")).To(cueassert.BeOptionalListOf("#TraitPatch"))
 			})
 
 			It("should reference #PatchParams in parameter components field", func() {
-				start := strings.Index(cueOutput, "parameter: {")
-				end := findClosingBrace(cueOutput, start)
-				block := cueOutput[start:end]
-				Expect(block).To(ContainSubstring("[...#PatchParams]"))
+				Expect(doc.Parameter().Field("components")).To(cueassert.BeListOf("#PatchParams"))
 			})
 
 			It("should define every referenced helper", func() {
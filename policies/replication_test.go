@@ -24,6 +24,7 @@ import (
 
 	"github.com/oam-dev/kubevela/pkg/definition/defkit"
 	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/policies"
 )
 
@@ -120,9 +121,11 @@ var _ = Describe("Replication Policy", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			cueOutput = policy.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -154,7 +157,7 @@ var _ = Describe("Replication Policy", func() {
 			})
 
 			It("should have keys as required string array", func() {
-				Expect(cueOutput).To(ContainSubstring("keys: [...string]"))
+				Expect(doc.Lookup("parameter.keys")).To(cueassert.HaveValue("[...string]"))
 			})
 
 			It("should NOT have keys as optional", func() {
@@ -162,7 +165,7 @@ var _ = Describe("Replication Policy", func() {
 			})
 
 			It("should have selector as optional string array", func() {
-				Expect(cueOutput).To(ContainSubstring("selector?: [...string]"))
+				Expect(doc.Lookup("parameter.selector")).To(cueassert.BeOptionalListOf("string"))
 			})
 
 			It("should include usage comment for keys", func() {
@@ -192,7 +195,7 @@ var _ = Describe("Replication Policy", func() {
 
 		Describe("Required vs optional field correctness", func() {
 			It("should have 1 required and 1 optional in parameter block", func() {
-				required, optional, _ := cueBlockFieldCounts(cueOutput, "parameter: {", "parameter")
+				required, optional, _ := doc.Parameter().Counts()
 				Expect(required).To(Equal(1), "parameter block should have 1 required field (keys)")
 				Expect(optional).To(Equal(1), "parameter block should have 1 optional field (selector)")
 			})
@@ -200,7 +203,7 @@ var _ = Describe("Replication Policy", func() {
 
 		Describe("No untyped arrays anywhere in generated CUE", func() {
 			It("should not contain any untyped array literals", func() {
-				assertNoUntypedArrays(cueOutput)
+				Expect(doc.UntypedLists()).To(BeEmpty())
 			})
 		})
 	})
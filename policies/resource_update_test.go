@@ -24,6 +24,7 @@ import (
 
 	"github.com/oam-dev/kubevela/pkg/definition/defkit"
 	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/policies"
 )
 
@@ -260,9 +261,11 @@ var _ = Describe("ResourceUpdate Policy", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			cueOutput = policy.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -321,7 +324,7 @@ var _ = Describe("ResourceUpdate Policy", func() {
 			})
 
 			It("should have op as enum with default patch", func() {
-				Expect(cueOutput).To(ContainSubstring(`op: *"patch" | "replace"`))
+				Expect(doc.Lookup("#Strategy.op")).To(SatisfyAll(cueassert.HaveDefault("patch"), cueassert.HaveType(`"replace"`)))
 			})
 
 			It("should NOT have op as plain string type", func() {
@@ -329,7 +332,7 @@ var _ = Describe("ResourceUpdate Policy", func() {
 			})
 
 			It("should have recreateFields as optional typed string array", func() {
-				Expect(cueOutput).To(ContainSubstring("recreateFields?: [...string]"))
+				Expect(doc.Lookup("#Strategy.recreateFields")).To(cueassert.BeOptionalListOf("string"))
 			})
 
 			It("should include usage comment for op", func() {
@@ -347,7 +350,7 @@ var _ = Describe("ResourceUpdate Policy", func() {
 			})
 
 			It("should have selector as required with ref", func() {
-				Expect(cueOutput).To(ContainSubstring("selector: #RuleSelector"))
+				Expect(doc.Lookup("#PolicyRule.selector")).To(cueassert.HaveValue("#RuleSelector"))
 			})
 
 			It("should NOT have selector as optional", func() {
@@ -355,7 +358,7 @@ var _ = Describe("ResourceUpdate Policy", func() {
 			})
 
 			It("should have strategy as required with ref", func() {
-				Expect(cueOutput).To(ContainSubstring("strategy: #Strategy"))
+				Expect(doc.Lookup("#PolicyRule.strategy")).To(cueassert.HaveValue("#Strategy"))
 			})
 
 			It("should NOT have strategy as optional", func() {
@@ -369,7 +372,7 @@ var _ = Describe("ResourceUpdate Policy", func() {
 			})
 
 			It("should have rules as optional array of PolicyRule", func() {
-				Expect(cueOutput).To(ContainSubstring("rules?: [...#PolicyRule]"))
+				Expect(doc.Lookup("parameter.rules")).To(cueassert.BeOptionalListOf("#PolicyRule"))
 			})
 
 			It("should include usage comment for rules", func() {
@@ -411,43 +414,34 @@ var _ = Describe("ResourceUpdate Policy", func() {
 
 		Describe("Required vs optional field correctness", func() {
 			It("should have 2 required fields in PolicyRule (selector, strategy)", func() {
-				required, optional, _ := cueBlockFieldCounts(cueOutput, "#PolicyRule:")
+				required, optional, _ := doc.Helper("PolicyRule").Counts()
 				Expect(required).To(Equal(2), "PolicyRule should have 2 required fields (selector, strategy)")
 				Expect(optional).To(Equal(0), "PolicyRule should have 0 optional fields")
 			})
 
 			It("should have 1 default and 1 optional in Strategy", func() {
-				_, optional, defaulted := cueBlockFieldCounts(cueOutput, "#Strategy:")
+				_, optional, defaulted := doc.Helper("Strategy").Counts()
 				Expect(defaulted).To(Equal(1), "Strategy should have 1 field with default (op)")
 				Expect(optional).To(Equal(1), "Strategy should have 1 optional field (recreateFields)")
 			})
 
 			It("should have all 6 optional fields in RuleSelector", func() {
-				_, optional, _ := cueBlockFieldCounts(cueOutput, "#RuleSelector:")
+				_, optional, _ := doc.Helper("RuleSelector").Counts()
 				Expect(optional).To(Equal(6))
 			})
 		})
 
 		Describe("Cross-reference integrity", func() {
 			It("should reference #RuleSelector in PolicyRule", func() {
-				start := strings.Index(cueOutput, "#PolicyRule:")
-				end := findClosingBrace(cueOutput, start)
-				block := cueOutput[start:end]
-				Expect(block).To(ContainSubstring("#RuleSelector"))
+				Expect(doc.Lookup("#PolicyRule.selector")).To(cueassert.HaveValue("#RuleSelector"))
 			})
 
 			It("should reference #Strategy in PolicyRule", func() {
-				start := strings.Index(cueOutput, "#PolicyRule:")
-				end := findClosingBrace(cueOutput, start)
-				block := cueOutput[start:end]
-				Expect(block).To(ContainSubstring("#Strategy"))
+				Expect(doc.Lookup("#PolicyRule.strategy")).To(cueassert.HaveValue("#Strategy"))
 			})
 
 			It("should reference #PolicyRule in parameter rules field", func() {
-				start := strings.Index(cueOutput, "parameter: {")
-				end := findClosingBrace(cueOutput, start)
-				block := cueOutput[start:end]
-				Expect(block).To(ContainSubstring("[...#PolicyRule]"))
+				Expect(doc.Parameter().Field("rules")).To(cueassert.BeOptionalListOf("#PolicyRule"))
 			})
 
 			It("should define every referenced helper", func() {
@@ -459,7 +453,7 @@ var _ = Describe("ResourceUpdate Policy", func() {
 
 		Describe("No untyped arrays anywhere in generated CUE", func() {
 			It("should not contain any untyped array literals", func() {
-				assertNoUntypedArrays(cueOutput)
+				Expect(doc.UntypedLists()).To(BeEmpty())
 			})
 		})
 	})
@@ -24,6 +24,7 @@ import (
 
 	"github.com/oam-dev/kubevela/pkg/definition/defkit"
 	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/policies"
 )
 
@@ -177,9 +178,11 @@ var _ = Describe("SharedResource Policy", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			cueOutput = policy.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -238,7 +241,7 @@ var _ = Describe("SharedResource Policy", func() {
 			})
 
 			It("should have selector as required with ref", func() {
-				Expect(cueOutput).To(ContainSubstring("selector: #ResourcePolicyRuleSelector"))
+				Expect(doc.Lookup("#SharedResourcePolicyRule.selector")).To(cueassert.HaveValue("#ResourcePolicyRuleSelector"))
 			})
 
 			It("should NOT have selector as optional", func() {
@@ -252,7 +255,7 @@ var _ = Describe("SharedResource Policy", func() {
 			})
 
 			It("should have rules as optional array of SharedResourcePolicyRule", func() {
-				Expect(cueOutput).To(ContainSubstring("rules?: [...#SharedResourcePolicyRule]"))
+				Expect(doc.Lookup("parameter.rules")).To(cueassert.BeOptionalListOf("#SharedResourcePolicyRule"))
 			})
 
 			It("should include usage comment for rules", func() {
@@ -288,19 +291,19 @@ var _ = Describe("SharedResource Policy", func() {
 
 		Describe("Required vs optional field correctness", func() {
 			It("should have exactly 1 required field in SharedResourcePolicyRule (selector)", func() {
-				required, optional, _ := cueBlockFieldCounts(cueOutput, "#SharedResourcePolicyRule:")
+				required, optional, _ := doc.Helper("SharedResourcePolicyRule").Counts()
 				Expect(required).To(Equal(1), "SharedResourcePolicyRule should have 1 required field (selector)")
 				Expect(optional).To(Equal(0), "SharedResourcePolicyRule should have 0 optional fields")
 			})
 
 			It("should have all 6 optional fields in ResourcePolicyRuleSelector", func() {
-				required, optional, _ := cueBlockFieldCounts(cueOutput, "#ResourcePolicyRuleSelector:")
+				required, optional, _ := doc.Helper("ResourcePolicyRuleSelector").Counts()
 				Expect(required).To(Equal(0), "ResourcePolicyRuleSelector should have 0 required fields")
 				Expect(optional).To(Equal(6), "ResourcePolicyRuleSelector should have 6 optional fields")
 			})
 
 			It("should have 1 optional field in parameter block (rules)", func() {
-				required, optional, _ := cueBlockFieldCounts(cueOutput, "parameter: {", "parameter")
+				required, optional, _ := doc.Parameter().Counts()
 				Expect(required).To(Equal(0), "parameter block should have 0 required fields")
 				Expect(optional).To(Equal(1), "parameter block should have 1 optional field (rules)")
 			})
@@ -308,17 +311,11 @@ var _ = Describe("SharedResource Policy", func() {
 
 		Describe("Cross-reference integrity", func() {
 			It("should reference #ResourcePolicyRuleSelector in SharedResourcePolicyRule", func() {
-				start := strings.Index(cueOutput, "#SharedResourcePolicyRule:")
-				end := findClosingBrace(cueOutput, start)
-				block := cueOutput[start:end]
-				Expect(block).To(ContainSubstring("#ResourcePolicyRuleSelector"))
+				Expect(doc.Lookup("#SharedResourcePolicyRule.selector")).To(cueassert.HaveValue("#ResourcePolicyRuleSelector"))
 			})
 
 			It("should reference #SharedResourcePolicyRule in parameter rules field", func() {
-				start := strings.Index(cueOutput, "parameter: {")
-				end := findClosingBrace(cueOutput, start)
-				block := cueOutput[start:end]
-				Expect(block).To(ContainSubstring("[...#SharedResourcePolicyRule]"))
+				Expect(doc.Parameter().Field("rules")).To(cueassert.BeOptionalListOf("#SharedResourcePolicyRule"))
 			})
 
 			It("should define every referenced helper", func() {
@@ -329,7 +326,7 @@ var _ = Describe("SharedResource Policy", func() {
 
 		Describe("No untyped arrays anywhere in generated CUE", func() {
 			It("should not contain any untyped array literals", func() {
-				assertNoUntypedArrays(cueOutput)
+				Expect(doc.UntypedLists()).To(BeEmpty())
 			})
 		})
 	})
@@ -24,6 +24,7 @@ import (
 
 	"github.com/oam-dev/kubevela/pkg/definition/defkit"
 	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/policies"
 )
 
@@ -177,9 +178,11 @@ var _ = Describe("TakeOver Policy", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			cueOutput = policy.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -238,7 +241,7 @@ var _ = Describe("TakeOver Policy", func() {
 			})
 
 			It("should have selector as required with ref", func() {
-				Expect(cueOutput).To(ContainSubstring("selector: #RuleSelector"))
+				Expect(doc.Lookup("#PolicyRule.selector")).To(cueassert.HaveValue("#RuleSelector"))
 			})
 
 			It("should NOT have selector as optional", func() {
@@ -252,7 +255,7 @@ var _ = Describe("TakeOver Policy", func() {
 			})
 
 			It("should have rules as optional array of PolicyRule", func() {
-				Expect(cueOutput).To(ContainSubstring("rules?: [...#PolicyRule]"))
+				Expect(doc.Lookup("parameter.rules")).To(cueassert.BeOptionalListOf("#PolicyRule"))
 			})
 
 			It("should include usage comment for rules", func() {
@@ -290,19 +293,19 @@ var _ = Describe("TakeOver Policy", func() {
 
 		Describe("Required vs optional field correctness", func() {
 			It("should have exactly 1 required field in PolicyRule (selector)", func() {
-				required, optional, _ := cueBlockFieldCounts(cueOutput, "#PolicyRule:")
+				required, optional, _ := doc.Helper("PolicyRule").Counts()
 				Expect(required).To(Equal(1), "PolicyRule should have 1 required field (selector)")
 				Expect(optional).To(Equal(0), "PolicyRule should have 0 optional fields")
 			})
 
 			It("should have all 6 optional fields in RuleSelector", func() {
-				required, optional, _ := cueBlockFieldCounts(cueOutput, "#RuleSelector:")
+				required, optional, _ := doc.Helper("RuleSelector").Counts()
 				Expect(required).To(Equal(0), "RuleSelector should have 0 required fields")
 				Expect(optional).To(Equal(6), "RuleSelector should have 6 optional fields")
 			})
 
 			It("should have 1 optional field in parameter block (rules)", func() {
-				required, optional, _ := cueBlockFieldCounts(cueOutput, "parameter: {", "parameter")
+				required, optional, _ := doc.Parameter().Counts()
 				Expect(required).To(Equal(0), "parameter block should have 0 required fields")
 				Expect(optional).To(Equal(1), "parameter block should have 1 optional field (rules)")
 			})
@@ -310,17 +313,11 @@ var _ = Describe("TakeOver Policy", func() {
 
 		Describe("Cross-reference integrity", func() {
 			It("should reference #RuleSelector in PolicyRule selector field", func() {
-				start := strings.Index(cueOutput, "#PolicyRule:")
-				end := findClosingBrace(cueOutput, start)
-				block := cueOutput[start:end]
-				Expect(block).To(ContainSubstring("#RuleSelector"))
+				Expect(doc.Lookup("#PolicyRule.selector")).To(cueassert.HaveValue("#RuleSelector"))
 			})
 
 			It("should reference #PolicyRule in parameter rules field", func() {
-				start := strings.Index(cueOutput, "parameter: {")
-				end := findClosingBrace(cueOutput, start)
-				block := cueOutput[start:end]
-				Expect(block).To(ContainSubstring("[...#PolicyRule]"))
+				Expect(doc.Parameter().Field("rules")).To(cueassert.BeOptionalListOf("#PolicyRule"))
 			})
 
 			It("should define every referenced helper", func() {
@@ -331,7 +328,7 @@ var _ = Describe("TakeOver Policy", func() {
 
 		Describe("No untyped arrays anywhere in generated CUE", func() {
 			It("should not contain any untyped array literals", func() {
-				assertNoUntypedArrays(cueOutput)
+				Expect(doc.UntypedLists()).To(BeEmpty())
 			})
 		})
 	})
@@ -24,6 +24,7 @@ import (
 
 	"github.com/oam-dev/kubevela/pkg/definition/defkit"
 	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/policies"
 )
 
@@ -168,9 +169,11 @@ var _ = Describe("Topology Policy", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			cueOutput = policy.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -202,7 +205,7 @@ var _ = Describe("Topology Policy", func() {
 			})
 
 			It("should have clusters as optional string array", func() {
-				Expect(cueOutput).To(ContainSubstring("clusters?: [...string]"))
+				Expect(doc.Lookup("parameter.clusters")).To(cueassert.BeOptionalListOf("string"))
 			})
 
 			It("should have clusterLabelSelector as optional string-key map", func() {
@@ -210,7 +213,7 @@ var _ = Describe("Topology Policy", func() {
 			})
 
 			It("should have allowEmpty as optional bool", func() {
-				Expect(cueOutput).To(ContainSubstring("allowEmpty?: bool"))
+				Expect(doc.Lookup("parameter.allowEmpty")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("bool")))
 			})
 
 			It("should have clusterSelector as optional string-key map", func() {
@@ -218,7 +221,7 @@ var _ = Describe("Topology Policy", func() {
 			})
 
 			It("should have namespace as optional string", func() {
-				Expect(cueOutput).To(ContainSubstring("namespace?: string"))
+				Expect(doc.Lookup("parameter.namespace")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
 			})
 
 			It("should NOT have any required parameters", func() {
@@ -268,7 +271,7 @@ var _ = Describe("Topology Policy", func() {
 
 		Describe("Required vs optional field correctness", func() {
 			It("should have 0 required and 5 optional in parameter block", func() {
-				required, optional, _ := cueBlockFieldCounts(cueOutput, "parameter: {", "parameter")
+				required, optional, _ := doc.Parameter().Counts()
 				Expect(required).To(Equal(0), "parameter block should have 0 required fields")
 				Expect(optional).To(Equal(5), "parameter block should have 5 optional fields")
 			})
@@ -276,7 +279,7 @@ var _ = Describe("Topology Policy", func() {
 
 		Describe("No untyped arrays anywhere in generated CUE", func() {
 			It("should not contain any untyped array literals", func() {
-				assertNoUntypedArrays(cueOutput)
+				Expect(doc.UntypedLists()).To(BeEmpty())
 			})
 		})
 	})
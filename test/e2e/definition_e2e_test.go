@@ -69,9 +69,15 @@ var _ = func() bool {
 							skipTests = map[string]string{}
 						}
 
-						It(fmt.Sprintf("should run %s", filepath.Base(file)), func() {
+						// Specs that place resources in managed clusters also carry the multicluster label.
+						args := []interface{}{}
+						if len(loadExpectations(file).managedClusters()) > 0 {
+							args = append(args, Label(multiClusterLabel))
+						}
+						args = append(args, func() {
 							runDefinitionTest(ctx, file, skipTests)
 						})
+						It(fmt.Sprintf("should run %s", filepath.Base(file)), args...)
 					}
 				})
 			})
@@ -38,7 +38,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/yaml"
@@ -55,6 +57,12 @@ const (
 
 var (
 	k8sClient client.Client
+	// restConfig is the hub cluster config, kept to build the cluster-gateway
+	// client for expectations scoped to managed clusters.
+	restConfig *rest.Config
+	// k8sClientset is used for requests the controller-runtime client cannot make,
+	// such as proxying to stand-in Services.
+	k8sClientset kubernetes.Interface
 )
 
 // initK8sClient initializes the Kubernetes controller-runtime client once.
@@ -68,6 +76,8 @@ func initK8sClient() error {
 		return fmt.Errorf("failed to get kubeconfig: %w", err)
 	}
 
+	restConfig = cfg
+
 	// Register KubeVela schemes
 	_ = v1beta1.AddToScheme(scheme.Scheme)
 
@@ -76,6 +86,11 @@ func initK8sClient() error {
 		return fmt.Errorf("failed to create k8s client: %w", err)
 	}
 
+	k8sClientset, err = kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create k8s clientset: %w", err)
+	}
+
 	return nil
 }
 
@@ -220,7 +235,8 @@ func applyPrerequisiteResources(ctx context.Context, filePath, namespace string)
 		return err
 	}
 
-	docs := strings.Split(string(content), "---")
+	rendered := strings.ReplaceAll(string(content), namespacePlaceholder, namespace)
+	docs := strings.Split(rendered, "---")
 	for _, doc := range docs {
 		doc = strings.TrimSpace(doc)
 		if doc == "" {
@@ -360,15 +376,10 @@ func getAppFailureDiagnostics(ctx context.Context, appName, namespace string) st
 // (cloud providers, terraform, Prometheus, container registries, webhook endpoints)
 // and cannot run in a standard CI environment.
 var skipWorkflowStepTests = map[string]string{
-	"deploy-cloud-resource.yaml":    "requires alibaba-rds component and multi-cluster setup",
-	"share-cloud-resource.yaml":     "requires alibaba-rds component and multi-cluster setup",
-	"generate-jdbc-connection.yaml": "requires alibaba-rds component",
+	"deploy-cloud-resource.yaml":    "requires the env-binding policy, which this module does not define, and multi-cluster setup",
+	"share-cloud-resource.yaml":     "requires the env-binding policy, which this module does not define, and multi-cluster setup",
 	"apply-terraform-config.yaml":   "requires Alibaba Cloud credentials and terraform provider",
 	"apply-terraform-provider.yaml": "requires Alibaba Cloud credentials",
-	"build-push-image.yaml":         "requires external container registry (ttl.sh) and GitHub access",
-	"check-metrics.yaml":            "requires external Prometheus endpoint (demo.promlabs.com)",
-	"restart-workflow.yaml":         "self-restarting workflow cannot be validated with single-shot test framework",
-	"clean-jobs.yaml":               "clean-jobs requires namespace property matching prerequisite Jobs location",
 }
 
 // skipTraitTests lists trait test files that cannot run in a standard CI environment.
@@ -380,6 +391,7 @@ var skipTraitTests = map[string]string{
 // It creates an isolated namespace, applies all applications from the YAML file,
 // waits for running status, validates expectations, and cleans up.
 func runDefinitionTest(ctx context.Context, file string, skipTests map[string]string) {
+	AddReportEntry(definitionReportEntry, filepath.Base(file), ReportEntryVisibilityNever)
 	if reason, ok := skipTests[filepath.Base(file)]; ok {
 		Skip(fmt.Sprintf("Skipping: %s", reason))
 	}
@@ -398,8 +410,16 @@ func runDefinitionTest(ctx context.Context, file string, skipTests map[string]st
 	for _, app := range apps {
 		app.SetNamespace(uniqueNs)
 		updateAppNamespaceReferences(app, uniqueNs)
+		Expect(renderNamespacePlaceholder(app, uniqueNs)).To(Succeed(), "Failed to render %s in %s", namespacePlaceholder, app.Name)
 	}
 
+	// Extra expectations from companion .expect.yaml (additive); loaded up front
+	// because they may request stand-ins that must exist before the apps run.
+	ef := loadExpectations(file)
+
+	// Skip when the expectations need a managed cluster this environment lacks.
+	requireClusters(ctx, ef.managedClusters())
+
 	// Track test success for cleanup diagnostics
 	testPassed := false
 
@@ -408,6 +428,12 @@ func runDefinitionTest(ctx context.Context, file string, skipTests map[string]st
 		if !testPassed {
 			GinkgoWriter.Printf("\nTest did not complete successfully, gathering diagnostics...\n")
 			GinkgoWriter.Printf("%s\n", getAppFailureDiagnostics(ctx, mainApp.Name, uniqueNs))
+			artifactsDir := getFailureArtifactsDir(definitionType(CurrentSpecReport().Labels()), file)
+			if err := collectFailureArtifacts(ctx, artifactsDir, apps, uniqueNs); err != nil {
+				GinkgoWriter.Printf("Failed to save failure artifacts to %s: %v\n", artifactsDir, err)
+			} else {
+				GinkgoWriter.Printf("Failure artifacts saved to %s\n", artifactsDir)
+			}
 		}
 		// Delete all apps first (to clear finalizers)
 		for _, app := range apps {
@@ -417,6 +443,7 @@ func runDefinitionTest(ctx context.Context, file string, skipTests map[string]st
 		GinkgoWriter.Printf("Deleting namespace %s...\n", uniqueNs)
 		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: uniqueNs}}
 		_ = k8sClient.Delete(ctx, ns)
+		cleanupManagedNamespaces(ctx, ef, uniqueNs)
 	})
 
 	GinkgoWriter.Printf("Creating namespace %s...\n", uniqueNs)
@@ -436,6 +463,11 @@ func runDefinitionTest(ctx context.Context, file string, skipTests map[string]st
 	}, 30*time.Second, 2*time.Second).Should(BeTrue(),
 		fmt.Sprintf("Application %s should be fully deleted before test", mainApp.Name))
 
+	// Deploy in-cluster stand-ins for external services (Prometheus, HTTP receivers, ...)
+	if ef != nil && len(ef.StandIns) > 0 {
+		deployStandIns(ctx, ef.StandIns, uniqueNs)
+	}
+
 	// Apply prerequisite non-Application resources (Deployments, Services, ConfigMaps, etc.)
 	if hasPrerequisiteResources(file) {
 		GinkgoWriter.Printf("Applying prerequisite resources from %s...\n", filepath.Base(file))
@@ -463,7 +495,6 @@ func runDefinitionTest(ctx context.Context, file string, skipTests map[string]st
 	autoValidate(ctx, mainApp, uniqueNs)
 
 	// Layer 2: Extra expectations from companion .expect.yaml (additive)
-	ef := loadExpectations(file)
 	if ef != nil {
 		if len(ef.Expectations) > 0 {
 			GinkgoWriter.Printf("Validating %d extra resource expectation(s)...\n", len(ef.Expectations))
@@ -473,6 +504,10 @@ func runDefinitionTest(ctx context.Context, file string, skipTests map[string]st
 			GinkgoWriter.Printf("Validating %d extra workflow step expectation(s)...\n", len(ef.WorkflowSteps))
 			validateWorkflowStepExpectations(ctx, mainApp.Name, uniqueNs, ef.WorkflowSteps)
 		}
+		if len(ef.ReceivedRequests) > 0 {
+			GinkgoWriter.Printf("Validating %d received request expectation(s)...\n", len(ef.ReceivedRequests))
+			validateReceivedRequests(ctx, uniqueNs, ef.ReceivedRequests)
+		}
 	}
 
 	testPassed = true
@@ -518,19 +553,31 @@ func waitForPrerequisiteResources(ctx context.Context, filePath, namespace strin
 // --------------------------------------------------------------------------
 
 // componentTypeToGVK maps KubeVela component types to the K8s resource they create.
-// Returns empty strings for types that create varied resources (k8s-objects, ref-objects).
+// Returns empty strings for types that create varied resources (k8s-objects, ref-objects, crossplane-claim).
 func componentTypeToGVK(componentType string) (apiVersion, kind string) {
 	switch componentType {
-	case "webservice", "worker":
+	case "webservice", "worker", "static-site":
 		return "apps/v1", "Deployment"
 	case "daemon":
 		return "apps/v1", "DaemonSet"
-	case "statefulset":
+	case "statefulset", "redis":
 		return "apps/v1", "StatefulSet"
 	case "task":
 		return "batch/v1", "Job"
 	case "cron-task":
 		return "batch/v1", "CronJob"
+	case "helm-release":
+		return "helm.toolkit.fluxcd.io/v2", "HelmRelease"
+	case "kustomize":
+		return "kustomize.toolkit.fluxcd.io/v1", "Kustomization"
+	case "knative-service":
+		return "serving.knative.dev/v1", "Service"
+	case "argo-rollout":
+		return "argoproj.io/v1alpha1", "Rollout"
+	case "postgres-cluster":
+		return "postgresql.cnpg.io/v1", "Cluster"
+	case "keda-scaled-job":
+		return "keda.sh/v1alpha1", "ScaledJob"
 	default:
 		return "", ""
 	}
@@ -569,15 +616,17 @@ func autoValidate(ctx context.Context, app *v1beta1.Application, namespace strin
 			continue // Skip types with varied resources (k8s-objects, ref-objects)
 		}
 
-		// Find the actual resource name and namespace from applied resources in status
+		// Find the actual resource name, namespace and cluster from applied resources in status
 		resourceName := ""
 		resourceNs := namespace
+		resourceCluster := ""
 		for _, ar := range currentApp.Status.AppliedResources {
 			if ar.Kind == kind {
 				resourceName = ar.Name
 				if ar.Namespace != "" {
 					resourceNs = ar.Namespace
 				}
+				resourceCluster = ar.Cluster
 				break
 			}
 		}
@@ -589,11 +638,15 @@ func autoValidate(ctx context.Context, app *v1beta1.Application, namespace strin
 
 		GinkgoWriter.Printf("Auto-validating %s/%s %q in %s...\n", apiVersion, kind, resourceName, resourceNs)
 
+		// Resources placed by a topology policy may live in a managed cluster
+		cctx, c, err := clusterClient(ctx, resourceCluster)
+		Expect(err).NotTo(HaveOccurred())
+
 		obj := &unstructured.Unstructured{}
 		obj.SetGroupVersionKind(parseGVK(apiVersion, kind))
 
 		Eventually(func() error {
-			return k8sClient.Get(ctx, types.NamespacedName{Namespace: resourceNs, Name: resourceName}, obj)
+			return c.Get(cctx, types.NamespacedName{Namespace: resourceNs, Name: resourceName}, obj)
 		}, 30*time.Second, 2*time.Second).Should(Succeed(),
 			fmt.Sprintf("Expected %s/%s %q to exist in namespace %s", apiVersion, kind, resourceName, resourceNs))
 
@@ -602,10 +655,13 @@ func autoValidate(ctx context.Context, app *v1beta1.Application, namespace strin
 			var props map[string]interface{}
 			if err := json.Unmarshal(comp.Properties.Raw, &props); err == nil {
 				if image, ok := props["image"].(string); ok && image != "" {
-					// Get actual image — for CronJob, image is nested under jobTemplate
+					// Get actual image — for CronJob and ScaledJob, the pod template is nested
 					imagePath := "spec.template.spec.containers[0].image"
-					if kind == "CronJob" {
+					switch kind {
+					case "CronJob":
 						imagePath = "spec.jobTemplate.spec.template.spec.containers[0].image"
+					case "ScaledJob":
+						imagePath = "spec.jobTargetRef.template.spec.containers[0].image"
 					}
 					actual, err := getNestedValue(obj.Object, imagePath)
 					if err == nil {
@@ -636,7 +692,9 @@ type ResourceExpectation struct {
 	APIVersion string                 `yaml:"apiVersion" json:"apiVersion"`
 	Kind       string                 `yaml:"kind" json:"kind"`
 	Name       string                 `yaml:"name" json:"name"`
-	Namespace  string                 `yaml:"namespace,omitempty" json:"namespace,omitempty"` // optional, defaults to test namespace
+	Namespace  string                 `yaml:"namespace,omitempty" json:"namespace,omitempty"` // optional, defaults to test namespace; may use ${E2E_NAMESPACE}
+	Cluster    string                 `yaml:"cluster,omitempty" json:"cluster,omitempty"`     // optional managed cluster, defaults to local
+	Absent     bool                   `yaml:"absent,omitempty" json:"absent,omitempty"`       // resource must not exist (e.g. cleaned up by a step)
 	Fields     map[string]interface{} `yaml:"fields" json:"fields"`
 }
 
@@ -649,8 +707,12 @@ type WorkflowStepExpectation struct {
 
 // ExpectationFile is the top-level structure of a .expect.yaml file.
 type ExpectationFile struct {
-	Expectations  []ResourceExpectation     `yaml:"expectations,omitempty" json:"expectations,omitempty"`
-	WorkflowSteps []WorkflowStepExpectation `yaml:"workflowSteps,omitempty" json:"workflowSteps,omitempty"`
+	// StandIns names manifests under TESTDATA_PATH/standins to deploy into the
+	// test namespace before the Applications are applied.
+	StandIns         []string                     `yaml:"standIns,omitempty" json:"standIns,omitempty"`
+	Expectations     []ResourceExpectation        `yaml:"expectations,omitempty" json:"expectations,omitempty"`
+	WorkflowSteps    []WorkflowStepExpectation    `yaml:"workflowSteps,omitempty" json:"workflowSteps,omitempty"`
+	ReceivedRequests []ReceivedRequestExpectation `yaml:"receivedRequests,omitempty" json:"receivedRequests,omitempty"`
 }
 
 // loadExpectations looks for a .expect.yaml file in the expectations/ directory
@@ -695,22 +757,38 @@ func parseGVK(apiVersion, kind string) schema.GroupVersionKind {
 }
 
 // validateResourceExpectations fetches each expected resource and validates its fields.
+// Expectations with a cluster are fetched from that managed cluster through the cluster-gateway.
 func validateResourceExpectations(ctx context.Context, namespace string, expectations []ResourceExpectation) {
 	for _, exp := range expectations {
 		ns := namespace
 		if exp.Namespace != "" {
-			ns = exp.Namespace
+			ns = renderNamespace(exp.Namespace, namespace)
+		}
+		where := ns
+		if exp.Cluster != "" {
+			where = fmt.Sprintf("%s (cluster %s)", ns, exp.Cluster)
 		}
-		GinkgoWriter.Printf("  Checking %s/%s %s in %s...\n", exp.APIVersion, exp.Kind, exp.Name, ns)
+		GinkgoWriter.Printf("  Checking %s/%s %s in %s...\n", exp.APIVersion, exp.Kind, exp.Name, where)
+
+		cctx, c, err := clusterClient(ctx, exp.Cluster)
+		Expect(err).NotTo(HaveOccurred())
 
 		obj := &unstructured.Unstructured{}
 		obj.SetGroupVersionKind(parseGVK(exp.APIVersion, exp.Kind))
 
+		if exp.Absent {
+			Eventually(func() bool {
+				return errors.IsNotFound(c.Get(cctx, types.NamespacedName{Namespace: ns, Name: exp.Name}, obj))
+			}, 30*time.Second, 2*time.Second).Should(BeTrue(),
+				fmt.Sprintf("Expected %s/%s %q to be absent from namespace %s", exp.APIVersion, exp.Kind, exp.Name, where))
+			continue
+		}
+
 		// Fetch the resource — retry briefly in case of propagation delay
 		Eventually(func() error {
-			return k8sClient.Get(ctx, types.NamespacedName{Namespace: ns, Name: exp.Name}, obj)
+			return c.Get(cctx, types.NamespacedName{Namespace: ns, Name: exp.Name}, obj)
 		}, 30*time.Second, 2*time.Second).Should(Succeed(),
-			fmt.Sprintf("Expected %s/%s %q to exist in namespace %s", exp.APIVersion, exp.Kind, exp.Name, ns))
+			fmt.Sprintf("Expected %s/%s %q to exist in namespace %s", exp.APIVersion, exp.Kind, exp.Name, where))
 
 		// Validate each field path
 		for path, expectedValue := range exp.Fields {
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevela/pkg/multicluster"
+)
+
+// --------------------------------------------------------------------------
+// Managed clusters joined by `make e2e-setup E2E_MULTICLUSTER=true`
+// --------------------------------------------------------------------------
+
+const (
+	// multiClusterLabel is added to specs whose expectations are scoped to a
+	// managed cluster, so they can be run on their own with --label-filter.
+	multiClusterLabel = "multicluster"
+
+	// clusterCredentialLabel marks the Secrets in vela-system that KubeVela keeps
+	// for every joined cluster; the Secret name is the cluster name.
+	clusterCredentialLabel = "cluster.core.oam.dev/cluster-credential-type"
+)
+
+// gatewayClient reaches managed clusters through the cluster-gateway
+// aggregated API of the hub, the same path the KubeVela controller uses.
+var gatewayClient client.Client
+
+// clusterClient returns the client and context for requests against cluster.
+// An empty cluster or "local" is the hub cluster the tests run against.
+func clusterClient(ctx context.Context, cluster string) (context.Context, client.Client, error) {
+	if multicluster.IsLocal(cluster) {
+		return ctx, k8sClient, nil
+	}
+	if gatewayClient == nil {
+		c, err := multicluster.NewClient(restConfig, multicluster.ClientOptions{
+			Options:                    client.Options{Scheme: scheme.Scheme},
+			DisableRemoteClusterClient: true,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create cluster-gateway client: %w", err)
+		}
+		gatewayClient = c
+	}
+	return multicluster.WithCluster(ctx, cluster), gatewayClient, nil
+}
+
+// joinedClusters lists the managed clusters registered with KubeVela.
+func joinedClusters(ctx context.Context) (map[string]bool, error) {
+	secrets := &corev1.SecretList{}
+	if err := k8sClient.List(ctx, secrets, client.InNamespace("vela-system"), client.HasLabels{clusterCredentialLabel}); err != nil {
+		return nil, fmt.Errorf("failed to list cluster credentials: %w", err)
+	}
+	joined := map[string]bool{}
+	for _, s := range secrets.Items {
+		joined[s.Name] = true
+	}
+	return joined, nil
+}
+
+// managedClusters returns the non-local clusters the expectations are scoped to.
+func (ef *ExpectationFile) managedClusters() []string {
+	if ef == nil {
+		return nil
+	}
+	seen := map[string]bool{}
+	var clusters []string
+	for _, exp := range ef.Expectations {
+		if !multicluster.IsLocal(exp.Cluster) && !seen[exp.Cluster] {
+			seen[exp.Cluster] = true
+			clusters = append(clusters, exp.Cluster)
+		}
+	}
+	sort.Strings(clusters)
+	return clusters
+}
+
+// requireClusters skips the current spec unless every cluster is joined.
+func requireClusters(ctx context.Context, clusters []string) {
+	if len(clusters) == 0 {
+		return
+	}
+	joined, err := joinedClusters(ctx)
+	if err != nil {
+		Skip(err.Error())
+	}
+	var missing []string
+	for _, c := range clusters {
+		if !joined[c] {
+			missing = append(missing, c)
+		}
+	}
+	if len(missing) > 0 {
+		Skip(fmt.Sprintf("requires managed cluster(s) %s; run `make e2e-setup E2E_MULTICLUSTER=true`", strings.Join(missing, ", ")))
+	}
+}
+
+// cleanupManagedNamespaces deletes the test namespace, and any e2e namespace
+// the expectations place resources in, from every managed cluster the test
+// used. KubeVela garbage-collects the resources but leaves the namespaces it
+// created.
+func cleanupManagedNamespaces(ctx context.Context, ef *ExpectationFile, namespace string) {
+	for _, cluster := range ef.managedClusters() {
+		cctx, c, err := clusterClient(ctx, cluster)
+		if err != nil {
+			GinkgoWriter.Printf("Skipping namespace cleanup in cluster %s: %v\n", cluster, err)
+			continue
+		}
+		namespaces := map[string]bool{namespace: true}
+		for _, exp := range ef.Expectations {
+			if ns := renderNamespace(exp.Namespace, namespace); exp.Cluster == cluster && strings.HasPrefix(ns, "e2e-") {
+				namespaces[ns] = true
+			}
+		}
+		for ns := range namespaces {
+			GinkgoWriter.Printf("Deleting namespace %s in cluster %s...\n", ns, cluster)
+			_ = c.Delete(cctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}})
+		}
+	}
+}
+
+// renderNamespace resolves namespacePlaceholder in an expectation namespace.
+func renderNamespace(ns, testNamespace string) string {
+	return strings.ReplaceAll(ns, namespacePlaceholder, testNamespace)
+}
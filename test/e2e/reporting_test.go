@@ -0,0 +1,344 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+)
+
+// --------------------------------------------------------------------------
+// Run reports and failure artifacts
+// --------------------------------------------------------------------------
+
+const (
+	// definitionReportEntry names the report entry that tags a spec with the
+	// definition it exercises, so the run summary can be keyed by definition.
+	definitionReportEntry = "definition"
+
+	// maxPodLogBytes caps the log captured per container in failure artifacts.
+	maxPodLogBytes = 1 << 20
+)
+
+// definitionResult is one entry of the JSON run summary.
+type definitionResult struct {
+	Definition      string  `json:"definition"`
+	Type            string  `json:"type"`
+	File            string  `json:"file"`
+	Result          string  `json:"result"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	SkipReason      string  `json:"skipReason,omitempty"`
+	Failure         string  `json:"failure,omitempty"`
+	Artifacts       string  `json:"artifacts,omitempty"`
+}
+
+// runSummary is the top-level structure of the JSON run summary.
+type runSummary struct {
+	Suite           string             `json:"suite"`
+	LabelFilter     string             `json:"labelFilter,omitempty"`
+	StartTime       time.Time          `json:"startTime"`
+	DurationSeconds float64            `json:"durationSeconds"`
+	Passed          int                `json:"passed"`
+	Failed          int                `json:"failed"`
+	Skipped         int                `json:"skipped"`
+	Results         []definitionResult `json:"results"`
+}
+
+// getArtifactsDir returns the directory that receives reports and failure artifacts.
+func getArtifactsDir() string {
+	if path := os.Getenv("E2E_ARTIFACTS_DIR"); path != "" {
+		if filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(getProjectRoot(), path)
+	}
+	return filepath.Join(getProjectRoot(), ".e2e-artifacts")
+}
+
+// getReportName derives the report file name from the label filter, so the
+// per-type make targets do not overwrite each other's reports.
+func getReportName(labelFilter string) string {
+	name := sanitizeForNamespace(labelFilter)
+	if name == "" {
+		return "all"
+	}
+	return name
+}
+
+// getFailureArtifactsDir returns the artifact directory of a definition test.
+func getFailureArtifactsDir(definitionType, file string) string {
+	return filepath.Join(getArtifactsDir(), "failures", definitionType, definitionName(file))
+}
+
+// definitionName returns the definition a test file exercises (its base name without extension).
+func definitionName(file string) string {
+	return strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+}
+
+// definitionType returns the suite label (components, traits, ...) of the current spec.
+func definitionType(labels []string) string {
+	for _, s := range suites {
+		for _, l := range labels {
+			if l == s.label {
+				return l
+			}
+		}
+	}
+	return "unknown"
+}
+
+// Write the JUnit XML and JSON summary once, on the primary process, from the
+// report aggregated across all parallel processes.
+var _ = ReportAfterSuite("e2e reports", func(report Report) {
+	dir := getArtifactsDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create artifacts directory %s: %v\n", dir, err)
+		return
+	}
+	name := getReportName(report.SuiteConfig.LabelFilter)
+
+	junitPath := filepath.Join(dir, fmt.Sprintf("junit-%s.xml", name))
+	if err := reporters.GenerateJUnitReport(report, junitPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write JUnit report: %v\n", err)
+	}
+
+	summaryPath := filepath.Join(dir, fmt.Sprintf("summary-%s.json", name))
+	if err := writeRunSummary(buildRunSummary(report), summaryPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write JSON summary: %v\n", err)
+	}
+})
+
+// buildRunSummary extracts one result per definition test from the suite report.
+// Specs without a definition report entry (e.g. the file listing checks) are left out.
+func buildRunSummary(report Report) runSummary {
+	summary := runSummary{
+		Suite:           report.SuiteDescription,
+		LabelFilter:     report.SuiteConfig.LabelFilter,
+		StartTime:       report.StartTime,
+		DurationSeconds: report.RunTime.Seconds(),
+		Results:         []definitionResult{},
+	}
+
+	for _, spec := range report.SpecReports {
+		if spec.LeafNodeType != types.NodeTypeIt {
+			continue
+		}
+		file := ""
+		for _, entry := range spec.ReportEntries {
+			if entry.Name == definitionReportEntry {
+				file = entry.StringRepresentation()
+				break
+			}
+		}
+		if file == "" {
+			continue
+		}
+
+		result := definitionResult{
+			Definition:      definitionName(file),
+			Type:            definitionType(spec.Labels()),
+			File:            file,
+			Result:          spec.State.String(),
+			DurationSeconds: spec.RunTime.Seconds(),
+		}
+		switch {
+		case spec.State == types.SpecStateSkipped:
+			summary.Skipped++
+			result.SkipReason = strings.TrimPrefix(spec.Failure.Message, "Skipping: ")
+		case spec.State.Is(types.SpecStateFailureStates):
+			summary.Failed++
+			result.Failure = spec.Failure.Message
+			if dir := getFailureArtifactsDir(result.Type, file); dirExists(dir) {
+				result.Artifacts = dir
+			}
+		case spec.State == types.SpecStatePassed:
+			summary.Passed++
+		}
+		summary.Results = append(summary.Results, result)
+	}
+	return summary
+}
+
+// writeRunSummary writes the run summary as indented JSON.
+func writeRunSummary(summary runSummary, path string) error {
+	bs, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bs, 0o644)
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// collectFailureArtifacts saves everything needed to debug a failed definition
+// test into dir: the Applications (with status), workflow step statuses, the
+// rendered resources, namespace events, pod logs and the text diagnostics.
+// Collection is best effort; errors are recorded in the files instead.
+func collectFailureArtifacts(ctx context.Context, dir string, apps []*v1beta1.Application, namespace string) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "logs"), 0o755); err != nil {
+		return err
+	}
+
+	var appDocs, stepDocs, resourceDocs []string
+	for _, app := range apps {
+		current := &v1beta1.Application{}
+		if err := k8sClient.Get(ctx, k8stypes.NamespacedName{Namespace: namespace, Name: app.Name}, current); err != nil {
+			appDocs = append(appDocs, fmt.Sprintf("# failed to get application %s: %v\n", app.Name, err))
+			current = app
+		} else {
+			appDocs = append(appDocs, marshalYAMLDoc(current))
+		}
+
+		steps := map[string]interface{}{"application": app.Name, "phase": current.Status.Phase}
+		if current.Status.Workflow != nil {
+			steps["workflow"] = current.Status.Workflow
+		}
+		stepDocs = append(stepDocs, marshalYAMLDoc(steps))
+
+		for _, ar := range current.Status.AppliedResources {
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(parseGVK(ar.APIVersion, ar.Kind))
+			ns := ar.Namespace
+			if ns == "" {
+				ns = namespace
+			}
+			if err := k8sClient.Get(ctx, k8stypes.NamespacedName{Namespace: ns, Name: ar.Name}, obj); err != nil {
+				resourceDocs = append(resourceDocs, fmt.Sprintf("# failed to get %s/%s %s: %v\n", ar.APIVersion, ar.Kind, ar.Name, err))
+				continue
+			}
+			resourceDocs = append(resourceDocs, marshalYAMLDoc(obj.Object))
+		}
+	}
+
+	files := map[string]string{
+		"applications.yaml":   strings.Join(appDocs, "---\n"),
+		"workflow-steps.yaml": strings.Join(stepDocs, "---\n"),
+		"resources.yaml":      strings.Join(resourceDocs, "---\n"),
+		"events.yaml":         collectEvents(ctx, namespace),
+		"diagnostics.txt":     getAppFailureDiagnostics(ctx, apps[len(apps)-1].Name, namespace),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			return err
+		}
+	}
+	return collectPodLogs(ctx, filepath.Join(dir, "logs"), namespace)
+}
+
+// marshalYAMLDoc renders v as a YAML document, or a comment describing the error.
+func marshalYAMLDoc(v interface{}) string {
+	bs, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("# failed to marshal: %v\n", err)
+	}
+	return string(bs)
+}
+
+// collectEvents returns the namespace events in chronological order as YAML.
+func collectEvents(ctx context.Context, namespace string) string {
+	events, err := k8sClientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Sprintf("# failed to list events: %v\n", err)
+	}
+	type event struct {
+		Time    string `json:"time"`
+		Type    string `json:"type"`
+		Reason  string `json:"reason"`
+		Object  string `json:"object"`
+		Message string `json:"message"`
+		Count   int32  `json:"count,omitempty"`
+	}
+	out := make([]event, 0, len(events.Items))
+	for _, e := range events.Items {
+		ts := e.LastTimestamp.Time
+		if ts.IsZero() {
+			ts = e.EventTime.Time
+		}
+		out = append(out, event{
+			Time:    ts.UTC().Format(time.RFC3339),
+			Type:    e.Type,
+			Reason:  e.Reason,
+			Object:  fmt.Sprintf("%s/%s", e.InvolvedObject.Kind, e.InvolvedObject.Name),
+			Message: e.Message,
+			Count:   e.Count,
+		})
+	}
+	return marshalYAMLDoc(out)
+}
+
+// collectPodLogs writes the logs of every container (including init containers)
+// of every pod in the namespace to <dir>/<pod>_<container>.log.
+func collectPodLogs(ctx context.Context, dir, namespace string) error {
+	pods, err := k8sClientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return os.WriteFile(filepath.Join(dir, "error.txt"), []byte(fmt.Sprintf("failed to list pods: %v\n", err)), 0o644)
+	}
+	limit := int64(maxPodLogBytes)
+	for _, pod := range pods.Items {
+		var containers []corev1.Container
+		containers = append(containers, pod.Spec.InitContainers...)
+		containers = append(containers, pod.Spec.Containers...)
+		for _, c := range containers {
+			path := filepath.Join(dir, fmt.Sprintf("%s_%s.log", pod.Name, c.Name))
+			content := readContainerLog(ctx, namespace, pod.Name, c.Name, limit)
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readContainerLog returns the log of a container, or a line describing why it is unavailable.
+func readContainerLog(ctx context.Context, namespace, pod, container string, limit int64) string {
+	stream, err := k8sClientset.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{
+		Container:  container,
+		LimitBytes: &limit,
+	}).Stream(ctx)
+	if err != nil {
+		return fmt.Sprintf("failed to get logs: %v\n", err)
+	}
+	defer func() { _ = stream.Close() }()
+	bs, err := io.ReadAll(stream)
+	if err != nil {
+		return fmt.Sprintf("%s\nfailed to read logs: %v\n", string(bs), err)
+	}
+	return string(bs)
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+)
+
+// --------------------------------------------------------------------------
+// In-cluster stand-ins for external services
+// --------------------------------------------------------------------------
+
+const (
+	// namespacePlaceholder is replaced with the test namespace in Applications
+	// and prerequisite resources, so fixtures can address stand-in Services by
+	// their cluster DNS name (e.g. echo-receiver.${E2E_NAMESPACE}.svc.cluster.local).
+	namespacePlaceholder = "${E2E_NAMESPACE}"
+
+	// defaultReceiver is the stand-in queried by receivedRequests expectations
+	// that do not name one explicitly.
+	defaultReceiver = "echo-receiver"
+
+	// StandInReadyTimeout bounds how long a stand-in Deployment may take to become available.
+	StandInReadyTimeout = 2 * time.Minute
+)
+
+// ReceivedRequestExpectation describes a request that an HTTP receiver stand-in
+// must have recorded. At least one recorded request has to match all fields.
+type ReceivedRequestExpectation struct {
+	Receiver string                 `yaml:"receiver,omitempty" json:"receiver,omitempty"` // defaults to echo-receiver
+	Method   string                 `yaml:"method,omitempty" json:"method,omitempty"`
+	Path     string                 `yaml:"path,omitempty" json:"path,omitempty"`
+	Body     map[string]interface{} `yaml:"body,omitempty" json:"body,omitempty"` // dot-path -> expected value in the JSON body
+}
+
+// receivedRequest is a single request as recorded by the echo-receiver stand-in.
+type receivedRequest struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers"`
+	Body    interface{}       `json:"body"`
+}
+
+// renderNamespacePlaceholder replaces namespacePlaceholder in the Application spec
+// with the test namespace.
+func renderNamespacePlaceholder(app *v1beta1.Application, namespace string) error {
+	bs, err := json.Marshal(app.Spec)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(string(bs), namespacePlaceholder) {
+		return nil
+	}
+	rendered := strings.ReplaceAll(string(bs), namespacePlaceholder, namespace)
+	return json.Unmarshal([]byte(rendered), &app.Spec)
+}
+
+// getStandInPath returns the manifest path of a stand-in under TESTDATA_PATH/standins.
+func getStandInPath(name string) string {
+	return filepath.Join(getTestDataPath(), "standins", name+".yaml")
+}
+
+// deployStandIns applies the named stand-in manifests into the test namespace and
+// waits until their Deployments are available.
+func deployStandIns(ctx context.Context, names []string, namespace string) {
+	for _, name := range names {
+		path := getStandInPath(name)
+		_, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred(), "Unknown stand-in %q", name)
+
+		GinkgoWriter.Printf("Deploying stand-in %s in namespace %s...\n", name, namespace)
+		Expect(applyPrerequisiteResources(ctx, path, namespace)).To(Succeed(), "Failed to apply stand-in %q", name)
+
+		Eventually(func(g Gomega) {
+			deploy := &appsv1.Deployment{}
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, deploy)).To(Succeed())
+			g.Expect(deploy.Status.AvailableReplicas).To(BeNumerically(">=", 1))
+		}, StandInReadyTimeout, PollInterval).Should(Succeed(),
+			fmt.Sprintf("Stand-in %s should become available in namespace %s", name, namespace))
+	}
+}
+
+// fetchReceivedRequests reads the requests recorded by a receiver stand-in through
+// the API server's service proxy, so it works from outside the cluster.
+func fetchReceivedRequests(ctx context.Context, receiver, namespace string) ([]receivedRequest, error) {
+	raw, err := k8sClientset.CoreV1().Services(namespace).ProxyGet("http", receiver, "http", "/__received", nil).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recorded requests from %s: %w", receiver, err)
+	}
+	var reqs []receivedRequest
+	if err := json.Unmarshal(raw, &reqs); err != nil {
+		return nil, fmt.Errorf("failed to decode recorded requests from %s: %w", receiver, err)
+	}
+	return reqs, nil
+}
+
+// matchesReceivedRequest reports whether a recorded request satisfies the expectation.
+func matchesReceivedRequest(req receivedRequest, exp ReceivedRequestExpectation) bool {
+	if exp.Method != "" && !strings.EqualFold(req.Method, exp.Method) {
+		return false
+	}
+	if exp.Path != "" && req.Path != exp.Path {
+		return false
+	}
+	if len(exp.Body) == 0 {
+		return true
+	}
+	body, ok := req.Body.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for path, expected := range exp.Body {
+		actual, err := getNestedValue(body, path)
+		if err != nil || !reflect.DeepEqual(normalizeValue(expected), normalizeValue(actual)) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateReceivedRequests checks that each expectation is matched by a request
+// recorded by the corresponding receiver stand-in.
+func validateReceivedRequests(ctx context.Context, namespace string, expectations []ReceivedRequestExpectation) {
+	for _, exp := range expectations {
+		receiver := exp.Receiver
+		if receiver == "" {
+			receiver = defaultReceiver
+		}
+		GinkgoWriter.Printf("  Checking %s received %s %s...\n", receiver, exp.Method, exp.Path)
+
+		var recorded []receivedRequest
+		Eventually(func(g Gomega) {
+			reqs, err := fetchReceivedRequests(ctx, receiver, namespace)
+			g.Expect(err).NotTo(HaveOccurred())
+			recorded = reqs
+			matched := false
+			for _, req := range reqs {
+				if matchesReceivedRequest(req, exp) {
+					matched = true
+					break
+				}
+			}
+			g.Expect(matched).To(BeTrue())
+		}, 30*time.Second, 2*time.Second).Should(Succeed(), func() string {
+			return fmt.Sprintf("%s did not record a request matching:\n%srecorded:\n%s", receiver, describeExpectation(exp), describeRecorded(recorded))
+		})
+	}
+}
+
+// describeExpectation renders a receivedRequests expectation for failure messages.
+func describeExpectation(exp ReceivedRequestExpectation) string {
+	bs, _ := yaml.Marshal(exp)
+	return string(bs)
+}
+
+// describeRecorded renders recorded requests for failure messages.
+func describeRecorded(reqs []receivedRequest) string {
+	if len(reqs) == 0 {
+		return "  (none)"
+	}
+	var sb strings.Builder
+	for _, req := range reqs {
+		body, _ := json.Marshal(req.Body)
+		sb.WriteString(fmt.Sprintf("  %s %s %s\n", req.Method, req.Path, string(body)))
+	}
+	return sb.String()
+}
@@ -0,0 +1,738 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+)
+
+// --------------------------------------------------------------------------
+// Upgrade safety: base-ref definitions -> working-tree definitions
+// --------------------------------------------------------------------------
+
+const (
+	// UpgradeReconcileTimeout bounds how long an Application may take to reconcile
+	// and roll out its workloads after the definitions are upgraded.
+	UpgradeReconcileTimeout = 5 * time.Minute
+	// UpgradeSettlePeriod is how long an Application whose revision did not change
+	// is observed before it is considered reconciled.
+	UpgradeSettlePeriod = 30 * time.Second
+
+	// upgradeNamespacePrefix keeps upgrade namespaces apart from the regular suites.
+	upgradeNamespacePrefix = "e2e-up-"
+	// upgradeReconcileAnnotation is bumped on every Application to trigger a reconcile
+	// against the upgraded definitions.
+	upgradeReconcileAnnotation = "e2e.oam.dev/upgrade-reconcile"
+)
+
+// upgradeSuiteLabels lists the suites whose Applications are deployed for the
+// upgrade check. Workflow steps are one-shot and leave no long-running workloads.
+var upgradeSuiteLabels = []string{"components", "traits", "policies"}
+
+// workloadKinds are the resources whose pod templates are tracked across the upgrade.
+var workloadKinds = map[string]bool{
+	"Deployment":  true,
+	"StatefulSet": true,
+	"DaemonSet":   true,
+	"Job":         true,
+	"CronJob":     true,
+}
+
+// UpgradeAllowlist is the structure of upgrade-allowlist.yaml under TESTDATA_PATH.
+type UpgradeAllowlist struct {
+	Allowed []UpgradeAllowlistEntry `yaml:"allowed" json:"allowed"`
+}
+
+// UpgradeAllowlistEntry permits the workloads of a test file to restart on upgrade.
+// Kind and Name narrow the entry to a single workload.
+type UpgradeAllowlistEntry struct {
+	File   string `yaml:"file" json:"file"`
+	Kind   string `yaml:"kind,omitempty" json:"kind,omitempty"`
+	Name   string `yaml:"name,omitempty" json:"name,omitempty"`
+	Reason string `yaml:"reason" json:"reason"`
+}
+
+// workloadSnapshot captures a workload and its pods at a point in time.
+type workloadSnapshot struct {
+	File         string
+	APIVersion   string
+	Kind         string
+	Name         string
+	Namespace    string
+	Spec         map[string]interface{}
+	TemplateHash string
+}
+
+// podSnapshot captures the identity and restart count of a pod.
+type podSnapshot struct {
+	UID      types.UID
+	Owner    string
+	Restarts int32
+}
+
+// upgradeDeployment tracks the Applications of one test file in its namespace.
+type upgradeDeployment struct {
+	File      string
+	Namespace string
+	Apps      []*v1beta1.Application
+	Revisions map[string]string
+}
+
+// WorkloadChange is one entry of the upgrade report.
+type WorkloadChange struct {
+	File            string   `json:"file"`
+	Kind            string   `json:"kind"`
+	Name            string   `json:"name"`
+	Namespace       string   `json:"namespace"`
+	TemplateChanged bool     `json:"templateChanged"`
+	SpecDiff        []string `json:"specDiff,omitempty"`
+	RestartedPods   []string `json:"restartedPods,omitempty"`
+	Allowed         bool     `json:"allowed"`
+	AllowReason     string   `json:"allowReason,omitempty"`
+}
+
+// Restarted reports whether the upgrade rolled or restarted the workload's pods.
+func (c WorkloadChange) Restarted() bool {
+	return c.TemplateChanged || len(c.RestartedPods) > 0
+}
+
+// UpgradeReport is written to E2E_ARTIFACTS_DIR/upgrade-report.json.
+type UpgradeReport struct {
+	BaseRef       string            `json:"baseRef"`
+	Changes       []WorkloadChange  `json:"changes"`
+	NotComparable map[string]string `json:"notComparable,omitempty"` // file -> reason
+}
+
+var _ = Describe("Definition Upgrade Safety", Label("upgrade"), Serial, func() {
+	ctx := context.Background()
+
+	It("should not restart workloads outside the allowlist when upgrading definitions", func() {
+		baseRef := os.Getenv("E2E_UPGRADE_BASE_REF")
+		if baseRef == "" {
+			Skip("Skipping: set E2E_UPGRADE_BASE_REF to run the upgrade safety check")
+		}
+
+		allowlist, err := loadUpgradeAllowlist()
+		Expect(err).NotTo(HaveOccurred(), "Failed to load upgrade allowlist")
+
+		report := UpgradeReport{BaseRef: baseRef, NotComparable: map[string]string{}}
+		var deployed []*upgradeDeployment
+
+		DeferCleanup(func() {
+			for _, d := range deployed {
+				for _, app := range d.Apps {
+					_ = k8sClient.Delete(ctx, app)
+				}
+				_ = k8sClient.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: d.Namespace}})
+			}
+		})
+
+		By(fmt.Sprintf("Installing definitions from base ref %s", baseRef))
+		baseDir := checkoutBaseRef(baseRef)
+		Expect(installDefinitions(ctx, baseDir)).To(Succeed(), "Failed to install base definitions")
+
+		By("Deploying example Applications against the base definitions")
+		for _, file := range listUpgradeFiles() {
+			d, reason := deployForUpgrade(ctx, file)
+			if d != nil {
+				deployed = append(deployed, d)
+			}
+			if reason != "" {
+				report.NotComparable[filepath.Base(file)] = reason
+			}
+		}
+		deployments := waitForUpgradeDeployments(ctx, deployed, report.NotComparable)
+
+		By("Capturing workloads and pods before the upgrade")
+		beforeWorkloads, beforePods := map[string]workloadSnapshot{}, map[string]map[string]podSnapshot{}
+		for _, d := range deployments {
+			for k, w := range snapshotWorkloads(ctx, d) {
+				beforeWorkloads[k] = w
+			}
+			beforePods[d.Namespace] = snapshotPods(ctx, d.Namespace)
+			for _, app := range d.Apps {
+				d.Revisions[app.Name] = appRevision(ctx, d.Namespace, app.Name)
+			}
+		}
+
+		By("Upgrading to the working-tree definitions")
+		Expect(installDefinitions(ctx, getProjectRoot())).To(Succeed(), "Failed to install working-tree definitions")
+		waitForUpgradeReconcile(ctx, deployments)
+
+		By("Comparing workloads and pods after the upgrade")
+		for _, d := range deployments {
+			afterWorkloads := snapshotWorkloads(ctx, d)
+			afterPods := snapshotPods(ctx, d.Namespace)
+			restarted := restartedPods(beforePods[d.Namespace], afterPods)
+			for key, before := range beforeWorkloads {
+				if before.Namespace != d.Namespace {
+					continue
+				}
+				change := WorkloadChange{File: before.File, Kind: before.Kind, Name: before.Name, Namespace: before.Namespace}
+				if after, ok := afterWorkloads[key]; ok {
+					change.TemplateChanged = before.TemplateHash != after.TemplateHash
+					diffValues("spec", before.Spec, after.Spec, &change.SpecDiff)
+				} else {
+					change.SpecDiff = []string{"workload was deleted"}
+				}
+				change.RestartedPods = restarted[before.Kind+"/"+before.Name]
+				if len(change.SpecDiff) == 0 && !change.Restarted() {
+					continue
+				}
+				if entry, ok := allowlist.match(change); ok {
+					change.Allowed, change.AllowReason = true, entry.Reason
+				}
+				report.Changes = append(report.Changes, change)
+			}
+		}
+		sort.Slice(report.Changes, func(i, j int) bool {
+			a, b := report.Changes[i], report.Changes[j]
+			return a.File+a.Kind+a.Name < b.File+b.Kind+b.Name
+		})
+
+		writeUpgradeReport(report)
+		GinkgoWriter.Printf("%s", describeUpgradeReport(report))
+
+		var violations []string
+		for _, c := range report.Changes {
+			if c.Restarted() && !c.Allowed {
+				violations = append(violations, fmt.Sprintf("%s: %s/%s", c.File, c.Kind, c.Name))
+			}
+		}
+		Expect(violations).To(BeEmpty(),
+			"Workloads restarted by the definition upgrade that are not in upgrade-allowlist.yaml:\n  %s\n%s",
+			strings.Join(violations, "\n  "), describeUpgradeReport(report))
+	})
+})
+
+// loadUpgradeAllowlist reads upgrade-allowlist.yaml from the test data directory.
+func loadUpgradeAllowlist() (*UpgradeAllowlist, error) {
+	data, err := os.ReadFile(filepath.Join(getTestDataPath(), "upgrade-allowlist.yaml"))
+	if os.IsNotExist(err) {
+		return &UpgradeAllowlist{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var al UpgradeAllowlist
+	if err := yaml.Unmarshal(data, &al); err != nil {
+		return nil, err
+	}
+	for _, e := range al.Allowed {
+		if e.File == "" || e.Reason == "" {
+			return nil, fmt.Errorf("allowlist entry %+v needs both file and reason", e)
+		}
+	}
+	return &al, nil
+}
+
+// match returns the first allowlist entry covering the change.
+func (al *UpgradeAllowlist) match(c WorkloadChange) (UpgradeAllowlistEntry, bool) {
+	for _, e := range al.Allowed {
+		if e.File != c.File {
+			continue
+		}
+		if (e.Kind == "" || e.Kind == c.Kind) && (e.Name == "" || e.Name == c.Name) {
+			return e, true
+		}
+	}
+	return UpgradeAllowlistEntry{}, false
+}
+
+// checkoutBaseRef checks out ref into a temporary git worktree that is removed
+// when the spec finishes.
+func checkoutBaseRef(ref string) string {
+	dir, err := os.MkdirTemp("", "e2e-upgrade-base-")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(os.Remove(dir)).To(Succeed()) // git worktree add creates it
+
+	root := getProjectRoot()
+	out, err := exec.Command("git", "-C", root, "worktree", "add", "--detach", dir, ref).CombinedOutput()
+	Expect(err).NotTo(HaveOccurred(), "git worktree add %s failed:\n%s", ref, string(out))
+	DeferCleanup(func() {
+		_ = exec.Command("git", "-C", root, "worktree", "remove", "--force", dir).Run()
+	})
+	return dir
+}
+
+// installDefinitions generates the CUE definitions of the module at moduleDir and
+// applies them with `vela def apply`, overwriting what is installed.
+func installDefinitions(ctx context.Context, moduleDir string) error {
+	outDir, err := os.MkdirTemp("", "e2e-upgrade-defs-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(outDir) }()
+
+	gen := exec.CommandContext(ctx, "go", "run", "./cmd/defkit", "generate", "--output-dir", outDir)
+	gen.Dir = moduleDir
+	if out, err := gen.CombinedOutput(); err != nil {
+		return fmt.Errorf("generate definitions in %s: %w\n%s", moduleDir, err, string(out))
+	}
+
+	files, err := filepath.Glob(filepath.Join(outDir, "*", "*.cue"))
+	if err != nil {
+		return err
+	}
+	GinkgoWriter.Printf("Applying %d definitions from %s...\n", len(files), moduleDir)
+	for _, f := range files {
+		if out, err := exec.CommandContext(ctx, "vela", "def", "apply", f).CombinedOutput(); err != nil {
+			return fmt.Errorf("vela def apply %s: %w\n%s", filepath.Base(f), err, string(out))
+		}
+	}
+	return nil
+}
+
+// listUpgradeFiles lists the test files of the upgrade suites, minus skipped ones
+// and those that need a managed cluster.
+func listUpgradeFiles() []string {
+	var files []string
+	for _, s := range suites {
+		if !containsString(upgradeSuiteLabels, s.label) {
+			continue
+		}
+		fs, err := listYAMLFiles(filepath.Join(getTestDataPath(), s.subdir))
+		Expect(err).NotTo(HaveOccurred())
+		for _, f := range fs {
+			if _, skip := s.skipTests[filepath.Base(f)]; skip {
+				continue
+			}
+			if len(loadExpectations(f).managedClusters()) > 0 {
+				continue
+			}
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// deployForUpgrade applies the stand-ins, prerequisites and Applications of a test
+// file into its own namespace. Failures do not fail the spec: the file is reported
+// as not comparable instead, since the base ref may predate its definitions.
+func deployForUpgrade(ctx context.Context, file string) (*upgradeDeployment, string) {
+	apps, err := readAllAppsFromFile(file)
+	if err != nil {
+		return nil, err.Error()
+	}
+	d := &upgradeDeployment{
+		File:      filepath.Base(file),
+		Namespace: upgradeNamespacePrefix + sanitizeForNamespace(apps[len(apps)-1].Name),
+		Revisions: map[string]string{},
+	}
+
+	err = InterceptGomegaFailure(func() {
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: d.Namespace}})).
+			To(Or(Succeed(), WithTransform(errors.IsAlreadyExists, BeTrue())))
+		for _, app := range apps {
+			app.SetNamespace(d.Namespace)
+			updateAppNamespaceReferences(app, d.Namespace)
+			Expect(renderNamespacePlaceholder(app, d.Namespace)).To(Succeed())
+		}
+		if ef := loadExpectations(file); ef != nil && len(ef.StandIns) > 0 {
+			deployStandIns(ctx, ef.StandIns, d.Namespace)
+		}
+		if hasPrerequisiteResources(file) {
+			Expect(applyPrerequisiteResources(ctx, file, d.Namespace)).To(Succeed())
+			waitForPrerequisiteResources(ctx, file, d.Namespace)
+		}
+		for _, app := range apps {
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+			d.Apps = append(d.Apps, app)
+		}
+	})
+	if err != nil {
+		return d, "deploy on base failed: " + err.Error()
+	}
+	return d, ""
+}
+
+// waitForUpgradeDeployments waits for all Applications to run on the base
+// definitions and returns the deployments that did; the rest are recorded in
+// notComparable.
+func waitForUpgradeDeployments(ctx context.Context, deployments []*upgradeDeployment, notComparable map[string]string) []*upgradeDeployment {
+	pending := map[*upgradeDeployment]bool{}
+	for _, d := range deployments {
+		if _, failed := notComparable[d.File]; !failed {
+			pending[d] = true
+		}
+	}
+
+	deadline := time.Now().Add(AppRunningTimeout)
+	for len(pending) > 0 && time.Now().Before(deadline) {
+		for d := range pending {
+			if allAppsRunning(ctx, d) {
+				delete(pending, d)
+			}
+		}
+		if len(pending) > 0 {
+			time.Sleep(PollInterval)
+		}
+	}
+
+	var ready []*upgradeDeployment
+	for _, d := range deployments {
+		if _, failed := notComparable[d.File]; failed {
+			continue
+		}
+		if pending[d] {
+			notComparable[d.File] = "not running on base definitions"
+			continue
+		}
+		ready = append(ready, d)
+	}
+	GinkgoWriter.Printf("%d of %d test files running on base definitions\n", len(ready), len(deployments))
+	return ready
+}
+
+func allAppsRunning(ctx context.Context, d *upgradeDeployment) bool {
+	for _, app := range d.Apps {
+		current := &v1beta1.Application{}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: d.Namespace, Name: app.Name}, current); err != nil {
+			return false
+		}
+		if current.Status.Phase != "running" {
+			return false
+		}
+	}
+	return true
+}
+
+// appRevision returns the name of the latest ApplicationRevision of an Application.
+func appRevision(ctx context.Context, namespace, name string) string {
+	app := &v1beta1.Application{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, app); err != nil {
+		return ""
+	}
+	if app.Status.LatestRevision == nil {
+		return ""
+	}
+	return app.Status.LatestRevision.Name
+}
+
+// waitForUpgradeReconcile triggers a reconcile of every Application and waits
+// until each runs again on a new revision (or stays unchanged for the settle
+// period) and its workloads have rolled out.
+func waitForUpgradeReconcile(ctx context.Context, deployments []*upgradeDeployment) {
+	stamp := time.Now().UTC().Format(time.RFC3339)
+	for _, d := range deployments {
+		for _, app := range d.Apps {
+			current := &v1beta1.Application{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: d.Namespace, Name: app.Name}, current)).To(Succeed())
+			patch := client.MergeFrom(current.DeepCopy())
+			annotations := current.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[upgradeReconcileAnnotation] = stamp
+			current.SetAnnotations(annotations)
+			Expect(k8sClient.Patch(ctx, current, patch)).To(Succeed())
+		}
+	}
+	bumped := time.Now()
+
+	for _, d := range deployments {
+		d := d
+		Eventually(func(g Gomega) {
+			for _, app := range d.Apps {
+				current := &v1beta1.Application{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: d.Namespace, Name: app.Name}, current)).To(Succeed())
+				g.Expect(string(current.Status.Phase)).To(Equal("running"))
+				revision := ""
+				if current.Status.LatestRevision != nil {
+					revision = current.Status.LatestRevision.Name
+				}
+				if revision == d.Revisions[app.Name] {
+					g.Expect(time.Since(bumped)).To(BeNumerically(">=", UpgradeSettlePeriod),
+						"%s has not produced a new revision yet", app.Name)
+				}
+			}
+			for _, w := range snapshotWorkloads(ctx, d) {
+				g.Expect(workloadRolledOut(ctx, w)).To(BeTrue(), "%s/%s is still rolling out", w.Kind, w.Name)
+			}
+		}, UpgradeReconcileTimeout, PollInterval).Should(Succeed(),
+			fmt.Sprintf("Applications of %s should reconcile after the upgrade", d.File))
+	}
+}
+
+// snapshotWorkloads captures the workloads listed in the Applications' appliedResources,
+// keyed by namespace/kind/name.
+func snapshotWorkloads(ctx context.Context, d *upgradeDeployment) map[string]workloadSnapshot {
+	out := map[string]workloadSnapshot{}
+	for _, app := range d.Apps {
+		current := &v1beta1.Application{}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: d.Namespace, Name: app.Name}, current); err != nil {
+			continue
+		}
+		for _, ar := range current.Status.AppliedResources {
+			if !workloadKinds[ar.Kind] {
+				continue
+			}
+			ns := ar.Namespace
+			if ns == "" {
+				ns = d.Namespace
+			}
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(parseGVK(ar.APIVersion, ar.Kind))
+			if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: ns, Name: ar.Name}, obj); err != nil {
+				continue
+			}
+			spec, _, _ := unstructured.NestedMap(obj.Object, "spec")
+			out[ns+"/"+ar.Kind+"/"+ar.Name] = workloadSnapshot{
+				File:         d.File,
+				APIVersion:   ar.APIVersion,
+				Kind:         ar.Kind,
+				Name:         ar.Name,
+				Namespace:    ns,
+				Spec:         spec,
+				TemplateHash: podTemplateHash(ar.Kind, obj),
+			}
+		}
+	}
+	return out
+}
+
+// podTemplateHash hashes a workload's pod template; a change means a rollout.
+func podTemplateHash(kind string, obj *unstructured.Unstructured) string {
+	path := []string{"spec", "template"}
+	if kind == "CronJob" {
+		path = []string{"spec", "jobTemplate", "spec", "template"}
+	}
+	tmpl, _, _ := unstructured.NestedMap(obj.Object, path...)
+	bs, _ := json.Marshal(tmpl)
+	sum := sha256.Sum256(bs)
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// workloadRolledOut reports whether the controller has finished rolling out the workload.
+func workloadRolledOut(ctx context.Context, w workloadSnapshot) bool {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(parseGVK(w.APIVersion, w.Kind))
+	if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: w.Namespace, Name: w.Name}, obj); err != nil {
+		return false
+	}
+	status := func(field string) int64 {
+		v, _, _ := unstructured.NestedInt64(obj.Object, "status", field)
+		return v
+	}
+	if observed, found, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration"); found && observed < obj.GetGeneration() {
+		return false
+	}
+	switch w.Kind {
+	case "Deployment":
+		replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+		if !found {
+			replicas = 1
+		}
+		return status("updatedReplicas") == replicas && status("replicas") == replicas
+	case "StatefulSet":
+		current, _, _ := unstructured.NestedString(obj.Object, "status", "currentRevision")
+		update, _, _ := unstructured.NestedString(obj.Object, "status", "updateRevision")
+		return current == update
+	case "DaemonSet":
+		return status("updatedNumberScheduled") == status("desiredNumberScheduled")
+	}
+	return true
+}
+
+// snapshotPods records the pods of a namespace by name.
+func snapshotPods(ctx context.Context, namespace string) map[string]podSnapshot {
+	out := map[string]podSnapshot{}
+	pods := &corev1.PodList{}
+	if err := k8sClient.List(ctx, pods, client.InNamespace(namespace)); err != nil {
+		return out
+	}
+	for _, pod := range pods.Items {
+		var restarts int32
+		for _, cs := range pod.Status.ContainerStatuses {
+			restarts += cs.RestartCount
+		}
+		out[pod.Name] = podSnapshot{UID: pod.UID, Owner: podWorkload(ctx, &pod), Restarts: restarts}
+	}
+	return out
+}
+
+// podWorkload resolves the workload (kind/name) owning a pod, following
+// ReplicaSet -> Deployment and Job -> CronJob.
+func podWorkload(ctx context.Context, pod *corev1.Pod) string {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return ""
+	}
+	var parent *unstructured.Unstructured
+	switch ref.Kind {
+	case "ReplicaSet", "Job":
+		parent = &unstructured.Unstructured{}
+		parent.SetAPIVersion(ref.APIVersion)
+		parent.SetKind(ref.Kind)
+		if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: ref.Name}, parent); err == nil {
+			if owner := metav1.GetControllerOf(parent); owner != nil {
+				return owner.Kind + "/" + owner.Name
+			}
+		}
+	}
+	return ref.Kind + "/" + ref.Name
+}
+
+// restartedPods returns, per owning workload, the pods that were replaced or
+// whose containers restarted between the two snapshots.
+func restartedPods(before, after map[string]podSnapshot) map[string][]string {
+	out := map[string][]string{}
+	for name, b := range before {
+		a, ok := after[name]
+		switch {
+		case !ok || a.UID != b.UID:
+			out[b.Owner] = append(out[b.Owner], name+" (replaced)")
+		case a.Restarts > b.Restarts:
+			out[b.Owner] = append(out[b.Owner], fmt.Sprintf("%s (%d container restarts)", name, a.Restarts-b.Restarts))
+		}
+	}
+	for _, pods := range out {
+		sort.Strings(pods)
+	}
+	return out
+}
+
+// diffValues appends a line per leaf path that differs between a and b.
+func diffValues(path string, a, b interface{}, out *[]string) {
+	am, aIsMap := a.(map[string]interface{})
+	bm, bIsMap := b.(map[string]interface{})
+	if aIsMap && bIsMap {
+		keys := map[string]bool{}
+		for k := range am {
+			keys[k] = true
+		}
+		for k := range bm {
+			keys[k] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		for _, k := range sorted {
+			diffValues(path+"."+k, am[k], bm[k], out)
+		}
+		return
+	}
+	as, aIsSlice := a.([]interface{})
+	bs, bIsSlice := b.([]interface{})
+	if aIsSlice && bIsSlice && len(as) == len(bs) {
+		for i := range as {
+			diffValues(fmt.Sprintf("%s[%d]", path, i), as[i], bs[i], out)
+		}
+		return
+	}
+	if !reflect.DeepEqual(a, b) {
+		*out = append(*out, fmt.Sprintf("%s: %s -> %s", path, compactJSON(a), compactJSON(b)))
+	}
+}
+
+func compactJSON(v interface{}) string {
+	if v == nil {
+		return "<unset>"
+	}
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(bs)
+}
+
+// writeUpgradeReport saves the report as JSON in the artifacts directory.
+func writeUpgradeReport(report UpgradeReport) {
+	dir := getArtifactsDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		GinkgoWriter.Printf("Failed to create artifacts directory %s: %v\n", dir, err)
+		return
+	}
+	bs, err := json.MarshalIndent(report, "", "  ")
+	if err == nil {
+		err = os.WriteFile(filepath.Join(dir, "upgrade-report.json"), bs, 0o644)
+	}
+	if err != nil {
+		GinkgoWriter.Printf("Failed to write upgrade report: %v\n", err)
+	}
+}
+
+// describeUpgradeReport renders the report for the test log and failure message.
+func describeUpgradeReport(report UpgradeReport) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n=== Upgrade from %s ===\n", report.BaseRef))
+	if len(report.Changes) == 0 {
+		sb.WriteString("No workload changes.\n")
+	}
+	for _, c := range report.Changes {
+		status := "changed"
+		if c.Restarted() {
+			status = "RESTARTED"
+			if c.Allowed {
+				status += " (allowed: " + c.AllowReason + ")"
+			}
+		}
+		sb.WriteString(fmt.Sprintf("%s %s/%s in %s: %s\n", c.File, c.Kind, c.Name, c.Namespace, status))
+		for _, line := range c.SpecDiff {
+			sb.WriteString("    " + line + "\n")
+		}
+		for _, pod := range c.RestartedPods {
+			sb.WriteString("    pod " + pod + "\n")
+		}
+	}
+	if len(report.NotComparable) > 0 {
+		files := make([]string, 0, len(report.NotComparable))
+		for f := range report.NotComparable {
+			files = append(files, f)
+		}
+		sort.Strings(files)
+		sb.WriteString("Not comparable:\n")
+		for _, f := range files {
+			sb.WriteString(fmt.Sprintf("    %s: %s\n", f, report.NotComparable[f]))
+		}
+	}
+	return sb.String()
+}
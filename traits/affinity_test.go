@@ -20,6 +20,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/traits"
 )
 
@@ -30,6 +31,7 @@ var _ = Describe("Affinity Trait", func() {
 		Expect(trait.GetName()).To(Equal("affinity"))
 
 		cue := trait.ToCue()
+		doc := cueassert.MustParse(cue)
 
 		// Header and attributes
 		Expect(cue).To(ContainSubstring(`type: "trait"`))
@@ -45,7 +47,7 @@ var _ = Describe("Affinity Trait", func() {
 		Expect(cue).To(ContainSubstring(`weight: int & >=1 & <=100`))
 
 		Expect(cue).To(ContainSubstring(`podAffinityTerm: #podAffinityTerm`))
-		Expect(cue).To(ContainSubstring(`nodeSelectorTerms: [...#nodeSelectorTerm]`))
+		Expect(doc.Lookup("parameter.nodeAffinity.required.nodeSelectorTerms")).To(cueassert.HaveValue("[...#nodeSelectorTerm]"))
 		Expect(cue).To(ContainSubstring(`preference: #nodeSelectorTerm`))
 
 		// Sub-field conditions
@@ -70,10 +72,10 @@ var _ = Describe("Affinity Trait", func() {
 
 		Expect(cue).To(ContainSubstring(`#labelSelector`))
 		Expect(cue).To(ContainSubstring(`matchLabels?: [string]: string`))
-		Expect(cue).To(ContainSubstring(`values?: [...string]`))
-		Expect(cue).To(ContainSubstring(`namespaces?: [...string]`))
+		Expect(doc.Lookup("#nodeSelector.values")).To(cueassert.BeOptionalListOf("string"))
+		Expect(doc.Lookup("#podAffinityTerm.namespaces")).To(cueassert.BeOptionalListOf("string"))
 		Expect(cue).To(ContainSubstring(`#podAffinityTerm`))
 		Expect(cue).To(ContainSubstring(`#nodeSelectorTerm`))
-		Expect(cue).To(ContainSubstring(`matchExpressions?: [...#nodeSelector]`))
+		Expect(doc.Lookup("#nodeSelectorTerm.matchExpressions")).To(cueassert.BeOptionalListOf("#nodeSelector"))
 	})
 })
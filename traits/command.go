@@ -29,6 +29,7 @@ func Command() *defkit.TraitDefinition {
 	return defkit.NewTrait("command").
 		Description("Add command on K8s pod for your workload which follows the pod spec in path 'spec.template'").
 		AppliesTo("deployments.apps", "statefulsets.apps", "daemonsets.apps", "jobs.batch").
+		WithImports("list").
 		Template(func(tpl *defkit.Template) {
 			tpl.UsePatchContainer(defkit.PatchContainerConfig{
 				ContainerNameParam:    "containerName",
@@ -114,7 +115,7 @@ if len(_matchContainers_) > 0 {
 	}
 
 	// +patchStrategy=replace
-	args: [for a in _args if _delArgs[a] == _|_ {a}] + [for a in _addArgs if _delArgs[a] == _|_ && _argsMap[a] == _|_ {a}]
+	args: list.Concat([[for a in _args if _delArgs[a] == _|_ {a}], [for a in _addArgs if _delArgs[a] == _|_ && _argsMap[a] == _|_ {a}]])
 }`,
 			})
 		})
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/traits"
 )
 
@@ -33,6 +34,7 @@ var _ = Describe("Command Trait", func() {
 		Expect(trait.GetDescription()).To(Equal("Add command on K8s pod for your workload which follows the pod spec in path 'spec.template'"))
 
 		cue := trait.ToCue()
+		doc := cueassert.MustParse(cue)
 
 		// Metadata
 		Expect(cue).To(ContainSubstring(`type: "trait"`))
@@ -54,27 +56,28 @@ var _ = Describe("Command Trait", func() {
 
 		// PatchContainer body: complex merge logic keywords
 		Expect(cue).To(ContainSubstring(`PatchContainer: {`))
-		Expect(cue).To(ContainSubstring(`_params:         #PatchParams`))
-		Expect(cue).To(ContainSubstring(`_baseContainers: context.output.spec.template.spec.containers`))
+		Expect(doc.Lookup("PatchContainer._params")).To(cueassert.HaveValue("#PatchParams"))
+		Expect(doc.Lookup("PatchContainer._baseContainers")).To(cueassert.HaveValue("context.output.spec.template.spec.containers"))
 		Expect(cue).To(ContainSubstring(`_matchContainers_:`))
-		Expect(cue).To(ContainSubstring(`_baseContainer: *_|_ | {...}`))
-		Expect(cue).To(ContainSubstring(`_delArgs: {...}`))
+		Expect(doc.Lookup("PatchContainer._baseContainer")).To(SatisfyAll(cueassert.HaveDefaultSource("_|_"), cueassert.HaveType("{...}")))
+		Expect(doc.Lookup("PatchContainer._delArgs")).To(cueassert.HaveValue("{...}"))
 		Expect(cue).To(ContainSubstring(`_argsMap: {for a in _args`))
-		Expect(cue).To(ContainSubstring(`_addArgs: [...string]`))
+		Expect(doc.Lookup("PatchContainer._addArgs")).To(cueassert.HaveValue("[...string]"))
+		Expect(cue).To(ContainSubstring(`list.Concat([`))
 
 		// _params mapping: auto-generated unconditional field mappings
 		Expect(cue).To(ContainSubstring("command: parameter.command"))
-		Expect(cue).To(ContainSubstring("args:    parameter.args"))
+		Expect(doc).To(cueassert.ContainField("args", "parameter.args"))
 		Expect(cue).To(ContainSubstring("addArgs: parameter.addArgs"))
 		Expect(cue).To(ContainSubstring("delArgs: parameter.delArgs"))
 
 		// Multi-container support
 		Expect(cue).To(ContainSubstring("if parameter.containers == _|_"))
 		Expect(cue).To(ContainSubstring("if parameter.containers != _|_"))
-		Expect(cue).To(ContainSubstring("containers: [...#PatchParams]"))
+		Expect(doc.Lookup("parameter.containers")).To(cueassert.HaveValue("[...#PatchParams]"))
 
 		// Error collection
-		Expect(cue).To(ContainSubstring(`errs: [for c in patch.spec.template.spec.containers if c.err != _|_ {c.err}]`))
+		Expect(doc.Lookup("errs")).To(cueassert.HaveValue("[for c in patch.spec.template.spec.containers if c.err != _|_ {c.err}]"))
 
 		// Descriptions
 		Expect(cue).To(ContainSubstring("// +usage=Specify the command to use in the target container"))
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/traits"
 )
 
@@ -33,13 +34,14 @@ var _ = Describe("Container Image Trait", func() {
 		Expect(trait.GetDescription()).To(Equal("Set the image of the container."))
 
 		cue := trait.ToCue()
+		doc := cueassert.MustParse(cue)
 
 		// Verify trait metadata
 		Expect(cue).To(ContainSubstring(`type: "trait"`))
 		Expect(cue).To(ContainSubstring(`podDisruptive: true`))
 		Expect(cue).To(ContainSubstring(`"deployments.apps"`))
 
-		Expect(cue).To(ContainSubstring(`imagePullPolicy: *"" | "IfNotPresent" | "Always" | "Never"`))
+		Expect(doc.Lookup("#PatchParams.imagePullPolicy")).To(SatisfyAll(cueassert.HaveDefault(""), cueassert.HaveType(`"IfNotPresent" | "Always" | "Never"`)))
 		Expect(cue).NotTo(ContainSubstring(`imagePullPolicy: *null`))
 
 		Expect(cue).To(ContainSubstring("imagePullPolicy: parameter.imagePullPolicy"))
@@ -56,9 +58,9 @@ var _ = Describe("Container Image Trait", func() {
 		// PatchContainer structure
 		Expect(cue).To(ContainSubstring(`#PatchParams: {`))
 		Expect(cue).To(ContainSubstring(`PatchContainer: {`))
-		Expect(cue).To(ContainSubstring(`_params:         #PatchParams`))
-		Expect(cue).To(ContainSubstring(`_baseContainers: context.output.spec.template.spec.containers`))
-		Expect(cue).To(ContainSubstring(`errs: [for c in patch.spec.template.spec.containers if c.err != _|_ {c.err}]`))
+		Expect(doc.Lookup("PatchContainer._params")).To(cueassert.HaveValue("#PatchParams"))
+		Expect(doc.Lookup("PatchContainer._baseContainers")).To(cueassert.HaveValue("context.output.spec.template.spec.containers"))
+		Expect(doc.Lookup("errs")).To(cueassert.HaveValue("[for c in patch.spec.template.spec.containers if c.err != _|_ {c.err}]"))
 
 		// PatchContainer body: conditional for imagePullPolicy inside PatchContainer
 		Expect(cue).To(ContainSubstring(`if _params.imagePullPolicy != ""`))
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/traits"
 )
 
@@ -33,6 +34,7 @@ var _ = Describe("Container Ports Trait", func() {
 		Expect(trait.GetDescription()).To(Equal("Expose on the host and bind the external port to host to enable web traffic for your component."))
 
 		cue := trait.ToCue()
+		doc := cueassert.MustParse(cue)
 
 		// Metadata
 		Expect(cue).To(ContainSubstring(`type: "trait"`))
@@ -58,9 +60,9 @@ var _ = Describe("Container Ports Trait", func() {
 
 		// PatchContainer body: complex port merge logic
 		Expect(cue).To(ContainSubstring(`PatchContainer: {`))
-		Expect(cue).To(ContainSubstring(`_params:         #PatchParams`))
-		Expect(cue).To(ContainSubstring(`_baseContainers: context.output.spec.template.spec.containers`))
-		Expect(cue).To(ContainSubstring(`_basePorts:     _baseContainer.ports`))
+		Expect(doc.Lookup("PatchContainer._params")).To(cueassert.HaveValue("#PatchParams"))
+		Expect(doc.Lookup("PatchContainer._baseContainers")).To(cueassert.HaveValue("context.output.spec.template.spec.containers"))
+		Expect(doc.Lookup("PatchContainer._basePorts")).To(cueassert.HaveValue("_baseContainer.ports"))
 		Expect(cue).To(ContainSubstring(`_basePortsMap:`))
 		Expect(cue).To(ContainSubstring(`_portsMap:`))
 		Expect(cue).To(ContainSubstring(`_uniqueKey:`))
@@ -73,10 +75,10 @@ var _ = Describe("Container Ports Trait", func() {
 		// Multi-container support
 		Expect(cue).To(ContainSubstring("if parameter.containers == _|_"))
 		Expect(cue).To(ContainSubstring("if parameter.containers != _|_"))
-		Expect(cue).To(ContainSubstring("containers: [...#PatchParams]"))
+		Expect(doc.Lookup("parameter.containers")).To(cueassert.HaveValue("[...#PatchParams]"))
 
 		// Error collection
-		Expect(cue).To(ContainSubstring(`errs: [for c in patch.spec.template.spec.containers if c.err != _|_ {c.err}]`))
+		Expect(doc.Lookup("errs")).To(cueassert.HaveValue("[for c in patch.spec.template.spec.containers if c.err != _|_ {c.err}]"))
 
 		// Descriptions
 		Expect(cue).To(ContainSubstring("// +usage=Specify ports you want customer traffic sent to"))
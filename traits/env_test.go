@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/traits"
 )
 
@@ -33,6 +34,7 @@ var _ = Describe("Env Trait", func() {
 		Expect(trait.GetDescription()).To(Equal("Add env on K8s pod for your workload which follows the pod spec in path 'spec.template'"))
 
 		cue := trait.ToCue()
+		doc := cueassert.MustParse(cue)
 
 		// Metadata
 		Expect(cue).To(ContainSubstring(`type: "trait"`))
@@ -53,25 +55,25 @@ var _ = Describe("Env Trait", func() {
 
 		// PatchContainer body: complex env merge logic keywords
 		Expect(cue).To(ContainSubstring(`PatchContainer: {`))
-		Expect(cue).To(ContainSubstring(`_params: #PatchParams`))
+		Expect(doc.Lookup("PatchContainer._params")).To(cueassert.HaveValue("#PatchParams"))
 		Expect(cue).To(ContainSubstring(`_delKeys: {for k in _params.unset`))
-		Expect(cue).To(ContainSubstring(`_baseContainers: context.output.spec.template.spec.containers`))
-		Expect(cue).To(ContainSubstring(`_baseEnv:       _baseContainer.env`))
+		Expect(doc.Lookup("PatchContainer._baseContainers")).To(cueassert.HaveValue("context.output.spec.template.spec.containers"))
+		Expect(doc.Lookup("PatchContainer._baseEnv")).To(cueassert.HaveValue("_baseContainer.env"))
 		Expect(cue).To(ContainSubstring(`_baseEnvMap: {for envVar in _baseEnv`))
 		Expect(cue).To(ContainSubstring(`envVar.valueFrom`))
 
 		// _params mapping: auto-generated unconditional field mappings
 		Expect(cue).To(ContainSubstring("replace: parameter.replace"))
-		Expect(cue).To(ContainSubstring("env:     parameter.env"))
-		Expect(cue).To(ContainSubstring("unset:   parameter.unset"))
+		Expect(doc).To(cueassert.ContainField("env", "parameter.env"))
+		Expect(doc).To(cueassert.ContainField("unset", "parameter.unset"))
 
 		// Multi-container support
 		Expect(cue).To(ContainSubstring("if parameter.containers == _|_"))
 		Expect(cue).To(ContainSubstring("if parameter.containers != _|_"))
-		Expect(cue).To(ContainSubstring("containers: [...#PatchParams]"))
+		Expect(doc.Lookup("parameter.containers")).To(cueassert.HaveValue("[...#PatchParams]"))
 
 		// Error collection
-		Expect(cue).To(ContainSubstring(`errs: [for c in patch.spec.template.spec.containers if c.err != _|_ {c.err}]`))
+		Expect(doc.Lookup("errs")).To(cueassert.HaveValue("[for c in patch.spec.template.spec.containers if c.err != _|_ {c.err}]"))
 
 		// Descriptions
 		Expect(cue).To(ContainSubstring("// +usage=Specify if replacing the whole environment settings"))
@@ -20,6 +20,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/traits"
 )
 
@@ -31,6 +32,7 @@ var _ = Describe("Expose Trait", func() {
 		Expect(trait.GetDescription()).To(Equal("Expose port to enable web traffic for your component."))
 
 		cue := trait.ToCue()
+		doc := cueassert.MustParse(cue)
 
 		// Header and attributes
 		Expect(cue).To(ContainSubstring(`type: "trait"`))
@@ -48,8 +50,8 @@ var _ = Describe("Expose Trait", func() {
 
 		// Output resource
 		Expect(cue).To(ContainSubstring(`outputs: service:`))
-		Expect(cue).To(ContainSubstring(`kind:       "Service"`))
-		Expect(cue).To(ContainSubstring(`metadata: name:        context.name`))
+		Expect(doc.Lookup("outputs.service.kind")).To(cueassert.HaveValue(`"Service"`))
+		Expect(doc.Lookup("outputs.service.metadata.name")).To(cueassert.HaveValue("context.name"))
 
 		// Dual-path port handling (legacy vs modern)
 		Expect(cue).To(ContainSubstring(`if parameter["port"] != _|_`))
@@ -58,7 +60,7 @@ var _ = Describe("Expose Trait", func() {
 		Expect(cue).To(ContainSubstring(`strings.ToLower`))
 
 		// Parameters
-		Expect(cue).To(ContainSubstring(`port?: [...int]`))
+		Expect(doc.Lookup("parameter.port")).To(cueassert.BeOptionalListOf("int"))
 		Expect(cue).To(ContainSubstring(`ports?: [`))
 		Expect(cue).To(ContainSubstring(`annotations: [string]:`))
 		Expect(cue).To(ContainSubstring(`matchLabels?: [string]:`))
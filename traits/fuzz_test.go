@@ -0,0 +1,33 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package traits_test
+
+import (
+	"testing"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+
+	"github.com/oam-dev/vela-go-definitions/internal/paramfuzz"
+)
+
+var _ = paramfuzz.DescribeParameters(defkit.Traits())
+
+// FuzzTraitParameters lets `go test -fuzz` explore generator seeds beyond
+// the fixed range the Ginkgo suite covers.
+func FuzzTraitParameters(f *testing.F) {
+	paramfuzz.FuzzParameters(f, defkit.Traits())
+}
@@ -166,9 +166,11 @@ outputs: (ingressOutputName): {
 		}
 		if parameter.secretName != _|_ {
 			tls: [{
-				hosts: [
-					parameter.domain,
-				]
+				if parameter.domain != _|_ {
+					hosts: [
+						parameter.domain,
+					]
+				}
 				secretName: parameter.secretName
 			}]
 		}
@@ -20,6 +20,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/traits"
 )
 
@@ -31,6 +32,7 @@ var _ = Describe("Gateway Trait", func() {
 		Expect(trait.GetDescription()).To(Equal("Enable public web traffic for the component, the ingress API matches K8s v1.20+."))
 
 		cue := trait.ToCue()
+		doc := cueassert.MustParse(cue)
 
 		// Header and attributes
 		Expect(cue).To(ContainSubstring(`type: "trait"`))
@@ -49,7 +51,7 @@ var _ = Describe("Gateway Trait", func() {
 
 		// Conditional Service output (only when no existing service)
 		Expect(cue).To(ContainSubstring(`if (parameter.existingServiceName == _|_)`))
-		Expect(cue).To(ContainSubstring(`kind:       "Service"`))
+		Expect(doc).To(cueassert.ContainField("kind", `"Service"`))
 
 		// Dynamic output names
 		Expect(cue).To(ContainSubstring(`(serviceOutputName):`))
@@ -72,12 +74,12 @@ var _ = Describe("Gateway Trait", func() {
 		Expect(cue).To(ContainSubstring(`if parameter.labels != _|_`))
 
 		// Parameters
-		Expect(cue).To(ContainSubstring(`domain?: string`))
+		Expect(doc.Lookup("parameter.domain")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
 		Expect(cue).To(ContainSubstring(`http: [string]: int`))
-		Expect(cue).To(ContainSubstring(`class: *"nginx" | string`))
-		Expect(cue).To(ContainSubstring(`classInSpec: *false | bool`))
-		Expect(cue).To(ContainSubstring(`secretName?: string`))
+		Expect(doc.Lookup("parameter.class")).To(SatisfyAll(cueassert.HaveDefault("nginx"), cueassert.HaveType("string")))
+		Expect(doc.Lookup("parameter.classInSpec")).To(SatisfyAll(cueassert.HaveDefault(false), cueassert.HaveType("bool")))
+		Expect(doc.Lookup("parameter.secretName")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
 		Expect(cue).To(ContainSubstring(`pathType: *"ImplementationSpecific"`))
-		Expect(cue).To(ContainSubstring(`existingServiceName?: string`))
+		Expect(doc.Lookup("parameter.existingServiceName")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
 	})
 })
@@ -20,12 +20,14 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/traits"
 )
 
 var _ = Describe("HostAlias", func() {
 	It("should have correct name and CUE output", func() {
 		cue := traits.HostAlias().ToCue()
+		doc := cueassert.MustParse(cue)
 
 		// Metadata
 		Expect(cue).To(ContainSubstring(`hostalias: {`))
@@ -39,7 +41,7 @@ var _ = Describe("HostAlias", func() {
 
 		// Patch block: patchKey annotation and direct array assignment (no wrapping)
 		Expect(cue).To(ContainSubstring(`// +patchKey=ip`))
-		Expect(cue).To(ContainSubstring(`hostAliases: parameter.hostAliases`))
+		Expect(doc.Lookup("patch.spec.template.spec.hostAliases")).To(cueassert.HaveValue("parameter.hostAliases"))
 		// Should NOT wrap in array brackets
 		Expect(cue).NotTo(ContainSubstring(`[parameter.hostAliases]`))
 
@@ -20,6 +20,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/traits"
 )
 
@@ -31,6 +32,7 @@ var _ = Describe("HPA", func() {
 		Expect(trait.GetDescription()).To(Equal("Configure k8s HPA for Deployment or Statefulsets"))
 
 		cue := trait.ToCue()
+		doc := cueassert.MustParse(cue)
 
 		// Header and attributes
 		Expect(cue).To(ContainSubstring(`type: "trait"`))
@@ -40,13 +42,13 @@ var _ = Describe("HPA", func() {
 
 		// Conditional apiVersion based on cluster version
 		Expect(cue).To(ContainSubstring(`if context.clusterVersion.minor < 23`))
-		Expect(cue).To(ContainSubstring(`apiVersion: "autoscaling/v2beta2"`))
+		Expect(doc.Lookup("outputs.hpa.apiVersion")).To(cueassert.HaveValue(`"autoscaling/v2beta2"`))
 		Expect(cue).To(ContainSubstring(`if context.clusterVersion.minor >= 23`))
 		Expect(cue).To(ContainSubstring(`apiVersion: "autoscaling/v2"`))
 
 		// Output resource
 		Expect(cue).To(ContainSubstring(`outputs: hpa:`))
-		Expect(cue).To(ContainSubstring(`kind: "HorizontalPodAutoscaler"`))
+		Expect(doc.Lookup("outputs.hpa.kind")).To(cueassert.HaveValue(`"HorizontalPodAutoscaler"`))
 		Expect(cue).To(ContainSubstring(`metadata: name: context.name`))
 
 		// Scale target ref
@@ -69,10 +71,10 @@ var _ = Describe("HPA", func() {
 		Expect(cue).To(ContainSubstring(`averageValue: parameter.cpu.value`))
 
 		// Parameters
-		Expect(cue).To(ContainSubstring(`min: *1 | int`))
-		Expect(cue).To(ContainSubstring(`max: *10 | int`))
-		Expect(cue).To(ContainSubstring(`targetAPIVersion: *"apps/v1" | string`))
-		Expect(cue).To(ContainSubstring(`targetKind: *"Deployment" | string`))
+		Expect(doc.Lookup("parameter.min")).To(SatisfyAll(cueassert.HaveDefault(1), cueassert.HaveType("int")))
+		Expect(doc.Lookup("parameter.max")).To(SatisfyAll(cueassert.HaveDefault(10), cueassert.HaveType("int")))
+		Expect(doc.Lookup("parameter.targetAPIVersion")).To(SatisfyAll(cueassert.HaveDefault("apps/v1"), cueassert.HaveType("string")))
+		Expect(doc.Lookup("parameter.targetKind")).To(SatisfyAll(cueassert.HaveDefault("Deployment"), cueassert.HaveType("string")))
 		Expect(cue).To(ContainSubstring(`mem?:`))
 		Expect(cue).To(ContainSubstring(`podCustomMetrics?:`))
 	})
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/traits"
 )
 
@@ -33,6 +34,7 @@ var _ = Describe("InitContainer", func() {
 		Expect(trait.GetDescription()).To(Equal("add an init container and use shared volume with pod"))
 
 		cue := trait.ToCue()
+		doc := cueassert.MustParse(cue)
 
 		// Header and attributes
 		Expect(cue).To(ContainSubstring(`type: "trait"`))
@@ -69,14 +71,14 @@ var _ = Describe("InitContainer", func() {
 		Expect(cue).To(ContainSubstring(`emptyDir: {}`))
 
 		// Parameters
-		Expect(cue).To(ContainSubstring(`name: string`))
-		Expect(cue).To(ContainSubstring(`image: string`))
+		Expect(doc.Lookup("parameter.name")).To(cueassert.HaveValue("string"))
+		Expect(doc.Lookup("parameter.image")).To(cueassert.HaveValue("string"))
 		Expect(cue).To(ContainSubstring(`imagePullPolicy: *"IfNotPresent"`))
-		Expect(cue).To(ContainSubstring(`cmd?: [...string]`))
-		Expect(cue).To(ContainSubstring(`args?: [...string]`))
-		Expect(cue).To(ContainSubstring(`mountName: *"workdir" | string`))
-		Expect(cue).To(ContainSubstring(`appMountPath: string`))
-		Expect(cue).To(ContainSubstring(`initMountPath: string`))
+		Expect(doc.Lookup("parameter.cmd")).To(cueassert.BeOptionalListOf("string"))
+		Expect(doc.Lookup("parameter.args")).To(cueassert.BeOptionalListOf("string"))
+		Expect(doc.Lookup("parameter.mountName")).To(SatisfyAll(cueassert.HaveDefault("workdir"), cueassert.HaveType("string")))
+		Expect(doc.Lookup("parameter.appMountPath")).To(cueassert.HaveValue("string"))
+		Expect(doc.Lookup("parameter.initMountPath")).To(cueassert.HaveValue("string"))
 		Expect(cue).To(ContainSubstring(`extraVolumeMounts:`))
 		Expect(cue).To(ContainSubstring(`secretKeyRef?:`))
 		Expect(cue).To(ContainSubstring(`configMapKeyRef?:`))
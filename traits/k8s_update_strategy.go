@@ -59,7 +59,12 @@ func K8sUpdateStrategy() *defkit.TraitDefinition {
 			isDaemonSet := defkit.Eq(defkit.ParameterField("targetKind"), defkit.Lit("DaemonSet"))
 			isNotOnDelete := defkit.Ne(strategyType, defkit.Lit("OnDelete"))
 			isNotRecreate := defkit.Ne(strategyType, defkit.Lit("Recreate"))
-			isRollingUpdate := defkit.Eq(strategyType, defkit.Lit("RollingUpdate"))
+			// rollingUpdate is only patched when rollingStrategy is given; otherwise
+			// the workload controller defaults apply
+			isRollingUpdate := defkit.And(
+				defkit.Eq(strategyType, defkit.Lit("RollingUpdate")),
+				defkit.PathExists("parameter.strategy.rollingStrategy"),
+			)
 
 			tpl.Patch().
 				// Deployment: uses "strategy" field, excludes OnDelete
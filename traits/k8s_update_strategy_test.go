@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/traits"
 )
 
@@ -33,6 +34,7 @@ var _ = Describe("K8sUpdateStrategy", func() {
 		Expect(trait.GetDescription()).To(Equal("Set k8s update strategy for Deployment/DaemonSet/StatefulSet"))
 
 		cue := trait.ToCue()
+		doc := cueassert.MustParse(cue)
 
 		// Three separate conditional blocks for each workload type
 		Expect(cue).To(ContainSubstring(`parameter.targetKind == "Deployment" && parameter.strategy.type != "OnDelete"`))
@@ -50,14 +52,14 @@ var _ = Describe("K8sUpdateStrategy", func() {
 		Expect(cue).To(ContainSubstring(`parameter.strategy.type == "RollingUpdate"`))
 
 		// Correct field assignments
-		Expect(cue).To(ContainSubstring("maxSurge:       parameter.strategy.rollingStrategy.maxSurge"))
+		Expect(doc).To(cueassert.ContainField("maxSurge", "parameter.strategy.rollingStrategy.maxSurge"))
 		Expect(cue).To(ContainSubstring("maxUnavailable: parameter.strategy.rollingStrategy.maxUnavailable"))
-		Expect(cue).To(ContainSubstring("partition: parameter.strategy.rollingStrategy.partition"))
+		Expect(doc.Lookup("patch.spec.updateStrategy.rollingUpdate.partition")).To(cueassert.HaveValue("parameter.strategy.rollingStrategy.partition"))
 
 		// Parameters
-		Expect(cue).To(ContainSubstring(`targetAPIVersion: *"apps/v1" | string`))
-		Expect(cue).To(ContainSubstring(`targetKind: *"Deployment" | "StatefulSet" | "DaemonSet"`))
-		Expect(cue).To(ContainSubstring(`type: *"RollingUpdate" | "Recreate" | "OnDelete"`))
+		Expect(doc.Lookup("parameter.targetAPIVersion")).To(SatisfyAll(cueassert.HaveDefault("apps/v1"), cueassert.HaveType("string")))
+		Expect(doc.Lookup("parameter.targetKind")).To(SatisfyAll(cueassert.HaveDefault("Deployment"), cueassert.HaveType(`"StatefulSet" | "DaemonSet"`)))
+		Expect(doc.Lookup("parameter.strategy.type")).To(SatisfyAll(cueassert.HaveDefault("RollingUpdate"), cueassert.HaveType(`"Recreate" | "OnDelete"`)))
 	})
 
 	It("should have optional rollingStrategy field", func() {
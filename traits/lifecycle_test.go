@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/traits"
 )
 
@@ -33,6 +34,7 @@ var _ = Describe("Lifecycle", func() {
 		Expect(trait.GetDescription()).To(Equal("Add lifecycle hooks for every container of K8s pod for your workload which follows the pod spec in path 'spec.template'."))
 
 		cue := trait.ToCue()
+		doc := cueassert.MustParse(cue)
 
 		// Metadata
 		Expect(cue).To(ContainSubstring(`podDisruptive: true`))
@@ -49,10 +51,10 @@ var _ = Describe("Lifecycle", func() {
 		Expect(cue).NotTo(ContainSubstring(`+patchKey`))
 
 		// #Port is a constrained int
-		Expect(cue).To(ContainSubstring(`#Port: int & >=1 & <=65535`))
+		Expect(doc.Lookup("#Port")).To(cueassert.HaveValue("int & >=1 & <=65535"))
 
 		// Port fields reference #Port helper
-		Expect(cue).To(ContainSubstring(`port:   #Port`))
+		Expect(doc).To(cueassert.ContainField("port", "#Port"))
 		lines := strings.Split(cue, "\n")
 		for _, line := range lines {
 			trimmed := strings.TrimSpace(line)
@@ -68,16 +70,16 @@ var _ = Describe("Lifecycle", func() {
 
 		// httpHeaders is typed struct array
 		Expect(cue).To(ContainSubstring(`httpHeaders?: [...{`))
-		Expect(cue).To(ContainSubstring(`name:  string`))
+		Expect(doc).To(cueassert.ContainField("name", "string"))
 		Expect(cue).To(ContainSubstring(`value: string`))
 
 		// Parameters reference #LifeCycleHandler
-		Expect(cue).To(ContainSubstring(`postStart?: #LifeCycleHandler`))
-		Expect(cue).To(ContainSubstring(`preStop?: #LifeCycleHandler`))
+		Expect(doc.Lookup("parameter.postStart")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("#LifeCycleHandler")))
+		Expect(doc.Lookup("parameter.preStop")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("#LifeCycleHandler")))
 
 		// Helper definitions
 		Expect(cue).To(ContainSubstring(`#LifeCycleHandler: {`))
-		Expect(cue).To(ContainSubstring(`scheme: *"HTTP" | "HTTPS"`))
+		Expect(doc.Lookup("#LifeCycleHandler.httpGet.scheme")).To(SatisfyAll(cueassert.HaveDefault("HTTP"), cueassert.HaveType(`"HTTPS"`)))
 		Expect(cue).To(ContainSubstring(`tcpSocket?: {`))
 		Expect(cue).To(ContainSubstring(`host?: string`))
 	})
@@ -20,6 +20,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/traits"
 )
 
@@ -31,6 +32,7 @@ var _ = Describe("Scaler Trait", func() {
 		Expect(trait.GetDescription()).To(Equal("Manually scale K8s pod for your workload which follows the pod spec in path 'spec.template'."))
 
 		cue := trait.ToCue()
+		doc := cueassert.MustParse(cue)
 
 		// Verify trait metadata
 		Expect(cue).To(ContainSubstring(`type: "trait"`))
@@ -39,7 +41,7 @@ var _ = Describe("Scaler Trait", func() {
 		Expect(cue).To(ContainSubstring(`"statefulsets.apps"`))
 
 		// Verify replicas parameter has correct type and default
-		Expect(cue).To(ContainSubstring(`replicas: *1 | int`))
+		Expect(doc.Lookup("parameter.replicas")).To(SatisfyAll(cueassert.HaveDefault(1), cueassert.HaveType("int")))
 
 		// Verify patch targets spec.replicas with retainKeys strategy
 		Expect(cue).To(ContainSubstring(`// +patchStrategy=retainKeys`))
@@ -20,6 +20,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/traits"
 )
 
@@ -31,6 +32,7 @@ var _ = Describe("SecurityContext Trait", func() {
 		Expect(trait.GetDescription()).To(Equal("Adds security context to the container spec in path 'spec.template.spec.containers.[].securityContext'."))
 
 		cue := trait.ToCue()
+		doc := cueassert.MustParse(cue)
 
 		// Metadata
 		Expect(cue).To(ContainSubstring(`type: "trait"`))
@@ -41,17 +43,17 @@ var _ = Describe("SecurityContext Trait", func() {
 		Expect(cue).To(ContainSubstring(`"jobs.batch"`))
 
 		// #PatchParams: fields with explicit defaults use *default | type
-		Expect(cue).To(ContainSubstring(`containerName: *"" | string`))
-		Expect(cue).To(ContainSubstring(`allowPrivilegeEscalation: *false | bool`))
-		Expect(cue).To(ContainSubstring(`readOnlyRootFilesystem: *false | bool`))
-		Expect(cue).To(ContainSubstring(`privileged: *false | bool`))
-		Expect(cue).To(ContainSubstring(`runAsNonRoot: *true | bool`))
+		Expect(doc.Lookup("#PatchParams.containerName")).To(SatisfyAll(cueassert.HaveDefault(""), cueassert.HaveType("string")))
+		Expect(doc.Lookup("#PatchParams.allowPrivilegeEscalation")).To(SatisfyAll(cueassert.HaveDefault(false), cueassert.HaveType("bool")))
+		Expect(doc.Lookup("#PatchParams.readOnlyRootFilesystem")).To(SatisfyAll(cueassert.HaveDefault(false), cueassert.HaveType("bool")))
+		Expect(doc.Lookup("#PatchParams.privileged")).To(SatisfyAll(cueassert.HaveDefault(false), cueassert.HaveType("bool")))
+		Expect(doc.Lookup("#PatchParams.runAsNonRoot")).To(SatisfyAll(cueassert.HaveDefault(true), cueassert.HaveType("bool")))
 
 		// #PatchParams: fields with != _|_ condition use optional syntax (field?: type)
-		Expect(cue).To(ContainSubstring(`runAsUser?: int`))
-		Expect(cue).To(ContainSubstring(`runAsGroup?: int`))
-		Expect(cue).To(ContainSubstring(`addCapabilities?: [...string]`))
-		Expect(cue).To(ContainSubstring(`dropCapabilities?: [...string]`))
+		Expect(doc.Lookup("#PatchParams.runAsUser")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("int")))
+		Expect(doc.Lookup("#PatchParams.runAsGroup")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("int")))
+		Expect(doc.Lookup("#PatchParams.addCapabilities")).To(cueassert.BeOptionalListOf("string"))
+		Expect(doc.Lookup("#PatchParams.dropCapabilities")).To(cueassert.BeOptionalListOf("string"))
 
 		// Must NOT have *null | type for optional fields
 		Expect(cue).NotTo(ContainSubstring(`runAsUser: *null | int`))
@@ -62,7 +64,7 @@ var _ = Describe("SecurityContext Trait", func() {
 		// PatchContainer structure
 		Expect(cue).To(ContainSubstring(`#PatchParams: {`))
 		Expect(cue).To(ContainSubstring(`PatchContainer: {`))
-		Expect(cue).To(ContainSubstring(`_params:         #PatchParams`))
+		Expect(doc.Lookup("PatchContainer._params")).To(cueassert.HaveValue("#PatchParams"))
 
 		// PatchContainer body: conditional blocks for optional fields
 		Expect(cue).To(ContainSubstring(`if _params.runAsUser != _|_`))
@@ -71,16 +73,16 @@ var _ = Describe("SecurityContext Trait", func() {
 		Expect(cue).To(ContainSubstring(`if _params.dropCapabilities != _|_`))
 
 		// PatchContainer body: unconditional assignments for fields with defaults
-		Expect(cue).To(ContainSubstring(`allowPrivilegeEscalation: _params.allowPrivilegeEscalation`))
-		Expect(cue).To(ContainSubstring(`readOnlyRootFilesystem:   _params.readOnlyRootFilesystem`))
-		Expect(cue).To(ContainSubstring(`privileged:               _params.privileged`))
-		Expect(cue).To(ContainSubstring(`runAsNonRoot:             _params.runAsNonRoot`))
+		Expect(doc.Lookup("PatchContainer.securityContext.allowPrivilegeEscalation")).To(cueassert.HaveValue("_params.allowPrivilegeEscalation"))
+		Expect(doc.Lookup("PatchContainer.securityContext.readOnlyRootFilesystem")).To(cueassert.HaveValue("_params.readOnlyRootFilesystem"))
+		Expect(doc.Lookup("PatchContainer.securityContext.privileged")).To(cueassert.HaveValue("_params.privileged"))
+		Expect(doc.Lookup("PatchContainer.securityContext.runAsNonRoot")).To(cueassert.HaveValue("_params.runAsNonRoot"))
 
 		// Multi-container support
 		Expect(cue).To(ContainSubstring("parameter: #PatchParams | close({"))
 		Expect(cue).To(ContainSubstring("containers: [...#PatchParams]"))
 
 		// Error collection
-		Expect(cue).To(ContainSubstring(`errs: [for c in patch.spec.template.spec.containers if c.err != _|_ {c.err}]`))
+		Expect(doc.Lookup("errs")).To(cueassert.HaveValue("[for c in patch.spec.template.spec.containers if c.err != _|_ {c.err}]"))
 	})
 })
@@ -20,6 +20,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/traits"
 )
 
@@ -30,6 +31,7 @@ var _ = Describe("ServiceAccount Trait", func() {
 		Expect(trait.GetName()).To(Equal("service-account"))
 
 		cue := trait.ToCue()
+		doc := cueassert.MustParse(cue)
 
 		// Header and attributes
 		Expect(cue).To(ContainSubstring(`type: "trait"`))
@@ -45,24 +47,24 @@ var _ = Describe("ServiceAccount Trait", func() {
 
 		// Patch
 		Expect(cue).To(ContainSubstring(`// +patchStrategy=retainKeys`))
-		Expect(cue).To(ContainSubstring(`serviceAccountName: parameter.name`))
+		Expect(doc.Lookup("patch.spec.template.spec.serviceAccountName")).To(cueassert.HaveValue("parameter.name"))
 
 		// Conditional ServiceAccount output
 		Expect(cue).To(ContainSubstring(`if parameter.create`))
 		Expect(cue).To(ContainSubstring(`"service-account":`))
-		Expect(cue).To(ContainSubstring(`kind:       "ServiceAccount"`))
+		Expect(doc.Lookup("outputs.service-account.kind")).To(cueassert.HaveValue(`"ServiceAccount"`))
 
 		// Conditional cluster-scoped RBAC output group
 		Expect(cue).To(ContainSubstring(`len(_clusterPrivileges) > 0`))
 		Expect(cue).To(ContainSubstring(`"cluster-role":`))
-		Expect(cue).To(ContainSubstring(`kind:       "ClusterRole"`))
+		Expect(doc).To(cueassert.ContainField("kind", `"ClusterRole"`))
 		Expect(cue).To(ContainSubstring(`"cluster-role-binding":`))
-		Expect(cue).To(ContainSubstring(`kind:       "ClusterRoleBinding"`))
+		Expect(doc.Lookup("outputs.cluster-role-binding.kind")).To(cueassert.HaveValue(`"ClusterRoleBinding"`))
 
 		// Conditional namespace-scoped RBAC output group
 		Expect(cue).To(ContainSubstring(`len(_namespacePrivileges) > 0`))
-		Expect(cue).To(ContainSubstring(`kind:       "Role"`))
-		Expect(cue).To(ContainSubstring(`kind:       "RoleBinding"`))
+		Expect(doc).To(cueassert.ContainField("kind", `"Role"`))
+		Expect(doc.Lookup("outputs.role-binding.kind")).To(cueassert.HaveValue(`"RoleBinding"`))
 
 		// String interpolation for cluster-scoped resource names
 		Expect(cue).To(ContainSubstring(`"\(context.namespace):\(parameter.name)"`))
@@ -74,11 +76,11 @@ var _ = Describe("ServiceAccount Trait", func() {
 
 		// Helper type definition
 		Expect(cue).To(ContainSubstring(`#Privileges`))
-		Expect(cue).To(ContainSubstring(`privileges?: [...#Privileges]`))
-		Expect(cue).To(ContainSubstring(`scope: *"namespace" | "cluster"`))
+		Expect(doc.Lookup("parameter.privileges")).To(cueassert.BeOptionalListOf("#Privileges"))
+		Expect(doc.Lookup("#Privileges.scope")).To(SatisfyAll(cueassert.HaveDefault("namespace"), cueassert.HaveType(`"cluster"`)))
 
 		// Parameters
-		Expect(cue).To(ContainSubstring(`name: string`))
-		Expect(cue).To(ContainSubstring(`create: *false | bool`))
+		Expect(doc.Lookup("parameter.name")).To(cueassert.HaveValue("string"))
+		Expect(doc.Lookup("parameter.create")).To(SatisfyAll(cueassert.HaveDefault(false), cueassert.HaveType("bool")))
 	})
 })
@@ -20,6 +20,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/traits"
 )
 
@@ -30,6 +31,7 @@ var _ = Describe("ServiceBinding Trait", func() {
 		Expect(trait.GetName()).To(Equal("service-binding"))
 
 		cue := trait.ToCue()
+		doc := cueassert.MustParse(cue)
 
 		// Header and attributes
 		Expect(cue).To(ContainSubstring(`type: "trait"`))
@@ -53,6 +55,6 @@ var _ = Describe("ServiceBinding Trait", func() {
 		// Fluent helper definition
 		Expect(cue).To(ContainSubstring(`#KeySecret:`))
 		Expect(cue).To(ContainSubstring(`key?:`))
-		Expect(cue).To(ContainSubstring(`secret: string`))
+		Expect(doc.Lookup("#KeySecret.secret")).To(cueassert.HaveValue("string"))
 	})
 })
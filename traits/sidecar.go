@@ -21,8 +21,16 @@ import (
 )
 
 // Sidecar creates the sidecar trait definition.
-// This trait injects a sidecar container to K8s pods.
+// This trait injects a sidecar container to K8s pods. In native mode the
+// sidecar is a restartable init container, which starts before and stops
+// after the main containers, so a Job completes when its own containers do.
+// Native sidecars need Kubernetes 1.29 or later. Older clusters get a regular
+// container, except for Jobs, which it would keep from completing: the trait
+// fails instead, like NativeSidecarsRequired does for the sidecars of
+// components.
 func Sidecar() *defkit.TraitDefinition {
+	vela := defkit.VelaCtx()
+
 	name := defkit.String("name").Description("Specify the name of sidecar container")
 	image := defkit.String("image").Description("Specify the image of sidecar container")
 	cmd := defkit.Array("cmd").Of(defkit.ParamTypeString).Optional().Description("Specify the commands run in the sidecar")
@@ -52,6 +60,10 @@ func Sidecar() *defkit.TraitDefinition {
 		defkit.String("name"),
 		defkit.String("path"),
 	)
+	cpu := defkit.String("cpu").Optional().Description("Number of CPU units for the sidecar, like `0.5` (0.5 CPU core), `1` (1 CPU core)")
+	memory := defkit.String("memory").Optional().Description("Specifies the attributes of the memory resource required for the sidecar.")
+	native := defkit.Bool("native").Default(false).Description("Run the sidecar as a restartable init container that starts before and stops after the main containers. Requires Kubernetes 1.29 or later for Jobs, otherwise the sidecar is a regular container")
+	startupProbe := defkit.Map("startupProbe").Optional().Description("Instructions for assessing whether the container has started. Other probes wait until it succeeds.").WithSchemaRef("HealthProbe")
 	livenessProbe := defkit.Map("livenessProbe").Optional().Description("Instructions for assessing whether the container is alive.").WithSchemaRef("HealthProbe")
 	readinessProbe := defkit.Map("readinessProbe").Optional().Description("Instructions for assessing whether the container is in a suitable state to serve traffic.").WithSchemaRef("HealthProbe")
 
@@ -59,27 +71,52 @@ func Sidecar() *defkit.TraitDefinition {
 		Description("Inject a sidecar container to K8s pod for your workload which follows the pod spec in path 'spec.template'.").
 		AppliesTo("deployments.apps", "statefulsets.apps", "daemonsets.apps", "jobs.batch").
 		PodDisruptive(true).
-		Params(name, image, cmd, args, env, volumes, livenessProbe, readinessProbe).
+		Params(name, image, cmd, args, env, volumes, cpu, memory, native, startupProbe, livenessProbe, readinessProbe).
 		Helper("HealthProbe", healthProbeSchema()).
 		Template(func(tpl *defkit.Template) {
+			isNative := defkit.And(native.IsTrue(), defkit.Ge(vela.ClusterVersion().Minor(), defkit.Lit(29)))
+
 			// Build the sidecar container element
-			container := defkit.NewArrayElement().
-				Set("name", name).
-				Set("image", image).
-				SetIf(cmd.IsSet(), "command", cmd).
-				SetIf(args.IsSet(), "args", args).
-				SetIf(env.IsSet(), "env", env).
-				SetIf(volumes.IsSet(), "volumeMounts",
-					defkit.From(volumes).Map(defkit.FieldMap{
-						"mountPath": defkit.F("path"),
-						"name":      defkit.F("name"),
-					})).
-				SetIf(livenessProbe.IsSet(), "livenessProbe", livenessProbe).
-				SetIf(readinessProbe.IsSet(), "readinessProbe", readinessProbe)
+			container := func() *defkit.ArrayElement {
+				return defkit.NewArrayElement().
+					Set("name", name).
+					Set("image", image).
+					SetIf(cmd.IsSet(), "command", cmd).
+					SetIf(args.IsSet(), "args", args).
+					SetIf(env.IsSet(), "env", env).
+					SetIf(volumes.IsSet(), "volumeMounts",
+						defkit.From(volumes).Map(defkit.FieldMap{
+							"mountPath": defkit.F("path"),
+							"name":      defkit.F("name"),
+						})).
+					SetIf(cpu.IsSet(), "resources.limits.cpu", cpu).
+					SetIf(cpu.IsSet(), "resources.requests.cpu", cpu).
+					SetIf(memory.IsSet(), "resources.limits.memory", memory).
+					SetIf(memory.IsSet(), "resources.requests.memory", memory).
+					SetIf(startupProbe.IsSet(), "startupProbe", startupProbe).
+					SetIf(livenessProbe.IsSet(), "livenessProbe", livenessProbe).
+					SetIf(readinessProbe.IsSet(), "readinessProbe", readinessProbe)
+			}
+
+			// Native sidecars of Jobs fail on clusters without them, as a
+			// regular container would keep the Job from completing: the
+			// fallback container then gets conflicting _nativeSidecars values
+			jobWithoutNative := defkit.And(
+				defkit.Lt(vela.ClusterVersion().Minor(), defkit.Lit(29)),
+				defkit.Eq(defkit.Reference("context.output.kind"), defkit.Lit("Job")),
+			)
 
-			// Apply patch with patchKey for containers array
+			// Apply patch with patchKey for the initContainers array in native
+			// mode, and for the containers array otherwise
 			tpl.Patch().
-				PatchKey("spec.template.spec.containers", "name", container)
+				If(isNative).
+				PatchKey("spec.template.spec.initContainers", "name", container().Set("restartPolicy", defkit.Lit("Always"))).
+				EndIf().
+				If(defkit.Not(isNative)).
+				PatchKey("spec.template.spec.containers", "name", container().
+					SetIf(native.IsTrue(), "_nativeSidecars", defkit.Lit(true)).
+					SetIf(defkit.And(native.IsTrue(), jobWithoutNative), "_nativeSidecars", defkit.Lit("native sidecars of Jobs require Kubernetes 1.29 or later"))).
+				EndIf()
 		})
 }
 
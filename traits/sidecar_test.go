@@ -17,9 +17,14 @@ limitations under the License.
 package traits_test
 
 import (
+	"fmt"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/traits"
 )
 
@@ -31,6 +36,7 @@ var _ = Describe("Sidecar Trait", func() {
 		Expect(trait.GetDescription()).To(Equal("Inject a sidecar container to K8s pod for your workload which follows the pod spec in path 'spec.template'."))
 
 		cue := trait.ToCue()
+		doc := cueassert.MustParse(cue)
 
 		// Verify trait metadata
 		Expect(cue).To(ContainSubstring(`type: "trait"`))
@@ -41,12 +47,12 @@ var _ = Describe("Sidecar Trait", func() {
 		Expect(cue).To(ContainSubstring(`"jobs.batch"`))
 
 		// Verify required parameters with types
-		Expect(cue).To(ContainSubstring(`name: string`))
-		Expect(cue).To(ContainSubstring(`image: string`))
+		Expect(doc.Lookup("parameter.name")).To(cueassert.HaveValue("string"))
+		Expect(doc.Lookup("parameter.image")).To(cueassert.HaveValue("string"))
 
 		// Verify optional sidecar parameters
-		Expect(cue).To(ContainSubstring(`cmd?: [...string]`))
-		Expect(cue).To(ContainSubstring(`args?: [...string]`))
+		Expect(doc.Lookup("parameter.cmd")).To(cueassert.BeOptionalListOf("string"))
+		Expect(doc.Lookup("parameter.args")).To(cueassert.BeOptionalListOf("string"))
 		Expect(cue).To(ContainSubstring(`env?: [...{`))
 		Expect(cue).To(ContainSubstring(`volumes?: [...{`))
 
@@ -60,12 +66,12 @@ var _ = Describe("Sidecar Trait", func() {
 		Expect(cue).To(ContainSubstring(`readinessProbe?:`))
 
 		// #HealthProbe exec.command should have string element type
-		Expect(cue).To(ContainSubstring(`command: [...string]`))
+		Expect(doc.Lookup("#HealthProbe.exec.command")).To(cueassert.HaveValue("[...string]"))
 		Expect(cue).NotTo(ContainSubstring("command: [...]"))
 
 		// #HealthProbe httpGet.httpHeaders should have structured elements
 		Expect(cue).To(ContainSubstring(`httpHeaders?: [...{`))
-		Expect(cue).To(ContainSubstring("name:  string"))
+		Expect(doc.Lookup("parameter.name")).To(cueassert.HaveValue("string"))
 		Expect(cue).To(ContainSubstring("value: string"))
 	})
 
@@ -92,4 +98,71 @@ var _ = Describe("Sidecar Trait", func() {
 		Expect(cue).To(ContainSubstring(`httpGet?: {`))
 		Expect(cue).To(ContainSubstring(`tcpSocket?: {`))
 	})
+
+	It("should have native mode, startup probe and resource parameters", func() {
+		doc := cueassert.MustParse(traits.Sidecar().ToCue())
+
+		Expect(doc.Lookup("parameter.native")).To(cueassert.HaveValue("*false | bool"))
+		Expect(doc.Lookup("parameter.startupProbe")).To(cueassert.BeOptionalField())
+		Expect(doc.Lookup("parameter.cpu")).To(cueassert.BeOptionalField())
+		Expect(doc.Lookup("parameter.memory")).To(cueassert.BeOptionalField())
+	})
+
+	Describe("Patch", func() {
+		// compilePatch compiles the patch for the given parameter on a cluster
+		// of the given Kubernetes minor version, applied to a workload of the
+		// given kind.
+		compilePatch := func(parameter, kind string, minor int) cue.Value {
+			v := cuecontext.New().CompileString(traits.Sidecar().ToCue() +
+				fmt.Sprintf("\ncontext: {clusterVersion: minor: %d, output: kind: %q}", minor, kind) +
+				"\ntemplate: parameter: " + parameter)
+			return v.LookupPath(cue.ParsePath("template.patch.spec.template.spec"))
+		}
+
+		// evalPatch evaluates the patch for the given parameter on a Deployment
+		// of a cluster of the given Kubernetes minor version.
+		evalPatch := func(parameter string, minor int) cue.Value {
+			patch := compilePatch(parameter, "Deployment", minor)
+			Expect(patch.Validate(cue.Concrete(true))).To(Succeed())
+			return patch
+		}
+
+		const sidecar = `{name: "proxy", image: "envoyproxy/envoy:v1.31", cpu: "100m", startupProbe: tcpSocket: port: 9901}`
+
+		It("should patch a restartable init container in native mode", func() {
+			patch := evalPatch(`{native: true}&`+sidecar, 29)
+			Expect(patch.LookupPath(cue.ParsePath("containers")).Exists()).To(BeFalse())
+			container := patch.LookupPath(cue.ParsePath("initContainers[0]"))
+			Expect(container.LookupPath(cue.ParsePath("name")).String()).To(Equal("proxy"))
+			Expect(container.LookupPath(cue.ParsePath("restartPolicy")).String()).To(Equal("Always"))
+			Expect(container.LookupPath(cue.ParsePath("resources.requests.cpu")).String()).To(Equal("100m"))
+			Expect(container.LookupPath(cue.ParsePath("startupProbe.tcpSocket.port")).Int64()).To(Equal(int64(9901)))
+		})
+
+		It("should patch a regular container without native mode", func() {
+			patch := evalPatch(sidecar, 30)
+			Expect(patch.LookupPath(cue.ParsePath("initContainers")).Exists()).To(BeFalse())
+			container := patch.LookupPath(cue.ParsePath("containers[0]"))
+			Expect(container.LookupPath(cue.ParsePath("name")).String()).To(Equal("proxy"))
+			Expect(container.LookupPath(cue.ParsePath("restartPolicy")).Exists()).To(BeFalse())
+			Expect(container.LookupPath(cue.ParsePath("startupProbe.tcpSocket.port")).Int64()).To(Equal(int64(9901)))
+		})
+
+		It("should fall back to a regular container before Kubernetes 1.29", func() {
+			patch := evalPatch(`{native: true}&`+sidecar, 28)
+			Expect(patch.LookupPath(cue.ParsePath("initContainers")).Exists()).To(BeFalse())
+			Expect(patch.LookupPath(cue.ParsePath("containers[0].name")).String()).To(Equal("proxy"))
+		})
+
+		It("should fail for native sidecars of Jobs before Kubernetes 1.29", func() {
+			patch := compilePatch(`{native: true}&`+sidecar, "Job", 28)
+			Expect(patch.Validate(cue.Concrete(true))).To(MatchError(ContainSubstring("native sidecars of Jobs require Kubernetes 1.29 or later")))
+
+			patch = compilePatch(sidecar, "Job", 28)
+			Expect(patch.Validate(cue.Concrete(true))).To(Succeed())
+			patch = compilePatch(`{native: true}&`+sidecar, "Job", 29)
+			Expect(patch.Validate(cue.Concrete(true))).To(Succeed())
+			Expect(patch.LookupPath(cue.ParsePath("initContainers[0].restartPolicy")).String()).To(Equal("Always"))
+		})
+	})
 })
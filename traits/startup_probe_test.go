@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/traits"
 )
 
@@ -33,6 +34,7 @@ var _ = Describe("StartupProbe Trait", func() {
 		Expect(trait.GetDescription()).To(Equal("Add startup probe hooks for the specified container of K8s pod for your workload which follows the pod spec in path 'spec.template'."))
 
 		cue := trait.ToCue()
+		doc := cueassert.MustParse(cue)
 
 		// Metadata
 		Expect(cue).To(ContainSubstring(`type: "trait"`))
@@ -59,8 +61,8 @@ var _ = Describe("StartupProbe Trait", func() {
 		// PatchContainer structure
 		Expect(cue).To(ContainSubstring(`#StartupProbeParams: {`))
 		Expect(cue).To(ContainSubstring(`PatchContainer: {`))
-		Expect(cue).To(ContainSubstring(`_params:         #StartupProbeParams`))
-		Expect(cue).To(ContainSubstring(`_baseContainers: context.output.spec.template.spec.containers`))
+		Expect(doc.Lookup("PatchContainer._params")).To(cueassert.HaveValue("#StartupProbeParams"))
+		Expect(doc.Lookup("PatchContainer._baseContainers")).To(cueassert.HaveValue("context.output.spec.template.spec.containers"))
 
 		// PatchContainer body: conditional blocks for optional probe types
 		Expect(cue).To(ContainSubstring(`if _params.exec != _|_`))
@@ -81,11 +83,13 @@ var _ = Describe("StartupProbe Trait", func() {
 
 		// Multi-container support with custom param name "probes"
 		Expect(cue).To(ContainSubstring(`parameter: *#StartupProbeParams | close({`))
-		Expect(cue).To(ContainSubstring(`probes: [...#StartupProbeParams]`))
+		Expect(doc.Lookup("parameter.probes")).To(cueassert.HaveValue("[...#StartupProbeParams]"))
 		Expect(cue).To(ContainSubstring(`// +usage=Specify the startup probe for multiple containers`))
+		Expect(cue).To(ContainSubstring(`if c.containerName == "" {`))
+		Expect(cue).NotTo(ContainSubstring(`if c.name == "" {`))
 
 		// Error collection
-		Expect(cue).To(ContainSubstring(`errs: [for c in patch.spec.template.spec.containers if c.err != _|_ {c.err}]`))
+		Expect(doc.Lookup("errs")).To(cueassert.HaveValue("[for c in patch.spec.template.spec.containers if c.err != _|_ {c.err}]"))
 
 		// Descriptions for probe fields
 		Expect(cue).To(ContainSubstring(`// +usage=Number of seconds after the container has started before liveness probes are initiated`))
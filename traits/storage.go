@@ -209,7 +209,7 @@ patch: spec: template: spec: {
 }
 
 outputs: {
-	for v in parameter.pvc {
+	if parameter.pvc != _|_ for v in parameter.pvc {
 		if v.mountOnly == false {
 			"pvc-\(v.name)": {
 				apiVersion: "v1"
@@ -250,7 +250,7 @@ outputs: {
 		}
 	}
 
-	for v in parameter.configMap {
+	if parameter.configMap != _|_ for v in parameter.configMap {
 		if v.mountOnly == false {
 			"configmap-\(v.name)": {
 				apiVersion: "v1"
@@ -263,7 +263,7 @@ outputs: {
 		}
 	}
 
-	for v in parameter.secret {
+	if parameter.secret != _|_ for v in parameter.secret {
 		if v.mountOnly == false {
 			"secret-\(v.name)": {
 				apiVersion: "v1"
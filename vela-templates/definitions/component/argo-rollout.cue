@@ -0,0 +1,576 @@
+import (
+	"strconv"
+)
+
+"argo-rollout": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Describes long-running, scalable, containerized services progressively delivered by an Argo Rollouts canary or blue-green strategy."
+	attributes: {
+		workload: {
+			definition: {
+				apiVersion: "argoproj.io/v1alpha1"
+				kind:       "Rollout"
+			}
+			type: "rollouts.argoproj.io"
+		}
+		status: {
+			customStatus: #"""
+				rollout: {
+					phase:   *"Progressing" | string
+					step:    *0 | int
+					stable:  *"" | string
+					current: *"" | string
+				} & {
+					if context.output.status.phase != _|_ {
+						phase: context.output.status.phase
+					}
+					if context.output.status.currentStepIndex != _|_ {
+						step: context.output.status.currentStepIndex
+					}
+					if context.output.status.stableRS != _|_ {
+						stable: context.output.status.stableRS
+					}
+					if context.output.status.currentPodHash != _|_ {
+						current: context.output.status.currentPodHash
+					}
+				}
+				_aborted: *"" | string
+				if context.output.status.abort != _|_ if context.output.status.abort {
+					_aborted: ", aborted"
+				}
+				message: "\(rollout.phase), step: \(rollout.step), stable: \(rollout.stable), canary: \(rollout.current)\(_aborted)"
+				"""#
+			healthPolicy: #"""
+				rollout: {
+					phase: *"Progressing" | string
+				} & {
+					if context.output.status.phase != _|_ {
+						phase: context.output.status.phase
+					}
+				}
+				isHealth: (rollout.phase == "Healthy" || rollout.phase == "Paused")
+				"""#
+		}
+	}
+}
+template: {
+	mountsArray: [
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.pvc != _|_ for v in parameter.volumeMounts.pvc {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.configMap != _|_ for v in parameter.volumeMounts.configMap {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.secret != _|_ for v in parameter.volumeMounts.secret {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.emptyDir != _|_ for v in parameter.volumeMounts.emptyDir {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.hostPath != _|_ for v in parameter.volumeMounts.hostPath {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+		}
+		}
+	]
+	volumesList: [
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.pvc != _|_ for v in parameter.volumeMounts.pvc {
+		{
+			name: v.name
+			persistentVolumeClaim: {
+				claimName: v.claimName
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.configMap != _|_ for v in parameter.volumeMounts.configMap {
+		{
+			configMap: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
+				}
+				name: v.cmName
+			}
+			name: v.name
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.secret != _|_ for v in parameter.volumeMounts.secret {
+		{
+			name: v.name
+			secret: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
+				}
+				secretName: v.secretName
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.emptyDir != _|_ for v in parameter.volumeMounts.emptyDir {
+		{
+			emptyDir: {
+				medium: v.medium
+			}
+			name: v.name
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.hostPath != _|_ for v in parameter.volumeMounts.hostPath {
+		{
+			hostPath: {
+				path: v.path
+			}
+			name: v.name
+		}
+		}
+	]
+	deDupVolumesArray: [
+		for val in [
+			for i, vi in volumesList {
+				for j, vj in volumesList if j < i && vi.name == vj.name {
+					_ignore: true
+				}
+				vi
+			},
+		] if val._ignore == _|_ {
+			val
+		},
+	]
+	output: {
+		apiVersion: "argoproj.io/v1alpha1"
+		kind:       "Rollout"
+		metadata: {
+			name: context.name
+		}
+		spec: {
+			selector: {
+				matchLabels: {
+					"app.oam.dev/component": context.name
+				}
+			}
+			template: {
+				metadata: {
+					labels: {
+						if parameter["labels"] != _|_ {
+							parameter.labels
+						}
+						"app.oam.dev/name": context.appName
+						"app.oam.dev/component": context.name
+					}
+					if parameter["annotations"] != _|_ {
+						annotations: parameter.annotations
+					}
+				}
+				spec: {
+					containers: [{
+						name: context.name
+						image: parameter.image
+						ports: [for v in parameter.ports {
+				{
+					containerPort: v.port
+					name: *v.name | "port-" + strconv.FormatInt(v.port, 10)
+					protocol: v.protocol
+				}
+			}]
+						if parameter["cpu"] != _|_ {
+							resources: {
+								limits: {
+									cpu: parameter.cpu
+								}
+								requests: {
+									cpu: parameter.cpu
+								}
+							}
+						}
+						if parameter["memory"] != _|_ {
+							resources: {
+								limits: {
+									memory: parameter.memory
+								}
+								requests: {
+									memory: parameter.memory
+								}
+							}
+						}
+						if parameter["args"] != _|_ {
+							args: parameter.args
+						}
+						if parameter["cmd"] != _|_ {
+							command: parameter.cmd
+						}
+						if parameter["env"] != _|_ {
+							env: parameter.env
+						}
+						if parameter["imagePullPolicy"] != _|_ {
+							imagePullPolicy: parameter.imagePullPolicy
+						}
+						if parameter["livenessProbe"] != _|_ {
+							livenessProbe: parameter.livenessProbe
+						}
+						if parameter["readinessProbe"] != _|_ {
+							readinessProbe: parameter.readinessProbe
+						}
+						if parameter["volumeMounts"] != _|_ {
+							volumeMounts: mountsArray
+						}
+					}]
+					if parameter["imagePullSecrets"] != _|_ {
+						imagePullSecrets: [for v in parameter.imagePullSecrets { name: v }]
+					}
+					if parameter["volumeMounts"] != _|_ {
+						volumes: deDupVolumesArray
+					}
+				}
+			}
+		}
+		if parameter.strategy.type == "canary" {
+			spec: {
+				strategy: {
+					canary: {
+						stableService: context.name
+						canaryService: "\(context.name)-canary"
+						if parameter.strategy.steps != _|_ {
+							steps: parameter.strategy.steps
+						}
+						if parameter.strategy.analysis != _|_ {
+							analysis: parameter.strategy.analysis
+						}
+						if parameter.strategy.trafficRouting != _|_ {
+							trafficRouting: parameter.strategy.trafficRouting
+						}
+					}
+				}
+			}
+		}
+		if parameter.strategy.type == "blueGreen" {
+			spec: {
+				strategy: {
+					blueGreen: {
+						activeService: context.name
+						previewService: "\(context.name)-preview"
+						autoPromotionEnabled: parameter.strategy.autoPromotionEnabled
+						if parameter.strategy.autoPromotionSeconds != _|_ {
+							autoPromotionSeconds: parameter.strategy.autoPromotionSeconds
+						}
+						if parameter.strategy.previewReplicaCount != _|_ {
+							previewReplicaCount: parameter.strategy.previewReplicaCount
+						}
+						if parameter.strategy.scaleDownDelaySeconds != _|_ {
+							scaleDownDelaySeconds: parameter.strategy.scaleDownDelaySeconds
+						}
+					}
+				}
+			}
+		}
+	}
+	outputs: {
+		if parameter.strategy.type == "canary" {
+			canaryService: {
+				apiVersion: "v1"
+				kind:       "Service"
+				metadata: {
+					name: "\(context.name)-canary"
+				}
+				spec: {
+					selector: {
+						"app.oam.dev/component": context.name
+					}
+					ports: [for v in parameter.ports {
+				{
+					name: *v.name | "port-" + strconv.FormatInt(*v.port | v.containerPort, 10)
+					port: *v.port | v.containerPort
+					protocol: v.protocol
+					targetPort: *v.port | v.containerPort
+				}
+			}]
+				}
+			}
+		}
+		if parameter.strategy.type == "blueGreen" {
+			previewService: {
+				apiVersion: "v1"
+				kind:       "Service"
+				metadata: {
+					name: "\(context.name)-preview"
+				}
+				spec: {
+					selector: {
+						"app.oam.dev/component": context.name
+					}
+					ports: [for v in parameter.ports {
+				{
+					name: *v.name | "port-" + strconv.FormatInt(*v.port | v.containerPort, 10)
+					port: *v.port | v.containerPort
+					protocol: v.protocol
+					targetPort: *v.port | v.containerPort
+				}
+			}]
+				}
+			}
+		}
+		rolloutService: {
+			apiVersion: "v1"
+			kind:       "Service"
+			metadata: {
+				name: context.name
+			}
+			spec: {
+				selector: {
+					"app.oam.dev/component": context.name
+				}
+				ports: [for v in parameter.ports {
+				{
+					name: *v.name | "port-" + strconv.FormatInt(*v.port | v.containerPort, 10)
+					port: *v.port | v.containerPort
+					protocol: v.protocol
+					targetPort: *v.port | v.containerPort
+				}
+			}]
+			}
+		}
+	}
+	parameter: {
+		// +usage=Specify the labels in the workload
+		labels?: [string]: string
+		// +usage=Specify the annotations in the workload
+		annotations?: [string]: string
+		// +usage=Which image would you like to use for your service
+		// +short=i
+		image: string
+		// +usage=Specify image pull policy for your service
+		imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
+		// +usage=Specify image pull secrets for your service
+		imagePullSecrets?: [...string]
+		// +usage=Ports of the container, all of them are exposed by the Services of the strategy
+		ports: [...{
+			// +usage=Number of the port, on both the container and the Services
+			port: int
+			// +usage=Name of the port, defaults to `port-<port>`
+			name?: string
+			// +usage=Protocol for port. Must be UDP, TCP, or SCTP
+			protocol: *"TCP" | "UDP" | "SCTP"
+		}]
+		// +usage=Commands to run in the container
+		cmd?: [...string]
+		// +usage=Arguments to the entrypoint
+		args?: [...string]
+		// +usage=Define arguments by using environment variables
+		env?: [...{
+			// +usage=Environment variable name
+			name: string
+			// +usage=The value of the environment variable
+			value?: string
+			// +usage=Specifies a source the value of this var should come from
+			valueFrom?: {
+				// +usage=Selects a key of a secret in the pod's namespace
+				secretKeyRef?: {
+					// +usage=The name of the secret in the pod's namespace to select from
+					name: string
+					// +usage=The key of the secret to select from. Must be a valid secret key
+					key: string
+				}
+				// +usage=Selects a key of a config map in the pod's namespace
+				configMapKeyRef?: {
+					// +usage=The name of the config map in the pod's namespace to select from
+					name: string
+					// +usage=The key of the config map to select from. Must be a valid secret key
+					key: string
+				}
+			}
+		}]
+		// +usage=Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+		cpu?: string
+		// +usage=Specifies the attributes of the memory resource required for the container.
+		memory?: string
+		volumeMounts?: {
+			// +usage=Mount PVC type volume
+			pvc?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				// +usage=The name of the PVC
+				claimName: string
+			}]
+			// +usage=Mount ConfigMap type volume
+			configMap?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				defaultMode: *420 | int
+				cmName: string
+				items?: [...{
+					key: string
+					path: string
+					mode: *511 | int
+				}]
+			}]
+			// +usage=Mount Secret type volume
+			secret?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				defaultMode: *420 | int
+				secretName: string
+				items?: [...{
+					key: string
+					path: string
+					mode: *511 | int
+				}]
+			}]
+			// +usage=Mount EmptyDir type volume
+			emptyDir?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				medium: *"" | "Memory"
+			}]
+			// +usage=Mount HostPath type volume
+			hostPath?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				path: string
+			}]
+		}
+		// +usage=Instructions for assessing whether the container is alive.
+		livenessProbe?: #HealthProbe
+		// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
+		readinessProbe?: #HealthProbe
+		// +usage=Progressive delivery strategy of the rollout
+		strategy: {
+			// +usage=Specify the strategy type, options: "canary","blueGreen", default to canary
+			type: *"canary" | "blueGreen"
+			if type == "canary" {
+				// +usage=Steps of the canary, the new version gets all the traffic once they complete
+				steps?: [...{
+					// +usage=Percentage of the traffic sent to the canary
+					setWeight?: int
+					// +usage=Pause the rollout, until promoted when duration is empty
+					pause?: {
+						// +usage=Duration of the pause, like `30s` or `5m`
+						duration?: string
+					}
+					// +usage=Run an analysis and wait for its result before the next step
+					analysis?: {
+						// +usage=AnalysisTemplates to run
+						templates: [...{
+							// +usage=Name of the AnalysisTemplate
+							templateName: string
+							// +usage=Refer to a ClusterAnalysisTemplate instead
+							clusterScope?: bool
+						}]
+					}
+				}]
+				// +usage=Analysis running in the background during the whole canary
+				analysis?: {
+					// +usage=AnalysisTemplates to run
+					templates: [...{
+						// +usage=Name of the AnalysisTemplate
+						templateName: string
+						// +usage=Refer to a ClusterAnalysisTemplate instead
+						clusterScope?: bool
+					}]
+					// +usage=Index of the step the background analysis starts at
+					startingStep?: int
+				}
+				// +usage=Traffic router splitting the traffic by weight, replica counts are used if empty
+				trafficRouting?: {
+					// +usage=NGINX ingress controller
+					nginx?: {
+						// +usage=Name of the Ingress routing to the stable Service
+						stableIngress: string
+					}
+					// +usage=Istio service mesh
+					istio?: {
+						// +usage=VirtualService whose routes are weighted
+						virtualService: {
+							// +usage=Name of the VirtualService
+							name: string
+							// +usage=Names of the HTTP routes to weight, required when the VirtualService has several
+							routes?: [...string]
+						}
+					}
+					// +usage=Service Mesh Interface
+					smi?: {
+						// +usage=Name of the TrafficSplit, defaults to the component name
+						trafficSplitName?: string
+					}
+				}
+			}
+			if type == "blueGreen" {
+				// +usage=Promote the preview version once it is ready, otherwise wait for a manual promotion
+				autoPromotionEnabled: *true | bool
+				// +usage=Delay in seconds before the automatic promotion
+				autoPromotionSeconds?: int
+				// +usage=Number of replicas of the preview version before promotion
+				previewReplicaCount?: int
+				// +usage=Delay in seconds before the previous version is scaled down after promotion
+				scaleDownDelaySeconds?: int
+			}
+		}
+	}
+	#HealthProbe: {
+		// +usage=Instructions for assessing container health by executing a command. Either this attribute or the httpGet attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the httpGet attribute and the tcpSocket attribute.
+		exec?: {
+			// +usage=A command to be executed inside the container to assess its health. Each space delimited token of the command is a separate array element. Commands exiting 0 are considered to be successful probes, whilst all other exit codes are considered failures.
+			command: [...string]
+		}
+		// +usage=Instructions for assessing container health by executing an HTTP GET request. Either this attribute or the exec attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the exec attribute and the tcpSocket attribute.
+		httpGet?: {
+			// +usage=The endpoint, relative to the port, to which the HTTP GET request should be directed.
+			path: string
+			// +usage=The TCP socket within the container to which the HTTP GET request should be directed.
+			port: int
+			host?: string
+			scheme?: *"HTTP" | string
+			httpHeaders?: [...{
+				name: string
+				value: string
+			}]
+		}
+		// +usage=Instructions for assessing container health by probing a TCP socket. Either this attribute or the exec attribute or the httpGet attribute MUST be specified. This attribute is mutually exclusive with both the exec attribute and the httpGet attribute.
+		tcpSocket?: {
+			// +usage=The TCP socket within the container that should be probed to assess container health.
+			port: int
+		}
+		// +usage=Number of seconds after the container is started before the first probe is initiated.
+		initialDelaySeconds: *0 | int
+		// +usage=How often, in seconds, to execute the probe.
+		periodSeconds: *10 | int
+		// +usage=Number of seconds after which the probe times out.
+		timeoutSeconds: *1 | int
+		// +usage=Minimum consecutive successes for the probe to be considered successful after having failed.
+		successThreshold: *1 | int
+		// +usage=Number of consecutive failures required to determine the container is not alive (liveness probe) or not ready (readiness probe).
+		failureThreshold: *3 | int
+	}
+}
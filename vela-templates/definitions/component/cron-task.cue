@@ -9,6 +9,53 @@ import (
 	description: "Describes cron jobs that run code or a script to completion."
 	attributes: {
 		workload: type: "autodetects.core.oam.dev"
+		status: {
+			customStatus: #"""
+				_active: *0 | int
+				if context.output.status.active != _|_ {
+					_active: len(context.output.status.active)
+				}
+				_lastSchedule: *"never" | string
+				if context.output.status.lastScheduleTime != _|_ {
+					_lastSchedule: context.output.status.lastScheduleTime
+				}
+				_lastSuccess: *"never" | string
+				if context.output.status.lastSuccessfulTime != _|_ {
+					_lastSuccess: context.output.status.lastSuccessfulTime
+				}
+				if context.output.status.lastSuccessfulTime == _|_ if context.output.apiVersion != _|_ if context.output.apiVersion == "batch/v1beta1" {
+					_lastSuccess: "unknown"
+				}
+				_zone: *"" | string
+				if context.output.spec.timeZone != _|_ {
+					_zone: " (\(context.output.spec.timeZone))"
+				}
+				_schedule: *"schedule: \(context.output.spec.schedule)\(_zone)" | string
+				if context.output.spec.suspend != _|_ if context.output.spec.suspend {
+					_schedule: "suspended"
+				}
+				message: "Active:\(_active), last schedule: \(_lastSchedule), last success: \(_lastSuccess), \(_schedule)"
+				"""#
+			healthPolicy: #"""
+				_active: *0 | int
+				if context.output.status.active != _|_ {
+					_active: len(context.output.status.active)
+				}
+				_lastSchedule: *"" | string
+				if context.output.status.lastScheduleTime != _|_ {
+					_lastSchedule: context.output.status.lastScheduleTime
+				}
+				_lastSuccess: *"" | string
+				if context.output.status.lastSuccessfulTime != _|_ {
+					_lastSuccess: context.output.status.lastSuccessfulTime
+				}
+				_unreported: *false | bool
+				if context.output.apiVersion != _|_ if context.output.apiVersion == "batch/v1beta1" {
+					_unreported: _lastSuccess == ""
+				}
+				isHealth: _active > 0 || _lastSchedule == "" || _unreported || _lastSuccess >= _lastSchedule
+				"""#
+		}
 	}
 }
 template: {
@@ -139,6 +186,7 @@ template: {
 		] | []
 
 	}
+	mountsList: list.Concat([mountsArray.pvc, mountsArray.configMap, mountsArray.secret, mountsArray.emptyDir, mountsArray.hostPath])
 	volumesList: list.Concat([volumesArray.pvc, volumesArray.configMap, volumesArray.secret, volumesArray.emptyDir, volumesArray.hostPath])
 	deDupVolumesArray: [
 		for val in [
@@ -222,7 +270,7 @@ template: {
 									}
 								}
 								if parameter["volumeMounts"] != _|_ {
-									volumeMounts: mountsArray.pvc + mountsArray.configMap + mountsArray.secret + mountsArray.emptyDir + mountsArray.hostPath
+									volumeMounts: mountsList
 								}
 								if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
 									volumeMounts: [for v in parameter.volumes {
@@ -291,6 +339,56 @@ template: {
 							if parameter["imagePullSecrets"] != _|_ {
 								imagePullSecrets: [for v in parameter.imagePullSecrets { name: v }]
 							}
+							if parameter["sidecars"] != _|_ if len(parameter["sidecars"]) > 0 {
+								initContainers: [
+		for m in parameter.sidecars {
+			{
+				image: m.image
+				name: m.name
+				restartPolicy: "Always"
+				if m.imagePullPolicy != _|_ {
+					imagePullPolicy: m.imagePullPolicy
+				}
+				if m.cmd != _|_ {
+					command: m.cmd
+				}
+				if m.args != _|_ {
+					args: m.args
+				}
+				if m.ports != _|_ {
+					ports: m.ports
+				}
+				if m.env != _|_ {
+					env: m.env
+				}
+				if m.cpu != _|_ {
+					resources: limits: cpu: m.cpu
+				}
+				if m.cpu != _|_ {
+					resources: requests: cpu: m.cpu
+				}
+				if m.memory != _|_ {
+					resources: limits: memory: m.memory
+				}
+				if m.memory != _|_ {
+					resources: requests: memory: m.memory
+				}
+				if m.startupProbe != _|_ {
+					startupProbe: m.startupProbe
+				}
+				if m.livenessProbe != _|_ {
+					livenessProbe: m.livenessProbe
+				}
+				if m.readinessProbe != _|_ {
+					readinessProbe: m.readinessProbe
+				}
+				if m.volumeMounts != _|_ {
+					volumeMounts: m.volumeMounts
+				}
+			}
+		},
+	]
+							}
 						}
 					}
 					if parameter["activeDeadlineSeconds"] != _|_ {
@@ -304,6 +402,9 @@ template: {
 			if parameter["startingDeadlineSeconds"] != _|_ {
 				startingDeadlineSeconds: parameter.startingDeadlineSeconds
 			}
+			if parameter["timeZone"] != _|_ {
+				timeZone: parameter.timeZone
+			}
 		}
 	}
 	parameter: {
@@ -313,6 +414,8 @@ template: {
 		annotations?: [string]: string
 		// +usage=Specify the schedule in Cron format, see https://en.wikipedia.org/wiki/Cron
 		schedule: string
+		// +usage=Specify the time zone of the schedule as an IANA name, like `Europe/Berlin`, defaults to the time zone of the kube-controller-manager. Requires Kubernetes 1.27 or later
+		timeZone?: string & =~"^[A-Za-z][A-Za-z0-9_+-]*(/[A-Za-z0-9_+-]+)*$"
 		// +usage=Specify deadline in seconds for starting the job if it misses scheduled
 		startingDeadlineSeconds?: int
 		// +usage=suspend subsequent executions
@@ -461,6 +564,89 @@ template: {
 		livenessProbe?: #HealthProbe
 		// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
 		readinessProbe?: #HealthProbe
+		// +usage=Sidecar containers that start before and stop after the main container, so the job completes once the main container exits. Requires Kubernetes 1.29 or later
+		sidecars?: [...{
+			// +usage=Name of the container, unique within the pod
+			name: string
+			// +usage=Image of the container
+			image: string
+			// +usage=Specify image pull policy for the container
+			imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
+			// +usage=Commands to run in the container
+			cmd?: [...string]
+			// +usage=Arguments to the entrypoint
+			args?: [...string]
+			// +usage=Ports the container listens on
+			ports?: [...{
+				// +usage=Number of port to expose on the pod's IP address
+				containerPort: int
+				// +usage=Name of the port
+				name?: string
+				// +usage=Protocol for port. Must be UDP, TCP, or SCTP
+				protocol: *"TCP" | "UDP" | "SCTP"
+			}]
+			// +usage=Define arguments by using environment variables
+			env?: [...{
+				// +usage=Environment variable name
+				name: string
+				// +usage=The value of the environment variable
+				value?: string
+				// +usage=Specifies a source the value of this var should come from
+				valueFrom?: {
+					// +usage=Selects a key of a secret in the pod's namespace
+					secretKeyRef?: {
+						// +usage=The name of the secret in the pod's namespace to select from
+						name: string
+						// +usage=The key of the secret to select from. Must be a valid secret key
+						key: string
+					}
+					// +usage=Selects a key of a config map in the pod's namespace
+					configMapKeyRef?: {
+						// +usage=The name of the config map in the pod's namespace to select from
+						name: string
+						// +usage=The key of the config map to select from. Must be a valid secret key
+						key: string
+					}
+				}
+			}]
+			// +usage=Number of CPU units for the container, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+			cpu?: string
+			// +usage=Specifies the attributes of the memory resource required for the container.
+			memory?: string
+			// +usage=Instructions for assessing whether the container has started. Other probes wait until it succeeds.
+			startupProbe?: #HealthProbe
+			// +usage=Instructions for assessing whether the container is alive.
+			livenessProbe?: #HealthProbe
+			// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
+			readinessProbe?: #HealthProbe
+			// +usage=Mount pod volumes declared in volumeMounts into the container
+			volumeMounts?: [...{
+				// +usage=Name of the pod volume
+				name: string
+				// +usage=Path to mount the volume at
+				mountPath: string
+				// +usage=Mount only this path of the volume
+				subPath?: string
+				// +usage=Mount the volume read-only
+				readOnly?: bool
+			}]
+		}]
+		if parameter["sidecars"] != _|_ if len(parameter["sidecars"]) > 0 {
+			_validateSidecars: {
+				"sidecars require Kubernetes 1.29 or later": true
+				if !(context.clusterVersion.minor >= 29) {
+					"sidecars require Kubernetes 1.29 or later": false
+				}
+			}
+		}
+		if parameter["timeZone"] != _|_ {
+			_validateTimeZone: {
+				"timeZone requires Kubernetes 1.27 or later": true
+				if context.clusterVersion.minor < 27 {
+					"timeZone requires Kubernetes 1.27 or later": false
+				}
+			}
+		}
 	}
 	#HealthProbe: {
 		// +usage=Instructions for assessing container health by executing a command. Either this attribute or the httpGet attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the httpGet attribute and the tcpSocket attribute.
@@ -0,0 +1,116 @@
+"crossplane-claim": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Describes infrastructure requested as a Crossplane claim, independent of the cloud that provides it."
+	attributes: {
+		workload: type: "autodetects.core.oam.dev"
+		status: {
+			customStatus: #"""
+				_conditions: *[] | [...]
+				if context.output.status != _|_ if context.output.status.conditions != _|_ {
+					_conditions: context.output.status.conditions
+				}
+				_synced: [ for c in _conditions if c.type == "Synced" && c.status == "True" { c } ]
+				_ready: [ for c in _conditions if c.type == "Ready" && c.status == "True" { c } ]
+				_pending: [ for c in _conditions if c.status != "True" { c } ]
+				if len(_pending) == 0 {
+					message: *"waiting for the Synced and Ready conditions" | string
+					if len(_synced) > 0 && len(_ready) > 0 {
+						message: "Synced and Ready"
+					}
+				}
+				if len(_pending) > 0 {
+					_reason: *"" | string
+					if _pending[0].reason != _|_ {
+						_reason: ", \(_pending[0].reason)"
+					}
+					_detail: *"" | string
+					if _pending[0].message != _|_ {
+						_detail: ": \(_pending[0].message)"
+					}
+					message: "\(_pending[0].type) is \(_pending[0].status)\(_reason)\(_detail)"
+				}
+				"""#
+			healthPolicy: #"""
+				_conditions: *[] | [...]
+				if context.output.status != _|_ if context.output.status.conditions != _|_ {
+					_conditions: context.output.status.conditions
+				}
+				_synced: [ for c in _conditions if c.type == "Synced" && c.status == "True" { c } ]
+				_ready: [ for c in _conditions if c.type == "Ready" && c.status == "True" { c } ]
+				isHealth: len(_synced) > 0 && len(_ready) > 0
+				"""#
+		}
+	}
+}
+template: {
+	output: {
+		apiVersion: parameter.apiVersion
+		kind:       parameter.kind
+		metadata: {
+			name: context.name
+			if parameter.labels != _|_ {
+				labels: parameter.labels
+			}
+			if parameter.annotations != _|_ {
+				annotations: parameter.annotations
+			}
+		}
+		spec: {
+			if parameter.spec != _|_ {
+				parameter.spec
+			}
+			if parameter.compositionSelector != _|_ {
+				compositionSelector: parameter.compositionSelector
+			}
+			if parameter.compositionRef != _|_ {
+				compositionRef: parameter.compositionRef
+			}
+			if parameter.compositionUpdatePolicy != _|_ {
+				compositionUpdatePolicy: parameter.compositionUpdatePolicy
+			}
+			if parameter.writeConnectionSecretToRef != _|_ {
+				writeConnectionSecretToRef: name: parameter.writeConnectionSecretToRef.name
+			}
+			if parameter.writeConnectionSecretToRef == _|_ {
+				writeConnectionSecretToRef: name: "\(context.name)-conn"
+			}
+		}
+	}
+	parameter: {
+		// +usage=API version of the claim, like `database.example.org/v1alpha1`
+		apiVersion: string
+		// +usage=Kind of the claim, like `PostgreSQLInstance`
+		kind: string
+		// +usage=Spec of the claim, as defined by the CompositeResourceDefinition
+		spec?: {...}
+		// +usage=Specify the labels of the claim
+		labels?: [string]: string
+		// +usage=Specify the annotations of the claim
+		annotations?: [string]: string
+		// +usage=Select the Composition by its labels, like the provider or environment it targets
+		compositionSelector?: {
+			// +usage=Labels the Composition must have
+			matchLabels: [string]: string
+		}
+		// +usage=Use the Composition with this name, exclusive to compositionSelector
+		compositionRef?: {
+			// +usage=Name of the Composition
+			name: string
+		}
+		// +usage=Whether the claim moves to new revisions of its Composition automatically
+		compositionUpdatePolicy?: "Automatic" | "Manual"
+		// +usage=Secret Crossplane writes the connection details of the claim to
+		writeConnectionSecretToRef?: {
+			// +usage=Name of the Secret, defaults to `<component>-conn`
+			name: string
+		}
+		_validateComposition: {
+			"compositionRef and compositionSelector are mutually exclusive": true
+			if parameter["compositionRef"] != _|_ && parameter["compositionSelector"] != _|_ {
+				"compositionRef and compositionSelector are mutually exclusive": false
+			}
+		}
+	}
+}
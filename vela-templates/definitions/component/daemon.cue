@@ -1,5 +1,6 @@
 import (
 	"strconv"
+	"strings"
 )
 
 daemon: {
@@ -211,78 +212,162 @@ template: {
 					}
 				}
 				spec: {
-					containers: [{
-						name: context.name
-						image: parameter.image
-						if parameter["port"] != _|_ && parameter["ports"] == _|_ {
-							ports: [{
-							containerPort: parameter.port
-						}]
-						}
-						if parameter["ports"] != _|_ {
-							ports: [for v in parameter.ports {
-				{
-					containerPort: v.port
-					if v.name != _|_ {
-						name: v.name
-					}
-					if v.name == _|_ {
-						name: "port-" + strconv.FormatInt(v.port, 10)
-					}
-					protocol: v.protocol
-				}
-			}]
-						}
-						if parameter["env"] != _|_ {
-							env: parameter.env
-						}
-						if context["config"] != _|_ {
-							env: context.config
-						}
-						if parameter["cpu"] != _|_ {
-							resources: {
-								limits: {
-									cpu: parameter.cpu
-								}
-								requests: {
-									cpu: parameter.cpu
-								}
-							}
-						}
-						if parameter["memory"] != _|_ {
-							resources: {
-								limits: {
-									memory: parameter.memory
+					containers: [
+		{
+			image: parameter.image
+			name: context.name
+			if parameter["port"] != _|_ && parameter["ports"] == _|_ {
+				ports: [{
+											containerPort: parameter.port
+										}]
+			}
+			if parameter["ports"] != _|_ {
+				ports: [for v in parameter.ports {
+								{
+									containerPort: v.port
+									if v.name != _|_ {
+										name: v.name
+									}
+									if v.name == _|_ {
+										name: "port-" + strconv.FormatInt(v.port, 10)
+									}
+									protocol: v.protocol
 								}
-								requests: {
-									memory: parameter.memory
+							}]
+			}
+			if parameter["imagePullPolicy"] != _|_ {
+				imagePullPolicy: parameter.imagePullPolicy
+			}
+			if parameter["cmd"] != _|_ {
+				command: parameter.cmd
+			}
+			if parameter["env"] != _|_ {
+				env: parameter.env
+			}
+			if context["config"] != _|_ {
+				env: context.config
+			}
+			if parameter["cpu"] != _|_ {
+				resources: limits: cpu: parameter.cpu
+			}
+			if parameter["cpu"] != _|_ {
+				resources: requests: cpu: parameter.cpu
+			}
+			if parameter["memory"] != _|_ {
+				resources: limits: memory: parameter.memory
+			}
+			if parameter["memory"] != _|_ {
+				resources: requests: memory: parameter.memory
+			}
+			if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
+				volumeMounts: [for v in parameter.volumes {
+								{
+									mountPath: v.mountPath
+									name: v.name
 								}
-							}
-						}
-						if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
-							volumeMounts: [for v in parameter.volumes {
-				{
-					mountPath: v.mountPath
-					name: v.name
+							}]
+			}
+			if parameter["volumeMounts"] != _|_ {
+				volumeMounts: mountsArray
+			}
+			if parameter["livenessProbe"] != _|_ {
+				livenessProbe: parameter.livenessProbe
+			}
+			if parameter["readinessProbe"] != _|_ {
+				readinessProbe: parameter.readinessProbe
+			}
+		},
+		if parameter["containers"] != _|_ for m in parameter.containers {
+			{
+				image: m.image
+				name: m.name
+				if m.imagePullPolicy != _|_ {
+					imagePullPolicy: m.imagePullPolicy
 				}
-			}]
-						}
-						if parameter["volumeMounts"] != _|_ {
-							volumeMounts: mountsArray
-						}
-						if parameter["cmd"] != _|_ {
-							command: parameter.cmd
-						}
-						if parameter["imagePullPolicy"] != _|_ {
-							imagePullPolicy: parameter.imagePullPolicy
-						}
-						if parameter["livenessProbe"] != _|_ {
-							livenessProbe: parameter.livenessProbe
-						}
-						if parameter["readinessProbe"] != _|_ {
-							readinessProbe: parameter.readinessProbe
-						}
-					}]
+				if m.cmd != _|_ {
+					command: m.cmd
+				}
+				if m.args != _|_ {
+					args: m.args
+				}
+				if m.ports != _|_ {
+					ports: m.ports
+				}
+				if m.env != _|_ {
+					env: m.env
+				}
+				if m.cpu != _|_ {
+					resources: limits: cpu: m.cpu
+				}
+				if m.cpu != _|_ {
+					resources: requests: cpu: m.cpu
+				}
+				if m.memory != _|_ {
+					resources: limits: memory: m.memory
+				}
+				if m.memory != _|_ {
+					resources: requests: memory: m.memory
+				}
+				if m.startupProbe != _|_ {
+					startupProbe: m.startupProbe
+				}
+				if m.livenessProbe != _|_ {
+					livenessProbe: m.livenessProbe
+				}
+				if m.readinessProbe != _|_ {
+					readinessProbe: m.readinessProbe
+				}
+				if m.volumeMounts != _|_ {
+					volumeMounts: m.volumeMounts
+				}
+			}
+		},
+		if parameter["sidecars"] != _|_ if len(parameter["sidecars"]) > 0 if !(context.clusterVersion.minor >= 29) for m in parameter.sidecars {
+			{
+				image: m.image
+				name: m.name
+				if m.imagePullPolicy != _|_ {
+					imagePullPolicy: m.imagePullPolicy
+				}
+				if m.cmd != _|_ {
+					command: m.cmd
+				}
+				if m.args != _|_ {
+					args: m.args
+				}
+				if m.ports != _|_ {
+					ports: m.ports
+				}
+				if m.env != _|_ {
+					env: m.env
+				}
+				if m.cpu != _|_ {
+					resources: limits: cpu: m.cpu
+				}
+				if m.cpu != _|_ {
+					resources: requests: cpu: m.cpu
+				}
+				if m.memory != _|_ {
+					resources: limits: memory: m.memory
+				}
+				if m.memory != _|_ {
+					resources: requests: memory: m.memory
+				}
+				if m.startupProbe != _|_ {
+					startupProbe: m.startupProbe
+				}
+				if m.livenessProbe != _|_ {
+					livenessProbe: m.livenessProbe
+				}
+				if m.readinessProbe != _|_ {
+					readinessProbe: m.readinessProbe
+				}
+				if m.volumeMounts != _|_ {
+					volumeMounts: m.volumeMounts
+				}
+			}
+		},
+	]
 					if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
 						volumes: [for v in parameter.volumes {
 				{
@@ -328,23 +413,273 @@ template: {
 					if parameter["imagePullSecrets"] != _|_ {
 						imagePullSecrets: [for v in parameter.imagePullSecrets { name: v }]
 					}
+					if parameter["sidecars"] != _|_ if len(parameter["sidecars"]) > 0 if context.clusterVersion.minor >= 29 {
+						initContainers: [
+		for m in parameter.sidecars {
+			{
+				image: m.image
+				name: m.name
+				restartPolicy: "Always"
+				if m.imagePullPolicy != _|_ {
+					imagePullPolicy: m.imagePullPolicy
+				}
+				if m.cmd != _|_ {
+					command: m.cmd
+				}
+				if m.args != _|_ {
+					args: m.args
+				}
+				if m.ports != _|_ {
+					ports: m.ports
+				}
+				if m.env != _|_ {
+					env: m.env
+				}
+				if m.cpu != _|_ {
+					resources: limits: cpu: m.cpu
+				}
+				if m.cpu != _|_ {
+					resources: requests: cpu: m.cpu
+				}
+				if m.memory != _|_ {
+					resources: limits: memory: m.memory
+				}
+				if m.memory != _|_ {
+					resources: requests: memory: m.memory
+				}
+				if m.startupProbe != _|_ {
+					startupProbe: m.startupProbe
+				}
+				if m.livenessProbe != _|_ {
+					livenessProbe: m.livenessProbe
+				}
+				if m.readinessProbe != _|_ {
+					readinessProbe: m.readinessProbe
+				}
+				if m.volumeMounts != _|_ {
+					volumeMounts: m.volumeMounts
+				}
+			}
+		},
+	]
+					}
 				}
 			}
 		}
 	}
-	exposePorts: [
+	exposedPorts: [
 		if parameter["ports"] != _|_ for v in parameter.ports if v.expose == true {
+			port: v.port
+			targetPort: v.port
 			if v.name != _|_ {
 				name: v.name
 			}
 			if v.name == _|_ {
 				name: "port-" + strconv.FormatInt(v.port, 10)
 			}
-			port: v.port
-			targetPort: v.port
+			if v.appProtocol != _|_ {
+				appProtocol: v.appProtocol
+			}
+			if v.sessionAffinity != _|_ {
+				sessionAffinity: v.sessionAffinity
+			}
+			if v.ipFamilyPolicy != _|_ {
+				ipFamilyPolicy: v.ipFamilyPolicy
+			}
+			if v.serviceType == _|_ {
+				serviceType: parameter.exposeType
+				if v.nodePort != _|_ {
+					if parameter.exposeType == "NodePort" {
+						nodePort: v.nodePort
+					}
+				}
+				if v.externalTrafficPolicy != _|_ {
+					if parameter.exposeType == "NodePort" || parameter.exposeType == "LoadBalancer" {
+						externalTrafficPolicy: v.externalTrafficPolicy
+					}
+				}
+				service: context.name
+			}
+			if v.serviceType != _|_ {
+				serviceType: v.serviceType
+				if v.nodePort != _|_ {
+					if v.serviceType == "NodePort" {
+						nodePort: v.nodePort
+					}
+				}
+				if v.externalTrafficPolicy != _|_ {
+					if v.serviceType == "NodePort" || v.serviceType == "LoadBalancer" {
+						externalTrafficPolicy: v.externalTrafficPolicy
+					}
+				}
+				if v.serviceType == parameter.exposeType {
+					service: context.name
+				}
+				if v.serviceType != parameter.exposeType {
+					service: context.name + "-" + strings.ToLower(v.serviceType)
+				}
+			}
+			routeType: *"" | string
+			if parameter["route"] != _|_ {
+				if v.protocol == "TCP" {
+					if v.appProtocol == _|_ {
+						if !(parameter.route.port != _|_) {
+							routeType: parameter.route.type
+						}
+						if parameter.route.port != _|_ {
+							if v.port == parameter.route.port {
+								routeType: parameter.route.type
+							}
+						}
+					}
+					if v.appProtocol != _|_ {
+						if v.appProtocol == "http" || v.appProtocol == "https" || v.appProtocol == "kubernetes.io/h2c" || v.appProtocol == "kubernetes.io/ws" || v.appProtocol == "kubernetes.io/wss" {
+							if !(parameter.route.port != _|_) {
+								routeType: parameter.route.type
+							}
+							if parameter.route.port != _|_ {
+								if v.port == parameter.route.port {
+									routeType: parameter.route.type
+								}
+							}
+						}
+					}
+				}
+			}
 		},
 	]
+	exposePorts: [for v in exposedPorts if v.serviceType == parameter.exposeType { v }]
+	exposeClusterIPPorts: [for v in exposedPorts if v.serviceType == "ClusterIP" { v }]
+	exposeNodePortPorts: [for v in exposedPorts if v.serviceType == "NodePort" { v }]
+	exposeLoadBalancerPorts: [for v in exposedPorts if v.serviceType == "LoadBalancer" { v }]
+	ingressPorts: [for v in exposedPorts if v.routeType == "Ingress" { v }]
+	httpRoutePorts: [for v in exposedPorts if v.routeType == "HTTPRoute" { v }]
 	outputs: {
+		if len(httpRoutePorts) != 0 {
+			httpRoute: {
+				apiVersion: "gateway.networking.k8s.io/v1"
+				kind:       "HTTPRoute"
+				metadata: {
+					name: context.name
+					if parameter.route.annotations != _|_ {
+						annotations: parameter.route.annotations
+					}
+				}
+				spec: {
+					parentRefs: [
+		{
+			name: parameter.route.gateway.name
+			if parameter.route.gateway.namespace != _|_ {
+				namespace: parameter.route.gateway.namespace
+			}
+			if parameter.route.gateway.sectionName != _|_ {
+				sectionName: parameter.route.gateway.sectionName
+			}
+		},
+	]
+					rules: [
+		{
+			backendRefs: [
+					{
+						name: httpRoutePorts[0].service
+						port: httpRoutePorts[0].port
+					},
+				]
+			matches: [
+					{
+						path: {
+								value: parameter.route.path
+								if parameter.route.pathType == "Prefix" {
+									type: "PathPrefix"
+								}
+								if parameter.route.pathType == "Exact" {
+									type: "Exact"
+								}
+							}
+					},
+				]
+		},
+	]
+					if parameter.route.hosts != _|_ {
+						hostnames: parameter.route.hosts
+					}
+				}
+			}
+		}
+		if len(ingressPorts) != 0 {
+			ingress: {
+				apiVersion: "networking.k8s.io/v1"
+				kind:       "Ingress"
+				metadata: {
+					name: context.name
+					if parameter.route.annotations != _|_ {
+						annotations: parameter.route.annotations
+					}
+				}
+				spec: {
+					if !(parameter.route.hosts != _|_) {
+						rules: [
+		{
+			http: {
+					paths: [
+							{
+								backend: {
+										service: {
+												name: ingressPorts[0].service
+												port: {
+														number: ingressPorts[0].port
+													}
+											}
+									}
+								path: parameter.route.path
+								pathType: parameter.route.pathType
+							},
+						]
+				}
+		},
+	]
+					}
+					if parameter.route.hosts != _|_ {
+						rules: [
+		for m in parameter.route.hosts {
+			{
+				host: m
+				http: {
+		paths: [
+				{
+					backend: {
+							service: {
+									name: ingressPorts[0].service
+									port: {
+											number: ingressPorts[0].port
+										}
+								}
+						}
+					path: parameter.route.path
+					pathType: parameter.route.pathType
+				},
+			]
+	}
+			}
+		},
+	]
+					}
+					if parameter.route.className != _|_ {
+						ingressClassName: parameter.route.className
+					}
+					if parameter.route.tlsSecretName != _|_ {
+						tls: [
+		{
+			secretName: parameter.route.tlsSecretName
+			if parameter.route.hosts != _|_ {
+				hosts: parameter.route.hosts
+			}
+		},
+	]
+					}
+				}
+			}
+		}
 		if len(exposePorts) != 0 {
 			webserviceExpose: {
 				apiVersion: "v1"
@@ -356,8 +691,152 @@ template: {
 					selector: {
 						"app.oam.dev/component": context.name
 					}
-					ports: exposePorts
+					ports: [for v in exposePorts {
+				{
+					if v.appProtocol != _|_ {
+						appProtocol: v.appProtocol
+					}
+					name: v.name
+					if v.nodePort != _|_ {
+						nodePort: v.nodePort
+					}
+					port: v.port
+					if v.protocol != _|_ {
+						protocol: v.protocol
+					}
+					targetPort: v.targetPort
+				}
+			}]
 					type: parameter.exposeType
+					if exposePorts[0].externalTrafficPolicy != _|_ {
+						externalTrafficPolicy: exposePorts[0].externalTrafficPolicy
+					}
+					if exposePorts[0].ipFamilyPolicy != _|_ {
+						ipFamilyPolicy: exposePorts[0].ipFamilyPolicy
+					}
+					if exposePorts[0].sessionAffinity != _|_ {
+						sessionAffinity: exposePorts[0].sessionAffinity
+					}
+				}
+			}
+		}
+		if parameter.exposeType != "ClusterIP" && len(exposeClusterIPPorts) != 0 {
+			webserviceExposeClusterIP: {
+				apiVersion: "v1"
+				kind:       "Service"
+				metadata: {
+					name: context.name + "-clusterip"
+				}
+				spec: {
+					selector: {
+						"app.oam.dev/component": context.name
+					}
+					ports: [for v in exposeClusterIPPorts {
+				{
+					if v.appProtocol != _|_ {
+						appProtocol: v.appProtocol
+					}
+					name: v.name
+					if v.nodePort != _|_ {
+						nodePort: v.nodePort
+					}
+					port: v.port
+					if v.protocol != _|_ {
+						protocol: v.protocol
+					}
+					targetPort: v.targetPort
+				}
+			}]
+					type: "ClusterIP"
+					if exposeClusterIPPorts[0].externalTrafficPolicy != _|_ {
+						externalTrafficPolicy: exposeClusterIPPorts[0].externalTrafficPolicy
+					}
+					if exposeClusterIPPorts[0].ipFamilyPolicy != _|_ {
+						ipFamilyPolicy: exposeClusterIPPorts[0].ipFamilyPolicy
+					}
+					if exposeClusterIPPorts[0].sessionAffinity != _|_ {
+						sessionAffinity: exposeClusterIPPorts[0].sessionAffinity
+					}
+				}
+			}
+		}
+		if parameter.exposeType != "LoadBalancer" && len(exposeLoadBalancerPorts) != 0 {
+			webserviceExposeLoadBalancer: {
+				apiVersion: "v1"
+				kind:       "Service"
+				metadata: {
+					name: context.name + "-loadbalancer"
+				}
+				spec: {
+					selector: {
+						"app.oam.dev/component": context.name
+					}
+					ports: [for v in exposeLoadBalancerPorts {
+				{
+					if v.appProtocol != _|_ {
+						appProtocol: v.appProtocol
+					}
+					name: v.name
+					if v.nodePort != _|_ {
+						nodePort: v.nodePort
+					}
+					port: v.port
+					if v.protocol != _|_ {
+						protocol: v.protocol
+					}
+					targetPort: v.targetPort
+				}
+			}]
+					type: "LoadBalancer"
+					if exposeLoadBalancerPorts[0].externalTrafficPolicy != _|_ {
+						externalTrafficPolicy: exposeLoadBalancerPorts[0].externalTrafficPolicy
+					}
+					if exposeLoadBalancerPorts[0].ipFamilyPolicy != _|_ {
+						ipFamilyPolicy: exposeLoadBalancerPorts[0].ipFamilyPolicy
+					}
+					if exposeLoadBalancerPorts[0].sessionAffinity != _|_ {
+						sessionAffinity: exposeLoadBalancerPorts[0].sessionAffinity
+					}
+				}
+			}
+		}
+		if parameter.exposeType != "NodePort" && len(exposeNodePortPorts) != 0 {
+			webserviceExposeNodePort: {
+				apiVersion: "v1"
+				kind:       "Service"
+				metadata: {
+					name: context.name + "-nodeport"
+				}
+				spec: {
+					selector: {
+						"app.oam.dev/component": context.name
+					}
+					ports: [for v in exposeNodePortPorts {
+				{
+					if v.appProtocol != _|_ {
+						appProtocol: v.appProtocol
+					}
+					name: v.name
+					if v.nodePort != _|_ {
+						nodePort: v.nodePort
+					}
+					port: v.port
+					if v.protocol != _|_ {
+						protocol: v.protocol
+					}
+					targetPort: v.targetPort
+				}
+			}]
+					type: "NodePort"
+					if exposeNodePortPorts[0].externalTrafficPolicy != _|_ {
+						externalTrafficPolicy: exposeNodePortPorts[0].externalTrafficPolicy
+					}
+					if exposeNodePortPorts[0].ipFamilyPolicy != _|_ {
+						ipFamilyPolicy: exposeNodePortPorts[0].ipFamilyPolicy
+					}
+					if exposeNodePortPorts[0].sessionAffinity != _|_ {
+						sessionAffinity: exposeNodePortPorts[0].sessionAffinity
+					}
 				}
 			}
 		}
@@ -388,10 +867,54 @@ template: {
 			protocol: *"TCP" | "UDP" | "SCTP"
 			// +usage=Specify if the port should be exposed
 			expose: *false | bool
+			// +usage=exposed node port. Only Valid when the Service of the port is NodePort
+			nodePort?: int
+			// +usage=Type of the Service exposing the port, defaults to exposeType. Ports of another type than exposeType are exposed by a Service named after the component and the type, like `web-nodeport`
+			serviceType?: "ClusterIP" | "NodePort" | "LoadBalancer"
+			// +usage=Application protocol of the port, like `http`, `https`, `kubernetes.io/h2c` or `grpc`. Ports with another protocol than HTTP cannot be routed
+			appProtocol?: string
+			// +usage=Route external traffic to node-local endpoints only to preserve the client source IP. Only valid for NodePort and LoadBalancer Services. Ports of the same Service must set it alike
+			externalTrafficPolicy?: "Cluster" | "Local"
+			// +usage=Send the requests of a client to the same pod. Ports of the same Service must set it alike
+			sessionAffinity?: "None" | "ClientIP"
+			// +usage=Assign the Service IPv4 and IPv6 cluster IPs on dual-stack clusters. Ports of the same Service must set it alike
+			ipFamilyPolicy?: "SingleStack" | "PreferDualStack" | "RequireDualStack"
 		}]
 		// +ignore
 		// +usage=Specify what kind of Service you want. options: "ClusterIP", "NodePort", "LoadBalancer", "ExternalName"
 		exposeType: *"ClusterIP" | "NodePort" | "LoadBalancer" | "ExternalName"
+		// +usage=Route external HTTP traffic to an exposed TCP port with an HTTP appProtocol
+		route?: {
+			// +usage=Specify the route type, options: "Ingress","HTTPRoute", default to Ingress
+			type: *"Ingress" | "HTTPRoute"
+			if type == "Ingress" {
+				// +usage=Class of the Ingress, the cluster default if empty
+				className?: string
+				// +usage=Secret holding the TLS certificate of the hosts
+				tlsSecretName?: string
+			}
+			if type == "HTTPRoute" {
+				// +usage=Gateway the HTTPRoute attaches to, it terminates TLS
+				gateway: {
+					// +usage=Name of the Gateway
+					name: string
+					// +usage=Namespace of the Gateway, defaults to the namespace of the component
+					namespace?: string
+					// +usage=Listener of the Gateway to attach to
+					sectionName?: string
+				}
+			}
+			// +usage=Exposed HTTP port to route to, defaults to the first one
+			port?: int
+			// +usage=Host names to route, all of them if empty
+			hosts?: [...string]
+			// +usage=Path to route
+			path: *"/" | string
+			// +usage=Match the path as a prefix or exactly
+			pathType: *"Prefix" | "Exact"
+			// +usage=Annotations of the Ingress or HTTPRoute
+			annotations?: [string]: string
+		}
 		// +ignore
 		// +usage=If addRevisionLabel is true, the revision label will be added to the underlying pods
 		addRevisionLabel: *false | bool
@@ -512,6 +1035,174 @@ template: {
 			ip: string
 			hostnames: [...string]
 		}]
+		// +usage=Additional containers to run in the pod next to the main container
+		containers?: [...{
+			// +usage=Name of the container, unique within the pod
+			name: string
+			// +usage=Image of the container
+			image: string
+			// +usage=Specify image pull policy for the container
+			imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
+			// +usage=Commands to run in the container
+			cmd?: [...string]
+			// +usage=Arguments to the entrypoint
+			args?: [...string]
+			// +usage=Ports the container listens on
+			ports?: [...{
+				// +usage=Number of port to expose on the pod's IP address
+				containerPort: int
+				// +usage=Name of the port
+				name?: string
+				// +usage=Protocol for port. Must be UDP, TCP, or SCTP
+				protocol: *"TCP" | "UDP" | "SCTP"
+			}]
+			// +usage=Define arguments by using environment variables
+			env?: [...{
+				// +usage=Environment variable name
+				name: string
+				// +usage=The value of the environment variable
+				value?: string
+				// +usage=Specifies a source the value of this var should come from
+				valueFrom?: {
+					// +usage=Selects a key of a secret in the pod's namespace
+					secretKeyRef?: {
+						// +usage=The name of the secret in the pod's namespace to select from
+						name: string
+						// +usage=The key of the secret to select from. Must be a valid secret key
+						key: string
+					}
+					// +usage=Selects a key of a config map in the pod's namespace
+					configMapKeyRef?: {
+						// +usage=The name of the config map in the pod's namespace to select from
+						name: string
+						// +usage=The key of the config map to select from. Must be a valid secret key
+						key: string
+					}
+				}
+			}]
+			// +usage=Number of CPU units for the container, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+			cpu?: string
+			// +usage=Specifies the attributes of the memory resource required for the container.
+			memory?: string
+			// +usage=Instructions for assessing whether the container has started. Other probes wait until it succeeds.
+			startupProbe?: #HealthProbe
+			// +usage=Instructions for assessing whether the container is alive.
+			livenessProbe?: #HealthProbe
+			// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
+			readinessProbe?: #HealthProbe
+			// +usage=Mount pod volumes declared in volumeMounts into the container
+			volumeMounts?: [...{
+				// +usage=Name of the pod volume
+				name: string
+				// +usage=Path to mount the volume at
+				mountPath: string
+				// +usage=Mount only this path of the volume
+				subPath?: string
+				// +usage=Mount the volume read-only
+				readOnly?: bool
+			}]
+		}]
+		// +usage=Sidecar containers that start before and stop after the main container. They run as restartable init containers on Kubernetes 1.29 or later, and next to the main container otherwise
+		sidecars?: [...{
+			// +usage=Name of the container, unique within the pod
+			name: string
+			// +usage=Image of the container
+			image: string
+			// +usage=Specify image pull policy for the container
+			imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
+			// +usage=Commands to run in the container
+			cmd?: [...string]
+			// +usage=Arguments to the entrypoint
+			args?: [...string]
+			// +usage=Ports the container listens on
+			ports?: [...{
+				// +usage=Number of port to expose on the pod's IP address
+				containerPort: int
+				// +usage=Name of the port
+				name?: string
+				// +usage=Protocol for port. Must be UDP, TCP, or SCTP
+				protocol: *"TCP" | "UDP" | "SCTP"
+			}]
+			// +usage=Define arguments by using environment variables
+			env?: [...{
+				// +usage=Environment variable name
+				name: string
+				// +usage=The value of the environment variable
+				value?: string
+				// +usage=Specifies a source the value of this var should come from
+				valueFrom?: {
+					// +usage=Selects a key of a secret in the pod's namespace
+					secretKeyRef?: {
+						// +usage=The name of the secret in the pod's namespace to select from
+						name: string
+						// +usage=The key of the secret to select from. Must be a valid secret key
+						key: string
+					}
+					// +usage=Selects a key of a config map in the pod's namespace
+					configMapKeyRef?: {
+						// +usage=The name of the config map in the pod's namespace to select from
+						name: string
+						// +usage=The key of the config map to select from. Must be a valid secret key
+						key: string
+					}
+				}
+			}]
+			// +usage=Number of CPU units for the container, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+			cpu?: string
+			// +usage=Specifies the attributes of the memory resource required for the container.
+			memory?: string
+			// +usage=Instructions for assessing whether the container has started. Other probes wait until it succeeds.
+			startupProbe?: #HealthProbe
+			// +usage=Instructions for assessing whether the container is alive.
+			livenessProbe?: #HealthProbe
+			// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
+			readinessProbe?: #HealthProbe
+			// +usage=Mount pod volumes declared in volumeMounts into the container
+			volumeMounts?: [...{
+				// +usage=Name of the pod volume
+				name: string
+				// +usage=Path to mount the volume at
+				mountPath: string
+				// +usage=Mount only this path of the volume
+				subPath?: string
+				// +usage=Mount the volume read-only
+				readOnly?: bool
+			}]
+		}]
+		if parameter["route"] != _|_ && !(parameter.route.port != _|_) {
+			_validateRoute: {
+				"route needs an exposed TCP port with an HTTP appProtocol": true
+				if len(ingressPorts) == 0 && len(httpRoutePorts) == 0 {
+					"route needs an exposed TCP port with an HTTP appProtocol": false
+				}
+			}
+		}
+		if parameter.route.port != _|_ {
+			_validateRoutePort: {
+				"route.port must be an exposed TCP port with an HTTP appProtocol": true
+				if len(ingressPorts) == 0 && len(httpRoutePorts) == 0 {
+					"route.port must be an exposed TCP port with an HTTP appProtocol": false
+				}
+			}
+		}
+		_validateSessionAffinity: {
+			"ports of the same Service must set the same sessionAffinity": true
+			if len([for a in exposedPorts for b in exposedPorts if a.serviceType == b.serviceType if (*a.sessionAffinity | "") != (*b.sessionAffinity | "") {a}]) > 0 {
+				"ports of the same Service must set the same sessionAffinity": false
+			}
+		}
+		_validateIpFamilyPolicy: {
+			"ports of the same Service must set the same ipFamilyPolicy": true
+			if len([for a in exposedPorts for b in exposedPorts if a.serviceType == b.serviceType if (*a.ipFamilyPolicy | "") != (*b.ipFamilyPolicy | "") {a}]) > 0 {
+				"ports of the same Service must set the same ipFamilyPolicy": false
+			}
+		}
+		_validateExternalTrafficPolicy: {
+			"ports of the same Service must set the same externalTrafficPolicy": true
+			if len([for a in exposedPorts for b in exposedPorts if a.serviceType == b.serviceType if (*a.externalTrafficPolicy | "") != (*b.externalTrafficPolicy | "") {a}]) > 0 {
+				"ports of the same Service must set the same externalTrafficPolicy": false
+			}
+		}
 	}
 	#HealthProbe: {
 		// +usage=Instructions for assessing container health by executing a command. Either this attribute or the httpGet attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the httpGet attribute and the tcpSocket attribute.
@@ -0,0 +1,220 @@
+"helm-release": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Installs a Helm chart through a FluxCD HelmRelease."
+	attributes: {
+		workload: {
+			definition: {
+				apiVersion: "helm.toolkit.fluxcd.io/v2"
+				kind:       "HelmRelease"
+			}
+			type: "helmreleases.helm.toolkit.fluxcd.io"
+		}
+		status: {
+			customStatus: #"""
+				_conditions: *[] | [...]
+				if context.output.status != _|_ if context.output.status.conditions != _|_ {
+					_conditions: context.output.status.conditions
+				}
+				_ready: [ for c in _conditions if c.type == "Ready" { c } ]
+				_generation: *0 | int
+				if context.output.metadata != _|_ if context.output.metadata.generation != _|_ {
+					_generation: context.output.metadata.generation
+				}
+				_observedGenerations: [
+					if context.output.status != _|_ if context.output.status.observedGeneration != _|_ {
+						context.output.status.observedGeneration
+					},
+					if len(_ready) > 0 if _ready[0].observedGeneration != _|_ {
+						_ready[0].observedGeneration
+					},
+				]
+				_stale: len([ for g in _observedGenerations if g < _generation { g } ]) > 0
+				_readyMessage: *"waiting for the Ready condition" | string
+				if _stale {
+					_readyMessage: "waiting for generation \(_generation) to be observed"
+				}
+				if !_stale if len(_ready) > 0 if _ready[0].message != _|_ {
+					_readyMessage: _ready[0].message
+				}
+				if !_stale if len(_ready) > 0 if _ready[0].message == _|_ {
+					_readyMessage: "Ready: \(_ready[0].status)"
+				}
+				_detail: *"" | string
+				if context.output.status.lastAttemptedRevision != _|_ {
+					_detail: "\(context.output.status.lastAttemptedRevision)"
+				}
+				message: *_readyMessage | string
+				if _detail != "" {
+					message: "\(_readyMessage), revision: \(_detail)"
+				}
+				"""#
+			healthPolicy: #"""
+				_conditions: *[] | [...]
+				if context.output.status != _|_ if context.output.status.conditions != _|_ {
+					_conditions: context.output.status.conditions
+				}
+				_ready: [ for c in _conditions if c.type == "Ready" { c } ]
+				_generation: *0 | int
+				if context.output.metadata != _|_ if context.output.metadata.generation != _|_ {
+					_generation: context.output.metadata.generation
+				}
+				_observedGenerations: [
+					if context.output.status != _|_ if context.output.status.observedGeneration != _|_ {
+						context.output.status.observedGeneration
+					},
+					if len(_ready) > 0 if _ready[0].observedGeneration != _|_ {
+						_ready[0].observedGeneration
+					},
+				]
+				_stale: len([ for g in _observedGenerations if g < _generation { g } ]) > 0
+				_readyStatus: *"Unknown" | string
+				if len(_ready) > 0 {
+					_readyStatus: _ready[0].status
+				}
+				isHealth: _readyStatus == "True" && !_stale
+				"""#
+		}
+	}
+}
+template: {
+	output: {
+		apiVersion: "helm.toolkit.fluxcd.io/v2"
+		kind:       "HelmRelease"
+		metadata: {
+			name: context.name
+		}
+		spec: {
+			interval: parameter.interval
+			if (parameter.repoType == "helm") && (parameter["version"] != _|_) {
+				chart: {
+					spec: {
+						version: parameter.version
+					}
+				}
+			}
+			if parameter.repoType == "helm" {
+				chart: {
+					spec: {
+						chart: parameter.chart
+						sourceRef: {
+							kind: "HelmRepository"
+							name: context.name
+						}
+						interval: parameter.interval
+					}
+				}
+			}
+			if parameter.repoType == "oci" {
+				chartRef: {
+					kind: "OCIRepository"
+					name: context.name
+				}
+			}
+			if parameter["install"] != _|_ {
+				install: parameter.install
+			}
+			if parameter["targetNamespace"] != _|_ {
+				targetNamespace: parameter.targetNamespace
+			}
+			if parameter["upgrade"] != _|_ {
+				upgrade: parameter.upgrade
+			}
+			if parameter["values"] != _|_ {
+				values: parameter.values
+			}
+			if parameter["valuesFrom"] != _|_ {
+				valuesFrom: parameter.valuesFrom
+			}
+		}
+	}
+	outputs: {
+		if parameter.repoType == "helm" {
+			helmRepository: {
+				apiVersion: "source.toolkit.fluxcd.io/v1"
+				kind:       "HelmRepository"
+				metadata: {
+					name: context.name
+				}
+				spec: {
+					url: parameter.url
+					interval: parameter.interval
+				}
+			}
+		}
+		if parameter.repoType == "oci" {
+			ociRepository: {
+				apiVersion: "source.toolkit.fluxcd.io/v1beta2"
+				kind:       "OCIRepository"
+				metadata: {
+					name: context.name
+				}
+				spec: {
+					url: "\(parameter.url)/\(parameter.chart)"
+					interval: parameter.interval
+					layerSelector: {
+						mediaType: "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+						operation: "copy"
+					}
+					if parameter["version"] != _|_ {
+						ref: {
+							semver: parameter.version
+						}
+					}
+				}
+			}
+		}
+	}
+	parameter: {
+		// +usage=Type of the chart repository, `helm` for an HTTP(S) Helm repository or `oci` for an OCI registry
+		repoType: *"helm" | "oci"
+		// +usage=URL of the chart repository, like `https://stefanprodan.github.io/podinfo` or `oci://ghcr.io/stefanprodan/charts`
+		url: string
+		// +usage=Name of the chart in the repository
+		chart: string
+		// +usage=Semver version constraint of the chart, like `6.x` or `>=6.0.0 <7.0.0`. The latest version is used if empty
+		version?: string
+		// +usage=Inline Helm values for the release
+		values?: {...}
+		// +usage=References to ConfigMaps or Secrets holding Helm values, merged in order before the inline values
+		valuesFrom?: [...{
+			// +usage=Kind of the values source
+			kind: *"ConfigMap" | "Secret"
+			// +usage=Name of the ConfigMap or Secret in the application namespace
+			name: string
+			// +usage=Data key holding the values, defaults to `values.yaml`
+			valuesKey?: string
+			// +usage=YAML dot notation path the value is merged at, instead of merging the whole document
+			targetPath?: string
+			// +usage=Do not fail the release when the source is missing
+			optional?: bool
+		}]
+		// +usage=Helm install configuration
+		install?: {
+			// +usage=Create the target namespace if it does not exist
+			createNamespace?: bool
+			// +usage=Actions taken when the install fails
+			remediation?: {
+				// +usage=Number of retries after a failed install, a negative value retries forever
+				retries?: int
+			}
+		}
+		// +usage=Helm upgrade configuration
+		upgrade?: {
+			// +usage=Actions taken when the upgrade fails
+			remediation?: {
+				// +usage=Number of retries after a failed upgrade, a negative value retries forever
+				retries?: int
+				// +usage=Remediate the last failure when no retries remain
+				remediateLastFailure?: bool
+				// +usage=Remediation strategy of a failed upgrade
+				strategy?: "rollback" | "uninstall"
+			}
+		}
+		// +usage=Namespace the chart is installed into, defaults to the application namespace
+		targetNamespace?: string
+		// +usage=Interval at which the source and the release are reconciled
+		interval: *"5m" | string
+	}
+}
@@ -0,0 +1,405 @@
+"keda-scaled-job": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Describes jobs started by KEDA as events arrive, such as queue messages or metrics."
+	attributes: {
+		workload: {
+			definition: {
+				apiVersion: "keda.sh/v1alpha1"
+				kind:       "ScaledJob"
+			}
+			type: "scaledjobs.keda.sh"
+		}
+		status: {
+			customStatus: #"""
+				_conditions: *[] | [...]
+				if context.output.status != _|_ if context.output.status.conditions != _|_ {
+					_conditions: context.output.status.conditions
+				}
+				_ready: [ for c in _conditions if c.type == "Ready" { c } ]
+				_generation: *0 | int
+				if context.output.metadata != _|_ if context.output.metadata.generation != _|_ {
+					_generation: context.output.metadata.generation
+				}
+				_observedGenerations: [
+					if context.output.status != _|_ if context.output.status.observedGeneration != _|_ {
+						context.output.status.observedGeneration
+					},
+					if len(_ready) > 0 if _ready[0].observedGeneration != _|_ {
+						_ready[0].observedGeneration
+					},
+				]
+				_stale: len([ for g in _observedGenerations if g < _generation { g } ]) > 0
+				_readyMessage: *"waiting for the Ready condition" | string
+				if len(_ready) > 0 if _ready[0].message != _|_ {
+					_readyMessage: _ready[0].message
+				}
+				_active: [ for c in _conditions if c.type == "Active" && c.status == "True" { c } ]
+				_lastActive: *"" | string
+				if context.output.status != _|_ if context.output.status.lastActiveTime != _|_ {
+					_lastActive: ", last active: \(context.output.status.lastActiveTime)"
+				}
+				message: *_readyMessage | string
+				if len(_ready) > 0 if _ready[0].status == "True" {
+					if len(_active) > 0 {
+						message: "Ready, jobs active"
+					}
+					if len(_active) == 0 {
+						message: "Ready, no active jobs\(_lastActive)"
+					}
+				}
+				"""#
+			healthPolicy: #"""
+				_conditions: *[] | [...]
+				if context.output.status != _|_ if context.output.status.conditions != _|_ {
+					_conditions: context.output.status.conditions
+				}
+				_ready: [ for c in _conditions if c.type == "Ready" { c } ]
+				_generation: *0 | int
+				if context.output.metadata != _|_ if context.output.metadata.generation != _|_ {
+					_generation: context.output.metadata.generation
+				}
+				_observedGenerations: [
+					if context.output.status != _|_ if context.output.status.observedGeneration != _|_ {
+						context.output.status.observedGeneration
+					},
+					if len(_ready) > 0 if _ready[0].observedGeneration != _|_ {
+						_ready[0].observedGeneration
+					},
+				]
+				_stale: len([ for g in _observedGenerations if g < _generation { g } ]) > 0
+				_readyStatus: *"Unknown" | string
+				if len(_ready) > 0 {
+					_readyStatus: _ready[0].status
+				}
+				isHealth: _readyStatus == "True" && !_stale
+				"""#
+		}
+	}
+}
+template: {
+	let _triggers = [ for t in parameter.triggers {
+		type: t.type
+		if t.name != _|_ {
+			name: t.name
+		}
+		metadata: {
+			for k, v in t if k != "type" && k != "name" && k != "authenticationRef" {
+				(k): "\(v)"
+			}
+		}
+		if t.authenticationRef != _|_ {
+			authenticationRef: t.authenticationRef
+		}
+	}]
+	output: {
+		apiVersion: "keda.sh/v1alpha1"
+		kind:       "ScaledJob"
+		metadata: {
+			name: context.name
+		}
+		spec: {
+			jobTargetRef: {
+				template: {
+					metadata: {
+						labels: {
+							if parameter["labels"] != _|_ {
+								parameter.labels
+							}
+							"app.oam.dev/name": context.appName
+							"app.oam.dev/component": context.name
+						}
+						if parameter["annotations"] != _|_ {
+							annotations: parameter.annotations
+						}
+					}
+					spec: {
+						restartPolicy: parameter.restart
+						containers: [{
+							name: context.name
+							image: parameter.image
+							if parameter["cpu"] != _|_ {
+								resources: {
+									limits: {
+										cpu: parameter.cpu
+									}
+									requests: {
+										cpu: parameter.cpu
+									}
+								}
+							}
+							if parameter["memory"] != _|_ {
+								resources: {
+									limits: {
+										memory: parameter.memory
+									}
+									requests: {
+										memory: parameter.memory
+									}
+								}
+							}
+							if parameter["cmd"] != _|_ {
+								command: parameter.cmd
+							}
+							if parameter["env"] != _|_ {
+								env: parameter.env
+							}
+							if parameter["imagePullPolicy"] != _|_ {
+								imagePullPolicy: parameter.imagePullPolicy
+							}
+							if parameter["volumes"] != _|_ {
+								volumeMounts: [for v in parameter.volumes {
+				{
+					mountPath: v.mountPath
+					name: v.name
+				}
+			}]
+							}
+						}]
+						if parameter["imagePullSecrets"] != _|_ {
+							imagePullSecrets: [for v in parameter.imagePullSecrets { name: v }]
+						}
+						if parameter["volumes"] != _|_ {
+							volumes: [for v in parameter.volumes {
+				{
+					name: v.name
+					if v.type == "pvc" {
+						persistentVolumeClaim: {
+				claimName: v.claimName
+			}
+					}
+					if v.type == "configMap" {
+						configMap: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
+				}
+				name: v.cmName
+			}
+					}
+					if v.type == "secret" {
+						secret: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
+				}
+				secretName: v.secretName
+			}
+					}
+					if v.type == "emptyDir" {
+						emptyDir: {
+				medium: v.medium
+			}
+					}
+				}
+			}]
+						}
+					}
+				}
+				if parameter["activeDeadlineSeconds"] != _|_ {
+					activeDeadlineSeconds: parameter.activeDeadlineSeconds
+				}
+				if parameter["backoffLimit"] != _|_ {
+					backoffLimit: parameter.backoffLimit
+				}
+				if parameter["completions"] != _|_ {
+					completions: parameter.completions
+				}
+				if parameter["parallelism"] != _|_ {
+					parallelism: parameter.parallelism
+				}
+			}
+			pollingInterval: parameter.pollingInterval
+			maxReplicaCount: parameter.maxReplicaCount
+			successfulJobsHistoryLimit: parameter.successfulJobsHistoryLimit
+			failedJobsHistoryLimit: parameter.failedJobsHistoryLimit
+			scalingStrategy: parameter.scalingStrategy
+			triggers: _triggers
+			if parameter["minReplicaCount"] != _|_ {
+				minReplicaCount: parameter.minReplicaCount
+			}
+		}
+	}
+	parameter: {
+		// +usage=Specify the labels in the workload
+		labels?: [string]: string
+		// +usage=Specify the annotations in the workload
+		annotations?: [string]: string
+		// +usage=Which image would you like to use for your service
+		// +short=i
+		image: string
+		// +usage=Specify image pull policy for your service
+		imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
+		// +usage=Specify image pull secrets for your service
+		imagePullSecrets?: [...string]
+		// +usage=Define the job restart policy, the value can only be Never or OnFailure. By default, it's Never.
+		restart: *"Never" | string
+		// +usage=Commands to run in the container
+		cmd?: [...string]
+		// +usage=Define arguments by using environment variables
+		env?: [...{
+			// +usage=Environment variable name
+			name: string
+			// +usage=The value of the environment variable
+			value?: string
+			// +usage=Specifies a source the value of this var should come from
+			valueFrom?: {
+				// +usage=Selects a key of a secret in the pod's namespace
+				secretKeyRef?: {
+					// +usage=The name of the secret in the pod's namespace to select from
+					name: string
+					// +usage=The key of the secret to select from. Must be a valid secret key
+					key: string
+				}
+				// +usage=Selects a key of a config map in the pod's namespace
+				configMapKeyRef?: {
+					// +usage=The name of the config map in the pod's namespace to select from
+					name: string
+					// +usage=The key of the config map to select from. Must be a valid secret key
+					key: string
+				}
+			}
+		}]
+		// +usage=Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+		cpu?: string
+		// +usage=Specifies the attributes of the memory resource required for the container.
+		memory?: string
+		// +usage=Declare volumes and volumeMounts
+		volumes?: [...{
+			name: string
+			mountPath: string
+			// +usage=Specify volume type, options: "pvc","configMap","secret","emptyDir", default to emptyDir
+			type: *"emptyDir" | "pvc" | "configMap" | "secret"
+			if type == "pvc" {
+				claimName: string
+			}
+			if type == "configMap" {
+				defaultMode: *420 | int
+				cmName: string
+				items?: [...{
+					key: string
+					path: string
+					mode: *511 | int
+				}]
+			}
+			if type == "secret" {
+				defaultMode: *420 | int
+				secretName: string
+				items?: [...{
+					key: string
+					path: string
+					mode: *511 | int
+				}]
+			}
+			if type == "emptyDir" {
+				medium: *"" | "Memory"
+			}
+		}]
+		// +usage=Number of pods of each job running at the same time
+		parallelism?: int
+		// +usage=Number of successful pods each job needs to complete
+		completions?: int
+		// +usage=Number of retries of each job before it is marked as failed
+		backoffLimit?: int
+		// +usage=Fail a job once it has been running for this many seconds
+		activeDeadlineSeconds?: int
+		// +usage=Event sources the jobs are scaled on. The scaler settings are passed to KEDA as trigger metadata
+		triggers: [...{
+			// +usage=Name of the trigger, shown in the KEDA metrics
+			name?: string
+			// +usage=TriggerAuthentication holding the credentials of the event source
+			authenticationRef?: {
+				// +usage=Name of the TriggerAuthentication
+				name: string
+				// +usage=Use ClusterTriggerAuthentication for credentials shared across namespaces
+				kind: *"TriggerAuthentication" | "ClusterTriggerAuthentication"
+			}
+			// +usage=Specify the scaler, options: "kafka","rabbitmq","redis" (list length),"prometheus","cron"
+			type: "kafka" | "rabbitmq" | "redis" | "prometheus" | "cron"
+			if type == "kafka" {
+				// +usage=Comma separated list of Kafka brokers
+				bootstrapServers: string
+				// +usage=Consumer group whose lag is measured
+				consumerGroup: string
+				// +usage=Topic to measure, all topics of the group when unset
+				topic?: string
+				// +usage=Lag per job
+				lagThreshold: *10 | int
+				// +usage=Offset of a consumer group without a committed offset
+				offsetResetPolicy?: "latest" | "earliest"
+			}
+			if type == "rabbitmq" {
+				// +usage=Queue to measure
+				queueName: string
+				// +usage=Connection string of the broker, usually read from the authenticationRef instead
+				host?: string
+				// +usage=Scale on the queue length or the publish rate
+				mode: *"QueueLength" | "MessageRate"
+				// +usage=Messages, or messages per second, per job
+				value: *"5" | string
+				// +usage=Protocol used to read the queue
+				protocol: *"auto" | "amqp" | "http"
+			}
+			if type == "redis" {
+				// +usage=Address of the Redis server, like `redis:6379`
+				address: string
+				// +usage=List to measure
+				listName: string
+				// +usage=List items per job
+				listLength: *5 | int
+				// +usage=Index of the database holding the list
+				databaseIndex?: int
+				// +usage=Connect over TLS
+				enableTLS?: bool
+			}
+			if type == "prometheus" {
+				// +usage=Address of the Prometheus server
+				serverAddress: string
+				// +usage=PromQL query returning a single value
+				query: string
+				// +usage=Query value per job, like `100` or `2.5`
+				threshold: string
+				// +usage=Tenant of a multi-tenant Prometheus, like Thanos or Cortex
+				namespace?: string
+			}
+			if type == "cron" {
+				// +usage=IANA time zone of start and end
+				timezone: *"Etc/UTC" | string
+				// +usage=Cron expression starting the window, like `0 8 * * *`
+				start: string
+				// +usage=Cron expression ending the window, like `0 18 * * *`
+				end: string
+				// +usage=Jobs running during the window
+				desiredReplicas: *1 | int
+			}
+		}]
+		// +usage=Seconds between checks of the triggers
+		pollingInterval: *30 | int
+		// +usage=Jobs kept running even when the triggers are idle
+		minReplicaCount?: int
+		// +usage=Maximum number of jobs running at the same time
+		maxReplicaCount: *100 | int
+		// +usage=Number of successful jobs to keep
+		successfulJobsHistoryLimit: *100 | int
+		// +usage=Number of failed jobs to keep
+		failedJobsHistoryLimit: *100 | int
+		// +usage=How the number of new jobs is computed from the pending events
+		scalingStrategy: {
+			// +usage=Use accurate when consumed messages leave the queue, eager to fill up to maxReplicaCount, custom to tune the deduction
+			strategy: *"default" | "custom" | "accurate" | "eager"
+			// +usage=Events subtracted from the queue length before scaling. Requires the custom strategy
+			customScalingQueueLengthDeduction?: int
+			// +usage=Fraction of the running jobs subtracted before scaling, like `0.5`. Requires the custom strategy
+			customScalingRunningJobPercentage?: string
+			// +usage=How the jobs wanted by several triggers are combined, max by default
+			multipleScalersCalculation?: "max" | "min" | "avg" | "sum"
+		}
+		_validateScalingStrategy: {
+			"customScalingQueueLengthDeduction and customScalingRunningJobPercentage require the custom strategy": true
+			if parameter.scalingStrategy.customScalingQueueLengthDeduction != _|_ && parameter.scalingStrategy.strategy != "custom" || parameter.scalingStrategy.customScalingRunningJobPercentage != _|_ && parameter.scalingStrategy.strategy != "custom" {
+				"customScalingQueueLengthDeduction and customScalingRunningJobPercentage require the custom strategy": false
+			}
+		}
+	}
+}
@@ -0,0 +1,411 @@
+"knative-service": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Describes request-driven, autoscaled containerized services served by Knative Serving."
+	attributes: {
+		workload: {
+			definition: {
+				apiVersion: "serving.knative.dev/v1"
+				kind:       "Service"
+			}
+			type: "services.serving.knative.dev"
+		}
+		status: {
+			customStatus: #"""
+				_conditions: *[] | [...]
+				if context.output.status != _|_ if context.output.status.conditions != _|_ {
+					_conditions: context.output.status.conditions
+				}
+				_ready: [ for c in _conditions if c.type == "Ready" { c } ]
+				_generation: *0 | int
+				if context.output.metadata != _|_ if context.output.metadata.generation != _|_ {
+					_generation: context.output.metadata.generation
+				}
+				_observedGenerations: [
+					if context.output.status != _|_ if context.output.status.observedGeneration != _|_ {
+						context.output.status.observedGeneration
+					},
+					if len(_ready) > 0 if _ready[0].observedGeneration != _|_ {
+						_ready[0].observedGeneration
+					},
+				]
+				_stale: len([ for g in _observedGenerations if g < _generation { g } ]) > 0
+				_readyMessage: *"waiting for the Ready condition" | string
+				if _stale {
+					_readyMessage: "waiting for generation \(_generation) to be observed"
+				}
+				if !_stale if len(_ready) > 0 if _ready[0].message != _|_ {
+					_readyMessage: _ready[0].message
+				}
+				if !_stale if len(_ready) > 0 if _ready[0].message == _|_ {
+					_readyMessage: "Ready: \(_ready[0].status)"
+				}
+				_detail: *"" | string
+				if context.output.status.url != _|_ {
+					_detail: "\(context.output.status.url)"
+				}
+				message: *_readyMessage | string
+				if _detail != "" {
+					message: "\(_readyMessage), url: \(_detail)"
+				}
+				"""#
+			healthPolicy: #"""
+				_conditions: *[] | [...]
+				if context.output.status != _|_ if context.output.status.conditions != _|_ {
+					_conditions: context.output.status.conditions
+				}
+				_ready: [ for c in _conditions if c.type == "Ready" { c } ]
+				_generation: *0 | int
+				if context.output.metadata != _|_ if context.output.metadata.generation != _|_ {
+					_generation: context.output.metadata.generation
+				}
+				_observedGenerations: [
+					if context.output.status != _|_ if context.output.status.observedGeneration != _|_ {
+						context.output.status.observedGeneration
+					},
+					if len(_ready) > 0 if _ready[0].observedGeneration != _|_ {
+						_ready[0].observedGeneration
+					},
+				]
+				_stale: len([ for g in _observedGenerations if g < _generation { g } ]) > 0
+				_readyStatus: *"Unknown" | string
+				if len(_ready) > 0 {
+					_readyStatus: _ready[0].status
+				}
+				isHealth: _readyStatus == "True" && !_stale
+				"""#
+		}
+	}
+}
+template: {
+	mountsArray: [
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.configMap != _|_ for v in parameter.volumeMounts.configMap {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.secret != _|_ for v in parameter.volumeMounts.secret {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.emptyDir != _|_ for v in parameter.volumeMounts.emptyDir {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+		}
+		}
+	]
+	volumesList: [
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.configMap != _|_ for v in parameter.volumeMounts.configMap {
+		{
+			configMap: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
+				}
+				name: v.cmName
+			}
+			name: v.name
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.secret != _|_ for v in parameter.volumeMounts.secret {
+		{
+			name: v.name
+			secret: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
+				}
+				secretName: v.secretName
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.emptyDir != _|_ for v in parameter.volumeMounts.emptyDir {
+		{
+			emptyDir: {
+				medium: v.medium
+			}
+			name: v.name
+		}
+		}
+	]
+	deDupVolumesArray: [
+		for val in [
+			for i, vi in volumesList {
+				for j, vj in volumesList if j < i && vi.name == vj.name {
+					_ignore: true
+				}
+				vi
+			},
+		] if val._ignore == _|_ {
+			val
+		},
+	]
+	output: {
+		apiVersion: "serving.knative.dev/v1"
+		kind:       "Service"
+		metadata: {
+			name: context.name
+		}
+		spec: {
+			template: {
+				metadata: {
+					labels: {
+						if parameter["labels"] != _|_ {
+							parameter.labels
+						}
+						"app.oam.dev/name": context.appName
+						"app.oam.dev/component": context.name
+					}
+					annotations: {
+						if parameter["annotations"] != _|_ {
+							parameter.annotations
+						}
+						if parameter["minScale"] != _|_ {
+							"autoscaling.knative.dev/min-scale": "\(parameter.minScale)"
+						}
+						if parameter["minScale"] == _|_ && !parameter.scaleToZero {
+							"autoscaling.knative.dev/min-scale": "1"
+						}
+						if parameter["concurrencyTarget"] != _|_ {
+							"autoscaling.knative.dev/target": "\(parameter.concurrencyTarget)"
+						}
+						if parameter["maxScale"] != _|_ {
+							"autoscaling.knative.dev/max-scale": "\(parameter.maxScale)"
+						}
+					}
+					if parameter["revisionSuffix"] != _|_ {
+						name: "\(context.name)-\(parameter.revisionSuffix)"
+					}
+				}
+				spec: {
+					containers: [{
+						name: context.name
+						image: parameter.image
+						if parameter["cpu"] != _|_ {
+							resources: {
+								limits: {
+									cpu: parameter.cpu
+								}
+								requests: {
+									cpu: parameter.cpu
+								}
+							}
+						}
+						if parameter["memory"] != _|_ {
+							resources: {
+								limits: {
+									memory: parameter.memory
+								}
+								requests: {
+									memory: parameter.memory
+								}
+							}
+						}
+						if parameter["args"] != _|_ {
+							args: parameter.args
+						}
+						if parameter["cmd"] != _|_ {
+							command: parameter.cmd
+						}
+						if parameter["env"] != _|_ {
+							env: parameter.env
+						}
+						if parameter["imagePullPolicy"] != _|_ {
+							imagePullPolicy: parameter.imagePullPolicy
+						}
+						if parameter["livenessProbe"] != _|_ {
+							livenessProbe: parameter.livenessProbe
+						}
+						if parameter["port"] != _|_ {
+							ports: [{
+							containerPort: parameter.port
+						}]
+						}
+						if parameter["readinessProbe"] != _|_ {
+							readinessProbe: parameter.readinessProbe
+						}
+						if parameter["volumeMounts"] != _|_ {
+							volumeMounts: mountsArray
+						}
+					}]
+					if parameter["containerConcurrency"] != _|_ {
+						containerConcurrency: parameter.containerConcurrency
+					}
+					if parameter["imagePullSecrets"] != _|_ {
+						imagePullSecrets: [for v in parameter.imagePullSecrets { name: v }]
+					}
+					if parameter["timeoutSeconds"] != _|_ {
+						timeoutSeconds: parameter.timeoutSeconds
+					}
+					if parameter["volumeMounts"] != _|_ {
+						volumes: deDupVolumesArray
+					}
+				}
+			}
+			if parameter["traffic"] != _|_ {
+				traffic: parameter.traffic
+			}
+		}
+	}
+	parameter: {
+		// +usage=Specify the labels of the revisions
+		labels?: [string]: string
+		// +usage=Specify the annotations of the revisions
+		annotations?: [string]: string
+		// +usage=Which image would you like to use for your service
+		// +short=i
+		image: string
+		// +usage=Specify image pull policy for your service
+		imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
+		// +usage=Specify image pull secrets for your service
+		imagePullSecrets?: [...string]
+		// +usage=Port the container listens on for requests, Knative defaults to 8080
+		// +short=p
+		port?: int
+		// +usage=Commands to run in the container
+		cmd?: [...string]
+		// +usage=Arguments to the entrypoint
+		args?: [...string]
+		// +usage=Define arguments by using environment variables
+		env?: [...{
+			// +usage=Environment variable name
+			name: string
+			// +usage=The value of the environment variable
+			value?: string
+			// +usage=Specifies a source the value of this var should come from
+			valueFrom?: {
+				// +usage=Selects a key of a secret in the pod's namespace
+				secretKeyRef?: {
+					// +usage=The name of the secret in the pod's namespace to select from
+					name: string
+					// +usage=The key of the secret to select from. Must be a valid secret key
+					key: string
+				}
+				// +usage=Selects a key of a config map in the pod's namespace
+				configMapKeyRef?: {
+					// +usage=The name of the config map in the pod's namespace to select from
+					name: string
+					// +usage=The key of the config map to select from. Must be a valid secret key
+					key: string
+				}
+			}
+		}]
+		// +usage=Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+		cpu?: string
+		// +usage=Specifies the attributes of the memory resource required for the container.
+		memory?: string
+		volumeMounts?: {
+			// +usage=Mount ConfigMap type volume
+			configMap?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				defaultMode: *420 | int
+				cmName: string
+				items?: [...{
+					key: string
+					path: string
+					mode: *511 | int
+				}]
+			}]
+			// +usage=Mount Secret type volume
+			secret?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				defaultMode: *420 | int
+				secretName: string
+				items?: [...{
+					key: string
+					path: string
+					mode: *511 | int
+				}]
+			}]
+			// +usage=Mount EmptyDir type volume
+			emptyDir?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				medium: *"" | "Memory"
+			}]
+		}
+		// +usage=Instructions for assessing whether the container is alive.
+		livenessProbe?: #HealthProbe
+		// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
+		readinessProbe?: #HealthProbe
+		// +usage=Minimum number of replicas of each revision, takes precedence over scaleToZero
+		minScale?: int
+		// +usage=Maximum number of replicas of each revision, 0 means unlimited
+		maxScale?: int
+		// +usage=Number of in-flight requests per replica the autoscaler aims for
+		concurrencyTarget?: int
+		// +usage=Scale revisions to zero replicas when they receive no traffic. If false, at least one replica is kept
+		scaleToZero: *true | bool
+		// +usage=Hard limit of concurrent requests per replica, 0 means unlimited
+		containerConcurrency?: int
+		// +usage=Maximum duration in seconds to respond to a request
+		timeoutSeconds?: int
+		// +usage=Name the revision created by this version `<component>-<revisionSuffix>`, so traffic can refer to it
+		revisionSuffix?: string
+		// +usage=Split of the traffic between revisions. All traffic goes to the latest ready revision if empty
+		traffic?: [...{
+			// +usage=Revision receiving this share of the traffic
+			revisionName?: string
+			// +usage=Send this share of the traffic to the latest ready revision instead of revisionName
+			latestRevision?: bool
+			// +usage=Share of the traffic in percent, the shares must add up to 100
+			percent: int
+			// +usage=Expose the target on its own URL `<tag>-<component>.<domain>`
+			tag?: string
+		}]
+	}
+	#HealthProbe: {
+		// +usage=Instructions for assessing container health by executing a command. Either this attribute or the httpGet attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the httpGet attribute and the tcpSocket attribute.
+		exec?: {
+			// +usage=A command to be executed inside the container to assess its health. Each space delimited token of the command is a separate array element. Commands exiting 0 are considered to be successful probes, whilst all other exit codes are considered failures.
+			command: [...string]
+		}
+		// +usage=Instructions for assessing container health by executing an HTTP GET request. Either this attribute or the exec attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the exec attribute and the tcpSocket attribute.
+		httpGet?: {
+			// +usage=The endpoint, relative to the port, to which the HTTP GET request should be directed.
+			path: string
+			// +usage=The TCP socket within the container to which the HTTP GET request should be directed.
+			port: int
+			host?: string
+			scheme?: *"HTTP" | string
+			httpHeaders?: [...{
+				name: string
+				value: string
+			}]
+		}
+		// +usage=Instructions for assessing container health by probing a TCP socket. Either this attribute or the exec attribute or the httpGet attribute MUST be specified. This attribute is mutually exclusive with both the exec attribute and the httpGet attribute.
+		tcpSocket?: {
+			// +usage=The TCP socket within the container that should be probed to assess container health.
+			port: int
+		}
+		// +usage=Number of seconds after the container is started before the first probe is initiated.
+		initialDelaySeconds: *0 | int
+		// +usage=How often, in seconds, to execute the probe.
+		periodSeconds: *10 | int
+		// +usage=Number of seconds after which the probe times out.
+		timeoutSeconds: *1 | int
+		// +usage=Minimum consecutive successes for the probe to be considered successful after having failed.
+		successThreshold: *1 | int
+		// +usage=Number of consecutive failures required to determine the container is not alive (liveness probe) or not ready (readiness probe).
+		failureThreshold: *3 | int
+	}
+}
@@ -0,0 +1,267 @@
+kustomize: {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Applies a Kustomize overlay through a FluxCD Kustomization."
+	attributes: {
+		workload: {
+			definition: {
+				apiVersion: "kustomize.toolkit.fluxcd.io/v1"
+				kind:       "Kustomization"
+			}
+			type: "kustomizations.kustomize.toolkit.fluxcd.io"
+		}
+		status: {
+			customStatus: #"""
+				_conditions: *[] | [...]
+				if context.output.status != _|_ if context.output.status.conditions != _|_ {
+					_conditions: context.output.status.conditions
+				}
+				_ready: [ for c in _conditions if c.type == "Ready" { c } ]
+				_generation: *0 | int
+				if context.output.metadata != _|_ if context.output.metadata.generation != _|_ {
+					_generation: context.output.metadata.generation
+				}
+				_observedGenerations: [
+					if context.output.status != _|_ if context.output.status.observedGeneration != _|_ {
+						context.output.status.observedGeneration
+					},
+					if len(_ready) > 0 if _ready[0].observedGeneration != _|_ {
+						_ready[0].observedGeneration
+					},
+				]
+				_stale: len([ for g in _observedGenerations if g < _generation { g } ]) > 0
+				_readyMessage: *"waiting for the Ready condition" | string
+				if _stale {
+					_readyMessage: "waiting for generation \(_generation) to be observed"
+				}
+				if !_stale if len(_ready) > 0 if _ready[0].message != _|_ {
+					_readyMessage: _ready[0].message
+				}
+				if !_stale if len(_ready) > 0 if _ready[0].message == _|_ {
+					_readyMessage: "Ready: \(_ready[0].status)"
+				}
+				_detail: *"" | string
+				if context.output.status.lastAppliedRevision != _|_ {
+					_detail: "\(context.output.status.lastAppliedRevision)"
+				}
+				message: *_readyMessage | string
+				if _detail != "" {
+					message: "\(_readyMessage), revision: \(_detail)"
+				}
+				"""#
+			healthPolicy: #"""
+				_conditions: *[] | [...]
+				if context.output.status != _|_ if context.output.status.conditions != _|_ {
+					_conditions: context.output.status.conditions
+				}
+				_ready: [ for c in _conditions if c.type == "Ready" { c } ]
+				_generation: *0 | int
+				if context.output.metadata != _|_ if context.output.metadata.generation != _|_ {
+					_generation: context.output.metadata.generation
+				}
+				_observedGenerations: [
+					if context.output.status != _|_ if context.output.status.observedGeneration != _|_ {
+						context.output.status.observedGeneration
+					},
+					if len(_ready) > 0 if _ready[0].observedGeneration != _|_ {
+						_ready[0].observedGeneration
+					},
+				]
+				_stale: len([ for g in _observedGenerations if g < _generation { g } ]) > 0
+				_readyStatus: *"Unknown" | string
+				if len(_ready) > 0 {
+					_readyStatus: _ready[0].status
+				}
+				isHealth: _readyStatus == "True" && !_stale
+				"""#
+		}
+	}
+}
+template: {
+	output: {
+		apiVersion: "kustomize.toolkit.fluxcd.io/v1"
+		kind:       "Kustomization"
+		metadata: {
+			name: context.name
+		}
+		spec: {
+			interval: parameter.interval
+			path: parameter.path
+			prune: parameter.prune
+			sourceRef: {
+				if parameter.sourceType == "git" {
+					kind: "GitRepository"
+				}
+				if parameter.sourceType == "oci" {
+					kind: "OCIRepository"
+				}
+				name: context.name
+			}
+			if parameter["targetNamespace"] != _|_ {
+				targetNamespace: parameter.targetNamespace
+			}
+			if parameter["targetNamespace"] == _|_ {
+				targetNamespace: context.namespace
+			}
+			if parameter["dependsOn"] != _|_ {
+				dependsOn: parameter.dependsOn
+			}
+			if parameter["images"] != _|_ {
+				images: parameter.images
+			}
+			if parameter["patches"] != _|_ {
+				patches: parameter.patches
+			}
+			if parameter["postBuild"] != _|_ {
+				postBuild: parameter.postBuild
+			}
+		}
+	}
+	outputs: {
+		if parameter.sourceType == "git" {
+			gitRepository: {
+				apiVersion: "source.toolkit.fluxcd.io/v1"
+				kind:       "GitRepository"
+				metadata: {
+					name: context.name
+				}
+				spec: {
+					url: parameter.url
+					interval: parameter.interval
+					ref: {
+						if (parameter["ref"] != _|_) && (parameter.ref.branch != _|_) {
+							branch: parameter.ref.branch
+						}
+						if (parameter["ref"] != _|_) && (parameter.ref.commit != _|_) {
+							commit: parameter.ref.commit
+						}
+						if (parameter["ref"] != _|_) && (parameter.ref.semver != _|_) {
+							semver: parameter.ref.semver
+						}
+						if (parameter["ref"] != _|_) && (parameter.ref.tag != _|_) {
+							tag: parameter.ref.tag
+						}
+					}
+					if parameter["secretRef"] != _|_ {
+						secretRef: {
+							name: parameter.secretRef
+						}
+					}
+				}
+			}
+		}
+		if parameter.sourceType == "oci" {
+			ociRepository: {
+				apiVersion: "source.toolkit.fluxcd.io/v1beta2"
+				kind:       "OCIRepository"
+				metadata: {
+					name: context.name
+				}
+				spec: {
+					url: parameter.url
+					interval: parameter.interval
+					ref: {
+						if (parameter["ref"] != _|_) && (parameter.ref.digest != _|_) {
+							digest: parameter.ref.digest
+						}
+						if (parameter["ref"] != _|_) && (parameter.ref.semver != _|_) {
+							semver: parameter.ref.semver
+						}
+						if (parameter["ref"] != _|_) && (parameter.ref.tag != _|_) {
+							tag: parameter.ref.tag
+						}
+					}
+					if parameter["secretRef"] != _|_ {
+						secretRef: {
+							name: parameter.secretRef
+						}
+					}
+				}
+			}
+		}
+	}
+	parameter: {
+		// +usage=Type of the source holding the overlay, `git` for a Git repository or `oci` for an OCI artifact
+		sourceType: *"git" | "oci"
+		// +usage=URL of the source, like `https://github.com/stefanprodan/podinfo` or `oci://ghcr.io/stefanprodan/manifests/podinfo`
+		url: string
+		// +usage=Revision of the source to check out. The default branch or the `latest` tag is used if empty
+		ref?: {
+			// +usage=Git branch, ignored for OCI sources
+			branch?: string
+			// +usage=Git or OCI tag
+			tag?: string
+			// +usage=Semver range the tag is selected by
+			semver?: string
+			// +usage=Git commit SHA, ignored for OCI sources
+			commit?: string
+			// +usage=OCI artifact digest, ignored for Git sources
+			digest?: string
+		}
+		// +usage=Name of the Secret holding the credentials of the source
+		secretRef?: string
+		// +usage=Path to the directory containing the kustomization.yaml file, relative to the source root
+		path: *"./" | string
+		// +usage=Delete the objects removed from the overlay
+		prune: *true | bool
+		// +usage=Namespace the objects are applied into, defaults to the application namespace
+		targetNamespace?: string
+		// +usage=Strategic merge or JSON6902 patches applied on top of the overlay
+		patches?: [...{
+			// +usage=Inline strategic merge patch, or JSON6902 patch as a YAML list of operations
+			patch: string
+			// +usage=Objects the patch applies to, required for JSON6902 patches
+			target?: {
+				// +usage=API group of the objects
+				group?: string
+				// +usage=API version of the objects
+				version?: string
+				// +usage=Kind of the objects
+				kind?: string
+				// +usage=Name of the objects, a regular expression
+				name?: string
+				// +usage=Namespace of the objects
+				namespace?: string
+				// +usage=Label selector of the objects
+				labelSelector?: string
+				// +usage=Annotation selector of the objects
+				annotationSelector?: string
+			}
+		}]
+		// +usage=Overrides of the container images used in the overlay
+		images?: [...{
+			// +usage=Image name as written in the overlay, without the tag
+			name: string
+			// +usage=Replacement image name
+			newName?: string
+			// +usage=Replacement image tag
+			newTag?: string
+			// +usage=Replacement image digest, takes precedence over newTag
+			digest?: string
+		}]
+		// +usage=Variable substitutions of `${var}` placeholders in the built manifests
+		postBuild?: {
+			// +usage=Inline variables
+			substitute?: [string]: string
+			// +usage=ConfigMaps or Secrets in the application namespace whose data are variables
+			substituteFrom?: [...{
+				// +usage=Kind of the variables source
+				kind: *"ConfigMap" | "Secret"
+				// +usage=Name of the ConfigMap or Secret
+				name: string
+				// +usage=Do not fail the build when the source is missing
+				optional?: bool
+			}]
+		}
+		// +usage=Kustomizations that must be ready before this one is applied
+		dependsOn?: [...{
+			// +usage=Name of the Kustomization
+			name: string
+			// +usage=Namespace of the Kustomization, defaults to the application namespace
+			namespace?: string
+		}]
+		// +usage=Interval at which the source and the overlay are reconciled
+		interval: *"5m" | string
+	}
+}
@@ -0,0 +1,323 @@
+"postgres-cluster": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Describes PostgreSQL clusters managed by the CloudNativePG operator."
+	attributes: {
+		workload: {
+			definition: {
+				apiVersion: "postgresql.cnpg.io/v1"
+				kind:       "Cluster"
+			}
+			type: "clusters.postgresql.cnpg.io"
+		}
+		status: {
+			customStatus: #"""
+				cluster: {
+					phase:          *"Setting up primary" | string
+					readyInstances: *0 | int
+					primary:        *"" | string
+				} & {
+					if context.output.status.phase != _|_ {
+						phase: context.output.status.phase
+					}
+					if context.output.status.readyInstances != _|_ {
+						readyInstances: context.output.status.readyInstances
+					}
+					if context.output.status.currentPrimary != _|_ {
+						primary: context.output.status.currentPrimary
+					}
+				}
+				message: "\(cluster.phase), ready: \(cluster.readyInstances)/\(context.output.spec.instances), primary: \(cluster.primary)"
+				"""#
+			healthPolicy: #"""
+				cluster: {
+					phase:          *"" | string
+					readyInstances: *0 | int
+				} & {
+					if context.output.status.phase != _|_ {
+						phase: context.output.status.phase
+					}
+					if context.output.status.readyInstances != _|_ {
+						readyInstances: context.output.status.readyInstances
+					}
+				}
+				isHealth: (cluster.phase == "Cluster in healthy state") && (cluster.readyInstances == context.output.spec.instances)
+				"""#
+		}
+	}
+}
+template: {
+	output: {
+		apiVersion: "postgresql.cnpg.io/v1"
+		kind:       "Cluster"
+		metadata: {
+			name: context.name
+		}
+		spec: {
+			instances: parameter.instances
+			if parameter["imageName"] != _|_ {
+				imageName: parameter.imageName
+			}
+			if parameter["imageName"] == _|_ {
+				imageName: "ghcr.io/cloudnative-pg/postgresql:\(parameter.postgresVersion)"
+			}
+			storage: {
+				size: parameter.storage.size
+				if parameter.storage.storageClass != _|_ {
+					storageClass: parameter.storage.storageClass
+				}
+			}
+			if parameter["cpu"] != _|_ {
+				resources: {
+					limits: {
+						cpu: parameter.cpu
+					}
+					requests: {
+						cpu: parameter.cpu
+					}
+				}
+			}
+			if parameter["memory"] != _|_ {
+				resources: {
+					limits: {
+						memory: parameter.memory
+					}
+					requests: {
+						memory: parameter.memory
+					}
+				}
+			}
+		}
+		if parameter.bootstrap.type == "initdb" {
+			spec: {
+				bootstrap: {
+					initdb: {
+						database: parameter.database
+						owner: parameter.owner
+						if parameter.bootstrap.postInitApplicationSQL != _|_ {
+							postInitApplicationSQL: parameter.bootstrap.postInitApplicationSQL
+						}
+					}
+				}
+			}
+		}
+		if parameter.bootstrap.type == "initdb" {
+			spec: {
+				bootstrap: {
+					initdb: {
+						secret: {
+							name: "\(context.name)-owner"
+						}
+					}
+				}
+			}
+		}
+		if parameter.bootstrap.type == "recovery" {
+			spec: {
+				bootstrap: {
+					recovery: {
+						database: parameter.database
+						owner: parameter.owner
+					}
+				}
+			}
+		}
+		if parameter.bootstrap.type == "recovery" {
+			spec: {
+				bootstrap: {
+					recovery: {
+						backup: {
+							name: parameter.bootstrap.backupName
+						}
+					}
+				}
+			}
+		}
+		if parameter.bootstrap.type == "recovery" {
+			spec: {
+				bootstrap: {
+					recovery: {
+						secret: {
+							name: "\(context.name)-owner"
+						}
+					}
+				}
+			}
+		}
+		if parameter.bootstrap.type == "recovery" && parameter.bootstrap.targetTime != _|_ {
+			spec: {
+				bootstrap: {
+					recovery: {
+						recoveryTarget: {
+							targetTime: parameter.bootstrap.targetTime
+						}
+					}
+				}
+			}
+		}
+		if parameter["backup"] != _|_ {
+			spec: {
+				backup: {
+					retentionPolicy: parameter.backup.retentionPolicy
+				}
+			}
+		}
+		if parameter["backup"] != _|_ {
+			spec: {
+				backup: {
+					barmanObjectStore: {
+						destinationPath: parameter.backup.destinationPath
+						if parameter.backup.endpointURL != _|_ {
+							endpointURL: parameter.backup.endpointURL
+						}
+					}
+				}
+			}
+		}
+		if parameter["backup"] != _|_ {
+			spec: {
+				backup: {
+					barmanObjectStore: {
+						s3Credentials: {
+							accessKeyId: {
+								name: parameter.backup.s3Credentials.secretName
+								key: parameter.backup.s3Credentials.accessKeyIdKey
+							}
+						}
+					}
+				}
+			}
+		}
+		if parameter["backup"] != _|_ {
+			spec: {
+				backup: {
+					barmanObjectStore: {
+						s3Credentials: {
+							secretAccessKey: {
+								name: parameter.backup.s3Credentials.secretName
+								key: parameter.backup.s3Credentials.secretAccessKeyKey
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	outputs: {
+		connectionSecret: {
+			apiVersion: "v1"
+			kind:       "Secret"
+			if parameter["writeConnectionSecretToRef"] != _|_ {
+				metadata: {
+					name: parameter.writeConnectionSecretToRef.name
+				}
+			}
+			if parameter["writeConnectionSecretToRef"] == _|_ {
+				metadata: {
+					name: "\(context.name)-conn"
+				}
+			}
+			stringData: {
+				DB_HOST: "\(context.name)-rw.\(context.namespace).svc"
+				DB_PORT: "5432"
+				DB_NAME: parameter.database
+				DB_USER: parameter.owner
+				DB_PASSWORD: parameter.password
+			}
+		}
+		ownerSecret: {
+			apiVersion: "v1"
+			kind:       "Secret"
+			metadata: {
+				name: "\(context.name)-owner"
+			}
+			type: "kubernetes.io/basic-auth"
+			stringData: {
+				username: parameter.owner
+				password: parameter.password
+			}
+		}
+		if parameter.backup.schedule != _|_ {
+			scheduledBackup: {
+				apiVersion: "postgresql.cnpg.io/v1"
+				kind:       "ScheduledBackup"
+				metadata: {
+					name: context.name
+				}
+				spec: {
+					schedule: parameter.backup.schedule
+					cluster: {
+						name: context.name
+					}
+					backupOwnerReference: "self"
+				}
+			}
+		}
+	}
+	parameter: {
+		// +usage=Number of PostgreSQL instances, one primary and the rest replicas
+		instances: *1 | int
+		// +usage=Major version of PostgreSQL, selects the CloudNativePG operand image
+		postgresVersion: *16 | int
+		// +usage=Image of PostgreSQL, takes precedence over postgresVersion
+		imageName?: string
+		// +usage=Storage of each instance
+		storage: {
+			// +usage=Size of the volume of each instance
+			size: *"1Gi" | string
+			// +usage=StorageClass of the volumes, the default StorageClass if empty
+			storageClass?: string
+		}
+		// +usage=Name of the application database
+		database: *"app" | string
+		// +usage=Name of the user owning the application database
+		owner: *"app" | string
+		// +usage=Password of the owner, stored in the `<component>-owner` Secret CloudNativePG bootstraps the owner with and written to DB_PASSWORD of the connection Secret
+		password: string
+		// +usage=How the cluster is created
+		bootstrap: {
+			// +usage=Specify the bootstrap type, options: "initdb","recovery", default to initdb
+			type: *"initdb" | "recovery"
+			if type == "initdb" {
+				// +usage=SQL statements run in the application database once it is created
+				postInitApplicationSQL?: [...string]
+			}
+			if type == "recovery" {
+				// +usage=Name of the Backup to restore, in the namespace of the component
+				backupName: string
+				// +usage=Restore up to this point in time, like `2025-01-02 15:04:05+00`, instead of the end of the WAL
+				targetTime?: string
+			}
+		}
+		// +usage=Continuous backup of the WAL and base backups to an S3 compatible object storage
+		backup?: {
+			// +usage=Path of the backups in the object storage, like `s3://bucket/path`
+			destinationPath: string
+			// +usage=Endpoint of the object storage, for storages other than AWS S3
+			endpointURL?: string
+			// +usage=Secret holding the credentials of the object storage
+			s3Credentials: {
+				// +usage=Name of the Secret
+				secretName: string
+				// +usage=Key of the access key id in the Secret
+				accessKeyIdKey: *"ACCESS_KEY_ID" | string
+				// +usage=Key of the secret access key in the Secret
+				secretAccessKeyKey: *"ACCESS_SECRET_KEY" | string
+			}
+			// +usage=How long backups are kept, like `30d` or `4w`
+			retentionPolicy: *"30d" | string
+			// +usage=Schedule of the base backups in cron format with seconds, like `0 0 0 * * *` for every midnight
+			schedule?: string
+		}
+		// +usage=Secret the connection details DB_HOST, DB_PORT, DB_NAME, DB_USER and DB_PASSWORD are written to
+		writeConnectionSecretToRef?: {
+			// +usage=Name of the Secret, defaults to `<component>-conn`
+			name: string
+		}
+		// +usage=Number of CPU units of each instance, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+		cpu?: string
+		// +usage=Specifies the attributes of the memory resource required for each instance.
+		memory?: string
+	}
+}
@@ -0,0 +1,514 @@
+import (
+	"strings"
+	"encoding/hex"
+	"crypto/sha256"
+)
+
+redis: {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Describes Redis, or Valkey, caches with persistence, replicas, Sentinel and metrics."
+	attributes: {
+		workload: {
+			definition: {
+				apiVersion: "apps/v1"
+				kind:       "StatefulSet"
+			}
+			type: "statefulsets.apps"
+		}
+		status: {
+			customStatus: #"""
+				ready: {
+					readyReplicas: *0 | int
+				} & {
+					if context.output.status.readyReplicas != _|_ {
+						readyReplicas: context.output.status.readyReplicas
+					}
+				}
+				message: "Ready:\(ready.readyReplicas)/\(context.output.spec.replicas), service: \(context.output.metadata.name):6379"
+				"""#
+			healthPolicy: #"""
+				ready: {
+					updatedReplicas:    *0 | int
+					readyReplicas:      *0 | int
+					replicas:           *0 | int
+					observedGeneration: *0 | int
+				} & {
+					if context.output.status.updatedReplicas != _|_ {
+						updatedReplicas: context.output.status.updatedReplicas
+					}
+					if context.output.status.readyReplicas != _|_ {
+						readyReplicas: context.output.status.readyReplicas
+					}
+					if context.output.status.replicas != _|_ {
+						replicas: context.output.status.replicas
+					}
+					if context.output.status.observedGeneration != _|_ {
+						observedGeneration: context.output.status.observedGeneration
+					}
+				}
+				_isHealth: (context.output.spec.replicas == ready.readyReplicas) && (context.output.spec.replicas == ready.updatedReplicas) && (context.output.spec.replicas == ready.replicas) && (ready.observedGeneration == context.output.metadata.generation || ready.observedGeneration > context.output.metadata.generation)
+				isHealth: *_isHealth | bool
+				if context.output.metadata.annotations != _|_ {
+					if context.output.metadata.annotations["app.oam.dev/disable-health-check"] != _|_ {
+						isHealth: true
+					}
+				}
+				"""#
+		}
+	}
+}
+template: {
+	let _redisConf = strings.Join([
+		"port 6379",
+		"bind * -::*",
+		"protected-mode no",
+		"dir /data",
+		if parameter.persistence.appendOnly {"appendonly yes"},
+		if !parameter.persistence.appendOnly {"appendonly no"},
+		if !parameter.persistence.enabled {"save \"\""},
+		if parameter.maxmemory != _|_ {"maxmemory \(parameter.maxmemory)"},
+		"maxmemory-policy \(parameter.maxmemoryPolicy)",
+		if parameter.extraConfig != _|_ {parameter.extraConfig},
+		"",
+	], "\n")
+	let _findPrimary = strings.Join([
+		"PRIMARY=\(context.name)-0.\(context.name)-headless.\(context.namespace).svc",
+		if parameter.sentinel.enabled {
+			"FOUND=$(redis-cli -h \(context.name) -p 26379 --raw sentinel get-master-addr-by-name \(parameter.sentinel.masterName) 2>/dev/null | head -n 1 || true)\nif [ -n \"$FOUND\" ]; then PRIMARY=$FOUND; fi"
+		},
+		"SELF=$(hostname).\(context.name)-headless.\(context.namespace).svc",
+	], "\n")
+	let _redisStart = strings.Join([
+		"set -e",
+		"cp /etc/redis/redis.conf /tmp/redis.conf",
+		_findPrimary,
+		"echo \"replica-announce-ip $SELF\" >> /tmp/redis.conf",
+		"if [ -n \"$REDIS_PASSWORD\" ]; then printf 'requirepass \"%s\"\\nmasterauth \"%s\"\\n' \"$REDIS_PASSWORD\" \"$REDIS_PASSWORD\" >> /tmp/redis.conf; fi",
+		"if [ \"$PRIMARY\" != \"$SELF\" ]; then echo \"replicaof $PRIMARY 6379\" >> /tmp/redis.conf; fi",
+		"exec redis-server /tmp/redis.conf",
+	], "\n")
+	let _sentinelStart = strings.Join([
+		"set -e",
+		_findPrimary,
+		"{",
+		"echo 'port 26379'",
+		"echo 'sentinel resolve-hostnames yes'",
+		"echo 'sentinel announce-hostnames yes'",
+		"echo \"sentinel announce-ip $SELF\"",
+		"echo \"sentinel monitor \(parameter.sentinel.masterName) $PRIMARY 6379 \(parameter.sentinel.quorum)\"",
+		"echo 'sentinel down-after-milliseconds \(parameter.sentinel.masterName) 5000'",
+		"echo 'sentinel failover-timeout \(parameter.sentinel.masterName) 60000'",
+		"if [ -n \"$REDIS_PASSWORD\" ]; then echo \"sentinel auth-pass \(parameter.sentinel.masterName) $REDIS_PASSWORD\"; fi",
+		"} > /tmp/sentinel.conf",
+		"exec redis-sentinel /tmp/sentinel.conf",
+	], "\n")
+	let _redisReady = strings.Join([
+		"if [ -n \"$REDIS_PASSWORD\" ]; then export REDISCLI_AUTH=$REDIS_PASSWORD; fi",
+		"test \"$(redis-cli ping)\" = PONG",
+		"if redis-cli info replication | grep -q '^role:slave'; then redis-cli info replication | grep -q '^master_link_status:up'; fi",
+	], "\n")
+	let _checksum = strings.SliceRunes(hex.Encode(sha256.Sum256(_redisConf + _redisStart + _sentinelStart)), 0, 16)
+	output: {
+		apiVersion: "apps/v1"
+		kind:       "StatefulSet"
+		metadata: {
+			name: context.name
+		}
+		spec: {
+			serviceName: "\(context.name)-headless"
+			replicas: parameter.replicas
+			selector: {
+				matchLabels: {
+					"app.oam.dev/component": context.name
+				}
+			}
+			template: {
+				metadata: {
+					labels: {
+						"app.oam.dev/name": context.appName
+						"app.oam.dev/component": context.name
+					}
+					annotations: {
+						"redis.oam.dev/checksum": _checksum
+						if parameter.metrics.enabled == true {
+							"prometheus.io/scrape": "true"
+							"prometheus.io/port": "9121"
+						}
+					}
+				}
+				spec: {
+					containers: [
+		{
+			command: ["sh", "-c", _redisStart]
+			env: [
+					if parameter["auth"] != _|_ {
+						{
+							name: "REDIS_PASSWORD"
+							valueFrom: {
+									secretKeyRef: {
+											if parameter.auth.existingSecret != _|_ {
+												name: parameter.auth.existingSecret
+											}
+											if parameter.auth.existingSecret != _|_ {
+												key: parameter.auth.existingSecretKey
+											}
+											if !(parameter.auth.existingSecret != _|_) {
+												name: "\(context.name)-auth"
+											}
+											if !(parameter.auth.existingSecret != _|_) {
+												key: "password"
+											}
+										}
+								}
+						}
+					},
+				]
+			image: parameter.image
+			livenessProbe: {
+					initialDelaySeconds: 15
+					tcpSocket: {
+							port: "redis"
+						}
+				}
+			name: "redis"
+			ports: [
+					{
+						containerPort: 6379
+						name: "redis"
+					},
+				]
+			readinessProbe: {
+					exec: {
+							command: ["sh", "-c", _redisReady]
+						}
+					periodSeconds: 5
+				}
+			volumeMounts: [
+					{
+						mountPath: "/data"
+						name: "data"
+					},
+					{
+						mountPath: "/etc/redis"
+						name: "config"
+					},
+				]
+			if parameter["cpu"] != _|_ {
+				resources: limits: cpu: parameter.cpu
+			}
+			if parameter["cpu"] != _|_ {
+				resources: requests: cpu: parameter.cpu
+			}
+			if parameter["memory"] != _|_ {
+				resources: limits: memory: parameter.memory
+			}
+			if parameter["memory"] != _|_ {
+				resources: requests: memory: parameter.memory
+			}
+		},
+		if parameter.sentinel.enabled == true {
+			{
+				command: ["sh", "-c", _sentinelStart]
+				env: [
+						if parameter["auth"] != _|_ {
+							{
+								name: "REDIS_PASSWORD"
+								valueFrom: {
+										secretKeyRef: {
+												if parameter.auth.existingSecret != _|_ {
+													name: parameter.auth.existingSecret
+												}
+												if parameter.auth.existingSecret != _|_ {
+													key: parameter.auth.existingSecretKey
+												}
+												if !(parameter.auth.existingSecret != _|_) {
+													name: "\(context.name)-auth"
+												}
+												if !(parameter.auth.existingSecret != _|_) {
+													key: "password"
+												}
+											}
+									}
+							}
+						},
+					]
+				image: parameter.image
+				name: "sentinel"
+				ports: [
+						{
+							containerPort: 26379
+							name: "sentinel"
+						},
+					]
+				readinessProbe: {
+						exec: {
+								command: ["sh", "-c", "test \"$(redis-cli -p 26379 ping)\" = PONG"]
+							}
+						periodSeconds: 5
+					}
+			}
+		},
+		if parameter.metrics.enabled == true {
+			{
+				env: [
+						{
+							name: "REDIS_ADDR"
+							value: "redis://localhost:6379"
+						},
+						if parameter["auth"] != _|_ {
+							{
+								name: "REDIS_PASSWORD"
+								valueFrom: {
+										secretKeyRef: {
+												if parameter.auth.existingSecret != _|_ {
+													name: parameter.auth.existingSecret
+												}
+												if parameter.auth.existingSecret != _|_ {
+													key: parameter.auth.existingSecretKey
+												}
+												if !(parameter.auth.existingSecret != _|_) {
+													name: "\(context.name)-auth"
+												}
+												if !(parameter.auth.existingSecret != _|_) {
+													key: "password"
+												}
+											}
+									}
+							}
+						},
+					]
+				image: parameter.metrics.image
+				name: "metrics"
+				ports: [
+						{
+							containerPort: 9121
+							name: "metrics"
+						},
+					]
+			}
+		},
+	]
+					volumes: [
+		{
+			configMap: {
+					name: "\(context.name)-config"
+				}
+			name: "config"
+		},
+		if !(parameter.persistence.enabled == true) {
+			{
+				emptyDir: {
+					}
+				name: "data"
+			}
+		},
+	]
+				}
+			}
+			if parameter.persistence.enabled == true {
+				volumeClaimTemplates: [
+		{
+			metadata: {
+					name: "data"
+				}
+			spec: {
+					accessModes: ["ReadWriteOnce"]
+					resources: {
+							requests: {
+									storage: parameter.persistence.size
+								}
+						}
+					if parameter.persistence.storageClass != _|_ {
+						storageClassName: parameter.persistence.storageClass
+					}
+				}
+		},
+	]
+			}
+		}
+	}
+	outputs: {
+		if parameter["auth"] != _|_ && !(parameter.auth.existingSecret != _|_) {
+			authSecret: {
+				apiVersion: "v1"
+				kind:       "Secret"
+				metadata: {
+					name: "\(context.name)-auth"
+				}
+				stringData: {
+					password: parameter.auth.password
+				}
+			}
+		}
+		config: {
+			apiVersion: "v1"
+			kind:       "ConfigMap"
+			metadata: {
+				name: "\(context.name)-config"
+			}
+			data: {
+				"redis.conf": _redisConf
+			}
+		}
+		connectionSecret: {
+			apiVersion: "v1"
+			kind:       "Secret"
+			if parameter["writeConnectionSecretToRef"] != _|_ {
+				metadata: {
+					name: parameter.writeConnectionSecretToRef.name
+				}
+			}
+			if parameter["writeConnectionSecretToRef"] == _|_ {
+				metadata: {
+					name: "\(context.name)-conn"
+				}
+			}
+			stringData: {
+				host: "\(context.name).\(context.namespace).svc"
+				port: "6379"
+				if parameter.sentinel.enabled == true {
+					sentinelHost: "\(context.name).\(context.namespace).svc"
+					sentinelPort: "26379"
+					sentinelMaster: parameter.sentinel.masterName
+				}
+				if parameter["auth"] != _|_ && !(parameter.auth.existingSecret != _|_) {
+					password: parameter.auth.password
+				}
+			}
+		}
+		headlessService: {
+			apiVersion: "v1"
+			kind:       "Service"
+			metadata: {
+				name: "\(context.name)-headless"
+			}
+			spec: {
+				clusterIP: "None"
+				publishNotReadyAddresses: true
+				selector: {
+					"app.oam.dev/component": context.name
+				}
+				ports: [
+		{
+			name: "redis"
+			port: 6379
+			targetPort: "redis"
+		},
+		if parameter.sentinel.enabled == true {
+			{
+				name: "sentinel"
+				port: 26379
+				targetPort: "sentinel"
+			}
+		},
+	]
+			}
+		}
+		service: {
+			apiVersion: "v1"
+			kind:       "Service"
+			metadata: {
+				name: context.name
+			}
+			spec: {
+				selector: {
+					"app.oam.dev/component": context.name
+					if parameter.sentinel.enabled == false {
+						"statefulset.kubernetes.io/pod-name": "\(context.name)-0"
+					}
+				}
+				ports: [
+		{
+			name: "redis"
+			port: 6379
+			targetPort: "redis"
+		},
+		if parameter.sentinel.enabled == true {
+			{
+				name: "sentinel"
+				port: 26379
+				targetPort: "sentinel"
+			}
+		},
+	]
+			}
+		}
+	}
+	parameter: {
+		// +usage=Image of Redis, Valkey images such as `valkey/valkey:8.0-alpine` also work
+		image: *"redis:7.2-alpine" | string
+		// +usage=Number of pods, the first one is the primary and the others replicate from it
+		replicas: *1 | int
+		// +usage=Persistence of the data of each pod
+		persistence: {
+			// +usage=Keep the data in a PersistentVolumeClaim per pod, otherwise in an emptyDir
+			enabled: *true | bool
+			// +usage=Size of the volume of each pod
+			size: *"1Gi" | string
+			// +usage=StorageClass of the volumes, the default StorageClass if empty
+			storageClass?: string
+			// +usage=Log every write to the append only file, in addition to the snapshots
+			appendOnly: *false | bool
+		}
+		// +usage=Require a password from clients. The password is either given, and stored in the `<component>-auth` Secret, or read from an existing Secret
+		auth?: {
+			// +usage=Password stored in the `<component>-auth` Secret
+			password?: string
+			// +usage=Existing Secret holding the password, takes precedence over password
+			existingSecret?: string
+			// +usage=Key of the password in existingSecret
+			existingSecretKey: *"password" | string
+		}
+		// +usage=Sentinel promoting a replica when the primary fails, requires at least 3 replicas
+		sentinel: {
+			// +usage=Run a Sentinel next to each Redis
+			enabled: *false | bool
+			// +usage=Name of the monitored primary, used by the clients to ask Sentinel for its address
+			masterName: *"mymaster" | string
+			// +usage=Number of Sentinels that need to agree the primary is down
+			quorum: *2 | int
+		}
+		// +usage=Memory limit of the data set, like `256mb`, keys are evicted with maxmemoryPolicy once it is reached
+		maxmemory?: string
+		// +usage=Which keys are evicted once maxmemory is reached
+		maxmemoryPolicy: *"allkeys-lru" | "noeviction" | "allkeys-lfu" | "allkeys-random" | "volatile-lru" | "volatile-lfu" | "volatile-random" | "volatile-ttl"
+		// +usage=Extra directives appended to redis.conf
+		extraConfig?: string
+		// +usage=Prometheus metrics exported by a redis_exporter sidecar on port 9121
+		metrics: {
+			// +usage=Run the redis_exporter sidecar
+			enabled: *true | bool
+			// +usage=Image of redis_exporter
+			image: *"oliver006/redis_exporter:v1.66.0" | string
+		}
+		// +usage=Secret the connection details host, port and password are written to
+		writeConnectionSecretToRef?: {
+			// +usage=Name of the Secret, defaults to `<component>-conn`
+			name: string
+		}
+		// +usage=Number of CPU units of each Redis, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+		cpu?: string
+		// +usage=Specifies the attributes of the memory resource required for each Redis.
+		memory?: string
+		_validateAuth: {
+			"auth requires either password or existingSecret": true
+			if parameter["auth"] != _|_ && !(parameter.auth.password != _|_) && !(parameter.auth.existingSecret != _|_) {
+				"auth requires either password or existingSecret": false
+			}
+		}
+		_validateSentinelReplicas: {
+			"sentinel requires at least 3 replicas": true
+			if parameter.sentinel.enabled == true && parameter.replicas < 3 {
+				"sentinel requires at least 3 replicas": false
+			}
+		}
+		_validateSentinelQuorum: {
+			"sentinel.quorum must not exceed replicas": true
+			if parameter.sentinel.enabled == true && parameter.sentinel.quorum > parameter.replicas {
+				"sentinel.quorum must not exceed replicas": false
+			}
+		}
+	}
+}
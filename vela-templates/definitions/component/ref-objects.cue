@@ -9,46 +9,363 @@
 		workload: type: "autodetects.core.oam.dev"
 		status: {
 			customStatus: #"""
-				if context.output.apiVersion == "apps/v1" && context.output.kind == "Deployment" {
-					ready: {
-						readyReplicas: *0 | int
-					} & {
-						if context.output.status.readyReplicas != _|_ {
-							readyReplicas: context.output.status.readyReplicas
+				#ObjectHealth: {
+					object: {...}
+					id:      "\(_kind)/\(_name)"
+					healthy: bool
+					message: string
+
+					_apiVersion: *"" | string
+					if object.apiVersion != _|_ {
+						_apiVersion: object.apiVersion
+					}
+					_kind: *"" | string
+					if object.kind != _|_ {
+						_kind: object.kind
+					}
+					_name: *"" | string
+					if object.metadata != _|_ if object.metadata.name != _|_ {
+						_name: object.metadata.name
+					}
+					_gvk: "\(_apiVersion)/\(_kind)"
+					_generation: *0 | int
+					if object.metadata != _|_ if object.metadata.generation != _|_ {
+						_generation: object.metadata.generation
+					}
+					_status: {...}
+					if object.status != _|_ {
+						_status: object.status
+					}
+					_conditions: *[] | [...]
+					if _status.conditions != _|_ {
+						_conditions: _status.conditions
+					}
+
+					if _gvk == "apps/v1/Deployment" || _gvk == "apps/v1/StatefulSet" {
+						_rule: {
+							context: output: object & {metadata: {generation: *0 | int, ...}, spec: {replicas: *1 | int, ...}, status: {observedGeneration: *0 | int, readyReplicas: *0 | int, updatedReplicas: *0 | int, replicas: *0 | int, ...}, ...}
+							isHealth: (context.output.status.readyReplicas == context.output.spec.replicas) && (context.output.status.updatedReplicas == context.output.spec.replicas) && (context.output.status.replicas == context.output.spec.replicas) && (context.output.status.observedGeneration >= context.output.metadata.generation)
+							message: "Ready:\(context.output.status.readyReplicas)/\(context.output.spec.replicas)"
+						}
+						healthy: _rule.isHealth
+						message: _rule.message
+					}
+
+					if _gvk == "apps/v1/DaemonSet" {
+						_rule: {
+							context: output: object & {metadata: {generation: *0 | int, ...}, status: {observedGeneration: *0 | int, desiredNumberScheduled: *0 | int, numberReady: *0 | int, updatedNumberScheduled: *0 | int, ...}, ...}
+							isHealth: (context.output.status.numberReady == context.output.status.desiredNumberScheduled) && (context.output.status.updatedNumberScheduled == context.output.status.desiredNumberScheduled) && (context.output.status.observedGeneration >= context.output.metadata.generation)
+							message: "Ready:\(context.output.status.numberReady)/\(context.output.status.desiredNumberScheduled)"
+						}
+						healthy: _rule.isHealth
+						message: _rule.message
+					}
+
+					if _gvk == "batch/v1/Job" {
+						_rule: {
+							context: output: object & {spec: {completions: *1 | int, ...}, status: {succeeded: *0 | int, conditions: *[] | [...], ...}, ...}
+							_failedCond: [ for c in context.output.status.conditions if c.type == "Failed" { c } ]
+							isHealth: (!(len(_failedCond) > 0)) && (context.output.status.succeeded >= context.output.spec.completions)
+							if len(_failedCond) == 0 {
+								message: "Succeeded:\(context.output.status.succeeded)/\(context.output.spec.completions)"
+							}
+							if len(_failedCond) > 0 if _failedCond[0].message != _|_ {
+								message: "Failed: \(_failedCond[0].message)"
+							}
+							if len(_failedCond) > 0 if _failedCond[0].message == _|_ {
+								message: "Failed"
+							}
 						}
+						healthy: _rule.isHealth
+						message: _rule.message
 					}
-					message: "Ready:\(ready.readyReplicas)/\(context.output.spec.replicas)"
+
+					if _gvk == "batch/v1/CronJob" {
+						_rule: {
+							context: output: object & {spec: {suspend: *false | bool, ...}, status: {active: *[] | [...], lastScheduleTime: *"" | string, ...}, ...}
+							isHealth: true
+							if context.output.spec.suspend {
+								message: "Suspended"
+							}
+							if !context.output.spec.suspend && context.output.status.lastScheduleTime == "" {
+								message: "Active:\(len(context.output.status.active)), not scheduled yet"
+							}
+							if !context.output.spec.suspend && context.output.status.lastScheduleTime != "" {
+								message: "Active:\(len(context.output.status.active)), last schedule: \(context.output.status.lastScheduleTime)"
+							}
+						}
+						healthy: _rule.isHealth
+						message: _rule.message
+					}
+
+					if _gvk == "v1/Service" {
+						_rule: {
+							context: output: object & {spec: {type: *"ClusterIP" | string, ...}, ...}
+							isHealth: (context.output.spec.type != "LoadBalancer") || (context.output.status.loadBalancer.ingress[0] != _|_)
+							_ingress: *[] | [...]
+							if context.output.status.loadBalancer.ingress != _|_ {
+								_ingress: context.output.status.loadBalancer.ingress
+							}
+							if context.output.spec.type != "LoadBalancer" {
+								message: context.output.spec.type
+							}
+							if context.output.spec.type == "LoadBalancer" && len(_ingress) == 0 {
+								message: "waiting for LoadBalancer ingress"
+							}
+							if context.output.spec.type == "LoadBalancer" && len(_ingress) > 0 {
+								if _ingress[0].ip != _|_ {
+									message: "LoadBalancer: \(_ingress[0].ip)"
+								}
+								if _ingress[0].ip == _|_ && _ingress[0].hostname != _|_ {
+									message: "LoadBalancer: \(_ingress[0].hostname)"
+								}
+								if _ingress[0].ip == _|_ && _ingress[0].hostname == _|_ {
+									message: "LoadBalancer"
+								}
+							}
+						}
+						healthy: _rule.isHealth
+						message: _rule.message
+					}
+
+					if _gvk == "v1/PersistentVolumeClaim" {
+						_rule: {
+							context: output: object & {status: {phase: *"Pending" | string, ...}, ...}
+							isHealth: context.output.status.phase == "Bound"
+							_selectedNode: context.output.metadata.annotations["volume.kubernetes.io/selected-node"] != _|_
+							if context.output.status.phase == "Pending" && !_selectedNode {
+								message: "Pending: waiting for first consumer"
+							}
+							if context.output.status.phase != "Pending" || _selectedNode {
+								message: context.output.status.phase
+							}
+						}
+						healthy: _rule.isHealth
+						message: _rule.message
+					}
+
+					_rules: ["apps/v1/Deployment", "apps/v1/StatefulSet", "apps/v1/DaemonSet", "batch/v1/Job", "batch/v1/CronJob", "v1/Service", "v1/PersistentVolumeClaim"]
+					if len([ for r in _rules if r == _gvk { r } ]) == 0 {
+						_ready: [ for t in ["Ready", "Available"] for c in _conditions if c.type == t { c } ]
+						if len(_ready) == 0 {
+							healthy: true
+							message: ""
+						}
+						if len(_ready) > 0 {
+							_observedGenerations: [
+								if _status.observedGeneration != _|_ {
+									_status.observedGeneration
+								},
+								if _ready[0].observedGeneration != _|_ {
+									_ready[0].observedGeneration
+								},
+							]
+							_stale: len([ for g in _observedGenerations if g < _generation { g } ]) > 0
+							healthy: _ready[0].status == "True" && !_stale
+							if _stale {
+								message: "waiting for generation \(_generation) to be observed"
+							}
+							if !_stale if _ready[0].message != _|_ {
+								message: _ready[0].message
+							}
+							if !_stale if _ready[0].message == _|_ {
+								message: "\(_ready[0].type): \(_ready[0].status)"
+							}
+						}
+					}
+				}
+				_outputs: {...}
+				if context.outputs != _|_ {
+					_outputs: context.outputs
 				}
-				if context.output.apiVersion != "apps/v1" || context.output.kind != "Deployment" {
-					message: ""
+				_health: [ for o in [context.output, for k, v in _outputs { v }] { #ObjectHealth & {object: o} } ]
+				_unhealthy: [ for h in _health if !h.healthy { h } ]
+				if len(_health) == 1 {
+					message: _health[0].message
+				}
+				if len(_health) > 1 && len(_unhealthy) == 0 {
+					message: "\(len(_health)) objects healthy"
+				}
+				if len(_health) > 1 && len(_unhealthy) == 1 {
+					message: "\(_unhealthy[0].id): \(_unhealthy[0].message)"
+				}
+				if len(_health) > 1 && len(_unhealthy) > 1 {
+					message: "\(_unhealthy[0].id): \(_unhealthy[0].message) (\(len(_unhealthy)) unhealthy)"
 				}
 				"""#
 			healthPolicy: #"""
-				if context.output.apiVersion == "apps/v1" && context.output.kind == "Deployment" {
-					ready: {
-						updatedReplicas:    *0 | int
-						readyReplicas:      *0 | int
-						replicas:           *0 | int
-						observedGeneration: *0 | int
-					} & {
-						if context.output.status.updatedReplicas != _|_ {
-							updatedReplicas: context.output.status.updatedReplicas
+				#ObjectHealth: {
+					object: {...}
+					id:      "\(_kind)/\(_name)"
+					healthy: bool
+					message: string
+
+					_apiVersion: *"" | string
+					if object.apiVersion != _|_ {
+						_apiVersion: object.apiVersion
+					}
+					_kind: *"" | string
+					if object.kind != _|_ {
+						_kind: object.kind
+					}
+					_name: *"" | string
+					if object.metadata != _|_ if object.metadata.name != _|_ {
+						_name: object.metadata.name
+					}
+					_gvk: "\(_apiVersion)/\(_kind)"
+					_generation: *0 | int
+					if object.metadata != _|_ if object.metadata.generation != _|_ {
+						_generation: object.metadata.generation
+					}
+					_status: {...}
+					if object.status != _|_ {
+						_status: object.status
+					}
+					_conditions: *[] | [...]
+					if _status.conditions != _|_ {
+						_conditions: _status.conditions
+					}
+
+					if _gvk == "apps/v1/Deployment" || _gvk == "apps/v1/StatefulSet" {
+						_rule: {
+							context: output: object & {metadata: {generation: *0 | int, ...}, spec: {replicas: *1 | int, ...}, status: {observedGeneration: *0 | int, readyReplicas: *0 | int, updatedReplicas: *0 | int, replicas: *0 | int, ...}, ...}
+							isHealth: (context.output.status.readyReplicas == context.output.spec.replicas) && (context.output.status.updatedReplicas == context.output.spec.replicas) && (context.output.status.replicas == context.output.spec.replicas) && (context.output.status.observedGeneration >= context.output.metadata.generation)
+							message: "Ready:\(context.output.status.readyReplicas)/\(context.output.spec.replicas)"
 						}
-						if context.output.status.readyReplicas != _|_ {
-							readyReplicas: context.output.status.readyReplicas
+						healthy: _rule.isHealth
+						message: _rule.message
+					}
+
+					if _gvk == "apps/v1/DaemonSet" {
+						_rule: {
+							context: output: object & {metadata: {generation: *0 | int, ...}, status: {observedGeneration: *0 | int, desiredNumberScheduled: *0 | int, numberReady: *0 | int, updatedNumberScheduled: *0 | int, ...}, ...}
+							isHealth: (context.output.status.numberReady == context.output.status.desiredNumberScheduled) && (context.output.status.updatedNumberScheduled == context.output.status.desiredNumberScheduled) && (context.output.status.observedGeneration >= context.output.metadata.generation)
+							message: "Ready:\(context.output.status.numberReady)/\(context.output.status.desiredNumberScheduled)"
 						}
-						if context.output.status.replicas != _|_ {
-							replicas: context.output.status.replicas
+						healthy: _rule.isHealth
+						message: _rule.message
+					}
+
+					if _gvk == "batch/v1/Job" {
+						_rule: {
+							context: output: object & {spec: {completions: *1 | int, ...}, status: {succeeded: *0 | int, conditions: *[] | [...], ...}, ...}
+							_failedCond: [ for c in context.output.status.conditions if c.type == "Failed" { c } ]
+							isHealth: (!(len(_failedCond) > 0)) && (context.output.status.succeeded >= context.output.spec.completions)
+							if len(_failedCond) == 0 {
+								message: "Succeeded:\(context.output.status.succeeded)/\(context.output.spec.completions)"
+							}
+							if len(_failedCond) > 0 if _failedCond[0].message != _|_ {
+								message: "Failed: \(_failedCond[0].message)"
+							}
+							if len(_failedCond) > 0 if _failedCond[0].message == _|_ {
+								message: "Failed"
+							}
+						}
+						healthy: _rule.isHealth
+						message: _rule.message
+					}
+
+					if _gvk == "batch/v1/CronJob" {
+						_rule: {
+							context: output: object & {spec: {suspend: *false | bool, ...}, status: {active: *[] | [...], lastScheduleTime: *"" | string, ...}, ...}
+							isHealth: true
+							if context.output.spec.suspend {
+								message: "Suspended"
+							}
+							if !context.output.spec.suspend && context.output.status.lastScheduleTime == "" {
+								message: "Active:\(len(context.output.status.active)), not scheduled yet"
+							}
+							if !context.output.spec.suspend && context.output.status.lastScheduleTime != "" {
+								message: "Active:\(len(context.output.status.active)), last schedule: \(context.output.status.lastScheduleTime)"
+							}
+						}
+						healthy: _rule.isHealth
+						message: _rule.message
+					}
+
+					if _gvk == "v1/Service" {
+						_rule: {
+							context: output: object & {spec: {type: *"ClusterIP" | string, ...}, ...}
+							isHealth: (context.output.spec.type != "LoadBalancer") || (context.output.status.loadBalancer.ingress[0] != _|_)
+							_ingress: *[] | [...]
+							if context.output.status.loadBalancer.ingress != _|_ {
+								_ingress: context.output.status.loadBalancer.ingress
+							}
+							if context.output.spec.type != "LoadBalancer" {
+								message: context.output.spec.type
+							}
+							if context.output.spec.type == "LoadBalancer" && len(_ingress) == 0 {
+								message: "waiting for LoadBalancer ingress"
+							}
+							if context.output.spec.type == "LoadBalancer" && len(_ingress) > 0 {
+								if _ingress[0].ip != _|_ {
+									message: "LoadBalancer: \(_ingress[0].ip)"
+								}
+								if _ingress[0].ip == _|_ && _ingress[0].hostname != _|_ {
+									message: "LoadBalancer: \(_ingress[0].hostname)"
+								}
+								if _ingress[0].ip == _|_ && _ingress[0].hostname == _|_ {
+									message: "LoadBalancer"
+								}
+							}
+						}
+						healthy: _rule.isHealth
+						message: _rule.message
+					}
+
+					if _gvk == "v1/PersistentVolumeClaim" {
+						_rule: {
+							context: output: object & {status: {phase: *"Pending" | string, ...}, ...}
+							isHealth: context.output.status.phase == "Bound"
+							_selectedNode: context.output.metadata.annotations["volume.kubernetes.io/selected-node"] != _|_
+							if context.output.status.phase == "Pending" && !_selectedNode {
+								message: "Pending: waiting for first consumer"
+							}
+							if context.output.status.phase != "Pending" || _selectedNode {
+								message: context.output.status.phase
+							}
+						}
+						healthy: _rule.isHealth
+						message: _rule.message
+					}
+
+					_rules: ["apps/v1/Deployment", "apps/v1/StatefulSet", "apps/v1/DaemonSet", "batch/v1/Job", "batch/v1/CronJob", "v1/Service", "v1/PersistentVolumeClaim"]
+					if len([ for r in _rules if r == _gvk { r } ]) == 0 {
+						_ready: [ for t in ["Ready", "Available"] for c in _conditions if c.type == t { c } ]
+						if len(_ready) == 0 {
+							healthy: true
+							message: ""
 						}
-						if context.output.status.observedGeneration != _|_ {
-							observedGeneration: context.output.status.observedGeneration
+						if len(_ready) > 0 {
+							_observedGenerations: [
+								if _status.observedGeneration != _|_ {
+									_status.observedGeneration
+								},
+								if _ready[0].observedGeneration != _|_ {
+									_ready[0].observedGeneration
+								},
+							]
+							_stale: len([ for g in _observedGenerations if g < _generation { g } ]) > 0
+							healthy: _ready[0].status == "True" && !_stale
+							if _stale {
+								message: "waiting for generation \(_generation) to be observed"
+							}
+							if !_stale if _ready[0].message != _|_ {
+								message: _ready[0].message
+							}
+							if !_stale if _ready[0].message == _|_ {
+								message: "\(_ready[0].type): \(_ready[0].status)"
+							}
 						}
 					}
-					isHealth: (context.output.spec.replicas == ready.readyReplicas) && (context.output.spec.replicas == ready.updatedReplicas) && (context.output.spec.replicas == ready.replicas) && (ready.observedGeneration == context.output.metadata.generation || ready.observedGeneration > context.output.metadata.generation)
 				}
-				if context.output.apiVersion != "apps/v1" || context.output.kind != "Deployment" {
-					isHealth: true
+				_outputs: {...}
+				if context.outputs != _|_ {
+					_outputs: context.outputs
 				}
+				_health: [ for o in [context.output, for k, v in _outputs { v }] { #ObjectHealth & {object: o} } ]
+				_unhealthy: [ for h in _health if !h.healthy { h } ]
+				isHealth: len(_unhealthy) == 0
 				"""#
 		}
 	}
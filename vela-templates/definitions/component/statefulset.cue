@@ -1,5 +1,6 @@
 import (
 	"strings"
+	"list"
 	"strconv"
 )
 
@@ -168,6 +169,38 @@ template: {
 			val
 		},
 	]
+	claimMounts: [
+		if parameter["volumeClaimTemplates"] != _|_ for v in parameter.volumeClaimTemplates if v.volumeMode == "Filesystem" {
+			mountPath: v.mountPath
+			name: v.name
+		},
+	]
+	claimDevices: [
+		if parameter["volumeClaimTemplates"] != _|_ for v in parameter.volumeClaimTemplates if v.volumeMode == "Block" {
+			devicePath: v.mountPath
+			name: v.name
+		},
+	]
+	claimTemplates: [
+		if parameter["volumeClaimTemplates"] != _|_ for v in parameter.volumeClaimTemplates {
+			metadata: {
+				name: v.name
+			}
+			spec: {
+				accessModes: v.accessModes
+				resources: {
+				requests: {
+				storage: v.size
+			}
+			}
+				if v.storageClass != _|_ {
+					storageClassName: v.storageClass
+				}
+				volumeMode: v.volumeMode
+			}
+		},
+	]
+	#IntOrPercent: int & >=0 | =~"^[0-9]+%$"
 	output: {
 		apiVersion: "apps/v1"
 		kind:       "StatefulSet"
@@ -194,99 +227,189 @@ template: {
 					}
 				}
 				spec: {
-					containers: [{
-						name: context.name
-						image: parameter.image
-						if parameter["port"] != _|_ && parameter["ports"] == _|_ {
-							ports: [{
-							containerPort: parameter.port
-						}]
-						}
-						if parameter["ports"] != _|_ {
-							ports: [
-		for v in parameter.ports {
-			if v.containerPort != _|_ {
-				containerPort: v.containerPort
+					containers: [
+		{
+			image: parameter.image
+			name: context.name
+			if parameter["port"] != _|_ && parameter["ports"] == _|_ {
+				ports: [{
+											containerPort: parameter.port
+										}]
 			}
-			if v.containerPort == _|_ {
-				containerPort: v.port
+			if parameter["ports"] != _|_ {
+				ports: [
+						for v in parameter.ports {
+							if v.containerPort != _|_ {
+								containerPort: v.containerPort
+							}
+							if v.containerPort == _|_ {
+								containerPort: v.port
+							}
+							protocol: v.protocol
+							if v.name != _|_ {
+								name: v.name
+							}
+							if v.name == _|_ {
+								if v.containerPort != _|_ {
+									_name: "port-" + strconv.FormatInt(v.containerPort, 10)
+									name: *_name | string
+									if v.protocol != "TCP" {
+										name: _name + "-" + strings.ToLower(v.protocol)
+									}
+								}
+								if v.containerPort == _|_ {
+									_name: "port-" + strconv.FormatInt(v.port, 10)
+									name: *_name | string
+									if v.protocol != "TCP" {
+										name: _name + "-" + strings.ToLower(v.protocol)
+									}
+								}
+							}
+						},
+					]
 			}
-			protocol: v.protocol
-			if v.name != _|_ {
-				name: v.name
+			if parameter["imagePullPolicy"] != _|_ {
+				imagePullPolicy: parameter.imagePullPolicy
 			}
-			if v.name == _|_ {
-				if v.containerPort != _|_ {
-					_name: "port-" + strconv.FormatInt(v.containerPort, 10)
-					name: *_name | string
-					if v.protocol != "TCP" {
-						name: _name + "-" + strings.ToLower(v.protocol)
-					}
+			if parameter["cmd"] != _|_ {
+				command: parameter.cmd
+			}
+			if parameter["args"] != _|_ {
+				args: parameter.args
+			}
+			if parameter["env"] != _|_ {
+				env: parameter.env
+			}
+			if context["config"] != _|_ {
+				env: context.config
+			}
+			if parameter["cpu"] != _|_ {
+				resources: limits: cpu: parameter.cpu
+			}
+			if parameter["cpu"] != _|_ {
+				resources: requests: cpu: parameter.cpu
+			}
+			if parameter["memory"] != _|_ {
+				resources: limits: memory: parameter.memory
+			}
+			if parameter["memory"] != _|_ {
+				resources: requests: memory: parameter.memory
+			}
+			if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
+				volumeMounts: list.Concat([[for v in parameter.volumes {
+								{
+									mountPath: v.mountPath
+									name: v.name
+								}
+							}], claimMounts])
+			}
+			if parameter["volumeMounts"] != _|_ {
+				volumeMounts: list.Concat([mountsArray, claimMounts])
+			}
+			if parameter["volumes"] == _|_ && parameter["volumeMounts"] == _|_ && len(claimMounts) != 0 {
+				volumeMounts: claimMounts
+			}
+			if len(claimDevices) != 0 {
+				volumeDevices: claimDevices
+			}
+			if parameter["livenessProbe"] != _|_ {
+				livenessProbe: parameter.livenessProbe
+			}
+			if parameter["readinessProbe"] != _|_ {
+				readinessProbe: parameter.readinessProbe
+			}
+		},
+		if parameter["containers"] != _|_ for m in parameter.containers {
+			{
+				image: m.image
+				name: m.name
+				if m.imagePullPolicy != _|_ {
+					imagePullPolicy: m.imagePullPolicy
 				}
-				if v.containerPort == _|_ {
-					_name: "port-" + strconv.FormatInt(v.port, 10)
-					name: *_name | string
-					if v.protocol != "TCP" {
-						name: _name + "-" + strings.ToLower(v.protocol)
-					}
+				if m.cmd != _|_ {
+					command: m.cmd
+				}
+				if m.args != _|_ {
+					args: m.args
+				}
+				if m.ports != _|_ {
+					ports: m.ports
+				}
+				if m.env != _|_ {
+					env: m.env
+				}
+				if m.cpu != _|_ {
+					resources: limits: cpu: m.cpu
+				}
+				if m.cpu != _|_ {
+					resources: requests: cpu: m.cpu
+				}
+				if m.memory != _|_ {
+					resources: limits: memory: m.memory
+				}
+				if m.memory != _|_ {
+					resources: requests: memory: m.memory
+				}
+				if m.startupProbe != _|_ {
+					startupProbe: m.startupProbe
+				}
+				if m.livenessProbe != _|_ {
+					livenessProbe: m.livenessProbe
+				}
+				if m.readinessProbe != _|_ {
+					readinessProbe: m.readinessProbe
+				}
+				if m.volumeMounts != _|_ {
+					volumeMounts: m.volumeMounts
 				}
 			}
 		},
-	]
-						}
-						if parameter["env"] != _|_ {
-							env: parameter.env
-						}
-						if context["config"] != _|_ {
-							env: context.config
-						}
-						if parameter["cpu"] != _|_ {
-							resources: {
-								limits: {
-									cpu: parameter.cpu
-								}
-								requests: {
-									cpu: parameter.cpu
-								}
-							}
-						}
-						if parameter["memory"] != _|_ {
-							resources: {
-								limits: {
-									memory: parameter.memory
-								}
-								requests: {
-									memory: parameter.memory
-								}
-							}
-						}
-						if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
-							volumeMounts: [for v in parameter.volumes {
-				{
-					mountPath: v.mountPath
-					name: v.name
+		if parameter["sidecars"] != _|_ if len(parameter["sidecars"]) > 0 if !(context.clusterVersion.minor >= 29) for m in parameter.sidecars {
+			{
+				image: m.image
+				name: m.name
+				if m.imagePullPolicy != _|_ {
+					imagePullPolicy: m.imagePullPolicy
 				}
-			}]
-						}
-						if parameter["volumeMounts"] != _|_ {
-							volumeMounts: mountsArray
-						}
-						if parameter["args"] != _|_ {
-							args: parameter.args
-						}
-						if parameter["cmd"] != _|_ {
-							command: parameter.cmd
-						}
-						if parameter["imagePullPolicy"] != _|_ {
-							imagePullPolicy: parameter.imagePullPolicy
-						}
-						if parameter["livenessProbe"] != _|_ {
-							livenessProbe: parameter.livenessProbe
-						}
-						if parameter["readinessProbe"] != _|_ {
-							readinessProbe: parameter.readinessProbe
-						}
-					}]
+				if m.cmd != _|_ {
+					command: m.cmd
+				}
+				if m.args != _|_ {
+					args: m.args
+				}
+				if m.ports != _|_ {
+					ports: m.ports
+				}
+				if m.env != _|_ {
+					env: m.env
+				}
+				if m.cpu != _|_ {
+					resources: limits: cpu: m.cpu
+				}
+				if m.cpu != _|_ {
+					resources: requests: cpu: m.cpu
+				}
+				if m.memory != _|_ {
+					resources: limits: memory: m.memory
+				}
+				if m.memory != _|_ {
+					resources: requests: memory: m.memory
+				}
+				if m.startupProbe != _|_ {
+					startupProbe: m.startupProbe
+				}
+				if m.livenessProbe != _|_ {
+					livenessProbe: m.livenessProbe
+				}
+				if m.readinessProbe != _|_ {
+					readinessProbe: m.readinessProbe
+				}
+				if m.volumeMounts != _|_ {
+					volumeMounts: m.volumeMounts
+				}
+			}
+		},
+	]
 					if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
 						volumes: [for v in parameter.volumes {
 				{
@@ -332,11 +455,79 @@ template: {
 					if parameter["imagePullSecrets"] != _|_ {
 						imagePullSecrets: [for v in parameter.imagePullSecrets { name: v }]
 					}
+					if parameter["sidecars"] != _|_ if len(parameter["sidecars"]) > 0 if context.clusterVersion.minor >= 29 {
+						initContainers: [
+		for m in parameter.sidecars {
+			{
+				image: m.image
+				name: m.name
+				restartPolicy: "Always"
+				if m.imagePullPolicy != _|_ {
+					imagePullPolicy: m.imagePullPolicy
+				}
+				if m.cmd != _|_ {
+					command: m.cmd
+				}
+				if m.args != _|_ {
+					args: m.args
+				}
+				if m.ports != _|_ {
+					ports: m.ports
+				}
+				if m.env != _|_ {
+					env: m.env
+				}
+				if m.cpu != _|_ {
+					resources: limits: cpu: m.cpu
+				}
+				if m.cpu != _|_ {
+					resources: requests: cpu: m.cpu
+				}
+				if m.memory != _|_ {
+					resources: limits: memory: m.memory
+				}
+				if m.memory != _|_ {
+					resources: requests: memory: m.memory
+				}
+				if m.startupProbe != _|_ {
+					startupProbe: m.startupProbe
+				}
+				if m.livenessProbe != _|_ {
+					livenessProbe: m.livenessProbe
 				}
+				if m.readinessProbe != _|_ {
+					readinessProbe: m.readinessProbe
+				}
+				if m.volumeMounts != _|_ {
+					volumeMounts: m.volumeMounts
+				}
+			}
+		},
+	]
+					}
+				}
+			}
+			if parameter.governingService {
+				serviceName: context.name + "-headless"
+			}
+			if parameter["ordinals"] != _|_ {
+				ordinals: parameter.ordinals
+			}
+			if parameter["persistentVolumeClaimRetentionPolicy"] != _|_ {
+				persistentVolumeClaimRetentionPolicy: parameter.persistentVolumeClaimRetentionPolicy
+			}
+			if parameter["podManagementPolicy"] != _|_ {
+				podManagementPolicy: parameter.podManagementPolicy
+			}
+			if parameter["updateStrategy"] != _|_ {
+				updateStrategy: parameter.updateStrategy
+			}
+			if parameter["volumeClaimTemplates"] != _|_ {
+				volumeClaimTemplates: claimTemplates
 			}
 		}
 	}
-	exposePorts: [
+	exposedPorts: [
 		if parameter["ports"] != _|_ for v in parameter.ports if v.expose == true {
 			port: v.port
 			if v.containerPort != _|_ {
@@ -364,17 +555,210 @@ template: {
 					}
 				}
 			}
-			if v.nodePort != _|_ {
-				if parameter.exposeType == "NodePort" {
-					nodePort: v.nodePort
+			protocol: v.protocol
+			if v.appProtocol != _|_ {
+				appProtocol: v.appProtocol
+			}
+			if v.sessionAffinity != _|_ {
+				sessionAffinity: v.sessionAffinity
+			}
+			if v.ipFamilyPolicy != _|_ {
+				ipFamilyPolicy: v.ipFamilyPolicy
+			}
+			if v.serviceType == _|_ {
+				serviceType: parameter.exposeType
+				if v.nodePort != _|_ {
+					if parameter.exposeType == "NodePort" {
+						nodePort: v.nodePort
+					}
 				}
+				if v.externalTrafficPolicy != _|_ {
+					if parameter.exposeType == "NodePort" || parameter.exposeType == "LoadBalancer" {
+						externalTrafficPolicy: v.externalTrafficPolicy
+					}
+				}
+				service: context.name
 			}
-			if v.protocol != _|_ {
-				protocol: v.protocol
+			if v.serviceType != _|_ {
+				serviceType: v.serviceType
+				if v.nodePort != _|_ {
+					if v.serviceType == "NodePort" {
+						nodePort: v.nodePort
+					}
+				}
+				if v.externalTrafficPolicy != _|_ {
+					if v.serviceType == "NodePort" || v.serviceType == "LoadBalancer" {
+						externalTrafficPolicy: v.externalTrafficPolicy
+					}
+				}
+				if v.serviceType == parameter.exposeType {
+					service: context.name
+				}
+				if v.serviceType != parameter.exposeType {
+					service: context.name + "-" + strings.ToLower(v.serviceType)
+				}
+			}
+			routeType: *"" | string
+			if parameter["route"] != _|_ {
+				if v.protocol == "TCP" {
+					if v.appProtocol == _|_ {
+						if !(parameter.route.port != _|_) {
+							routeType: parameter.route.type
+						}
+						if parameter.route.port != _|_ {
+							if v.port == parameter.route.port {
+								routeType: parameter.route.type
+							}
+						}
+					}
+					if v.appProtocol != _|_ {
+						if v.appProtocol == "http" || v.appProtocol == "https" || v.appProtocol == "kubernetes.io/h2c" || v.appProtocol == "kubernetes.io/ws" || v.appProtocol == "kubernetes.io/wss" {
+							if !(parameter.route.port != _|_) {
+								routeType: parameter.route.type
+							}
+							if parameter.route.port != _|_ {
+								if v.port == parameter.route.port {
+									routeType: parameter.route.type
+								}
+							}
+						}
+					}
+				}
 			}
 		},
 	]
+	exposePorts: [for v in exposedPorts if v.serviceType == parameter.exposeType { v }]
+	exposeClusterIPPorts: [for v in exposedPorts if v.serviceType == "ClusterIP" { v }]
+	exposeNodePortPorts: [for v in exposedPorts if v.serviceType == "NodePort" { v }]
+	exposeLoadBalancerPorts: [for v in exposedPorts if v.serviceType == "LoadBalancer" { v }]
+	ingressPorts: [for v in exposedPorts if v.routeType == "Ingress" { v }]
+	httpRoutePorts: [for v in exposedPorts if v.routeType == "HTTPRoute" { v }]
 	outputs: {
+		if len(httpRoutePorts) != 0 {
+			httpRoute: {
+				apiVersion: "gateway.networking.k8s.io/v1"
+				kind:       "HTTPRoute"
+				metadata: {
+					name: context.name
+					if parameter.route.annotations != _|_ {
+						annotations: parameter.route.annotations
+					}
+				}
+				spec: {
+					parentRefs: [
+		{
+			name: parameter.route.gateway.name
+			if parameter.route.gateway.namespace != _|_ {
+				namespace: parameter.route.gateway.namespace
+			}
+			if parameter.route.gateway.sectionName != _|_ {
+				sectionName: parameter.route.gateway.sectionName
+			}
+		},
+	]
+					rules: [
+		{
+			backendRefs: [
+					{
+						name: httpRoutePorts[0].service
+						port: httpRoutePorts[0].port
+					},
+				]
+			matches: [
+					{
+						path: {
+								value: parameter.route.path
+								if parameter.route.pathType == "Prefix" {
+									type: "PathPrefix"
+								}
+								if parameter.route.pathType == "Exact" {
+									type: "Exact"
+								}
+							}
+					},
+				]
+		},
+	]
+					if parameter.route.hosts != _|_ {
+						hostnames: parameter.route.hosts
+					}
+				}
+			}
+		}
+		if len(ingressPorts) != 0 {
+			ingress: {
+				apiVersion: "networking.k8s.io/v1"
+				kind:       "Ingress"
+				metadata: {
+					name: context.name
+					if parameter.route.annotations != _|_ {
+						annotations: parameter.route.annotations
+					}
+				}
+				spec: {
+					if !(parameter.route.hosts != _|_) {
+						rules: [
+		{
+			http: {
+					paths: [
+							{
+								backend: {
+										service: {
+												name: ingressPorts[0].service
+												port: {
+														number: ingressPorts[0].port
+													}
+											}
+									}
+								path: parameter.route.path
+								pathType: parameter.route.pathType
+							},
+						]
+				}
+		},
+	]
+					}
+					if parameter.route.hosts != _|_ {
+						rules: [
+		for m in parameter.route.hosts {
+			{
+				host: m
+				http: {
+		paths: [
+				{
+					backend: {
+							service: {
+									name: ingressPorts[0].service
+									port: {
+											number: ingressPorts[0].port
+										}
+								}
+						}
+					path: parameter.route.path
+					pathType: parameter.route.pathType
+				},
+			]
+	}
+			}
+		},
+	]
+					}
+					if parameter.route.className != _|_ {
+						ingressClassName: parameter.route.className
+					}
+					if parameter.route.tlsSecretName != _|_ {
+						tls: [
+		{
+			secretName: parameter.route.tlsSecretName
+			if parameter.route.hosts != _|_ {
+				hosts: parameter.route.hosts
+			}
+		},
+	]
+					}
+				}
+			}
+		}
 		if len(exposePorts) != 0 {
 			statefulsetsExpose: {
 				apiVersion: "v1"
@@ -386,8 +770,167 @@ template: {
 					selector: {
 						"app.oam.dev/component": context.name
 					}
-					ports: exposePorts
+					ports: [for v in exposePorts {
+				{
+					if v.appProtocol != _|_ {
+						appProtocol: v.appProtocol
+					}
+					name: v.name
+					if v.nodePort != _|_ {
+						nodePort: v.nodePort
+					}
+					port: v.port
+					if v.protocol != _|_ {
+						protocol: v.protocol
+					}
+					targetPort: v.targetPort
+				}
+			}]
 					type: parameter.exposeType
+					if exposePorts[0].externalTrafficPolicy != _|_ {
+						externalTrafficPolicy: exposePorts[0].externalTrafficPolicy
+					}
+					if exposePorts[0].ipFamilyPolicy != _|_ {
+						ipFamilyPolicy: exposePorts[0].ipFamilyPolicy
+					}
+					if exposePorts[0].sessionAffinity != _|_ {
+						sessionAffinity: exposePorts[0].sessionAffinity
+					}
+				}
+			}
+		}
+		if parameter.exposeType != "ClusterIP" && len(exposeClusterIPPorts) != 0 {
+			statefulsetsExposeClusterIP: {
+				apiVersion: "v1"
+				kind:       "Service"
+				metadata: {
+					name: context.name + "-clusterip"
+				}
+				spec: {
+					selector: {
+						"app.oam.dev/component": context.name
+					}
+					ports: [for v in exposeClusterIPPorts {
+				{
+					if v.appProtocol != _|_ {
+						appProtocol: v.appProtocol
+					}
+					name: v.name
+					if v.nodePort != _|_ {
+						nodePort: v.nodePort
+					}
+					port: v.port
+					if v.protocol != _|_ {
+						protocol: v.protocol
+					}
+					targetPort: v.targetPort
+				}
+			}]
+					type: "ClusterIP"
+					if exposeClusterIPPorts[0].externalTrafficPolicy != _|_ {
+						externalTrafficPolicy: exposeClusterIPPorts[0].externalTrafficPolicy
+					}
+					if exposeClusterIPPorts[0].ipFamilyPolicy != _|_ {
+						ipFamilyPolicy: exposeClusterIPPorts[0].ipFamilyPolicy
+					}
+					if exposeClusterIPPorts[0].sessionAffinity != _|_ {
+						sessionAffinity: exposeClusterIPPorts[0].sessionAffinity
+					}
+				}
+			}
+		}
+		if parameter.exposeType != "LoadBalancer" && len(exposeLoadBalancerPorts) != 0 {
+			statefulsetsExposeLoadBalancer: {
+				apiVersion: "v1"
+				kind:       "Service"
+				metadata: {
+					name: context.name + "-loadbalancer"
+				}
+				spec: {
+					selector: {
+						"app.oam.dev/component": context.name
+					}
+					ports: [for v in exposeLoadBalancerPorts {
+				{
+					if v.appProtocol != _|_ {
+						appProtocol: v.appProtocol
+					}
+					name: v.name
+					if v.nodePort != _|_ {
+						nodePort: v.nodePort
+					}
+					port: v.port
+					if v.protocol != _|_ {
+						protocol: v.protocol
+					}
+					targetPort: v.targetPort
+				}
+			}]
+					type: "LoadBalancer"
+					if exposeLoadBalancerPorts[0].externalTrafficPolicy != _|_ {
+						externalTrafficPolicy: exposeLoadBalancerPorts[0].externalTrafficPolicy
+					}
+					if exposeLoadBalancerPorts[0].ipFamilyPolicy != _|_ {
+						ipFamilyPolicy: exposeLoadBalancerPorts[0].ipFamilyPolicy
+					}
+					if exposeLoadBalancerPorts[0].sessionAffinity != _|_ {
+						sessionAffinity: exposeLoadBalancerPorts[0].sessionAffinity
+					}
+				}
+			}
+		}
+		if parameter.exposeType != "NodePort" && len(exposeNodePortPorts) != 0 {
+			statefulsetsExposeNodePort: {
+				apiVersion: "v1"
+				kind:       "Service"
+				metadata: {
+					name: context.name + "-nodeport"
+				}
+				spec: {
+					selector: {
+						"app.oam.dev/component": context.name
+					}
+					ports: [for v in exposeNodePortPorts {
+				{
+					if v.appProtocol != _|_ {
+						appProtocol: v.appProtocol
+					}
+					name: v.name
+					if v.nodePort != _|_ {
+						nodePort: v.nodePort
+					}
+					port: v.port
+					if v.protocol != _|_ {
+						protocol: v.protocol
+					}
+					targetPort: v.targetPort
+				}
+			}]
+					type: "NodePort"
+					if exposeNodePortPorts[0].externalTrafficPolicy != _|_ {
+						externalTrafficPolicy: exposeNodePortPorts[0].externalTrafficPolicy
+					}
+					if exposeNodePortPorts[0].ipFamilyPolicy != _|_ {
+						ipFamilyPolicy: exposeNodePortPorts[0].ipFamilyPolicy
+					}
+					if exposeNodePortPorts[0].sessionAffinity != _|_ {
+						sessionAffinity: exposeNodePortPorts[0].sessionAffinity
+					}
+				}
+			}
+		}
+		if parameter.governingService {
+			statefulsetsHeadless: {
+				apiVersion: "v1"
+				kind:       "Service"
+				metadata: {
+					name: context.name + "-headless"
+				}
+				spec: {
+					selector: {
+						"app.oam.dev/component": context.name
+					}
+					clusterIP: "None"
 				}
 			}
 		}
@@ -420,12 +963,54 @@ template: {
 			protocol: *"TCP" | "UDP" | "SCTP"
 			// +usage=Specify if the port should be exposed
 			expose: *false | bool
-			// +usage=exposed node port. Only Valid when exposeType is NodePort
+			// +usage=exposed node port. Only Valid when the Service of the port is NodePort
 			nodePort?: int
+			// +usage=Type of the Service exposing the port, defaults to exposeType. Ports of another type than exposeType are exposed by a Service named after the component and the type, like `web-nodeport`
+			serviceType?: "ClusterIP" | "NodePort" | "LoadBalancer"
+			// +usage=Application protocol of the port, like `http`, `https`, `kubernetes.io/h2c` or `grpc`. Ports with another protocol than HTTP cannot be routed
+			appProtocol?: string
+			// +usage=Route external traffic to node-local endpoints only to preserve the client source IP. Only valid for NodePort and LoadBalancer Services. Ports of the same Service must set it alike
+			externalTrafficPolicy?: "Cluster" | "Local"
+			// +usage=Send the requests of a client to the same pod. Ports of the same Service must set it alike
+			sessionAffinity?: "None" | "ClientIP"
+			// +usage=Assign the Service IPv4 and IPv6 cluster IPs on dual-stack clusters. Ports of the same Service must set it alike
+			ipFamilyPolicy?: "SingleStack" | "PreferDualStack" | "RequireDualStack"
 		}]
 		// +ignore
 		// +usage=Specify what kind of Service you want. options: "ClusterIP", "NodePort", "LoadBalancer"
 		exposeType: *"ClusterIP" | "NodePort" | "LoadBalancer"
+		// +usage=Route external HTTP traffic to an exposed TCP port with an HTTP appProtocol
+		route?: {
+			// +usage=Specify the route type, options: "Ingress","HTTPRoute", default to Ingress
+			type: *"Ingress" | "HTTPRoute"
+			if type == "Ingress" {
+				// +usage=Class of the Ingress, the cluster default if empty
+				className?: string
+				// +usage=Secret holding the TLS certificate of the hosts
+				tlsSecretName?: string
+			}
+			if type == "HTTPRoute" {
+				// +usage=Gateway the HTTPRoute attaches to, it terminates TLS
+				gateway: {
+					// +usage=Name of the Gateway
+					name: string
+					// +usage=Namespace of the Gateway, defaults to the namespace of the component
+					namespace?: string
+					// +usage=Listener of the Gateway to attach to
+					sectionName?: string
+				}
+			}
+			// +usage=Exposed HTTP port to route to, defaults to the first one
+			port?: int
+			// +usage=Host names to route, all of them if empty
+			hosts?: [...string]
+			// +usage=Path to route
+			path: *"/" | string
+			// +usage=Match the path as a prefix or exactly
+			pathType: *"Prefix" | "Exact"
+			// +usage=Annotations of the Ingress or HTTPRoute
+			annotations?: [string]: string
+		}
 		// +ignore
 		// +usage=If addRevisionLabel is true, the revision label will be added to the underlying pods
 		addRevisionLabel: *false | bool
@@ -551,6 +1136,217 @@ template: {
 			ip: string
 			hostnames: [...string]
 		}]
+		// +usage=Additional containers to run in the pod next to the main container
+		containers?: [...{
+			// +usage=Name of the container, unique within the pod
+			name: string
+			// +usage=Image of the container
+			image: string
+			// +usage=Specify image pull policy for the container
+			imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
+			// +usage=Commands to run in the container
+			cmd?: [...string]
+			// +usage=Arguments to the entrypoint
+			args?: [...string]
+			// +usage=Ports the container listens on
+			ports?: [...{
+				// +usage=Number of port to expose on the pod's IP address
+				containerPort: int
+				// +usage=Name of the port
+				name?: string
+				// +usage=Protocol for port. Must be UDP, TCP, or SCTP
+				protocol: *"TCP" | "UDP" | "SCTP"
+			}]
+			// +usage=Define arguments by using environment variables
+			env?: [...{
+				// +usage=Environment variable name
+				name: string
+				// +usage=The value of the environment variable
+				value?: string
+				// +usage=Specifies a source the value of this var should come from
+				valueFrom?: {
+					// +usage=Selects a key of a secret in the pod's namespace
+					secretKeyRef?: {
+						// +usage=The name of the secret in the pod's namespace to select from
+						name: string
+						// +usage=The key of the secret to select from. Must be a valid secret key
+						key: string
+					}
+					// +usage=Selects a key of a config map in the pod's namespace
+					configMapKeyRef?: {
+						// +usage=The name of the config map in the pod's namespace to select from
+						name: string
+						// +usage=The key of the config map to select from. Must be a valid secret key
+						key: string
+					}
+				}
+			}]
+			// +usage=Number of CPU units for the container, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+			cpu?: string
+			// +usage=Specifies the attributes of the memory resource required for the container.
+			memory?: string
+			// +usage=Instructions for assessing whether the container has started. Other probes wait until it succeeds.
+			startupProbe?: #HealthProbe
+			// +usage=Instructions for assessing whether the container is alive.
+			livenessProbe?: #HealthProbe
+			// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
+			readinessProbe?: #HealthProbe
+			// +usage=Mount pod volumes declared in volumeMounts into the container
+			volumeMounts?: [...{
+				// +usage=Name of the pod volume
+				name: string
+				// +usage=Path to mount the volume at
+				mountPath: string
+				// +usage=Mount only this path of the volume
+				subPath?: string
+				// +usage=Mount the volume read-only
+				readOnly?: bool
+			}]
+		}]
+		// +usage=Sidecar containers that start before and stop after the main container. They run as restartable init containers on Kubernetes 1.29 or later, and next to the main container otherwise
+		sidecars?: [...{
+			// +usage=Name of the container, unique within the pod
+			name: string
+			// +usage=Image of the container
+			image: string
+			// +usage=Specify image pull policy for the container
+			imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
+			// +usage=Commands to run in the container
+			cmd?: [...string]
+			// +usage=Arguments to the entrypoint
+			args?: [...string]
+			// +usage=Ports the container listens on
+			ports?: [...{
+				// +usage=Number of port to expose on the pod's IP address
+				containerPort: int
+				// +usage=Name of the port
+				name?: string
+				// +usage=Protocol for port. Must be UDP, TCP, or SCTP
+				protocol: *"TCP" | "UDP" | "SCTP"
+			}]
+			// +usage=Define arguments by using environment variables
+			env?: [...{
+				// +usage=Environment variable name
+				name: string
+				// +usage=The value of the environment variable
+				value?: string
+				// +usage=Specifies a source the value of this var should come from
+				valueFrom?: {
+					// +usage=Selects a key of a secret in the pod's namespace
+					secretKeyRef?: {
+						// +usage=The name of the secret in the pod's namespace to select from
+						name: string
+						// +usage=The key of the secret to select from. Must be a valid secret key
+						key: string
+					}
+					// +usage=Selects a key of a config map in the pod's namespace
+					configMapKeyRef?: {
+						// +usage=The name of the config map in the pod's namespace to select from
+						name: string
+						// +usage=The key of the config map to select from. Must be a valid secret key
+						key: string
+					}
+				}
+			}]
+			// +usage=Number of CPU units for the container, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+			cpu?: string
+			// +usage=Specifies the attributes of the memory resource required for the container.
+			memory?: string
+			// +usage=Instructions for assessing whether the container has started. Other probes wait until it succeeds.
+			startupProbe?: #HealthProbe
+			// +usage=Instructions for assessing whether the container is alive.
+			livenessProbe?: #HealthProbe
+			// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
+			readinessProbe?: #HealthProbe
+			// +usage=Mount pod volumes declared in volumeMounts into the container
+			volumeMounts?: [...{
+				// +usage=Name of the pod volume
+				name: string
+				// +usage=Path to mount the volume at
+				mountPath: string
+				// +usage=Mount only this path of the volume
+				subPath?: string
+				// +usage=Mount the volume read-only
+				readOnly?: bool
+			}]
+		}]
+		// +usage=Declare a PVC for every pod of the StatefulSet, mounted into the main container. Additional containers can mount it by name
+		volumeClaimTemplates?: [...{
+			// +usage=Name of the claim and of the pod volume
+			name: string
+			// +usage=Path to mount the volume at, or the device path when volumeMode is Block
+			mountPath: string
+			// +usage=Storage class of the claim, defaults to the cluster default
+			storageClass?: string
+			// +usage=Requested storage of each claim
+			size: *"8Gi" | string & =~"^([1-9][0-9]{0,63})(E|P|T|G|M|K|Ei|Pi|Ti|Gi|Mi|Ki)$"
+			// +usage=Access modes of the claim
+			accessModes: [...("ReadWriteOnce" | "ReadOnlyMany" | "ReadWriteMany" | "ReadWriteOncePod")] | *["ReadWriteOnce"]
+			// +usage=Mount the claim as a filesystem or attach it as a raw block device
+			volumeMode: *"Filesystem" | "Block"
+		}]
+		// +usage=Specify whether the PVCs of volumeClaimTemplates are deleted with the StatefulSet or when it is scaled down
+		persistentVolumeClaimRetentionPolicy?: {
+			// +usage=What happens to the PVCs when the StatefulSet is deleted
+			whenDeleted: *"Retain" | "Delete"
+			// +usage=What happens to the PVCs of removed pods when the StatefulSet is scaled down
+			whenScaled: *"Retain" | "Delete"
+		}
+		// +usage=Create and delete pods one by one in order, or all at once. Can't be changed after creation
+		podManagementPolicy?: "OrderedReady" | "Parallel"
+		// +usage=Specify the ordinals of the pod names
+		ordinals?: {
+			// +usage=Ordinal of the first pod
+			start: int & >=0
+		}
+		// +usage=Specify how pods are replaced when the pod template changes
+		updateStrategy?: {
+			// +usage=Replace pods automatically, or only when they are deleted
+			type: *"RollingUpdate" | "OnDelete"
+			// +usage=Only valid when type is RollingUpdate
+			rollingUpdate?: {
+				// +usage=Only pods with an ordinal greater than or equal to partition are updated
+				partition?: int & >=0
+				// +usage=Maximum number or percentage of pods that can be unavailable during the update
+				maxUnavailable?: #IntOrPercent
+			}
+		}
+		// +usage=Create a headless Service named <component>-headless and set it as the serviceName of the StatefulSet, giving every pod a stable DNS name. The serviceName of a StatefulSet can't be changed, so enable it when the component is created
+		governingService: *false | bool
+		if parameter["route"] != _|_ && !(parameter.route.port != _|_) {
+			_validateRoute: {
+				"route needs an exposed TCP port with an HTTP appProtocol": true
+				if len(ingressPorts) == 0 && len(httpRoutePorts) == 0 {
+					"route needs an exposed TCP port with an HTTP appProtocol": false
+				}
+			}
+		}
+		if parameter.route.port != _|_ {
+			_validateRoutePort: {
+				"route.port must be an exposed TCP port with an HTTP appProtocol": true
+				if len(ingressPorts) == 0 && len(httpRoutePorts) == 0 {
+					"route.port must be an exposed TCP port with an HTTP appProtocol": false
+				}
+			}
+		}
+		_validateSessionAffinity: {
+			"ports of the same Service must set the same sessionAffinity": true
+			if len([for a in exposedPorts for b in exposedPorts if a.serviceType == b.serviceType if (*a.sessionAffinity | "") != (*b.sessionAffinity | "") {a}]) > 0 {
+				"ports of the same Service must set the same sessionAffinity": false
+			}
+		}
+		_validateIpFamilyPolicy: {
+			"ports of the same Service must set the same ipFamilyPolicy": true
+			if len([for a in exposedPorts for b in exposedPorts if a.serviceType == b.serviceType if (*a.ipFamilyPolicy | "") != (*b.ipFamilyPolicy | "") {a}]) > 0 {
+				"ports of the same Service must set the same ipFamilyPolicy": false
+			}
+		}
+		_validateExternalTrafficPolicy: {
+			"ports of the same Service must set the same externalTrafficPolicy": true
+			if len([for a in exposedPorts for b in exposedPorts if a.serviceType == b.serviceType if (*a.externalTrafficPolicy | "") != (*b.externalTrafficPolicy | "") {a}]) > 0 {
+				"ports of the same Service must set the same externalTrafficPolicy": false
+			}
+		}
 	}
 	#HealthProbe: {
 		// +usage=Instructions for assessing container health by executing a command. Either this attribute or the httpGet attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the httpGet attribute and the tcpSocket attribute.
@@ -0,0 +1,302 @@
+import (
+	"strings"
+	"encoding/json"
+	"encoding/hex"
+	"crypto/sha256"
+)
+
+"static-site": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Serves static content, such as docs or status pages, with nginx from inline files or a ConfigMap."
+	attributes: {
+		workload: {
+			definition: {
+				apiVersion: "apps/v1"
+				kind:       "Deployment"
+			}
+			type: "deployments.apps"
+		}
+		status: {
+			customStatus: #"""
+				ready: {
+					readyReplicas: *0 | int
+				} & {
+					if context.output.status.readyReplicas != _|_ {
+						readyReplicas: context.output.status.readyReplicas
+					}
+				}
+				site: {
+					checksum: *"" | string
+				} & {
+					if context.output.spec.template.metadata.annotations["static-site.oam.dev/checksum"] != _|_ {
+						checksum: context.output.spec.template.metadata.annotations["static-site.oam.dev/checksum"]
+					}
+				}
+				_files: *"" | string
+				if context.output.spec.template.metadata.annotations["static-site.oam.dev/files"] != _|_ {
+					_files: ", files: \(context.output.spec.template.metadata.annotations["static-site.oam.dev/files"])"
+				}
+				message: "Ready:\(ready.readyReplicas)/\(context.output.spec.replicas)\(_files), checksum: \(site.checksum)"
+				"""#
+			healthPolicy: #"""
+				ready: {
+					updatedReplicas:    *0 | int
+					readyReplicas:      *0 | int
+					replicas:           *0 | int
+					observedGeneration: *0 | int
+				} & {
+					if context.output.status.updatedReplicas != _|_ {
+						updatedReplicas: context.output.status.updatedReplicas
+					}
+					if context.output.status.readyReplicas != _|_ {
+						readyReplicas: context.output.status.readyReplicas
+					}
+					if context.output.status.replicas != _|_ {
+						replicas: context.output.status.replicas
+					}
+					if context.output.status.observedGeneration != _|_ {
+						observedGeneration: context.output.status.observedGeneration
+					}
+				}
+				_isHealth: (context.output.spec.replicas == ready.readyReplicas) && (context.output.spec.replicas == ready.updatedReplicas) && (context.output.spec.replicas == ready.replicas) && (ready.observedGeneration == context.output.metadata.generation || ready.observedGeneration > context.output.metadata.generation)
+				isHealth: *_isHealth | bool
+				if context.output.metadata.annotations != _|_ {
+					if context.output.metadata.annotations["app.oam.dev/disable-health-check"] != _|_ {
+						isHealth: true
+					}
+				}
+				"""#
+		}
+	}
+}
+template: {
+	let _nginxConf = strings.Join([
+		"server {",
+		"\tlisten 8080;",
+		"\troot /usr/share/nginx/html;",
+		"\tindex index.html;",
+		if parameter.gzip {
+			"\tgzip on;\n\tgzip_min_length 1024;\n\tgzip_types text/plain text/css text/xml application/javascript application/json application/xml image/svg+xml;"
+		},
+		if parameter.basicAuth != _|_ {
+			"\tauth_basic \"\(parameter.basicAuth.realm)\";\n\tauth_basic_user_file /etc/nginx/auth/htpasswd;"
+		},
+		"\tlocation / {",
+		if parameter.spa {
+			"\t\ttry_files $uri $uri/ /index.html;"
+		},
+		if !parameter.spa {
+			"\t\ttry_files $uri $uri/ =404;"
+		},
+		if parameter.cacheControl != _|_ {
+			"\t\tadd_header Cache-Control \"\(parameter.cacheControl)\" always;"
+		},
+		"\t}",
+		"\tlocation = /healthz {",
+		"\t\tauth_basic off;",
+		"\t\taccess_log off;",
+		"\t\treturn 200 \"ok\";",
+		"\t}",
+		if parameter.nginxConfig != _|_ {
+			parameter.nginxConfig
+		},
+		"}",
+		"",
+	], "\n")
+	let _siteFiles = {
+		if parameter.existingConfigMap == _|_ && parameter.files != _|_ {
+			parameter.files
+		}
+		if parameter.existingConfigMap != _|_ || parameter.files == _|_ {
+			{}
+		}
+	}
+	let _siteKeys = {
+		for k, _ in _siteFiles {
+			(k): strings.Replace(strings.Replace(k, "_", "_.", -1), "/", "__", -1)
+		}
+	}
+	let _siteData = {
+		for k, v in _siteFiles {
+			(_siteKeys[k]): v
+		}
+	}
+	let _siteItems = [for k, _ in _siteFiles {
+		key:  _siteKeys[k]
+		path: k
+	}]
+	let _fileCount = "\(len(_siteFiles))"
+	let _checksum = strings.SliceRunes(hex.Encode(sha256.Sum256(_nginxConf + json.Marshal(_siteFiles))), 0, 16)
+	let _volumeMounts = [
+		{name: "nginx-config", mountPath: "/etc/nginx/conf.d"},
+		if parameter.existingConfigMap != _|_ || parameter.files != _|_ {
+			{name: "content", mountPath: "/usr/share/nginx/html"}
+		},
+		if parameter.basicAuth != _|_ {
+			{name: "auth", mountPath: "/etc/nginx/auth"}
+		},
+	]
+	let _volumes = [
+		{name: "nginx-config", configMap: name: "\(context.name)-nginx"},
+		if parameter.existingConfigMap != _|_ {
+			{name: "content", configMap: name: parameter.existingConfigMap}
+		},
+		if parameter.existingConfigMap == _|_ && parameter.files != _|_ {
+			{name: "content", configMap: {name: "\(context.name)-content", items: _siteItems}}
+		},
+		if parameter.basicAuth != _|_ {
+			{name: "auth", secret: {secretName: parameter.basicAuth.secretName, items: [{key: parameter.basicAuth.key, path: "htpasswd"}]}}
+		},
+	]
+	output: {
+		apiVersion: "apps/v1"
+		kind:       "Deployment"
+		spec: {
+			replicas: parameter.replicas
+			selector: {
+				matchLabels: {
+					"app.oam.dev/component": context.name
+				}
+			}
+			template: {
+				metadata: {
+					labels: {
+						if parameter["labels"] != _|_ {
+							parameter.labels
+						}
+						"app.oam.dev/name": context.appName
+						"app.oam.dev/component": context.name
+					}
+					annotations: {
+						if parameter["annotations"] != _|_ {
+							parameter.annotations
+						}
+						"static-site.oam.dev/checksum": _checksum
+						if parameter["existingConfigMap"] == _|_ && parameter["files"] != _|_ {
+							"static-site.oam.dev/files": _fileCount
+						}
+					}
+				}
+				spec: {
+					containers: [{
+						name: context.name
+						image: parameter.image
+						ports: [{
+							containerPort: 8080
+							name: "http"
+						}]
+						readinessProbe: {
+							httpGet: {
+								path: "/healthz"
+								port: "http"
+							}
+						}
+						if parameter["cpu"] != _|_ {
+							resources: {
+								limits: {
+									cpu: parameter.cpu
+								}
+								requests: {
+									cpu: parameter.cpu
+								}
+							}
+						}
+						if parameter["memory"] != _|_ {
+							resources: {
+								limits: {
+									memory: parameter.memory
+								}
+								requests: {
+									memory: parameter.memory
+								}
+							}
+						}
+						volumeMounts: _volumeMounts
+					}]
+					volumes: _volumes
+				}
+			}
+		}
+	}
+	outputs: {
+		if parameter["existingConfigMap"] == _|_ && parameter["files"] != _|_ {
+			content: {
+				apiVersion: "v1"
+				kind:       "ConfigMap"
+				metadata: {
+					name: "\(context.name)-content"
+				}
+				data: _siteData
+			}
+		}
+		nginxConfig: {
+			apiVersion: "v1"
+			kind:       "ConfigMap"
+			metadata: {
+				name: "\(context.name)-nginx"
+			}
+			data: {
+				"default.conf": _nginxConf
+			}
+		}
+		service: {
+			apiVersion: "v1"
+			kind:       "Service"
+			metadata: {
+				name: context.name
+			}
+			spec: {
+				selector: {
+					"app.oam.dev/component": context.name
+				}
+				ports: [{
+					name: "http"
+					port: parameter.port
+					targetPort: "http"
+				}]
+				type: parameter.exposeType
+			}
+		}
+	}
+	parameter: {
+		// +usage=Specify the labels in the workload
+		labels?: [string]: string
+		// +usage=Specify the annotations in the workload
+		annotations?: [string]: string
+		// +usage=nginx image serving the site
+		image: *"nginx:1.27-alpine" | string
+		// +usage=Number of nginx replicas
+		replicas: *1 | int
+		// +usage=Content of the site as a map of path to content, like `index.html` or `css/site.css`. Stored in a ConfigMap, so the total size is limited to 1MiB
+		files?: [string]: string
+		// +usage=Serve the keys of an existing ConfigMap as files instead of the inline files. Changes to its content do not restart the pods
+		existingConfigMap?: string
+		// +usage=Serve index.html for paths that match no file, for single-page applications with client-side routing
+		spa: *false | bool
+		// +usage=Value of the Cache-Control header of the responses, like `public, max-age=3600`
+		cacheControl?: string
+		// +usage=Compress text responses with gzip
+		gzip: *true | bool
+		// +usage=Extra nginx directives added to the server block, like additional location blocks or headers
+		nginxConfig?: string
+		// +usage=Protect the site with basic authentication
+		basicAuth?: {
+			// +usage=Secret holding the users in htpasswd format
+			secretName: string
+			// +usage=Key of the htpasswd content in the Secret
+			key: *"auth" | string
+			// +usage=Realm shown by the browser when asking for credentials
+			realm: *"Restricted" | string
+		}
+		// +usage=Port of the Service
+		port: *80 | int
+		// +usage=Specify what kind of Service you want. options: "ClusterIP", "NodePort", "LoadBalancer"
+		exposeType: *"ClusterIP" | "NodePort" | "LoadBalancer"
+		// +usage=Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+		cpu?: string
+		// +usage=Specifies the attributes of the memory resource required for the container.
+		memory?: string
+	}
+}
@@ -28,14 +28,22 @@ task: {
 						succeeded: context.output.status.succeeded
 					}
 				}
-				message: "Active/Failed/Succeeded:\(status.active)/\(status.failed)/\(status.succeeded)"
+				_indexes: *"" | string
+				if context.output.status.completedIndexes != _|_ if context.output.status.completedIndexes != "" {
+					_indexes: ", completed indexes: \(context.output.status.completedIndexes)"
+				}
+				_failedIndexes: *"" | string
+				if context.output.status.failedIndexes != _|_ if context.output.status.failedIndexes != "" {
+					_failedIndexes: ", failed indexes: \(context.output.status.failedIndexes)"
+				}
+				message: "Active/Failed/Succeeded:\(status.active)/\(status.failed)/\(status.succeeded)\(_indexes)\(_failedIndexes)"
 				"""#
 			healthPolicy: #"""
 				succeeded: *0 | int
 				if context.output.status.succeeded != _|_ {
 					succeeded: context.output.status.succeeded
 				}
-				isHealth: succeeded == context.output.spec.parallelism
+				isHealth: succeeded == context.output.spec.completions
 				"""#
 		}
 	}
@@ -48,8 +56,18 @@ template: {
 			name: "\(context.appName)-\(context.name)"
 		}
 		spec: {
-			parallelism: parameter.count
-			completions: parameter.count
+			if parameter["parallelism"] != _|_ {
+				parallelism: parameter.parallelism
+			}
+			if parameter["parallelism"] == _|_ {
+				parallelism: parameter.count
+			}
+			if parameter["completions"] != _|_ {
+				completions: parameter.completions
+			}
+			if parameter["completions"] == _|_ {
+				completions: parameter.count
+			}
 			template: {
 				metadata: {
 					labels: {
@@ -109,6 +127,56 @@ template: {
 					if parameter["imagePullSecrets"] != _|_ {
 						imagePullSecrets: [for v in parameter.imagePullSecrets { name: v }]
 					}
+					if parameter["sidecars"] != _|_ if len(parameter["sidecars"]) > 0 {
+						initContainers: [
+		for m in parameter.sidecars {
+			{
+				image: m.image
+				name: m.name
+				restartPolicy: "Always"
+				if m.imagePullPolicy != _|_ {
+					imagePullPolicy: m.imagePullPolicy
+				}
+				if m.cmd != _|_ {
+					command: m.cmd
+				}
+				if m.args != _|_ {
+					args: m.args
+				}
+				if m.ports != _|_ {
+					ports: m.ports
+				}
+				if m.env != _|_ {
+					env: m.env
+				}
+				if m.cpu != _|_ {
+					resources: limits: cpu: m.cpu
+				}
+				if m.cpu != _|_ {
+					resources: requests: cpu: m.cpu
+				}
+				if m.memory != _|_ {
+					resources: limits: memory: m.memory
+				}
+				if m.memory != _|_ {
+					resources: requests: memory: m.memory
+				}
+				if m.startupProbe != _|_ {
+					startupProbe: m.startupProbe
+				}
+				if m.livenessProbe != _|_ {
+					livenessProbe: m.livenessProbe
+				}
+				if m.readinessProbe != _|_ {
+					readinessProbe: m.readinessProbe
+				}
+				if m.volumeMounts != _|_ {
+					volumeMounts: m.volumeMounts
+				}
+			}
+		},
+	]
+					}
 					if parameter["volumes"] != _|_ {
 						volumes: [for v in parameter.volumes {
 				{
@@ -146,6 +214,32 @@ template: {
 					}
 				}
 			}
+			if parameter["activeDeadlineSeconds"] != _|_ {
+				activeDeadlineSeconds: parameter.activeDeadlineSeconds
+			}
+			if parameter["backoffLimitPerIndex"] != _|_ {
+				backoffLimitPerIndex: parameter.backoffLimitPerIndex
+			}
+			if parameter["completionMode"] != _|_ {
+				completionMode: parameter.completionMode
+			}
+			if parameter["maxFailedIndexes"] != _|_ {
+				maxFailedIndexes: parameter.maxFailedIndexes
+			}
+			if parameter["podFailurePolicy"] != _|_ {
+				podFailurePolicy: {
+					rules: parameter.podFailurePolicy
+				}
+			}
+			if parameter["podReplacementPolicy"] != _|_ {
+				podReplacementPolicy: parameter.podReplacementPolicy
+			}
+			if parameter["suspend"] != _|_ {
+				suspend: parameter.suspend
+			}
+			if parameter["ttlSecondsAfterFinished"] != _|_ {
+				ttlSecondsAfterFinished: parameter.ttlSecondsAfterFinished
+			}
 		}
 	}
 	parameter: {
@@ -230,6 +324,120 @@ template: {
 		livenessProbe?: #HealthProbe
 		// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
 		readinessProbe?: #HealthProbe
+		// +usage=Sidecar containers that start before and stop after the main container, so the job completes once the main container exits. Requires Kubernetes 1.29 or later
+		sidecars?: [...{
+			// +usage=Name of the container, unique within the pod
+			name: string
+			// +usage=Image of the container
+			image: string
+			// +usage=Specify image pull policy for the container
+			imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
+			// +usage=Commands to run in the container
+			cmd?: [...string]
+			// +usage=Arguments to the entrypoint
+			args?: [...string]
+			// +usage=Ports the container listens on
+			ports?: [...{
+				// +usage=Number of port to expose on the pod's IP address
+				containerPort: int
+				// +usage=Name of the port
+				name?: string
+				// +usage=Protocol for port. Must be UDP, TCP, or SCTP
+				protocol: *"TCP" | "UDP" | "SCTP"
+			}]
+			// +usage=Define arguments by using environment variables
+			env?: [...{
+				// +usage=Environment variable name
+				name: string
+				// +usage=The value of the environment variable
+				value?: string
+				// +usage=Specifies a source the value of this var should come from
+				valueFrom?: {
+					// +usage=Selects a key of a secret in the pod's namespace
+					secretKeyRef?: {
+						// +usage=The name of the secret in the pod's namespace to select from
+						name: string
+						// +usage=The key of the secret to select from. Must be a valid secret key
+						key: string
+					}
+					// +usage=Selects a key of a config map in the pod's namespace
+					configMapKeyRef?: {
+						// +usage=The name of the config map in the pod's namespace to select from
+						name: string
+						// +usage=The key of the config map to select from. Must be a valid secret key
+						key: string
+					}
+				}
+			}]
+			// +usage=Number of CPU units for the container, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+			cpu?: string
+			// +usage=Specifies the attributes of the memory resource required for the container.
+			memory?: string
+			// +usage=Instructions for assessing whether the container has started. Other probes wait until it succeeds.
+			startupProbe?: #HealthProbe
+			// +usage=Instructions for assessing whether the container is alive.
+			livenessProbe?: #HealthProbe
+			// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
+			readinessProbe?: #HealthProbe
+			// +usage=Mount pod volumes declared in volumeMounts into the container
+			volumeMounts?: [...{
+				// +usage=Name of the pod volume
+				name: string
+				// +usage=Path to mount the volume at
+				mountPath: string
+				// +usage=Mount only this path of the volume
+				subPath?: string
+				// +usage=Mount the volume read-only
+				readOnly?: bool
+			}]
+		}]
+		// +usage=Number of pods running at the same time, overrides count
+		parallelism?: int
+		// +usage=Number of successful pods the job needs to complete, overrides count. In Indexed mode, pods get the indexes 0 to completions-1
+		completions?: int
+		// +usage=Use Indexed to give each pod a completion index, exposed as the JOB_COMPLETION_INDEX environment variable
+		completionMode?: "NonIndexed" | "Indexed"
+		// +usage=Number of retries of each index before it is marked as failed. Only valid in Indexed mode
+		backoffLimitPerIndex?: int
+		// +usage=Number of failed indexes after which the whole job fails. Requires backoffLimitPerIndex
+		maxFailedIndexes?: int
+		// +usage=Rules deciding how pod failures are handled, the first matching rule applies. Requires restart to be Never
+		podFailurePolicy?: [...{
+			// +usage=Action taken when the rule matches: fail the job, fail the index, ignore the failure or count it against the backoff limit
+			action: "FailJob" | "FailIndex" | "Ignore" | "Count"
+			// +usage=Match container exit codes
+			onExitCodes?: {
+				// +usage=Only match the exit codes of this container
+				containerName?: string
+				// +usage=Match the exit codes in or not in values
+				operator: "In" | "NotIn"
+				// +usage=Exit codes to match
+				values: [...int]
+			}
+			// +usage=Match pod conditions, such as DisruptionTarget
+			onPodConditions?: [...{
+				// +usage=Type of the pod condition
+				type: string
+				// +usage=Status of the pod condition
+				status: *"True" | "False" | "Unknown"
+			}]
+		}]
+		// +usage=Delete the job this many seconds after it finishes
+		ttlSecondsAfterFinished?: int
+		// +usage=Fail the job once it has been running for this many seconds
+		activeDeadlineSeconds?: int
+		// +usage=Create the job suspended, no pods run until it is resumed
+		suspend?: bool
+		// +usage=When to create replacement pods: as soon as a pod is terminating, or only once it has fully failed
+		podReplacementPolicy?: "TerminatingOrFailed" | "Failed"
+		if parameter["sidecars"] != _|_ if len(parameter["sidecars"]) > 0 {
+			_validateSidecars: {
+				"sidecars require Kubernetes 1.29 or later": true
+				if !(context.clusterVersion.minor >= 29) {
+					"sidecars require Kubernetes 1.29 or later": false
+				}
+			}
+		}
 	}
 	#HealthProbe: {
 		// +usage=Instructions for assessing container health by executing a command. Either this attribute or the httpGet attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the httpGet attribute and the tcpSocket attribute.
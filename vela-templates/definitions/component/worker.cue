@@ -159,6 +159,7 @@ template: {
 			val
 		},
 	]
+	#IntOrPercent: int & >=0 | =~"^[0-9]+%$"
 	output: {
 		apiVersion: "apps/v1"
 		kind:       "Deployment"
@@ -176,56 +177,140 @@ template: {
 					}
 				}
 				spec: {
-					containers: [{
-						name: context.name
-						image: parameter.image
-						if parameter["cpu"] != _|_ {
-							resources: {
-								limits: {
-									cpu: parameter.cpu
-								}
-								requests: {
-									cpu: parameter.cpu
-								}
-							}
-						}
-						if parameter["memory"] != _|_ {
-							resources: {
-								limits: {
-									memory: parameter.memory
-								}
-								requests: {
-									memory: parameter.memory
+					containers: [
+		{
+			image: parameter.image
+			name: context.name
+			if parameter["imagePullPolicy"] != _|_ {
+				imagePullPolicy: parameter.imagePullPolicy
+			}
+			if parameter["cmd"] != _|_ {
+				command: parameter.cmd
+			}
+			if parameter["env"] != _|_ {
+				env: parameter.env
+			}
+			if parameter["cpu"] != _|_ {
+				resources: limits: cpu: parameter.cpu
+			}
+			if parameter["cpu"] != _|_ {
+				resources: requests: cpu: parameter.cpu
+			}
+			if parameter["memory"] != _|_ {
+				resources: limits: memory: parameter.memory
+			}
+			if parameter["memory"] != _|_ {
+				resources: requests: memory: parameter.memory
+			}
+			if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
+				volumeMounts: [for v in parameter.volumes {
+								{
+									mountPath: v.mountPath
+									name: v.name
 								}
-							}
-						}
-						if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
-							volumeMounts: [for v in parameter.volumes {
-				{
-					mountPath: v.mountPath
-					name: v.name
+							}]
+			}
+			if parameter["volumeMounts"] != _|_ {
+				volumeMounts: mountsArray
+			}
+			if parameter["livenessProbe"] != _|_ {
+				livenessProbe: parameter.livenessProbe
+			}
+			if parameter["readinessProbe"] != _|_ {
+				readinessProbe: parameter.readinessProbe
+			}
+		},
+		if parameter["containers"] != _|_ for m in parameter.containers {
+			{
+				image: m.image
+				name: m.name
+				if m.imagePullPolicy != _|_ {
+					imagePullPolicy: m.imagePullPolicy
 				}
-			}]
-						}
-						if parameter["volumeMounts"] != _|_ {
-							volumeMounts: mountsArray
-						}
-						if parameter["cmd"] != _|_ {
-							command: parameter.cmd
-						}
-						if parameter["env"] != _|_ {
-							env: parameter.env
-						}
-						if parameter["imagePullPolicy"] != _|_ {
-							imagePullPolicy: parameter.imagePullPolicy
-						}
-						if parameter["livenessProbe"] != _|_ {
-							livenessProbe: parameter.livenessProbe
-						}
-						if parameter["readinessProbe"] != _|_ {
-							readinessProbe: parameter.readinessProbe
-						}
-					}]
+				if m.cmd != _|_ {
+					command: m.cmd
+				}
+				if m.args != _|_ {
+					args: m.args
+				}
+				if m.ports != _|_ {
+					ports: m.ports
+				}
+				if m.env != _|_ {
+					env: m.env
+				}
+				if m.cpu != _|_ {
+					resources: limits: cpu: m.cpu
+				}
+				if m.cpu != _|_ {
+					resources: requests: cpu: m.cpu
+				}
+				if m.memory != _|_ {
+					resources: limits: memory: m.memory
+				}
+				if m.memory != _|_ {
+					resources: requests: memory: m.memory
+				}
+				if m.startupProbe != _|_ {
+					startupProbe: m.startupProbe
+				}
+				if m.livenessProbe != _|_ {
+					livenessProbe: m.livenessProbe
+				}
+				if m.readinessProbe != _|_ {
+					readinessProbe: m.readinessProbe
+				}
+				if m.volumeMounts != _|_ {
+					volumeMounts: m.volumeMounts
+				}
+			}
+		},
+		if parameter["sidecars"] != _|_ if len(parameter["sidecars"]) > 0 if !(context.clusterVersion.minor >= 29) for m in parameter.sidecars {
+			{
+				image: m.image
+				name: m.name
+				if m.imagePullPolicy != _|_ {
+					imagePullPolicy: m.imagePullPolicy
+				}
+				if m.cmd != _|_ {
+					command: m.cmd
+				}
+				if m.args != _|_ {
+					args: m.args
+				}
+				if m.ports != _|_ {
+					ports: m.ports
+				}
+				if m.env != _|_ {
+					env: m.env
+				}
+				if m.cpu != _|_ {
+					resources: limits: cpu: m.cpu
+				}
+				if m.cpu != _|_ {
+					resources: requests: cpu: m.cpu
+				}
+				if m.memory != _|_ {
+					resources: limits: memory: m.memory
+				}
+				if m.memory != _|_ {
+					resources: requests: memory: m.memory
+				}
+				if m.startupProbe != _|_ {
+					startupProbe: m.startupProbe
+				}
+				if m.livenessProbe != _|_ {
+					livenessProbe: m.livenessProbe
+				}
+				if m.readinessProbe != _|_ {
+					readinessProbe: m.readinessProbe
+				}
+				if m.volumeMounts != _|_ {
+					volumeMounts: m.volumeMounts
+				}
+			}
+		},
+	]
 					if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
 						volumes: [for v in parameter.volumes {
 				{
@@ -267,8 +352,85 @@ template: {
 					if parameter["imagePullSecrets"] != _|_ {
 						imagePullSecrets: [for v in parameter.imagePullSecrets { name: v }]
 					}
+					if parameter["nodeSelector"] != _|_ {
+						nodeSelector: *parameter.nodeSelector | {...}
+					}
+					if parameter["priorityClassName"] != _|_ {
+						priorityClassName: *parameter.priorityClassName | string
+					}
+					if parameter["serviceAccountName"] != _|_ {
+						serviceAccountName: *parameter.serviceAccountName | string
+					}
+					if parameter["sidecars"] != _|_ if len(parameter["sidecars"]) > 0 if context.clusterVersion.minor >= 29 {
+						initContainers: [
+		for m in parameter.sidecars {
+			{
+				image: m.image
+				name: m.name
+				restartPolicy: "Always"
+				if m.imagePullPolicy != _|_ {
+					imagePullPolicy: m.imagePullPolicy
+				}
+				if m.cmd != _|_ {
+					command: m.cmd
+				}
+				if m.args != _|_ {
+					args: m.args
+				}
+				if m.ports != _|_ {
+					ports: m.ports
+				}
+				if m.env != _|_ {
+					env: m.env
+				}
+				if m.cpu != _|_ {
+					resources: limits: cpu: m.cpu
+				}
+				if m.cpu != _|_ {
+					resources: requests: cpu: m.cpu
+				}
+				if m.memory != _|_ {
+					resources: limits: memory: m.memory
+				}
+				if m.memory != _|_ {
+					resources: requests: memory: m.memory
+				}
+				if m.startupProbe != _|_ {
+					startupProbe: m.startupProbe
+				}
+				if m.livenessProbe != _|_ {
+					livenessProbe: m.livenessProbe
+				}
+				if m.readinessProbe != _|_ {
+					readinessProbe: m.readinessProbe
+				}
+				if m.volumeMounts != _|_ {
+					volumeMounts: m.volumeMounts
 				}
 			}
+		},
+	]
+					}
+					if parameter["terminationGracePeriodSeconds"] != _|_ {
+						terminationGracePeriodSeconds: *parameter.terminationGracePeriodSeconds | int
+					}
+					if parameter["tolerations"] != _|_ {
+						tolerations: *parameter.tolerations | [...]
+					}
+				}
+			}
+			if parameter["minReadySeconds"] != _|_ {
+				minReadySeconds: *parameter.minReadySeconds | int
+			}
+			if parameter["progressDeadlineSeconds"] != _|_ {
+				progressDeadlineSeconds: *parameter.progressDeadlineSeconds | int
+			}
+			if parameter["revisionHistoryLimit"] != _|_ {
+				revisionHistoryLimit: *parameter.revisionHistoryLimit | int
+			}
+			if parameter["strategy"] != _|_ {
+				strategy: *parameter.strategy | {...}
+			}
 		}
 	}
 	parameter: {
@@ -389,6 +551,179 @@ template: {
 		livenessProbe?: #HealthProbe
 		// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
 		readinessProbe?: #HealthProbe
+		// +usage=Additional containers to run in the pod next to the main container
+		containers?: [...{
+			// +usage=Name of the container, unique within the pod
+			name: string
+			// +usage=Image of the container
+			image: string
+			// +usage=Specify image pull policy for the container
+			imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
+			// +usage=Commands to run in the container
+			cmd?: [...string]
+			// +usage=Arguments to the entrypoint
+			args?: [...string]
+			// +usage=Ports the container listens on
+			ports?: [...{
+				// +usage=Number of port to expose on the pod's IP address
+				containerPort: int
+				// +usage=Name of the port
+				name?: string
+				// +usage=Protocol for port. Must be UDP, TCP, or SCTP
+				protocol: *"TCP" | "UDP" | "SCTP"
+			}]
+			// +usage=Define arguments by using environment variables
+			env?: [...{
+				// +usage=Environment variable name
+				name: string
+				// +usage=The value of the environment variable
+				value?: string
+				// +usage=Specifies a source the value of this var should come from
+				valueFrom?: {
+					// +usage=Selects a key of a secret in the pod's namespace
+					secretKeyRef?: {
+						// +usage=The name of the secret in the pod's namespace to select from
+						name: string
+						// +usage=The key of the secret to select from. Must be a valid secret key
+						key: string
+					}
+					// +usage=Selects a key of a config map in the pod's namespace
+					configMapKeyRef?: {
+						// +usage=The name of the config map in the pod's namespace to select from
+						name: string
+						// +usage=The key of the config map to select from. Must be a valid secret key
+						key: string
+					}
+				}
+			}]
+			// +usage=Number of CPU units for the container, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+			cpu?: string
+			// +usage=Specifies the attributes of the memory resource required for the container.
+			memory?: string
+			// +usage=Instructions for assessing whether the container has started. Other probes wait until it succeeds.
+			startupProbe?: #HealthProbe
+			// +usage=Instructions for assessing whether the container is alive.
+			livenessProbe?: #HealthProbe
+			// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
+			readinessProbe?: #HealthProbe
+			// +usage=Mount pod volumes declared in volumeMounts into the container
+			volumeMounts?: [...{
+				// +usage=Name of the pod volume
+				name: string
+				// +usage=Path to mount the volume at
+				mountPath: string
+				// +usage=Mount only this path of the volume
+				subPath?: string
+				// +usage=Mount the volume read-only
+				readOnly?: bool
+			}]
+		}]
+		// +usage=Sidecar containers that start before and stop after the main container. They run as restartable init containers on Kubernetes 1.29 or later, and next to the main container otherwise
+		sidecars?: [...{
+			// +usage=Name of the container, unique within the pod
+			name: string
+			// +usage=Image of the container
+			image: string
+			// +usage=Specify image pull policy for the container
+			imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
+			// +usage=Commands to run in the container
+			cmd?: [...string]
+			// +usage=Arguments to the entrypoint
+			args?: [...string]
+			// +usage=Ports the container listens on
+			ports?: [...{
+				// +usage=Number of port to expose on the pod's IP address
+				containerPort: int
+				// +usage=Name of the port
+				name?: string
+				// +usage=Protocol for port. Must be UDP, TCP, or SCTP
+				protocol: *"TCP" | "UDP" | "SCTP"
+			}]
+			// +usage=Define arguments by using environment variables
+			env?: [...{
+				// +usage=Environment variable name
+				name: string
+				// +usage=The value of the environment variable
+				value?: string
+				// +usage=Specifies a source the value of this var should come from
+				valueFrom?: {
+					// +usage=Selects a key of a secret in the pod's namespace
+					secretKeyRef?: {
+						// +usage=The name of the secret in the pod's namespace to select from
+						name: string
+						// +usage=The key of the secret to select from. Must be a valid secret key
+						key: string
+					}
+					// +usage=Selects a key of a config map in the pod's namespace
+					configMapKeyRef?: {
+						// +usage=The name of the config map in the pod's namespace to select from
+						name: string
+						// +usage=The key of the config map to select from. Must be a valid secret key
+						key: string
+					}
+				}
+			}]
+			// +usage=Number of CPU units for the container, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+			cpu?: string
+			// +usage=Specifies the attributes of the memory resource required for the container.
+			memory?: string
+			// +usage=Instructions for assessing whether the container has started. Other probes wait until it succeeds.
+			startupProbe?: #HealthProbe
+			// +usage=Instructions for assessing whether the container is alive.
+			livenessProbe?: #HealthProbe
+			// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
+			readinessProbe?: #HealthProbe
+			// +usage=Mount pod volumes declared in volumeMounts into the container
+			volumeMounts?: [...{
+				// +usage=Name of the pod volume
+				name: string
+				// +usage=Path to mount the volume at
+				mountPath: string
+				// +usage=Mount only this path of the volume
+				subPath?: string
+				// +usage=Mount the volume read-only
+				readOnly?: bool
+			}]
+		}]
+		// +usage=Specify how pods are replaced when the pod template changes
+		strategy?: {
+			// +usage=Replace pods gradually, or delete all of them before creating new ones
+			type: *"RollingUpdate" | "Recreate"
+			// +usage=Only valid when type is RollingUpdate
+			rollingUpdate?: {
+				// +usage=Maximum number or percentage of pods created over the desired replicas, defaults to 25%
+				maxSurge?: #IntOrPercent
+				// +usage=Maximum number or percentage of pods unavailable during the update, defaults to 25%
+				maxUnavailable?: #IntOrPercent
+			}
+		}
+		// +usage=Number of seconds a new pod must be ready without crashing to count as available
+		minReadySeconds?: int & >=0
+		// +usage=Number of seconds the rollout may make no progress before it is reported as failed
+		progressDeadlineSeconds?: int & >=1
+		// +usage=Number of old ReplicaSets kept to allow a rollback
+		revisionHistoryLimit?: int & >=0
+		// +usage=Number of seconds the pod is given to shut down gracefully before it is killed
+		terminationGracePeriodSeconds?: int & >=0
+		// +usage=Name of the PriorityClass of the pods
+		priorityClassName?: string
+		// +usage=Only schedule the pods on nodes with all of these labels
+		nodeSelector?: [string]: string
+		// +usage=Allow the pods to be scheduled on nodes with matching taints
+		tolerations?: [...{
+			// +usage=Taint key to tolerate, all keys when empty and operator is Exists
+			key?: string
+			// +usage=Whether the taint value must equal value, or any value is tolerated
+			operator: *"Equal" | "Exists"
+			// +usage=Taint value to tolerate when operator is Equal
+			value?: string
+			// +usage=Taint effect to tolerate, all effects when empty
+			effect?: "NoSchedule" | "PreferNoSchedule" | "NoExecute"
+			// +usage=Number of seconds the pod stays bound to a node tainted NoExecute
+			tolerationSeconds?: int
+		}]
+		// +usage=Name of the ServiceAccount the pods run as
+		serviceAccountName?: string
 	}
 	#HealthProbe: {
 		// +usage=Instructions for assessing container health by executing a command. Either this attribute or the httpGet attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the httpGet attribute and the tcpSocket attribute.
@@ -134,9 +134,11 @@ template: {
 			}
 			if parameter.secretName != _|_ {
 				tls: [{
-					hosts: [
-						parameter.domain,
-					]
+					if parameter.domain != _|_ {
+						hosts: [
+							parameter.domain,
+						]
+					}
 					secretName: parameter.secretName
 				}]
 			}
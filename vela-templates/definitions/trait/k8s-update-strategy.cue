@@ -16,7 +16,7 @@ template: {
 			// +patchStrategy=retainKeys
 			strategy: {
 				type: parameter.strategy.type
-				if parameter.strategy.type == "RollingUpdate" {
+				if parameter.strategy.type == "RollingUpdate" && parameter.strategy.rollingStrategy != _|_ {
 					rollingUpdate: {
 						maxSurge:       parameter.strategy.rollingStrategy.maxSurge
 						maxUnavailable: parameter.strategy.rollingStrategy.maxUnavailable
@@ -28,7 +28,7 @@ template: {
 			// +patchStrategy=retainKeys
 			updateStrategy: {
 				type: parameter.strategy.type
-				if parameter.strategy.type == "RollingUpdate" {
+				if parameter.strategy.type == "RollingUpdate" && parameter.strategy.rollingStrategy != _|_ {
 					rollingUpdate: partition: parameter.strategy.rollingStrategy.partition
 				}
 			}
@@ -37,7 +37,7 @@ template: {
 			// +patchStrategy=retainKeys
 			updateStrategy: {
 				type: parameter.strategy.type
-				if parameter.strategy.type == "RollingUpdate" {
+				if parameter.strategy.type == "RollingUpdate" && parameter.strategy.rollingStrategy != _|_ {
 					rollingUpdate: {
 						maxSurge:       parameter.strategy.rollingStrategy.maxSurge
 						maxUnavailable: parameter.strategy.rollingStrategy.maxUnavailable
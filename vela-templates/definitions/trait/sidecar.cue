@@ -9,35 +9,108 @@ sidecar: {
 	}
 }
 template: {
-	patch: spec: template: spec: {
-		// +patchKey=name
-		containers: [{
-			image: parameter.image
-			name:  parameter.name
-			if parameter["cmd"] != _|_ {
-				command: parameter.cmd
-			}
-			if parameter["args"] != _|_ {
-				args: parameter.args
-			}
-			if parameter["env"] != _|_ {
-				env: parameter.env
-			}
-			if parameter["volumes"] != _|_ {
-				volumeMounts: [for v in parameter.volumes {
-					{
-						mountPath: v.path
-						name:      v.name
+	patch: {
+		if parameter.native && context.clusterVersion.minor >= 29 {
+			spec: template: spec: {
+				// +patchKey=name
+				initContainers: [{
+					image:         parameter.image
+					name:          parameter.name
+					restartPolicy: "Always"
+					if parameter["cmd"] != _|_ {
+						command: parameter.cmd
+					}
+					if parameter["args"] != _|_ {
+						args: parameter.args
+					}
+					if parameter["env"] != _|_ {
+						env: parameter.env
+					}
+					if parameter["volumes"] != _|_ {
+						volumeMounts: [for v in parameter.volumes {
+							{
+								mountPath: v.path
+								name:      v.name
+							}
+						}]
+					}
+					if parameter["cpu"] != _|_ {
+						resources: limits: cpu: parameter.cpu
+					}
+					if parameter["cpu"] != _|_ {
+						resources: requests: cpu: parameter.cpu
+					}
+					if parameter["memory"] != _|_ {
+						resources: limits: memory: parameter.memory
+					}
+					if parameter["memory"] != _|_ {
+						resources: requests: memory: parameter.memory
+					}
+					if parameter["startupProbe"] != _|_ {
+						startupProbe: parameter.startupProbe
+					}
+					if parameter["livenessProbe"] != _|_ {
+						livenessProbe: parameter.livenessProbe
+					}
+					if parameter["readinessProbe"] != _|_ {
+						readinessProbe: parameter.readinessProbe
 					}
 				}]
 			}
-			if parameter["livenessProbe"] != _|_ {
-				livenessProbe: parameter.livenessProbe
-			}
-			if parameter["readinessProbe"] != _|_ {
-				readinessProbe: parameter.readinessProbe
+		}
+		if !(parameter.native && context.clusterVersion.minor >= 29) {
+			spec: template: spec: {
+				// +patchKey=name
+				containers: [{
+					image: parameter.image
+					name:  parameter.name
+					if parameter["cmd"] != _|_ {
+						command: parameter.cmd
+					}
+					if parameter["args"] != _|_ {
+						args: parameter.args
+					}
+					if parameter["env"] != _|_ {
+						env: parameter.env
+					}
+					if parameter["volumes"] != _|_ {
+						volumeMounts: [for v in parameter.volumes {
+							{
+								mountPath: v.path
+								name:      v.name
+							}
+						}]
+					}
+					if parameter["cpu"] != _|_ {
+						resources: limits: cpu: parameter.cpu
+					}
+					if parameter["cpu"] != _|_ {
+						resources: requests: cpu: parameter.cpu
+					}
+					if parameter["memory"] != _|_ {
+						resources: limits: memory: parameter.memory
+					}
+					if parameter["memory"] != _|_ {
+						resources: requests: memory: parameter.memory
+					}
+					if parameter["startupProbe"] != _|_ {
+						startupProbe: parameter.startupProbe
+					}
+					if parameter["livenessProbe"] != _|_ {
+						livenessProbe: parameter.livenessProbe
+					}
+					if parameter["readinessProbe"] != _|_ {
+						readinessProbe: parameter.readinessProbe
+					}
+					if parameter.native {
+						_nativeSidecars: true
+					}
+					if parameter.native && context.clusterVersion.minor < 29 && context.output.kind == "Job" {
+						_nativeSidecars: "native sidecars of Jobs require Kubernetes 1.29 or later"
+					}
+				}]
 			}
-		}]
+		}
 	}
 	parameter: {
 		// +usage=Specify the name of sidecar container
@@ -82,6 +155,14 @@ template: {
 			name: string
 			path: string
 		}]
+		// +usage=Number of CPU units for the sidecar, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+		cpu?: string
+		// +usage=Specifies the attributes of the memory resource required for the sidecar.
+		memory?: string
+		// +usage=Run the sidecar as a restartable init container that starts before and stops after the main containers. Requires Kubernetes 1.29 or later for Jobs, otherwise the sidecar is a regular container
+		native: *false | bool
+		// +usage=Instructions for assessing whether the container has started. Other probes wait until it succeeds.
+		startupProbe?: #HealthProbe
 		// +usage=Instructions for assessing whether the container is alive.
 		livenessProbe?: #HealthProbe
 		// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
@@ -141,10 +141,10 @@ template: {
 		if parameter.probes != _|_ {
 			// +patchKey=name
 			containers: [for c in parameter.probes {
-				if c.name == "" {
+				if c.containerName == "" {
 					err: "containerName must be set when specifying startup probe for multiple containers"
 				}
-				if c.name != "" {
+				if c.containerName != "" {
 					PatchContainer & {_params: c}
 				}
 			}]
@@ -184,7 +184,7 @@ template: {
 	}
 
 	outputs: {
-		for v in parameter.pvc {
+		if parameter.pvc != _|_ for v in parameter.pvc {
 			if v.mountOnly == false {
 				"pvc-\(v.name)": {
 					apiVersion: "v1"
@@ -223,7 +223,7 @@ template: {
 			}
 		}
 
-		for v in parameter.configMap {
+		if parameter.configMap != _|_ for v in parameter.configMap {
 			if v.mountOnly == false {
 				"configmap-\(v.name)": {
 					apiVersion: "v1"
@@ -236,7 +236,7 @@ template: {
 			}
 		}
 
-		for v in parameter.secret {
+		if parameter.secret != _|_ for v in parameter.secret {
 			if v.mountOnly == false {
 				"secret-\(v.name)": {
 					apiVersion: "v1"
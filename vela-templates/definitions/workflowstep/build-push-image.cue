@@ -57,6 +57,9 @@ template: {
 					if parameter.buildArgs != _|_ for arg in parameter.buildArgs {
 						"--build-arg=\(arg)"
 					},
+					if parameter.insecureRegistries != _|_ for registry in parameter.insecureRegistries {
+						"--insecure-registry=\(registry)"
+					},
 				]
 				image: parameter.kanikoExecutor
 				name:  "kaniko"
@@ -68,17 +71,25 @@ template: {
 						},
 					]
 				}
-				if parameter.credentials != _|_ && parameter.credentials.git != _|_ {
+				if (parameter.credentials != _|_ && parameter.credentials.git != _|_) || parameter.gitPullMethod != "https" {
 					env: [
-						{
-							name: "GIT_TOKEN"
-							valueFrom: {
-								secretKeyRef: {
-									key:  parameter.credentials.git.key
-									name: parameter.credentials.git.name
+						if parameter.credentials != _|_ && parameter.credentials.git != _|_ {
+							{
+								name: "GIT_TOKEN"
+								valueFrom: {
+									secretKeyRef: {
+										key:  parameter.credentials.git.key
+										name: parameter.credentials.git.name
+									}
 								}
 							}
 						},
+						if parameter.gitPullMethod != "https" {
+							{
+								name:  "GIT_PULL_METHOD"
+								value: parameter.gitPullMethod
+							}
+						},
 					]
 				}
 			},
@@ -164,6 +175,10 @@ template: {
 		}
 		// +usage=Specify the verbosity level
 		verbosity: *"info" | "panic" | "fatal" | "error" | "warn" | "debug" | "trace"
+		// +usage=Specify the registries to pull from and push to over plain HTTP
+		insecureRegistries?: [...string]
+		// +usage=Specify the protocol to clone the git context over
+		gitPullMethod: *"https" | "http"
 		// +usage=Specify the context to build image, you can use context with git and branch or directly specify the context, please refer to https://github.com/GoogleContainerTools/kaniko#kaniko-build-contexts
 		context: #git | string
 	}
@@ -20,6 +20,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -32,10 +33,12 @@ var _ = Describe("ApplyComponent WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.ApplyComponent()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -47,9 +50,9 @@ var _ = Describe("ApplyComponent WorkflowStep", func() {
 		})
 
 		It("should declare component, cluster, and namespace parameters", func() {
-			Expect(cueOutput).To(ContainSubstring("component: string"))
-			Expect(cueOutput).To(ContainSubstring(`cluster: *"" | string`))
-			Expect(cueOutput).To(ContainSubstring(`namespace: *"" | string`))
+			Expect(doc.Lookup("parameter.component")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.cluster")).To(SatisfyAll(cueassert.HaveDefault(""), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.namespace")).To(SatisfyAll(cueassert.HaveDefault(""), cueassert.HaveType("string")))
 		})
 
 		It("should have no imports or template actions", func() {
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("ApplyDeployment WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.ApplyDeployment()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -53,10 +56,10 @@ var _ = Describe("ApplyDeployment WorkflowStep", func() {
 		})
 
 		It("should declare image, replicas, cluster, and cmd parameters with correct types and defaults", func() {
-			Expect(cueOutput).To(ContainSubstring("image: string"))
-			Expect(cueOutput).To(ContainSubstring("replicas: *1 | int"))
-			Expect(cueOutput).To(ContainSubstring(`cluster: *"" | string`))
-			Expect(cueOutput).To(ContainSubstring("cmd?: [...string]"))
+			Expect(doc.Lookup("parameter.image")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.replicas")).To(SatisfyAll(cueassert.HaveDefault(1), cueassert.HaveType("int")))
+			Expect(doc.Lookup("parameter.cluster")).To(SatisfyAll(cueassert.HaveDefault(""), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.cmd")).To(cueassert.BeOptionalListOf("string"))
 		})
 
 		It("should build the Deployment resource with correct metadata, selector, pod spec, and conditional cmd", func() {
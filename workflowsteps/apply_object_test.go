@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("ApplyObject WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.ApplyObject()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -52,8 +55,8 @@ var _ = Describe("ApplyObject WorkflowStep", func() {
 		})
 
 		It("should declare value and cluster parameters", func() {
-			Expect(cueOutput).To(ContainSubstring("value: {...}"))
-			Expect(cueOutput).To(ContainSubstring(`cluster: *"" | string`))
+			Expect(doc.Lookup("parameter.value")).To(cueassert.HaveValue("{...}"))
+			Expect(doc.Lookup("parameter.cluster")).To(SatisfyAll(cueassert.HaveDefault(""), cueassert.HaveType("string")))
 		})
 
 		It("should generate template with exactly one kube.#Apply passing full parameter object", func() {
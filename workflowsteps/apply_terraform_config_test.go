@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("ApplyTerraformConfig WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.ApplyTerraformConfig()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -55,16 +58,16 @@ var _ = Describe("ApplyTerraformConfig WorkflowStep", func() {
 
 		It("should declare all parameters with correct types and defaults", func() {
 			Expect(cueOutput).To(ContainSubstring("source: close({"))
-			Expect(cueOutput).To(ContainSubstring("hcl: string"))
-			Expect(cueOutput).To(ContainSubstring(`remote: *"https://github.com/kubevela-contrib/terraform-modules.git" | string`))
-			Expect(cueOutput).To(ContainSubstring("path?: string"))
-			Expect(cueOutput).To(ContainSubstring("deleteResource: *true | bool"))
-			Expect(cueOutput).To(ContainSubstring("forceDelete: *false | bool"))
-			Expect(cueOutput).To(ContainSubstring("variable: {...}"))
-			Expect(cueOutput).To(ContainSubstring("jobEnv?: {...}"))
+			Expect(doc.Lookup("parameter.source.hcl")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.source.remote")).To(SatisfyAll(cueassert.HaveDefault("https://github.com/kubevela-contrib/terraform-modules.git"), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.source.path")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.deleteResource")).To(SatisfyAll(cueassert.HaveDefault(true), cueassert.HaveType("bool")))
+			Expect(doc.Lookup("parameter.forceDelete")).To(SatisfyAll(cueassert.HaveDefault(false), cueassert.HaveType("bool")))
+			Expect(doc.Lookup("parameter.variable")).To(cueassert.HaveValue("{...}"))
+			Expect(doc.Lookup("parameter.jobEnv")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("{...}")))
 			Expect(cueOutput).To(ContainSubstring("writeConnectionSecretToRef?: {"))
 			Expect(cueOutput).To(ContainSubstring("providerRef?: {"))
-			Expect(cueOutput).To(ContainSubstring("region?: string"))
+			Expect(doc.Lookup("parameter.region")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
 		})
 
 		It("should create a terraform Configuration resource with correct metadata and spec", func() {
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("ApplyTerraformProvider WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.ApplyTerraformProvider()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -64,49 +67,40 @@ var _ = Describe("ApplyTerraformProvider WorkflowStep", func() {
 			Expect(cueOutput).To(ContainSubstring("#TencentProvider: {"))
 			Expect(cueOutput).To(ContainSubstring("#UCloudProvider: {"))
 
-			Expect(cueOutput).To(ContainSubstring(`name: *"alibaba-provider" | string`))
-			Expect(cueOutput).To(ContainSubstring(`name: *"aws-provider" | string`))
-			Expect(cueOutput).To(ContainSubstring(`name: *"azure-provider" | string`))
-			Expect(cueOutput).To(ContainSubstring(`name: *"baidu-provider" | string`))
-			Expect(cueOutput).To(ContainSubstring(`name: *"ec-provider" | string`))
-			Expect(cueOutput).To(ContainSubstring(`name: *"gcp-provider" | string`))
-			Expect(cueOutput).To(ContainSubstring(`name: *"tencent-provider" | string`))
-			Expect(cueOutput).To(ContainSubstring(`name: *"ucloud-provider" | string`))
-
-			Expect(cueOutput).To(ContainSubstring(`type: "alibaba"`))
-			Expect(cueOutput).To(ContainSubstring(`type: "aws"`))
-			Expect(cueOutput).To(ContainSubstring(`type: "baidu"`))
-			Expect(cueOutput).To(ContainSubstring(`type: "ec"`))
-			Expect(cueOutput).To(ContainSubstring(`type: "gcp"`))
-			Expect(cueOutput).To(ContainSubstring(`type: "tencent"`))
-			Expect(cueOutput).To(ContainSubstring(`type: "ucloud"`))
+			Expect(doc.Lookup("#AlibabaProvider.name")).To(SatisfyAll(cueassert.HaveDefault("alibaba-provider"), cueassert.HaveType("string")))
+			Expect(doc.Lookup("#AWSProvider.name")).To(SatisfyAll(cueassert.HaveDefault("aws-provider"), cueassert.HaveType("string")))
+			Expect(doc.Lookup("#AzureProvider.name")).To(SatisfyAll(cueassert.HaveDefault("azure-provider"), cueassert.HaveType("string")))
+			Expect(doc.Lookup("#BaiduProvider.name")).To(SatisfyAll(cueassert.HaveDefault("baidu-provider"), cueassert.HaveType("string")))
+			Expect(doc.Lookup("#ECProvider.name")).To(SatisfyAll(cueassert.HaveDefault("ec-provider"), cueassert.HaveType("string")))
+			Expect(doc.Lookup("#GCPProvider.name")).To(SatisfyAll(cueassert.HaveDefault("gcp-provider"), cueassert.HaveType("string")))
+			Expect(doc.Lookup("#TencentProvider.name")).To(SatisfyAll(cueassert.HaveDefault("tencent-provider"), cueassert.HaveType("string")))
+			Expect(doc.Lookup("#UCloudProvider.name")).To(SatisfyAll(cueassert.HaveDefault("ucloud-provider"), cueassert.HaveType("string")))
+
+			Expect(doc.Lookup("#AlibabaProvider.type")).To(cueassert.HaveValue(`"alibaba"`))
+			Expect(doc.Lookup("#AWSProvider.type")).To(cueassert.HaveValue(`"aws"`))
+			Expect(doc.Lookup("#BaiduProvider.type")).To(cueassert.HaveValue(`"baidu"`))
+			Expect(doc.Lookup("#ECProvider.type")).To(cueassert.HaveValue(`"ec"`))
+			Expect(doc.Lookup("#GCPProvider.type")).To(cueassert.HaveValue(`"gcp"`))
+			Expect(doc.Lookup("#TencentProvider.type")).To(cueassert.HaveValue(`"tencent"`))
+			Expect(doc.Lookup("#UCloudProvider.type")).To(cueassert.HaveValue(`"ucloud"`))
 		})
 
 		It("should mark accessKey, secretKey, region as required in providers with providerBasic", func() {
-			alibabaIdx := strings.Index(cueOutput, "#AlibabaProvider: {")
-			Expect(alibabaIdx).To(BeNumerically(">", 0))
-			alibabaBlock := cueOutput[alibabaIdx : alibabaIdx+200]
-			Expect(alibabaBlock).To(ContainSubstring("accessKey!: string"))
-			Expect(alibabaBlock).To(ContainSubstring("secretKey!: string"))
-			Expect(alibabaBlock).To(ContainSubstring("region!: string"))
-
-			awsIdx := strings.Index(cueOutput, "#AWSProvider: {")
-			Expect(awsIdx).To(BeNumerically(">", 0))
-			awsBlock := cueOutput[awsIdx : awsIdx+200]
-			Expect(awsBlock).To(ContainSubstring("accessKey!: string"))
-			Expect(awsBlock).To(ContainSubstring("secretKey!: string"))
-			Expect(awsBlock).To(ContainSubstring("region!: string"))
-
-			baiduIdx := strings.Index(cueOutput, "#BaiduProvider: {")
-			Expect(baiduIdx).To(BeNumerically(">", 0))
-			baiduBlock := cueOutput[baiduIdx : baiduIdx+200]
-			Expect(baiduBlock).To(ContainSubstring("accessKey!: string"))
-			Expect(baiduBlock).To(ContainSubstring("secretKey!: string"))
-			Expect(baiduBlock).To(ContainSubstring("region!: string"))
+			for _, name := range []string{"accessKey", "secretKey", "region"} {
+				Expect(doc.Helper("AlibabaProvider").Field(name)).To(SatisfyAll(cueassert.BeRequiredField(), cueassert.HaveType("string")))
+			}
+
+			for _, name := range []string{"accessKey", "secretKey", "region"} {
+				Expect(doc.Helper("AWSProvider").Field(name)).To(SatisfyAll(cueassert.BeRequiredField(), cueassert.HaveType("string")))
+			}
+
+			for _, name := range []string{"accessKey", "secretKey", "region"} {
+				Expect(doc.Helper("BaiduProvider").Field(name)).To(SatisfyAll(cueassert.BeRequiredField(), cueassert.HaveType("string")))
+			}
 		})
 
 		It("should declare parameter as a union of all provider helpers", func() {
-			Expect(cueOutput).To(ContainSubstring("parameter: #AlibabaProvider | #AWSProvider | #AzureProvider | #BaiduProvider | #ECProvider | #GCPProvider | #TencentProvider | #UCloudProvider"))
+			Expect(doc.Lookup("parameter")).To(cueassert.HaveValue("#AlibabaProvider | #AWSProvider | #AzureProvider | #BaiduProvider | #ECProvider | #GCPProvider | #TencentProvider | #UCloudProvider"))
 		})
 
 		It("should create config and conditionally set provider-specific keys", func() {
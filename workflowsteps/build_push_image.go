@@ -60,6 +60,13 @@ func BuildPushImage() *defkit.WorkflowStepDefinition {
 		Values("info", "panic", "fatal", "error", "warn", "debug", "trace").
 		Default("info").
 		Description("Specify the verbosity level")
+	insecureRegistries := defkit.StringList("insecureRegistries").
+		Optional().
+		Description("Specify the registries to pull from and push to over plain HTTP")
+	gitPullMethod := defkit.Enum("gitPullMethod").
+		Values("https", "http").
+		Default("https").
+		Description("Specify the protocol to clone the git context over")
 	context := defkit.Object("context").
 		Description("Specify the context to build image, you can use context with git and branch or directly specify the context, please refer to https://github.com/GoogleContainerTools/kaniko#kaniko-build-contexts").
 		WithSchema("#git | string")
@@ -80,7 +87,7 @@ func BuildPushImage() *defkit.WorkflowStepDefinition {
 			defkit.Field("git", defkit.ParamTypeString),
 			defkit.Field("branch", defkit.ParamTypeString).Default("master"),
 		)).
-		Params(kanikoExecutor, dockerfile, image, platform, buildArgs, credentials, verbosity, context).
+		Params(kanikoExecutor, dockerfile, image, platform, buildArgs, credentials, verbosity, insecureRegistries, gitPullMethod, context).
 		Template(func(tpl *defkit.WorkflowStepTemplate) {
 			tpl.Set("url", defkit.Reference(`{
 	if parameter.context.git != _|_ {
@@ -115,6 +122,9 @@ func BuildPushImage() *defkit.WorkflowStepDefinition {
 					if parameter.buildArgs != _|_ for arg in parameter.buildArgs {
 						"--build-arg=\(arg)"
 					},
+					if parameter.insecureRegistries != _|_ for registry in parameter.insecureRegistries {
+						"--insecure-registry=\(registry)"
+					},
 				]
 				image: parameter.kanikoExecutor
 				name:  "kaniko"
@@ -126,17 +136,25 @@ func BuildPushImage() *defkit.WorkflowStepDefinition {
 						},
 					]
 				}
-				if parameter.credentials != _|_ && parameter.credentials.git != _|_ {
+				if (parameter.credentials != _|_ && parameter.credentials.git != _|_) || parameter.gitPullMethod != "https" {
 					env: [
-						{
-							name: "GIT_TOKEN"
-							valueFrom: {
-								secretKeyRef: {
-									key:  parameter.credentials.git.key
-									name: parameter.credentials.git.name
+						if parameter.credentials != _|_ && parameter.credentials.git != _|_ {
+							{
+								name: "GIT_TOKEN"
+								valueFrom: {
+									secretKeyRef: {
+										key:  parameter.credentials.git.key
+										name: parameter.credentials.git.name
+									}
 								}
 							}
 						},
+						if parameter.gitPullMethod != "https" {
+							{
+								name:  "GIT_PULL_METHOD"
+								value: parameter.gitPullMethod
+							}
+						},
 					]
 				}
 			},
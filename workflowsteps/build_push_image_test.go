@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("BuildPushImage WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.BuildPushImage()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -64,18 +67,20 @@ var _ = Describe("BuildPushImage WorkflowStep", func() {
 			Expect(secretBlock).To(ContainSubstring("key: string"))
 
 			Expect(cueOutput).To(ContainSubstring("#git: {"))
-			Expect(cueOutput).To(ContainSubstring("git: string"))
-			Expect(cueOutput).To(ContainSubstring(`branch: *"master" | string`))
+			Expect(doc.Lookup("#git.git")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("#git.branch")).To(SatisfyAll(cueassert.HaveDefault("master"), cueassert.HaveType("string")))
 		})
 
 		It("should declare all parameters with correct types, defaults, and credentials structure", func() {
-			Expect(cueOutput).To(ContainSubstring(`kanikoExecutor: *"oamdev/kaniko-executor:v1.9.1" | string`))
-			Expect(cueOutput).To(ContainSubstring(`dockerfile: *"./Dockerfile" | string`))
-			Expect(cueOutput).To(ContainSubstring("image: string"))
-			Expect(cueOutput).To(ContainSubstring("platform?: string"))
-			Expect(cueOutput).To(ContainSubstring("buildArgs?: [...string]"))
-			Expect(cueOutput).To(ContainSubstring(`verbosity: *"info" | "panic" | "fatal" | "error" | "warn" | "debug" | "trace"`))
-			Expect(cueOutput).To(ContainSubstring("context: #git | string"))
+			Expect(doc.Lookup("parameter.kanikoExecutor")).To(SatisfyAll(cueassert.HaveDefault("oamdev/kaniko-executor:v1.9.1"), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.dockerfile")).To(SatisfyAll(cueassert.HaveDefault("./Dockerfile"), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.image")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.platform")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.buildArgs")).To(cueassert.BeOptionalListOf("string"))
+			Expect(doc.Lookup("parameter.verbosity")).To(SatisfyAll(cueassert.HaveDefault("info"), cueassert.HaveType(`"panic" | "fatal" | "error" | "warn" | "debug" | "trace"`)))
+			Expect(doc.Lookup("parameter.insecureRegistries")).To(cueassert.BeOptionalListOf("string"))
+			Expect(doc.Lookup("parameter.gitPullMethod")).To(SatisfyAll(cueassert.HaveDefault("https"), cueassert.HaveType(`"http"`)))
+			Expect(doc.Lookup("parameter.context")).To(cueassert.HaveValue("#git | string"))
 
 			credIdx := strings.Index(cueOutput, "credentials?: {")
 			Expect(credIdx).To(BeNumerically(">", 0))
@@ -98,7 +103,7 @@ var _ = Describe("BuildPushImage WorkflowStep", func() {
 		It("should build kaniko Pod with correct spec, container args, and conditional mounts", func() {
 			Expect(cueOutput).To(ContainSubstring("kaniko: kube.#Apply & {"))
 			Expect(cueOutput).To(ContainSubstring(`apiVersion: "v1"`))
-			Expect(cueOutput).To(ContainSubstring(`kind:       "Pod"`))
+			Expect(doc).To(cueassert.ContainField("kind", `"Pod"`))
 			Expect(cueOutput).To(ContainSubstring(`\(context.name)-\(context.stepSessionID)-kaniko`))
 			Expect(cueOutput).To(ContainSubstring(`--dockerfile=\(parameter.dockerfile)`))
 			Expect(cueOutput).To(ContainSubstring(`--context=\(url.value)`))
@@ -113,6 +118,10 @@ var _ = Describe("BuildPushImage WorkflowStep", func() {
 			Expect(cueOutput).To(ContainSubstring(`mountPath: "/kaniko/.docker/"`))
 			Expect(cueOutput).To(ContainSubstring("parameter.credentials.git != _|_"))
 			Expect(cueOutput).To(ContainSubstring(`name: "GIT_TOKEN"`))
+			Expect(cueOutput).To(ContainSubstring("parameter.insecureRegistries != _|_"))
+			Expect(cueOutput).To(ContainSubstring(`--insecure-registry=\(registry)`))
+			Expect(cueOutput).To(ContainSubstring(`parameter.gitPullMethod != "https"`))
+			Expect(cueOutput).To(ContainSubstring(`name:  "GIT_PULL_METHOD"`))
 			Expect(cueOutput).To(ContainSubstring("secretKeyRef:"))
 			Expect(cueOutput).To(ContainSubstring(`restartPolicy: "Never"`))
 		})
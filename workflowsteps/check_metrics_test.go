@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("CheckMetrics WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.CheckMetrics()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -54,11 +57,11 @@ var _ = Describe("CheckMetrics WorkflowStep", func() {
 		})
 
 		It("should declare all parameters with correct types and defaults", func() {
-			Expect(cueOutput).To(ContainSubstring("query: string"))
-			Expect(cueOutput).To(ContainSubstring(`metricEndpoint?: "http://prometheus-server.o11y-system.svc:9090" | string`))
-			Expect(cueOutput).To(ContainSubstring("condition: string"))
-			Expect(cueOutput).To(ContainSubstring(`duration?: *"5m" | string`))
-			Expect(cueOutput).To(ContainSubstring(`failDuration?: *"2m" | string`))
+			Expect(doc.Lookup("parameter.query")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.metricEndpoint")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType(`"http://prometheus-server.o11y-system.svc:9090" | string`)))
+			Expect(doc.Lookup("parameter.condition")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.duration")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.failDuration")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
 		})
 
 		It("should generate the check action using metrics.#PromCheck", func() {
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("CleanJobs WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.CleanJobs()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -52,8 +55,8 @@ var _ = Describe("CleanJobs WorkflowStep", func() {
 		})
 
 		It("should declare the expected parameters", func() {
-			Expect(cueOutput).To(ContainSubstring("labelselector?: {...}"))
-			Expect(cueOutput).To(ContainSubstring("namespace: *context.namespace | string"))
+			Expect(doc.Lookup("parameter.labelselector")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("{...}")))
+			Expect(doc.Lookup("parameter.namespace")).To(SatisfyAll(cueassert.HaveDefaultSource("context.namespace"), cueassert.HaveType("string")))
 		})
 
 		It("should generate the cleanJobs kube.#Delete action", func() {
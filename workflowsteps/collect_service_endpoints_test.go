@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("CollectServiceEndpoints WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.CollectServiceEndpoints()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -54,13 +57,13 @@ var _ = Describe("CollectServiceEndpoints WorkflowStep", func() {
 		})
 
 		It("should declare all parameter fields with correct types and defaults", func() {
-			Expect(cueOutput).To(ContainSubstring("name: *context.name | string"))
-			Expect(cueOutput).To(ContainSubstring("namespace: *context.namespace | string"))
-			Expect(cueOutput).To(ContainSubstring("components?: [...string]"))
-			Expect(cueOutput).To(ContainSubstring("port?: int"))
-			Expect(cueOutput).To(ContainSubstring("portName?: string"))
-			Expect(cueOutput).To(ContainSubstring("outer?: bool"))
-			Expect(cueOutput).To(ContainSubstring(`protocal: *"http" | "https"`))
+			Expect(doc.Lookup("parameter.name")).To(SatisfyAll(cueassert.HaveDefaultSource("context.name"), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.namespace")).To(SatisfyAll(cueassert.HaveDefaultSource("context.namespace"), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.components")).To(cueassert.BeOptionalListOf("string"))
+			Expect(doc.Lookup("parameter.port")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("int")))
+			Expect(doc.Lookup("parameter.portName")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.outer")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("bool")))
+			Expect(doc.Lookup("parameter.protocal")).To(SatisfyAll(cueassert.HaveDefault("http"), cueassert.HaveType(`"https"`)))
 		})
 
 		It("should invoke query.#CollectServiceEndpoints with app params and conditional components filter", func() {
@@ -72,7 +75,7 @@ var _ = Describe("CollectServiceEndpoints WorkflowStep", func() {
 		})
 
 		It("should filter by portName when set, falling back to full list", func() {
-			Expect(cueOutput).To(ContainSubstring("eps_port_name_filtered: *[] | [...]"))
+			Expect(doc.Lookup("outputs.eps_port_name_filtered")).To(SatisfyAll(cueassert.HaveDefaultSource("[]"), cueassert.HaveType("[...]")))
 			Expect(cueOutput).To(ContainSubstring(`parameter["portName"] != _|_`))
 			Expect(cueOutput).To(ContainSubstring("parameter.portName == ep.endpoint.portName"))
 			Expect(cueOutput).To(ContainSubstring(`parameter["portName"] == _|_`))
@@ -80,14 +83,14 @@ var _ = Describe("CollectServiceEndpoints WorkflowStep", func() {
 		})
 
 		It("should filter by port when set and alias result to eps", func() {
-			Expect(cueOutput).To(ContainSubstring("eps_port_filtered: *[] | [...]"))
+			Expect(doc.Lookup("outputs.eps_port_filtered")).To(SatisfyAll(cueassert.HaveDefaultSource("[]"), cueassert.HaveType("[...]")))
 			Expect(cueOutput).To(ContainSubstring(`parameter["port"] != _|_`))
 			Expect(cueOutput).To(ContainSubstring("parameter.port == ep.endpoint.port"))
-			Expect(cueOutput).To(ContainSubstring("eps: eps_port_filtered"))
+			Expect(doc.Lookup("outputs.eps")).To(cueassert.HaveValue("eps_port_filtered"))
 		})
 
 		It("should filter endpoints by outer flag when set, passing through otherwise", func() {
-			Expect(cueOutput).To(ContainSubstring("endpoints: *[] | [...]"))
+			Expect(doc.Lookup("outputs.endpoints")).To(SatisfyAll(cueassert.HaveDefaultSource("[]"), cueassert.HaveType("[...]")))
 			Expect(cueOutput).To(ContainSubstring(`parameter["outer"] != _|_`))
 			Expect(cueOutput).To(ContainSubstring("tmps:"))
 			Expect(cueOutput).To(ContainSubstring("ep.endpoint.inner == _|_"))
@@ -103,7 +106,7 @@ var _ = Describe("CollectServiceEndpoints WorkflowStep", func() {
 		})
 
 		It("should extract first endpoint and build URL with protocal interpolation", func() {
-			Expect(cueOutput).To(ContainSubstring("endpoint: outputs.endpoints[0].endpoint"))
+			Expect(doc.Lookup("value.endpoint")).To(cueassert.HaveValue("outputs.endpoints[0].endpoint"))
 			Expect(cueOutput).To(ContainSubstring("strconv.FormatInt(endpoint.port, 10)"))
 			Expect(cueOutput).To(ContainSubstring(`\(parameter.protocal)`))
 			Expect(cueOutput).To(ContainSubstring(`\(endpoint.host)`))
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("CreateConfig WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.CreateConfig()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -52,10 +55,10 @@ var _ = Describe("CreateConfig WorkflowStep", func() {
 		})
 
 		It("should declare all parameters with correct types and defaults", func() {
-			Expect(cueOutput).To(ContainSubstring("name: string"))
+			Expect(doc.Lookup("parameter.name")).To(cueassert.HaveValue("string"))
 			Expect(cueOutput).To(ContainSubstring("*context.namespace | string"))
-			Expect(cueOutput).To(ContainSubstring("template?: string"))
-			Expect(cueOutput).To(ContainSubstring("config: {...}"))
+			Expect(doc.Lookup("parameter.template")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.config")).To(cueassert.HaveValue("{...}"))
 		})
 
 		It("should generate the template calling config.#CreateConfig with parameters", func() {
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("DependsOnApp WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.DependsOnApp()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -54,15 +57,15 @@ var _ = Describe("DependsOnApp WorkflowStep", func() {
 		})
 
 		It("should declare name and namespace as required string parameters", func() {
-			Expect(cueOutput).To(ContainSubstring("name: string"))
-			Expect(cueOutput).To(ContainSubstring("namespace: string"))
+			Expect(doc.Lookup("parameter.name")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.namespace")).To(cueassert.HaveValue("string"))
 		})
 
 		It("should read an Application resource via kube.#Read", func() {
 			Expect(cueOutput).To(ContainSubstring("dependsOn: kube.#Read & {"))
 			Expect(cueOutput).To(ContainSubstring(`apiVersion: "core.oam.dev/v1beta1"`))
-			Expect(cueOutput).To(ContainSubstring(`kind:       "Application"`))
-			Expect(cueOutput).To(ContainSubstring("name:      parameter.name"))
+			Expect(doc).To(cueassert.ContainField("kind", `"Application"`))
+			Expect(doc).To(cueassert.ContainField("name", "parameter.name"))
 			Expect(cueOutput).To(ContainSubstring("namespace: parameter.namespace"))
 		})
 
@@ -71,7 +74,7 @@ var _ = Describe("DependsOnApp WorkflowStep", func() {
 			Expect(cueOutput).To(ContainSubstring("dependsOn.$returns.err != _|_"))
 			Expect(cueOutput).To(ContainSubstring("configMap: kube.#Read & {"))
 			Expect(cueOutput).To(ContainSubstring(`apiVersion: "v1"`))
-			Expect(cueOutput).To(ContainSubstring(`kind:       "ConfigMap"`))
+			Expect(doc).To(cueassert.ContainField("kind", `"ConfigMap"`))
 			Expect(cueOutput).To(ContainSubstring(`configMap.$returns.value.data["application"]`))
 			Expect(cueOutput).To(ContainSubstring("kube.#Apply & {"))
 			Expect(cueOutput).To(ContainSubstring("yaml.Unmarshal(template)"))
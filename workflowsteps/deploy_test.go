@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("Deploy WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.Deploy()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -53,10 +56,10 @@ var _ = Describe("Deploy WorkflowStep", func() {
 		})
 
 		It("should declare all parameters with correct types and defaults", func() {
-			Expect(cueOutput).To(ContainSubstring("auto: *true | bool"))
-			Expect(cueOutput).To(ContainSubstring("policies: *[] | [...string]"))
-			Expect(cueOutput).To(ContainSubstring("parallelism: *5 | int"))
-			Expect(cueOutput).To(ContainSubstring("ignoreTerraformComponent: *true | bool"))
+			Expect(doc.Lookup("parameter.auto")).To(SatisfyAll(cueassert.HaveDefault(true), cueassert.HaveType("bool")))
+			Expect(doc.Lookup("parameter.policies")).To(SatisfyAll(cueassert.HaveDefaultSource("[]"), cueassert.HaveType("[...string]")))
+			Expect(doc.Lookup("parameter.parallelism")).To(SatisfyAll(cueassert.HaveDefault(5), cueassert.HaveType("int")))
+			Expect(doc.Lookup("parameter.ignoreTerraformComponent")).To(SatisfyAll(cueassert.HaveDefault(true), cueassert.HaveType("bool")))
 		})
 
 		It("should conditionally suspend when auto is false with correct message", func() {
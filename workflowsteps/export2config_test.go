@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("Export2Config WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.Export2Config()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -51,10 +54,10 @@ var _ = Describe("Export2Config WorkflowStep", func() {
 		})
 
 		It("should declare all parameters with correct types", func() {
-			Expect(cueOutput).To(ContainSubstring("configName: string"))
-			Expect(cueOutput).To(ContainSubstring("namespace?: string"))
-			Expect(cueOutput).To(ContainSubstring("data: {}"))
-			Expect(cueOutput).To(ContainSubstring(`cluster: *"" | string`))
+			Expect(doc.Lookup("parameter.configName")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.namespace")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.data")).To(cueassert.HaveValue("{}"))
+			Expect(doc.Lookup("parameter.cluster")).To(SatisfyAll(cueassert.HaveDefault(""), cueassert.HaveType("string")))
 		})
 
 		It("should generate kube.#Apply with ConfigMap resource", func() {
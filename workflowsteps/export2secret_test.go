@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("Export2Secret WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.Export2Secret()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -53,21 +56,21 @@ var _ = Describe("Export2Secret WorkflowStep", func() {
 		})
 
 		It("should declare all parameters with correct types and defaults", func() {
-			Expect(cueOutput).To(ContainSubstring("secretName: string"))
-			Expect(cueOutput).To(ContainSubstring("namespace?: string"))
-			Expect(cueOutput).To(ContainSubstring("type?: string"))
-			Expect(cueOutput).To(ContainSubstring("data: {}"))
-			Expect(cueOutput).To(ContainSubstring(`cluster: *"" | string`))
-			Expect(cueOutput).To(ContainSubstring(`kind: *"generic" | "docker-registry"`))
+			Expect(doc.Lookup("parameter.secretName")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.namespace")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.type")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.data")).To(cueassert.HaveValue("{}"))
+			Expect(doc.Lookup("parameter.cluster")).To(SatisfyAll(cueassert.HaveDefault(""), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.kind")).To(SatisfyAll(cueassert.HaveDefault("generic"), cueassert.HaveType(`"docker-registry"`)))
 			Expect(cueOutput).To(ContainSubstring("dockerRegistry?: {"))
-			Expect(cueOutput).To(ContainSubstring("username: string"))
-			Expect(cueOutput).To(ContainSubstring("password: string"))
-			Expect(cueOutput).To(ContainSubstring(`server: *"https://index.docker.io/v1/" | string`))
+			Expect(doc.Lookup("parameter.dockerRegistry.username")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.dockerRegistry.password")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.dockerRegistry.server")).To(SatisfyAll(cueassert.HaveDefault("https://index.docker.io/v1/"), cueassert.HaveType("string")))
 		})
 
 		It("should wrap template in secret block with data helper", func() {
 			Expect(cueOutput).To(ContainSubstring("secret: {"))
-			Expect(cueOutput).To(ContainSubstring("data: *parameter.data | {}"))
+			Expect(doc.Lookup("secret.data")).To(SatisfyAll(cueassert.HaveDefaultSource("parameter.data"), cueassert.HaveType("{}")))
 		})
 
 		It("should use mutually exclusive namespace guards", func() {
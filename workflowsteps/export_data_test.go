@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("ExportData WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.ExportData()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -54,26 +57,26 @@ var _ = Describe("ExportData WorkflowStep", func() {
 		})
 
 		It("should declare all parameters with correct types and defaults", func() {
-			Expect(cueOutput).To(ContainSubstring("name?: string"))
-			Expect(cueOutput).To(ContainSubstring("namespace?: string"))
-			Expect(cueOutput).To(ContainSubstring(`kind: *"ConfigMap" | "Secret"`))
-			Expect(cueOutput).To(ContainSubstring("data: {}"))
-			Expect(cueOutput).To(ContainSubstring("topology?: string"))
+			Expect(doc.Lookup("parameter.name")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.namespace")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.kind")).To(SatisfyAll(cueassert.HaveDefault("ConfigMap"), cueassert.HaveType(`"Secret"`)))
+			Expect(doc.Lookup("parameter.data")).To(cueassert.HaveValue("{}"))
+			Expect(doc.Lookup("parameter.topology")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
 		})
 
 		It("should build object block with v1 resource, conditional metadata, and conditional data fields", func() {
-			Expect(cueOutput).To(ContainSubstring(`apiVersion: "v1"`))
-			Expect(cueOutput).To(ContainSubstring("kind: parameter.kind"))
-			Expect(cueOutput).To(ContainSubstring("name: *context.name | string"))
-			Expect(cueOutput).To(ContainSubstring("namespace: *context.namespace | string"))
+			Expect(doc.Lookup("object.apiVersion")).To(cueassert.HaveValue(`"v1"`))
+			Expect(doc.Lookup("object.kind")).To(cueassert.HaveValue("parameter.kind"))
+			Expect(doc.Lookup("object.metadata.name")).To(SatisfyAll(cueassert.HaveDefaultSource("context.name"), cueassert.HaveType("string")))
+			Expect(doc.Lookup("object.metadata.namespace")).To(SatisfyAll(cueassert.HaveDefaultSource("context.namespace"), cueassert.HaveType("string")))
 			Expect(cueOutput).To(ContainSubstring(`parameter["name"] != _|_`))
 			Expect(cueOutput).To(ContainSubstring("name: parameter.name"))
 			Expect(cueOutput).To(ContainSubstring(`parameter["namespace"] != _|_`))
 			Expect(cueOutput).To(ContainSubstring("namespace: parameter.namespace"))
 			Expect(cueOutput).To(ContainSubstring(`parameter.kind == "ConfigMap"`))
-			Expect(cueOutput).To(ContainSubstring("data: parameter.data"))
+			Expect(doc.Lookup("object.data")).To(cueassert.HaveValue("parameter.data"))
 			Expect(cueOutput).To(ContainSubstring(`parameter.kind == "Secret"`))
-			Expect(cueOutput).To(ContainSubstring("stringData: parameter.data"))
+			Expect(doc.Lookup("object.stringData")).To(cueassert.HaveValue("parameter.data"))
 		})
 
 		It("should get placements from topology policies and apply via comprehension", func() {
@@ -84,7 +87,7 @@ var _ = Describe("ExportData WorkflowStep", func() {
 			Expect(cueOutput).To(ContainSubstring("for p in getPlacements.placements"))
 			Expect(cueOutput).To(ContainSubstring("(p.cluster):"))
 			Expect(cueOutput).To(ContainSubstring("kube.#Apply & {"))
-			Expect(cueOutput).To(ContainSubstring("value:   object"))
+			Expect(doc).To(cueassert.ContainField("value", "object"))
 			Expect(cueOutput).To(ContainSubstring("cluster: p.cluster"))
 		})
 
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("ExportService WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.ExportService()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -54,12 +57,12 @@ var _ = Describe("ExportService WorkflowStep", func() {
 		})
 
 		It("should declare all parameters with correct types", func() {
-			Expect(cueOutput).To(ContainSubstring("name?: string"))
-			Expect(cueOutput).To(ContainSubstring("namespace?: string"))
-			Expect(cueOutput).To(ContainSubstring("ip: string"))
-			Expect(cueOutput).To(ContainSubstring("port: int"))
-			Expect(cueOutput).To(ContainSubstring("targetPort: int"))
-			Expect(cueOutput).To(ContainSubstring("topology?: string"))
+			Expect(doc.Lookup("parameter.name")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.namespace")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.ip")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.port")).To(cueassert.HaveValue("int"))
+			Expect(doc.Lookup("parameter.targetPort")).To(cueassert.HaveValue("int"))
+			Expect(doc.Lookup("parameter.topology")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
 		})
 
 		It("should define meta block with context defaults and conditional overrides", func() {
@@ -96,7 +99,7 @@ var _ = Describe("ExportService WorkflowStep", func() {
 			Expect(cueOutput).To(ContainSubstring("for o in objects"))
 			Expect(cueOutput).To(ContainSubstring(`"\(p.cluster)-\(o.kind)"`))
 			Expect(cueOutput).To(ContainSubstring("kube.#Apply & {"))
-			Expect(cueOutput).To(ContainSubstring("value:   o"))
+			Expect(doc).To(cueassert.ContainField("value", "o"))
 			Expect(cueOutput).To(ContainSubstring("cluster: p.cluster"))
 		})
 
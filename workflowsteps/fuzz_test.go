@@ -0,0 +1,45 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflowsteps_test
+
+import (
+	"testing"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+
+	"github.com/oam-dev/vela-go-definitions/internal/paramfuzz"
+)
+
+// fuzzableSteps returns the workflow steps that declare a parameter schema;
+// step-group only groups sub-steps and has none.
+func fuzzableSteps() []defkit.Definition {
+	var defs []defkit.Definition
+	for _, def := range defkit.WorkflowSteps() {
+		if def.DefName() != "step-group" {
+			defs = append(defs, def)
+		}
+	}
+	return defs
+}
+
+var _ = paramfuzz.DescribeParameters(fuzzableSteps())
+
+// FuzzWorkflowStepParameters lets `go test -fuzz` explore generator seeds
+// beyond the fixed range the Ginkgo suite covers.
+func FuzzWorkflowStepParameters(f *testing.F) {
+	paramfuzz.FuzzParameters(f, fuzzableSteps())
+}
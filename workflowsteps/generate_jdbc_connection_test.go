@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("GenerateJDBCConnection WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.GenerateJDBCConnection()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -54,8 +57,8 @@ var _ = Describe("GenerateJDBCConnection WorkflowStep", func() {
 		})
 
 		It("should declare name and optional namespace parameters", func() {
-			Expect(cueOutput).To(ContainSubstring("name: string"))
-			Expect(cueOutput).To(ContainSubstring("namespace?: string"))
+			Expect(doc.Lookup("parameter.name")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.namespace")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
 		})
 
 		It("should read a v1 Secret via kube.#Read with conditional namespace", func() {
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("ListConfig WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.ListConfig()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -52,7 +55,7 @@ var _ = Describe("ListConfig WorkflowStep", func() {
 		})
 
 		It("should declare template and namespace parameters with descriptions", func() {
-			Expect(cueOutput).To(ContainSubstring("template: string"))
+			Expect(doc.Lookup("parameter.template")).To(cueassert.HaveValue("string"))
 			Expect(cueOutput).To(ContainSubstring("// +usage=Specify the template of the config."))
 			Expect(cueOutput).To(ContainSubstring("*context.namespace | string"))
 			Expect(cueOutput).To(ContainSubstring("// +usage=Specify the namespace of the config."))
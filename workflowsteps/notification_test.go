@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("Notification WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.Notification()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -60,27 +63,27 @@ var _ = Describe("Notification WorkflowStep", func() {
 			Expect(cueOutput).To(ContainSubstring("#TextType: {"))
 			Expect(cueOutput).To(ContainSubstring("type: string"))
 			Expect(cueOutput).To(ContainSubstring("text: string"))
-			Expect(cueOutput).To(ContainSubstring("emoji?: bool"))
-			Expect(cueOutput).To(ContainSubstring("verbatim?: bool"))
+			Expect(doc.Lookup("#TextType.emoji")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("bool")))
+			Expect(doc.Lookup("#TextType.verbatim")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("bool")))
 
 			// #Option referencing #TextType
 			Expect(cueOutput).To(ContainSubstring("#Option: {"))
-			Expect(cueOutput).To(ContainSubstring("text: #TextType"))
-			Expect(cueOutput).To(ContainSubstring("description?: #TextType"))
+			Expect(doc.Lookup("#Option.text")).To(cueassert.HaveValue("#TextType"))
+			Expect(doc.Lookup("#Option.description")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("#TextType")))
 
 			// #DingLink
 			Expect(cueOutput).To(ContainSubstring("#DingLink: {"))
-			Expect(cueOutput).To(ContainSubstring("messageUrl?: string"))
-			Expect(cueOutput).To(ContainSubstring("picUrl?: string"))
+			Expect(doc.Lookup("#DingLink.messageUrl")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
+			Expect(doc.Lookup("#DingLink.picUrl")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
 
 			// #DingBtn
 			Expect(cueOutput).To(ContainSubstring("#DingBtn: {"))
 			Expect(cueOutput).To(ContainSubstring("title: string"))
-			Expect(cueOutput).To(ContainSubstring("actionURL: string"))
+			Expect(doc.Lookup("#DingBtn.actionURL")).To(cueassert.HaveValue("string"))
 
 			// #Block with element references to #TextType and #Option
 			Expect(cueOutput).To(ContainSubstring("#Block: {"))
-			Expect(cueOutput).To(ContainSubstring("block_id?: string"))
+			Expect(doc.Lookup("#Block.block_id")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
 			Expect(cueOutput).To(ContainSubstring("elements?: [...{"))
 			Expect(cueOutput).To(ContainSubstring("text?: #TextType"))
 			Expect(cueOutput).To(ContainSubstring("placeholder?: #TextType"))
@@ -100,7 +103,7 @@ var _ = Describe("Notification WorkflowStep", func() {
 			Expect(larkBlock).To(ContainSubstring("}) | close({"))
 			Expect(larkBlock).To(ContainSubstring("secretRef: {"))
 
-			Expect(cueOutput).To(ContainSubstring("msg_type: string"))
+			Expect(doc.Lookup("parameter.lark.message.msg_type")).To(cueassert.HaveValue("string"))
 			Expect(cueOutput).To(ContainSubstring("// +usage=content should be json encode string"))
 		})
 
@@ -116,24 +119,24 @@ var _ = Describe("Notification WorkflowStep", func() {
 			Expect(dingBlock).To(ContainSubstring("}) | close({"))
 
 			// message with msgtype enum
-			Expect(cueOutput).To(ContainSubstring(`msgtype: *"text" | "link" | "markdown" | "actionCard" | "feedCard"`))
+			Expect(doc.Lookup("parameter.dingding.message.msgtype")).To(SatisfyAll(cueassert.HaveDefault("text"), cueassert.HaveType(`"link" | "markdown" | "actionCard" | "feedCard"`)))
 
 			// text, link, markdown, at, actionCard, feedCard
 			Expect(cueOutput).To(ContainSubstring("text?: close({"))
 			Expect(cueOutput).To(ContainSubstring("content: string"))
-			Expect(cueOutput).To(ContainSubstring("link?: #DingLink"))
+			Expect(doc.Lookup("parameter.dingding.message.link")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("#DingLink")))
 			Expect(cueOutput).To(ContainSubstring("markdown?: close({"))
 			Expect(cueOutput).To(ContainSubstring("at?: close({"))
-			Expect(cueOutput).To(ContainSubstring("atMobiles?: [...string]"))
-			Expect(cueOutput).To(ContainSubstring("isAtAll?: bool"))
+			Expect(doc.Lookup("parameter.dingding.message.at.atMobiles")).To(cueassert.BeOptionalListOf("string"))
+			Expect(doc.Lookup("parameter.dingding.message.at.isAtAll")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("bool")))
 			Expect(cueOutput).To(ContainSubstring("actionCard?: close({"))
-			Expect(cueOutput).To(ContainSubstring("hideAvatar: string"))
-			Expect(cueOutput).To(ContainSubstring("btnOrientation: string"))
-			Expect(cueOutput).To(ContainSubstring("singleTitle: string"))
-			Expect(cueOutput).To(ContainSubstring("singleURL: string"))
+			Expect(doc.Lookup("parameter.dingding.message.actionCard.hideAvatar")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.dingding.message.actionCard.btnOrientation")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.dingding.message.actionCard.singleTitle")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.dingding.message.actionCard.singleURL")).To(cueassert.HaveValue("string"))
 			Expect(cueOutput).To(ContainSubstring("#DingBtn"))
 			Expect(cueOutput).To(ContainSubstring("feedCard?: close({"))
-			Expect(cueOutput).To(ContainSubstring("links: [...#DingLink]"))
+			Expect(doc.Lookup("parameter.dingding.message.feedCard.links")).To(cueassert.HaveValue("[...#DingLink]"))
 		})
 
 		It("should define slack parameter with message, blocks, attachments, and options", func() {
@@ -141,19 +144,19 @@ var _ = Describe("Notification WorkflowStep", func() {
 			Expect(cueOutput).To(ContainSubstring("// +usage=Specify the message text for slack notification"))
 			Expect(cueOutput).To(ContainSubstring("blocks?: [...#Block]"))
 			Expect(cueOutput).To(ContainSubstring("attachments?: close({"))
-			Expect(cueOutput).To(ContainSubstring("color?: string"))
-			Expect(cueOutput).To(ContainSubstring("thread_ts?: string"))
-			Expect(cueOutput).To(ContainSubstring("mrkdwn?: *true | bool"))
+			Expect(doc.Lookup("parameter.slack.message.attachments.color")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.slack.message.thread_ts")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.slack.message.mrkdwn")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("bool")))
 		})
 
 		It("should define email parameter with from, password ClosedUnion, to, and content", func() {
 			Expect(cueOutput).To(ContainSubstring("email?: {"))
 
 			// from fields
-			Expect(cueOutput).To(ContainSubstring("address: string"))
-			Expect(cueOutput).To(ContainSubstring("alias?: string"))
-			Expect(cueOutput).To(ContainSubstring("host: string"))
-			Expect(cueOutput).To(ContainSubstring("port: *587 | int"))
+			Expect(doc.Lookup("parameter.email.from.address")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.email.from.alias")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.email.from.host")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.email.from.port")).To(SatisfyAll(cueassert.HaveDefault(587), cueassert.HaveType("int")))
 
 			// password as ClosedUnion
 			Expect(cueOutput).To(ContainSubstring("// +usage=Specify the password of the email"))
@@ -164,9 +167,9 @@ var _ = Describe("Notification WorkflowStep", func() {
 			Expect(emailBlock).To(ContainSubstring("}) | close({"))
 
 			// to and content
-			Expect(cueOutput).To(ContainSubstring("to: [...string]"))
-			Expect(cueOutput).To(ContainSubstring("subject: string"))
-			Expect(cueOutput).To(ContainSubstring("body: string"))
+			Expect(doc.Lookup("parameter.email.to")).To(cueassert.HaveValue("[...string]"))
+			Expect(doc.Lookup("parameter.email.content.subject")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.email.content.body")).To(cueassert.HaveValue("string"))
 		})
 
 		It("should generate guarded channel blocks with guards inside field scope", func() {
@@ -188,24 +191,24 @@ var _ = Describe("Notification WorkflowStep", func() {
 
 		It("should generate dingding channel template actions with value and secretRef paths", func() {
 			Expect(cueOutput).To(ContainSubstring("parameter.dingding.url.value != _|_"))
-			Expect(cueOutput).To(ContainSubstring("url:    parameter.dingding.url.value"))
+			Expect(doc).To(cueassert.ContainField("url", "parameter.dingding.url.value"))
 			Expect(cueOutput).To(ContainSubstring("parameter.dingding.url.secretRef != _|_ && parameter.dingding.url.value == _|_"))
-			Expect(cueOutput).To(ContainSubstring("name:      parameter.dingding.url.secretRef.name"))
+			Expect(doc).To(cueassert.ContainField("name", "parameter.dingding.url.secretRef.name"))
 			Expect(cueOutput).To(ContainSubstring("base64.Decode(null, read.$returns.value.data[parameter.dingding.url.secretRef.key])"))
-			Expect(cueOutput).To(ContainSubstring("url:    stringValue.$returns.str"))
+			Expect(doc).To(cueassert.ContainField("url", "stringValue.$returns.str"))
 			Expect(cueOutput).To(ContainSubstring("json.Marshal(parameter.dingding.message)"))
 		})
 
 		It("should generate lark channel template actions with value and secretRef paths", func() {
 			Expect(cueOutput).To(ContainSubstring("parameter.lark.url.value != _|_"))
-			Expect(cueOutput).To(ContainSubstring("url:    parameter.lark.url.value"))
+			Expect(doc).To(cueassert.ContainField("url", "parameter.lark.url.value"))
 			Expect(cueOutput).To(ContainSubstring("parameter.lark.url.secretRef != _|_ && parameter.lark.url.value == _|_"))
 			Expect(cueOutput).To(ContainSubstring("json.Marshal(parameter.lark.message)"))
 		})
 
 		It("should generate slack channel template actions with value and secretRef paths", func() {
 			Expect(cueOutput).To(ContainSubstring("parameter.slack.url.value != _|_"))
-			Expect(cueOutput).To(ContainSubstring("url:    parameter.slack.url.value"))
+			Expect(doc).To(cueassert.ContainField("url", "parameter.slack.url.value"))
 			Expect(cueOutput).To(ContainSubstring("parameter.slack.url.secretRef != _|_ && parameter.slack.url.value == _|_"))
 			Expect(cueOutput).To(ContainSubstring("json.Marshal(parameter.slack.message)"))
 		})
@@ -215,10 +218,10 @@ var _ = Describe("Notification WorkflowStep", func() {
 			Expect(cueOutput).To(ContainSubstring("email.#SendEmail"))
 			Expect(cueOutput).To(ContainSubstring("parameter.email.from.password.secretRef != _|_ && parameter.email.from.password.value == _|_"))
 			Expect(cueOutput).To(ContainSubstring("address: parameter.email.from.address"))
-			Expect(cueOutput).To(ContainSubstring("host:     parameter.email.from.host"))
+			Expect(doc).To(cueassert.ContainField("host", "parameter.email.from.host"))
 			Expect(cueOutput).To(ContainSubstring("if parameter.email.from.alias != _|_"))
 			Expect(cueOutput).To(ContainSubstring("alias: parameter.email.from.alias"))
-			Expect(cueOutput).To(ContainSubstring("to:      parameter.email.to"))
+			Expect(doc).To(cueassert.ContainField("to", "parameter.email.to"))
 			Expect(cueOutput).To(ContainSubstring("content: parameter.email.content"))
 		})
 
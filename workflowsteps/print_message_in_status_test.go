@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("PrintMessageInStatus WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.PrintMessageInStatus()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -51,7 +54,7 @@ var _ = Describe("PrintMessageInStatus WorkflowStep", func() {
 		})
 
 		It("should declare required message parameter", func() {
-			Expect(cueOutput).To(ContainSubstring("message: string"))
+			Expect(doc.Lookup("parameter.message")).To(cueassert.HaveValue("string"))
 		})
 
 		It("should generate template with a single builtin.#Message call passing full parameter", func() {
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("ReadConfig WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.ReadConfig()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -51,7 +54,7 @@ var _ = Describe("ReadConfig WorkflowStep", func() {
 		})
 
 		It("should declare name and namespace parameters with descriptions", func() {
-			Expect(cueOutput).To(ContainSubstring("name: string"))
+			Expect(doc.Lookup("parameter.name")).To(cueassert.HaveValue("string"))
 			Expect(cueOutput).To(ContainSubstring("*context.namespace | string"))
 			Expect(cueOutput).To(ContainSubstring("name of the config"))
 			Expect(cueOutput).To(ContainSubstring("namespace of the config"))
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("ReadObject WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.ReadObject()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -53,7 +56,7 @@ var _ = Describe("ReadObject WorkflowStep", func() {
 		It("should declare all parameters with correct types, defaults, and descriptions", func() {
 			Expect(cueOutput).To(ContainSubstring(`apiVersion: *"core.oam.dev/v1beta1"`))
 			Expect(cueOutput).To(ContainSubstring(`kind: *"Application"`))
-			Expect(cueOutput).To(ContainSubstring("name: string"))
+			Expect(doc.Lookup("parameter.name")).To(cueassert.HaveValue("string"))
 			Expect(cueOutput).To(ContainSubstring(`namespace: *"default"`))
 			Expect(cueOutput).To(ContainSubstring(`cluster: *""`))
 
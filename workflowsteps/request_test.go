@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("Request WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.Request()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -54,9 +57,9 @@ var _ = Describe("Request WorkflowStep", func() {
 		})
 
 		It("should declare all parameters with correct types and defaults", func() {
-			Expect(cueOutput).To(ContainSubstring("url: string"))
-			Expect(cueOutput).To(ContainSubstring(`method: *"GET" | "POST" | "PUT" | "DELETE"`))
-			Expect(cueOutput).To(ContainSubstring("body?: {...}"))
+			Expect(doc.Lookup("parameter.url")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.method")).To(SatisfyAll(cueassert.HaveDefault("GET"), cueassert.HaveType(`"POST" | "PUT" | "DELETE"`)))
+			Expect(doc.Lookup("parameter.body")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("{...}")))
 			Expect(cueOutput).To(ContainSubstring("header?: [string]: string"))
 		})
 
@@ -75,7 +78,7 @@ var _ = Describe("Request WorkflowStep", func() {
 			Expect(cueOutput).To(ContainSubstring("req.$returns.statusCode > 400"))
 			Expect(cueOutput).To(ContainSubstring("requestFail: op.#Fail & {"))
 			Expect(cueOutput).To(ContainSubstring(`message: "request of \(parameter.url) is fail: \(req.$returns.statusCode)"`))
-			Expect(cueOutput).To(ContainSubstring("response: json.Unmarshal(req.$returns.body)"))
+			Expect(doc.Lookup("response")).To(cueassert.HaveValue("json.Unmarshal(req.$returns.body)"))
 		})
 
 		It("should have exactly one of each action type", func() {
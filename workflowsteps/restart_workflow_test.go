@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -37,10 +38,12 @@ var _ = Describe("RestartWorkflow WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.RestartWorkflow()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -64,7 +67,7 @@ var _ = Describe("RestartWorkflow WorkflowStep", func() {
 			Expect(cueOutput).To(ContainSubstring("if parameter.at != _|_"))
 			Expect(cueOutput).To(ContainSubstring("if parameter.after != _|_"))
 			Expect(cueOutput).To(ContainSubstring("if parameter.every != _|_"))
-			Expect(cueOutput).To(ContainSubstring("_script: string"))
+			Expect(doc.Lookup("_script")).To(cueassert.HaveValue("string"))
 			Expect(cueOutput).To(ContainSubstring("app.oam.dev/restart-workflow"))
 			Expect(cueOutput).To(ContainSubstring("Convert duration to seconds"))
 			Expect(cueOutput).To(ContainSubstring("builtin.#Fail"))
@@ -75,11 +78,11 @@ var _ = Describe("RestartWorkflow WorkflowStep", func() {
 		It("should create a Job via kube.#Apply with kubectl annotate container", func() {
 			Expect(cueOutput).To(ContainSubstring("kube.#Apply & {"))
 			Expect(cueOutput).To(ContainSubstring(`apiVersion: "batch/v1"`))
-			Expect(cueOutput).To(ContainSubstring(`kind:       "Job"`))
+			Expect(doc).To(cueassert.ContainField("kind", `"Job"`))
 			Expect(cueOutput).To(ContainSubstring("restart-workflow"))
 			Expect(cueOutput).To(ContainSubstring("context.stepSessionID"))
-			Expect(cueOutput).To(ContainSubstring(`image:   "bitnami/kubectl:latest"`))
-			Expect(cueOutput).To(ContainSubstring(`name:    "kubectl-annotate"`))
+			Expect(doc).To(cueassert.ContainField("image", `"bitnami/kubectl:latest"`))
+			Expect(doc).To(cueassert.ContainField("name", `"kubectl-annotate"`))
 			Expect(cueOutput).To(ContainSubstring(`serviceAccountName: "kubevela-vela-core"`))
 		})
 
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("ShareCloudResource WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.ShareCloudResource()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -61,8 +64,8 @@ var _ = Describe("ShareCloudResource WorkflowStep", func() {
 			Expect(placementsBlock).To(ContainSubstring("namespace?: string"))
 			Expect(placementsBlock).To(ContainSubstring("cluster?:"))
 
-			Expect(cueOutput).To(ContainSubstring(`policy: *"" | string`))
-			Expect(cueOutput).To(ContainSubstring("env: string"))
+			Expect(doc.Lookup("parameter.policy")).To(SatisfyAll(cueassert.HaveDefault(""), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.env")).To(cueassert.HaveValue("string"))
 			Expect(cueOutput).To(ContainSubstring("// +usage=Declare the name of the env in policy"))
 		})
 
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("Suspend WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.Suspend()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -51,8 +54,8 @@ var _ = Describe("Suspend WorkflowStep", func() {
 		})
 
 		It("should declare duration and message as optional string parameters with descriptions", func() {
-			Expect(cueOutput).To(ContainSubstring("duration?: string"))
-			Expect(cueOutput).To(ContainSubstring("message?: string"))
+			Expect(doc.Lookup("parameter.duration")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
+			Expect(doc.Lookup("parameter.message")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("string")))
 			Expect(cueOutput).To(ContainSubstring("wait duration"))
 			Expect(cueOutput).To(ContainSubstring("suspend message"))
 		})
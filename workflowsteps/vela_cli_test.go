@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("VelaCli WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.VelaCli()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -53,11 +56,11 @@ var _ = Describe("VelaCli WorkflowStep", func() {
 		})
 
 		It("should declare all parameters with correct types, defaults, and descriptions", func() {
-			Expect(cueOutput).To(ContainSubstring("command: [...string]"))
+			Expect(doc.Lookup("parameter.command")).To(cueassert.HaveValue("[...string]"))
 			Expect(cueOutput).To(ContainSubstring("// +usage=Specify the vela command"))
-			Expect(cueOutput).To(ContainSubstring(`image: *"oamdev/vela-cli:v1.6.4" | string`))
+			Expect(doc.Lookup("parameter.image")).To(SatisfyAll(cueassert.HaveDefault("oamdev/vela-cli:v1.6.4"), cueassert.HaveType("string")))
 			Expect(cueOutput).To(ContainSubstring("// +usage=Specify the image"))
-			Expect(cueOutput).To(ContainSubstring(`serviceAccountName: *"kubevela-vela-core" | string`))
+			Expect(doc.Lookup("parameter.serviceAccountName")).To(SatisfyAll(cueassert.HaveDefault("kubevela-vela-core"), cueassert.HaveType("string")))
 			Expect(cueOutput).To(ContainSubstring("// +usage=specify serviceAccountName want to use"))
 			Expect(cueOutput).To(ContainSubstring("storage?:"))
 			Expect(cueOutput).To(ContainSubstring("secret?:"))
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/oam-dev/vela-go-definitions/internal/cueassert"
 	"github.com/oam-dev/vela-go-definitions/workflowsteps"
 )
 
@@ -34,10 +35,12 @@ var _ = Describe("Webhook WorkflowStep", func() {
 
 	Describe("CUE Generation", func() {
 		var cueOutput string
+		var doc *cueassert.Document
 
 		BeforeEach(func() {
 			step := workflowsteps.Webhook()
 			cueOutput = step.ToCue()
+			doc = cueassert.MustParse(cueOutput)
 			Expect(cueOutput).NotTo(BeEmpty())
 		})
 
@@ -56,26 +59,26 @@ var _ = Describe("Webhook WorkflowStep", func() {
 
 		It("should declare url as ClosedUnion with value and secretRef options", func() {
 			Expect(cueOutput).To(ContainSubstring("url: close({"))
-			Expect(cueOutput).To(ContainSubstring("value: string"))
+			Expect(doc.Lookup("parameter.url.value")).To(cueassert.HaveValue("string"))
 			Expect(cueOutput).To(ContainSubstring("}) | close({"))
 			Expect(cueOutput).To(ContainSubstring("secretRef: {"))
-			Expect(cueOutput).To(ContainSubstring("name: string"))
-			Expect(cueOutput).To(ContainSubstring("key: string"))
+			Expect(doc.Lookup("parameter.url.secretRef.name")).To(cueassert.HaveValue("string"))
+			Expect(doc.Lookup("parameter.url.secretRef.key")).To(cueassert.HaveValue("string"))
 			Expect(cueOutput).To(ContainSubstring("// +usage=name is the name of the secret"))
 			Expect(cueOutput).To(ContainSubstring("// +usage=key is the key in the secret"))
 			Expect(cueOutput).To(ContainSubstring("// +usage=Specify the webhook url"))
 		})
 
 		It("should declare optional data parameter", func() {
-			Expect(cueOutput).To(ContainSubstring("data?: {...}"))
+			Expect(doc.Lookup("parameter.data")).To(SatisfyAll(cueassert.BeOptionalField(), cueassert.HaveType("{...}")))
 			Expect(cueOutput).To(ContainSubstring("// +usage=Specify the data you want to send"))
 		})
 
 		It("should read Application when no data provided and marshal accordingly", func() {
 			Expect(cueOutput).To(ContainSubstring("kube.#Read & {"))
 			Expect(cueOutput).To(ContainSubstring(`apiVersion: "core.oam.dev/v1beta1"`))
-			Expect(cueOutput).To(ContainSubstring(`kind:       "Application"`))
-			Expect(cueOutput).To(ContainSubstring("name:      context.name"))
+			Expect(doc).To(cueassert.ContainField("kind", `"Application"`))
+			Expect(doc).To(cueassert.ContainField("name", "context.name"))
 			Expect(cueOutput).To(ContainSubstring("namespace: context.namespace"))
 			Expect(cueOutput).To(ContainSubstring("json.Marshal(read.$returns.value)"))
 			Expect(cueOutput).To(ContainSubstring("json.Marshal(parameter.data)"))
@@ -87,7 +90,7 @@ var _ = Describe("Webhook WorkflowStep", func() {
 			Expect(cueOutput).To(ContainSubstring("parameter.url.value != _|_"))
 			Expect(cueOutput).To(ContainSubstring("http.#HTTPDo & {"))
 			Expect(cueOutput).To(ContainSubstring(`method: "POST"`))
-			Expect(cueOutput).To(ContainSubstring("url:    parameter.url.value"))
+			Expect(doc).To(cueassert.ContainField("url", "parameter.url.value"))
 			Expect(cueOutput).To(ContainSubstring(`header: "Content-Type": "application/json"`))
 			Expect(cueOutput).To(ContainSubstring("body: data.value"))
 		})
@@ -95,11 +98,11 @@ var _ = Describe("Webhook WorkflowStep", func() {
 		It("should read Secret and POST via resolved URL when secretRef is set", func() {
 			Expect(cueOutput).To(ContainSubstring("parameter.url.secretRef != _|_ && parameter.url.value == _|_"))
 			Expect(cueOutput).To(ContainSubstring(`apiVersion: "v1"`))
-			Expect(cueOutput).To(ContainSubstring(`kind:       "Secret"`))
-			Expect(cueOutput).To(ContainSubstring("name:      parameter.url.secretRef.name"))
+			Expect(doc).To(cueassert.ContainField("kind", `"Secret"`))
+			Expect(doc).To(cueassert.ContainField("name", "parameter.url.secretRef.name"))
 			Expect(cueOutput).To(ContainSubstring("util.#ConvertString & {"))
 			Expect(cueOutput).To(ContainSubstring("base64.Decode(null, read.$returns.value.data[parameter.url.secretRef.key])"))
-			Expect(cueOutput).To(ContainSubstring("url:    stringValue.$returns.str"))
+			Expect(doc).To(cueassert.ContainField("url", "stringValue.$returns.str"))
 		})
 
 		It("should have correct structural counts", func() {